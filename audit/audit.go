@@ -0,0 +1,56 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package audit records the changes made to trees through the TrillianAdmin
+// API, so that their history can be inspected later.
+package audit
+
+import (
+	"context"
+
+	"github.com/google/trillian"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// Log is an append-only store of tree audit entries.
+type Log interface {
+	// Append records entry. Recorded entries are never changed or removed.
+	Append(ctx context.Context, entry *trillian.TreeAuditEntry) error
+	// List returns up to limit of the entries recorded for treeID, oldest
+	// first, starting at position start: zero for the oldest entry, or the
+	// next position returned by an earlier call. A zero limit means no limit.
+	// Fewer than limit entries may be returned even if more follow; next is
+	// zero once there are no more entries.
+	List(ctx context.Context, treeID, start int64, limit int) (entries []*trillian.TreeAuditEntry, next int64, err error)
+}
+
+// Principal returns the identity of the gRPC caller of ctx: the common name of
+// its TLS client certificate if it presented one, otherwise its network
+// address. Returns an empty string if ctx doesn't belong to a gRPC call.
+func Principal(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+		if certs := tlsInfo.State.PeerCertificates; len(certs) > 0 && certs[0].Subject.CommonName != "" {
+			return certs[0].Subject.CommonName
+		}
+	}
+	if p.Addr != nil {
+		return p.Addr.String()
+	}
+	return ""
+}
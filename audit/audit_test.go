@@ -0,0 +1,265 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/trillian"
+	"github.com/google/trillian/crypto/keys"
+	"github.com/google/trillian/crypto/keys/pem"
+	"github.com/google/trillian/crypto/keyspb"
+	"github.com/google/trillian/merkle"
+	"github.com/google/trillian/merkle/rfc6962"
+	"github.com/google/trillian/types"
+	"github.com/kylelemons/godebug/pretty"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	tcrypto "github.com/google/trillian/crypto"
+	ttestonly "github.com/google/trillian/testonly"
+)
+
+// fakeLogClient is a TrillianLogClient that sequences queued leaves
+// immediately. Methods other than the ones below panic.
+type fakeLogClient struct {
+	trillian.TrillianLogClient
+	signer   *tcrypto.Signer
+	tree     *merkle.InMemoryMerkleTree
+	leaves   []*trillian.LogLeaf
+	queueErr error
+}
+
+// newFakeLogClient returns an empty fakeLogClient, and the public key of the
+// roots it signs.
+func newFakeLogClient(t *testing.T) (*fakeLogClient, crypto.PublicKey) {
+	t.Helper()
+	key, err := pem.UnmarshalPrivateKey(ttestonly.DemoPrivateKey, ttestonly.DemoPrivateKeyPass)
+	if err != nil {
+		t.Fatalf("UnmarshalPrivateKey(): %v", err)
+	}
+	c := &fakeLogClient{
+		signer: tcrypto.NewSigner(0, key, crypto.SHA256),
+		tree:   merkle.NewInMemoryMerkleTree(rfc6962.DefaultHasher),
+	}
+	return c, key.Public()
+}
+
+func (c *fakeLogClient) QueueLeaf(ctx context.Context, req *trillian.QueueLeafRequest, opts ...grpc.CallOption) (*trillian.QueueLeafResponse, error) {
+	if c.queueErr != nil {
+		return nil, c.queueErr
+	}
+	leaf := proto.Clone(req.Leaf).(*trillian.LogLeaf)
+	leaf.LeafIndex = int64(len(c.leaves))
+	c.leaves = append(c.leaves, leaf)
+	if _, _, err := c.tree.AddLeaf(leaf.LeafValue); err != nil {
+		return nil, err
+	}
+	return &trillian.QueueLeafResponse{QueuedLeaf: &trillian.QueuedLogLeaf{Leaf: leaf}}, nil
+}
+
+func (c *fakeLogClient) GetLatestSignedLogRoot(ctx context.Context, req *trillian.GetLatestSignedLogRootRequest, opts ...grpc.CallOption) (*trillian.GetLatestSignedLogRootResponse, error) {
+	root, err := c.signer.SignLogRoot(&types.LogRootV1{
+		TreeSize: uint64(c.tree.LeafCount()),
+		RootHash: c.tree.CurrentRoot().Hash(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &trillian.GetLatestSignedLogRootResponse{SignedLogRoot: root}, nil
+}
+
+func (c *fakeLogClient) GetLeavesByRange(ctx context.Context, req *trillian.GetLeavesByRangeRequest, opts ...grpc.CallOption) (*trillian.GetLeavesByRangeResponse, error) {
+	// Return at most 2 leaves, to exercise batching.
+	end := req.StartIndex + req.Count
+	if end > req.StartIndex+2 {
+		end = req.StartIndex + 2
+	}
+	if end > int64(len(c.leaves)) {
+		end = int64(len(c.leaves))
+	}
+	return &trillian.GetLeavesByRangeResponse{Leaves: c.leaves[req.StartIndex:end]}, nil
+}
+
+func (c *fakeLogClient) GetInclusionProof(ctx context.Context, req *trillian.GetInclusionProofRequest, opts ...grpc.CallOption) (*trillian.GetInclusionProofResponse, error) {
+	var hashes [][]byte
+	for _, node := range c.tree.PathToRootAtSnapshot(req.LeafIndex+1, req.TreeSize) {
+		hashes = append(hashes, node.Value.Hash())
+	}
+	return &trillian.GetInclusionProofResponse{Proof: &trillian.Proof{LeafIndex: req.LeafIndex, Hashes: hashes}}, nil
+}
+
+// listAll returns all the entries of log for treeID, listing them limit at a
+// time.
+func listAll(ctx context.Context, log Log, treeID int64, limit int) ([]*trillian.TreeAuditEntry, error) {
+	var all []*trillian.TreeAuditEntry
+	var start int64
+	for {
+		entries, next, err := log.List(ctx, treeID, start, limit)
+		if err != nil {
+			return nil, err
+		}
+		if limit > 0 && len(entries) > limit {
+			return nil, fmt.Errorf("List(%v, %v, %v) returned %v entries", treeID, start, limit, len(entries))
+		}
+		all = append(all, entries...)
+		if next == 0 {
+			return all, nil
+		}
+		if next <= start {
+			return nil, fmt.Errorf("List(%v, %v, %v) returned next position %v", treeID, start, limit, next)
+		}
+		start = next
+	}
+}
+
+func TestLogs(t *testing.T) {
+	ctx := context.Background()
+	entries := []*trillian.TreeAuditEntry{
+		{TreeId: 1, Method: "CreateTree", After: &trillian.Tree{TreeId: 1}},
+		{TreeId: 2, Method: "CreateTree", After: &trillian.Tree{TreeId: 2}},
+		{TreeId: 1, Method: "UpdateTree", Before: &trillian.Tree{TreeId: 1}, After: &trillian.Tree{TreeId: 1, DisplayName: "Llamas"}},
+		{TreeId: 1, Method: "DeleteTree", Before: &trillian.Tree{TreeId: 1, DisplayName: "Llamas"}, After: &trillian.Tree{TreeId: 1, DisplayName: "Llamas", Deleted: true}},
+	}
+
+	logClient, pubKey := newFakeLogClient(t)
+	for _, test := range []struct {
+		desc string
+		log  Log
+	}{
+		{desc: "memory", log: NewMemoryLog()},
+		{desc: "trillian", log: NewTrillianLog(logClient, 42, rfc6962.DefaultHasher, pubKey)},
+	} {
+		for _, entry := range entries {
+			if err := test.log.Append(ctx, entry); err != nil {
+				t.Fatalf("%v: Append(): %v", test.desc, err)
+			}
+		}
+		for _, want := range []struct {
+			treeID  int64
+			entries []*trillian.TreeAuditEntry
+		}{
+			{treeID: 1, entries: []*trillian.TreeAuditEntry{entries[0], entries[2], entries[3]}},
+			{treeID: 2, entries: entries[1:2]},
+			{treeID: 3},
+		} {
+			for _, limit := range []int{0, 1, 2} {
+				got, err := listAll(ctx, test.log, want.treeID, limit)
+				if err != nil {
+					t.Errorf("%v: List(%v) with limit %v: %v", test.desc, want.treeID, limit, err)
+					continue
+				}
+				if diff := pretty.Compare(got, want.entries); diff != "" {
+					t.Errorf("%v: List(%v) with limit %v diff (-got +want):\n%v", test.desc, want.treeID, limit, diff)
+				}
+			}
+		}
+	}
+}
+
+func TestTrillianLogErrors(t *testing.T) {
+	ctx := context.Background()
+	logClient, pubKey := newFakeLogClient(t)
+	logClient.queueErr = errors.New("QueueLeaf() failed")
+	if err := NewTrillianLog(logClient, 42, rfc6962.DefaultHasher, pubKey).Append(ctx, &trillian.TreeAuditEntry{TreeId: 1}); err == nil {
+		t.Error("Append(): nil err, want QueueLeaf error")
+	}
+
+	entry, err := proto.Marshal(&trillian.TreeAuditEntry{TreeId: 1, Method: "CreateTree"})
+	if err != nil {
+		t.Fatalf("Marshal(): %v", err)
+	}
+	otherKey, err := keys.NewFromSpec(&keyspb.Specification{Params: &keyspb.Specification_EcdsaParams{}})
+	if err != nil {
+		t.Fatalf("NewFromSpec(): %v", err)
+	}
+
+	for _, test := range []struct {
+		desc   string
+		values [][]byte
+		// tamper, if set, changes the leaves of the log once they're added.
+		tamper func(leaves []*trillian.LogLeaf)
+		// pubKey, if set, replaces the log's public key.
+		pubKey crypto.PublicKey
+	}{
+		{desc: "notAProto", values: [][]byte{[]byte("not a proto")}},
+		{
+			desc:   "alteredEntry",
+			values: [][]byte{entry},
+			tamper: func(leaves []*trillian.LogLeaf) {
+				leaves[0].LeafValue = append([]byte(nil), leaves[0].LeafValue...)
+				leaves[0].LeafValue[len(leaves[0].LeafValue)-1]++
+			},
+		},
+		{desc: "wrongKey", values: [][]byte{entry}, pubKey: otherKey.Public()},
+	} {
+		logClient, pubKey := newFakeLogClient(t)
+		for _, value := range test.values {
+			if _, err := logClient.QueueLeaf(ctx, &trillian.QueueLeafRequest{Leaf: &trillian.LogLeaf{LeafValue: value}}); err != nil {
+				t.Fatalf("%v: QueueLeaf(): %v", test.desc, err)
+			}
+		}
+		if test.tamper != nil {
+			test.tamper(logClient.leaves)
+		}
+		if test.pubKey != nil {
+			pubKey = test.pubKey
+		}
+		if _, _, err := NewTrillianLog(logClient, 42, rfc6962.DefaultHasher, pubKey).List(ctx, 1, 0, 0); err == nil {
+			t.Errorf("%v: List(): nil err, want error", test.desc)
+		}
+	}
+}
+
+func TestPrincipal(t *testing.T) {
+	addr := &net.TCPAddr{IP: net.IPv4(192, 0, 2, 1), Port: 1234}
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "admin"}}
+
+	for _, test := range []struct {
+		desc string
+		ctx  context.Context
+		want string
+	}{
+		{desc: "noPeer", ctx: context.Background()},
+		{desc: "addr", ctx: peer.NewContext(context.Background(), &peer.Peer{Addr: addr}), want: "192.0.2.1:1234"},
+		{
+			desc: "tlsNoClientCert",
+			ctx:  peer.NewContext(context.Background(), &peer.Peer{Addr: addr, AuthInfo: credentials.TLSInfo{}}),
+			want: "192.0.2.1:1234",
+		},
+		{
+			desc: "tlsClientCert",
+			ctx: peer.NewContext(context.Background(), &peer.Peer{
+				Addr:     addr,
+				AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}},
+			}),
+			want: "admin",
+		},
+	} {
+		if got := Principal(test.ctx); got != test.want {
+			t.Errorf("%v: Principal() = %q, want %q", test.desc, got, test.want)
+		}
+	}
+}
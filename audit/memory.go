@@ -0,0 +1,69 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/trillian"
+)
+
+// MemoryLog is a Log that keeps its entries in memory.
+// The entries are lost when the process exits, so it's only suitable for
+// tests and single-process deployments that don't need durable history.
+type MemoryLog struct {
+	mu      sync.RWMutex
+	entries map[int64][]*trillian.TreeAuditEntry
+}
+
+// NewMemoryLog returns an empty MemoryLog.
+func NewMemoryLog() *MemoryLog {
+	return &MemoryLog{entries: make(map[int64][]*trillian.TreeAuditEntry)}
+}
+
+// Append implements Log.Append.
+func (l *MemoryLog) Append(ctx context.Context, entry *trillian.TreeAuditEntry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries[entry.TreeId] = append(l.entries[entry.TreeId], proto.Clone(entry).(*trillian.TreeAuditEntry))
+	return nil
+}
+
+// List implements Log.List.
+// The positions of MemoryLog are indices into the entries of a tree.
+func (l *MemoryLog) List(ctx context.Context, treeID, start int64, limit int) ([]*trillian.TreeAuditEntry, int64, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	all := l.entries[treeID]
+	if start < 0 || start > int64(len(all)) {
+		return nil, 0, fmt.Errorf("audit: invalid start position %v", start)
+	}
+	end := int64(len(all))
+	if limit > 0 && end > start+int64(limit) {
+		end = start + int64(limit)
+	}
+
+	var entries []*trillian.TreeAuditEntry
+	for _, entry := range all[start:end] {
+		entries = append(entries, proto.Clone(entry).(*trillian.TreeAuditEntry))
+	}
+	if end == int64(len(all)) {
+		return entries, 0, nil
+	}
+	return entries, end, nil
+}
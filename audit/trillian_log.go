@@ -0,0 +1,180 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"crypto"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/trillian"
+	"github.com/google/trillian/merkle"
+	"github.com/google/trillian/merkle/hashers"
+	"github.com/google/trillian/types"
+
+	tcrypto "github.com/google/trillian/crypto"
+)
+
+// listBatchSize is the number of leaves TrillianLog.List reads per request.
+const listBatchSize = 1000
+
+// listScanLimit is the number of leaves TrillianLog.List reads at most per
+// call, so that listing the entries of a rarely changed tree doesn't read
+// the whole log at once.
+const listScanLimit = 100 * listBatchSize
+
+// TrillianLog is a Log that stores each entry as a leaf of a dedicated
+// Trillian log, so that the audit trail itself can be verified by the
+// clients of that log.
+//
+// Appended entries are only returned by List once the log has integrated
+// them. List checks the signature of the log's root, and the inclusion of
+// each returned entry in it, so that entries altered in the log's storage
+// aren't returned. These are the checks made by client.LogVerifier, which
+// can't be used here as the client tests depend on this package. The
+// positions of TrillianLog are leaf indices.
+type TrillianLog struct {
+	client   trillian.TrillianLogClient
+	logID    int64
+	hasher   hashers.LogHasher
+	verifier merkle.LogVerifier
+	pubKey   crypto.PublicKey
+}
+
+// NewTrillianLog returns a TrillianLog that stores entries in the log logID,
+// accessed through client. The log's leaves are hashed by hasher, and its
+// roots signed by pubKey's private key using SHA-256.
+func NewTrillianLog(client trillian.TrillianLogClient, logID int64, hasher hashers.LogHasher, pubKey crypto.PublicKey) *TrillianLog {
+	return &TrillianLog{
+		client:   client,
+		logID:    logID,
+		hasher:   hasher,
+		verifier: merkle.NewLogVerifier(hasher),
+		pubKey:   pubKey,
+	}
+}
+
+// Append implements Log.Append.
+func (l *TrillianLog) Append(ctx context.Context, entry *trillian.TreeAuditEntry) error {
+	value, err := proto.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("audit: failed to marshal entry: %v", err)
+	}
+	if _, err := l.client.QueueLeaf(ctx, &trillian.QueueLeafRequest{
+		LogId: l.logID,
+		Leaf:  &trillian.LogLeaf{LeafValue: value},
+	}); err != nil {
+		return fmt.Errorf("audit: failed to queue entry in log %v: %v", l.logID, err)
+	}
+	return nil
+}
+
+// List implements Log.List.
+func (l *TrillianLog) List(ctx context.Context, treeID, start int64, limit int) ([]*trillian.TreeAuditEntry, int64, error) {
+	root, err := l.latestRoot(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	size := int64(root.TreeSize)
+	if start < 0 || start > size {
+		return nil, 0, fmt.Errorf("audit: invalid start position %v", start)
+	}
+	end := size
+	if end > start+listScanLimit {
+		end = start + listScanLimit
+	}
+
+	var entries []*trillian.TreeAuditEntry
+	for index := start; index < end; {
+		count := end - index
+		if count > listBatchSize {
+			count = listBatchSize
+		}
+		resp, err := l.client.GetLeavesByRange(ctx, &trillian.GetLeavesByRangeRequest{
+			LogId:      l.logID,
+			StartIndex: index,
+			Count:      count,
+		})
+		if err != nil {
+			return nil, 0, fmt.Errorf("audit: failed to get leaves [%v, %v) of log %v: %v", index, index+count, l.logID, err)
+		}
+		if len(resp.Leaves) == 0 {
+			return nil, 0, fmt.Errorf("audit: log %v returned no leaves from index %v", l.logID, index)
+		}
+		for _, leaf := range resp.Leaves {
+			if leaf.LeafIndex != index {
+				return nil, 0, fmt.Errorf("audit: log %v returned leaf %v, want %v", l.logID, leaf.LeafIndex, index)
+			}
+			index++
+			var entry trillian.TreeAuditEntry
+			if err := proto.Unmarshal(leaf.LeafValue, &entry); err != nil {
+				return nil, 0, fmt.Errorf("audit: leaf %v of log %v isn't a TreeAuditEntry: %v", leaf.LeafIndex, l.logID, err)
+			}
+			if entry.TreeId != treeID {
+				continue
+			}
+			if err := l.verifyInclusion(ctx, root, leaf); err != nil {
+				return nil, 0, err
+			}
+			entries = append(entries, &entry)
+			if limit > 0 && len(entries) == limit {
+				end = index
+				break
+			}
+			if index == end {
+				break
+			}
+		}
+	}
+	if end == size {
+		return entries, 0, nil
+	}
+	return entries, end, nil
+}
+
+// latestRoot returns the latest root of the log, once its signature is
+// verified.
+func (l *TrillianLog) latestRoot(ctx context.Context) (*types.LogRootV1, error) {
+	resp, err := l.client.GetLatestSignedLogRoot(ctx, &trillian.GetLatestSignedLogRootRequest{LogId: l.logID})
+	if err != nil {
+		return nil, fmt.Errorf("audit: failed to get root of log %v: %v", l.logID, err)
+	}
+	root, err := tcrypto.VerifySignedLogRoot(l.pubKey, crypto.SHA256, resp.GetSignedLogRoot())
+	if err != nil {
+		return nil, fmt.Errorf("audit: failed to verify root of log %v: %v", l.logID, err)
+	}
+	return root, nil
+}
+
+// verifyInclusion checks that leaf is included in root.
+func (l *TrillianLog) verifyInclusion(ctx context.Context, root *types.LogRootV1, leaf *trillian.LogLeaf) error {
+	resp, err := l.client.GetInclusionProof(ctx, &trillian.GetInclusionProofRequest{
+		LogId:     l.logID,
+		LeafIndex: leaf.LeafIndex,
+		TreeSize:  int64(root.TreeSize),
+	})
+	if err != nil {
+		return fmt.Errorf("audit: failed to get inclusion proof of leaf %v of log %v: %v", leaf.LeafIndex, l.logID, err)
+	}
+	leafHash, err := l.hasher.HashLeaf(leaf.LeafValue)
+	if err != nil {
+		return fmt.Errorf("audit: failed to hash leaf %v of log %v: %v", leaf.LeafIndex, l.logID, err)
+	}
+	if err := l.verifier.VerifyInclusionProof(leaf.LeafIndex, int64(root.TreeSize), resp.GetProof().GetHashes(), root.RootHash, leafHash); err != nil {
+		return fmt.Errorf("audit: failed to verify inclusion of leaf %v of log %v: %v", leaf.LeafIndex, l.logID, err)
+	}
+	return nil
+}
@@ -15,9 +15,12 @@
 package client
 
 import (
+	"bytes"
 	"crypto"
 	"fmt"
+	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/google/trillian"
 	"github.com/google/trillian/crypto/keys/der"
 	"github.com/google/trillian/merkle"
@@ -26,6 +29,8 @@ import (
 	"github.com/google/trillian/types"
 
 	tcrypto "github.com/google/trillian/crypto"
+	_ "github.com/google/trillian/merkle/protohasher" // Make PROTO_RFC6962_SHA256 available
+	_ "github.com/google/trillian/merkle/rfc6962"     // Make the RFC6962 family of log hashers available
 )
 
 // LogVerifier contains state needed to verify output from Trillian Logs.
@@ -36,7 +41,29 @@ type LogVerifier struct {
 	PubKey crypto.PublicKey
 	// SigHash computes the digest of LogRoot for signing.
 	SigHash crypto.Hash
-	v       merkle.LogVerifier
+	// KeyVersion is the version of PubKey, which is incremented each time the
+	// log's key is rotated.
+	KeyVersion int64
+	// RetiredKeys verify roots signed before the log's key was rotated.
+	RetiredKeys []RetiredKey
+	// CoSignerKeys verify the co-signatures on LogRoot of a multi-signature
+	// log, in MultiSigConfig.signers order.
+	CoSignerKeys []crypto.PublicKey
+	// CoSignThreshold is the number of CoSignerKeys that must have signed
+	// LogRoot. If zero, co-signatures are not checked.
+	CoSignThreshold int
+	v               merkle.LogVerifier
+}
+
+// RetiredKey is a key of a log that was replaced by key rotation.
+type RetiredKey struct {
+	// Version is the version of PubKey.
+	Version int64
+	// PubKey verifies the signature on the digest of LogRoot.
+	PubKey crypto.PublicKey
+	// RetireTime is the end of the key's transition period. Roots with later
+	// timestamps are not accepted from PubKey.
+	RetireTime time.Time
 }
 
 // NewLogVerifier returns an object that can verify output from Trillian Logs.
@@ -51,6 +78,8 @@ func NewLogVerifier(hasher hashers.LogHasher, pubKey crypto.PublicKey, sigHash c
 
 // NewLogVerifierFromTree creates a new LogVerifier using the algorithms
 // specified by *trillian.Tree.
+// The tree's previous keys are accepted as RetiredKeys, provided that each of
+// them signed a statement naming the key that replaced it.
 func NewLogVerifierFromTree(config *trillian.Tree) (*LogVerifier, error) {
 	if got, want := config.TreeType, trillian.TreeType_LOG; got != want {
 		return nil, fmt.Errorf("client: NewLogVerifierFromTree(): TreeType: %v, want %v", got, want)
@@ -71,7 +100,60 @@ func NewLogVerifierFromTree(config *trillian.Tree) (*LogVerifier, error) {
 		return nil, fmt.Errorf("client: NewLogVerifierFromTree(): Failed parsing Log signature hash: %v", err)
 	}
 
-	return NewLogVerifier(logHasher, logPubKey, sigHash), nil
+	retiredKeys, err := retiredKeys(config, sigHash)
+	if err != nil {
+		return nil, fmt.Errorf("client: NewLogVerifierFromTree(): %v", err)
+	}
+
+	v := NewLogVerifier(logHasher, logPubKey, sigHash)
+	v.KeyVersion = config.GetKeyVersion()
+	v.RetiredKeys = retiredKeys
+	if multiSig := config.GetMultiSig(); multiSig != nil {
+		for i, signer := range multiSig.GetSigners() {
+			pubKey, err := der.UnmarshalPublicKey(signer.GetPublicKey().GetDer())
+			if err != nil {
+				return nil, fmt.Errorf("client: NewLogVerifierFromTree(): Failed parsing public key of signer %v: %v", i, err)
+			}
+			v.CoSignerKeys = append(v.CoSignerKeys, pubKey)
+		}
+		v.CoSignThreshold = int(multiSig.GetThreshold())
+	}
+	return v, nil
+}
+
+// retiredKeys returns the keys of tree that were replaced by key rotation,
+// after checking that each of them vouched for the key that replaced it.
+func retiredKeys(tree *trillian.Tree, sigHash crypto.Hash) ([]RetiredKey, error) {
+	previousKeys := tree.GetPreviousKeys()
+	retired := make([]RetiredKey, 0, len(previousKeys))
+	for i, key := range previousKeys {
+		pubKey, err := der.UnmarshalPublicKey(key.GetPublicKey().GetDer())
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing public key version %v: %v", key.KeyVersion, err)
+		}
+		retireTime, err := ptypes.Timestamp(key.RetireTime)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing retire time of key version %v: %v", key.KeyVersion, err)
+		}
+
+		next := &trillian.TreeKey{KeyVersion: tree.GetKeyVersion(), PublicKey: tree.GetPublicKey()}
+		if i+1 < len(previousKeys) {
+			next = previousKeys[i+1]
+		}
+		rotation, err := tcrypto.VerifyKeyRotation(pubKey, sigHash, key.Rotation, key.RotationSignature)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rotation statement of key version %v: %v", key.KeyVersion, err)
+		}
+		if rotation.TreeID != uint64(tree.GetTreeId()) ||
+			next.KeyVersion != key.KeyVersion+1 ||
+			rotation.KeyVersion != uint64(next.KeyVersion) ||
+			!bytes.Equal(rotation.PublicKey, next.GetPublicKey().GetDer()) {
+			return nil, fmt.Errorf("rotation statement of key version %v doesn't name key version %v", key.KeyVersion, next.KeyVersion)
+		}
+
+		retired = append(retired, RetiredKey{Version: key.KeyVersion, PubKey: pubKey, RetireTime: retireTime})
+	}
+	return retired, nil
 }
 
 // VerifyRoot verifies that newRoot is a valid append-only operation from trusted.
@@ -87,10 +169,15 @@ func (c *LogVerifier) VerifyRoot(trusted *types.LogRootV1, newRoot *trillian.Sig
 	}
 
 	// Verify SignedLogRoot signature.
-	r, err := tcrypto.VerifySignedLogRoot(c.PubKey, c.SigHash, newRoot)
+	r, err := c.verifySignedLogRoot(newRoot)
 	if err != nil {
 		return nil, err
 	}
+	if c.CoSignThreshold > 0 {
+		if _, err := tcrypto.VerifySignedLogRootThreshold(c.CoSignerKeys, c.CoSignThreshold, c.SigHash, newRoot); err != nil {
+			return nil, fmt.Errorf("VerifyRoot() error: co-signatures: %v", err)
+		}
+	}
 
 	// Implicitly trust the first root we get.
 	if trusted.TreeSize != 0 {
@@ -105,6 +192,51 @@ func (c *LogVerifier) VerifyRoot(trusted *types.LogRootV1, newRoot *trillian.Sig
 	return r, nil
 }
 
+// verifySignedLogRoot verifies the signature on root with the key identified by
+// its key hint. Roots whose hint doesn't identify a key version, such as those
+// read back from storage, are checked against each of the known keys.
+func (c *LogVerifier) verifySignedLogRoot(root *trillian.SignedLogRoot) (*types.LogRootV1, error) {
+	_, version, hasVersion, err := types.ParseKeyVersionHint(root.KeyHint)
+	hasVersion = err == nil && hasVersion
+
+	// The current key has no retire time.
+	if !hasVersion || version == c.KeyVersion {
+		r, err := tcrypto.VerifySignedLogRoot(c.PubKey, c.SigHash, root)
+		if err == nil || hasVersion || len(c.RetiredKeys) == 0 {
+			return r, err
+		}
+	}
+
+	err = fmt.Errorf("VerifyRoot() error: unknown key version %v", version)
+	for _, k := range c.RetiredKeys {
+		if hasVersion && k.Version != version {
+			continue
+		}
+		r, verr := tcrypto.VerifySignedLogRoot(k.PubKey, c.SigHash, root)
+		if verr != nil {
+			err = verr
+			continue
+		}
+		if ts := time.Unix(0, int64(r.TimestampNanos)); ts.After(k.RetireTime) {
+			err = fmt.Errorf("VerifyRoot() error: root at %v signed by key version %v, which retired at %v", ts, k.Version, k.RetireTime)
+			continue
+		}
+		return r, nil
+	}
+	return nil, err
+}
+
+// VerifyCheckpoint verifies that note is a checkpoint of the log named origin,
+// signed by PubKey, and returns its contents. Unlike VerifyRoot, it does not
+// check that the checkpoint is consistent with an earlier one.
+func (c *LogVerifier) VerifyCheckpoint(origin string, note []byte) (*types.Checkpoint, error) {
+	checkpoint, err := tcrypto.VerifyCheckpoint(c.PubKey, c.SigHash, origin, note)
+	if err != nil {
+		return nil, fmt.Errorf("VerifyCheckpoint() error: %v", err)
+	}
+	return checkpoint, nil
+}
+
 // VerifyInclusionAtIndex verifies that the inclusion proof for data at index matches
 // the currently trusted root. The inclusion proof must be requested for Root().TreeSize.
 func (c *LogVerifier) VerifyInclusionAtIndex(trusted *types.LogRootV1, data []byte, leafIndex int64, proof [][]byte) error {
@@ -133,6 +265,23 @@ func (c *LogVerifier) VerifyInclusionByHash(trusted *types.LogRootV1, leafHash [
 		trusted.RootHash, leafHash)
 }
 
+// VerifyLeafHash checks that the value of leaf, which may have been redacted,
+// hashes to its MerkleLeafHash. Once this passes, inclusion proofs for the
+// Merkle leaf hash also prove the inclusion of the value.
+func (c *LogVerifier) VerifyLeafHash(leaf *trillian.LogLeaf) error {
+	if leaf == nil {
+		return fmt.Errorf("VerifyLeafHash() error: leaf == nil")
+	}
+	leafHash, err := c.Hasher.HashLeaf(leaf.LeafValue)
+	if err != nil {
+		return fmt.Errorf("HashLeaf(): %v", err)
+	}
+	if !bytes.Equal(leafHash, leaf.MerkleLeafHash) {
+		return fmt.Errorf("leaf value hashes to %x, want %x", leafHash, leaf.MerkleLeafHash)
+	}
+	return nil
+}
+
 // BuildLeaf runs the leaf hasher over data and builds a leaf.
 func (c *LogVerifier) BuildLeaf(data []byte) (*trillian.LogLeaf, error) {
 	leafHash, err := c.Hasher.HashLeaf(data)
@@ -17,9 +17,13 @@ package client
 import (
 	"crypto"
 	"testing"
+	"time"
 
 	"github.com/google/trillian"
+	"github.com/google/trillian/crypto/keys"
 	"github.com/google/trillian/crypto/keys/pem"
+	"github.com/google/trillian/crypto/keyspb"
+	"github.com/google/trillian/merkle/protohasher"
 	"github.com/google/trillian/merkle/rfc6962"
 	"github.com/google/trillian/testonly"
 	"github.com/google/trillian/types"
@@ -90,3 +94,161 @@ func TestVerifyInclusionByHashErrors(t *testing.T) {
 		}
 	}
 }
+
+func TestVerifyRootWithRotatedKey(t *testing.T) {
+	oldKey, err := pem.UnmarshalPrivateKey(testonly.DemoPrivateKey, testonly.DemoPrivateKeyPass)
+	if err != nil {
+		t.Fatalf("Failed to open test key, err=%v", err)
+	}
+	newKey, err := keys.NewFromSpec(&keyspb.Specification{
+		Params: &keyspb.Specification_EcdsaParams{EcdsaParams: &keyspb.Specification_ECDSA{}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to generate key, err=%v", err)
+	}
+	oldSigner := tcrypto.NewSigner(0, oldKey, crypto.SHA256)
+	oldSigner.KeyHint = types.SerializeKeyVersionHint(0, 0)
+	newSigner := tcrypto.NewSigner(0, newKey, crypto.SHA256)
+	newSigner.KeyHint = types.SerializeKeyVersionHint(0, 1)
+	unknownSigner := tcrypto.NewSigner(0, newKey, crypto.SHA256)
+	unknownSigner.KeyHint = types.SerializeKeyVersionHint(0, 2)
+	versionlessSigner := tcrypto.NewSigner(0, oldKey, crypto.SHA256)
+
+	retireTime := time.Unix(0, 2000)
+	logVerifier := NewLogVerifier(rfc6962.DefaultHasher, newKey.Public(), crypto.SHA256)
+	logVerifier.KeyVersion = 1
+	logVerifier.RetiredKeys = []RetiredKey{{Version: 0, PubKey: oldKey.Public(), RetireTime: retireTime}}
+
+	for _, tc := range []struct {
+		desc      string
+		signer    *tcrypto.Signer
+		timestamp uint64
+		wantErr   bool
+	}{
+		{desc: "currentKey", signer: newSigner, timestamp: 3000},
+		{desc: "retiredKeyBeforeRetireTime", signer: oldSigner, timestamp: 1000},
+		{desc: "retiredKeyAtRetireTime", signer: oldSigner, timestamp: 2000},
+		{desc: "retiredKeyAfterRetireTime", signer: oldSigner, timestamp: 3000, wantErr: true},
+		{desc: "unknownVersion", signer: unknownSigner, timestamp: 1000, wantErr: true},
+		{desc: "versionlessHint", signer: versionlessSigner, timestamp: 1000},
+		{desc: "versionlessHintAfterRetireTime", signer: versionlessSigner, timestamp: 3000, wantErr: true},
+	} {
+		signedRoot, err := tc.signer.SignLogRoot(&types.LogRootV1{TimestampNanos: tc.timestamp})
+		if err != nil {
+			t.Fatalf("%v: SignLogRoot(): %v", tc.desc, err)
+		}
+		_, err = logVerifier.VerifyRoot(&types.LogRootV1{}, signedRoot, nil)
+		if got, want := err != nil, tc.wantErr; got != want {
+			t.Errorf("%v: VerifyRoot(): %v, wantErr %v", tc.desc, err, want)
+		}
+	}
+}
+
+func TestVerifyRootWithCoSignatures(t *testing.T) {
+	key, err := pem.UnmarshalPrivateKey(testonly.DemoPrivateKey, testonly.DemoPrivateKeyPass)
+	if err != nil {
+		t.Fatalf("Failed to open test key, err=%v", err)
+	}
+	coKey, err := keys.NewFromSpec(&keyspb.Specification{
+		Params: &keyspb.Specification_EcdsaParams{EcdsaParams: &keyspb.Specification_ECDSA{}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to generate key, err=%v", err)
+	}
+
+	for _, tc := range []struct {
+		desc      string
+		coSigners []crypto.Signer
+		threshold int
+		wantErr   bool
+	}{
+		{desc: "allSigned", coSigners: []crypto.Signer{key, coKey}, threshold: 2},
+		{desc: "thresholdMet", coSigners: []crypto.Signer{key, coKey}, threshold: 1},
+		{desc: "belowThreshold", coSigners: []crypto.Signer{key}, threshold: 2, wantErr: true},
+		{desc: "wrongSigner", coSigners: []crypto.Signer{coKey}, threshold: 1, wantErr: true},
+		{desc: "noSignatures", threshold: 1, wantErr: true},
+	} {
+		signer := tcrypto.NewSigner(0, key, crypto.SHA256)
+		signer.CoSigners = tc.coSigners
+		signer.CoSignThreshold = len(tc.coSigners)
+		signedRoot, err := signer.SignLogRoot(&types.LogRootV1{TimestampNanos: 1000})
+		if err != nil {
+			t.Fatalf("%v: SignLogRoot(): %v", tc.desc, err)
+		}
+
+		logVerifier := NewLogVerifier(rfc6962.DefaultHasher, key.Public(), crypto.SHA256)
+		logVerifier.CoSignerKeys = []crypto.PublicKey{key.Public(), coKey.Public()}
+		logVerifier.CoSignThreshold = tc.threshold
+		_, err = logVerifier.VerifyRoot(&types.LogRootV1{}, signedRoot, nil)
+		if got, want := err != nil, tc.wantErr; got != want {
+			t.Errorf("%v: VerifyRoot(): %v, wantErr %v", tc.desc, err, want)
+		}
+	}
+}
+
+func TestVerifyCheckpoint(t *testing.T) {
+	key, err := pem.UnmarshalPrivateKey(testonly.DemoPrivateKey, testonly.DemoPrivateKeyPass)
+	if err != nil {
+		t.Fatalf("Failed to open test key, err=%v", err)
+	}
+	otherKey, err := keys.NewFromSpec(&keyspb.Specification{
+		Params: &keyspb.Specification_EcdsaParams{EcdsaParams: &keyspb.Specification_ECDSA{}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to generate key, err=%v", err)
+	}
+	logVerifier := NewLogVerifier(rfc6962.DefaultHasher, key.Public(), crypto.SHA256)
+	checkpoint := &types.Checkpoint{Origin: "example.com/log", TreeSize: 1, RootHash: []byte("root")}
+
+	for _, tc := range []struct {
+		desc    string
+		signer  crypto.Signer
+		origin  string
+		wantErr bool
+	}{
+		{desc: "valid", signer: key, origin: "example.com/log"},
+		{desc: "wrongOrigin", signer: key, origin: "example.com/other", wantErr: true},
+		{desc: "wrongKey", signer: otherKey, origin: "example.com/log", wantErr: true},
+	} {
+		note, err := tcrypto.NewSigner(0, tc.signer, crypto.SHA256).SignCheckpoint(checkpoint)
+		if err != nil {
+			t.Fatalf("%v: SignCheckpoint(): %v", tc.desc, err)
+		}
+		_, err = logVerifier.VerifyCheckpoint(tc.origin, note)
+		if got, want := err != nil, tc.wantErr; got != want {
+			t.Errorf("%v: VerifyCheckpoint(): %v, wantErr %v", tc.desc, err, want)
+		}
+	}
+}
+
+func TestVerifyLeafHash(t *testing.T) {
+	// value encodes {1: 150, 2: "hi"}.
+	value := []byte{0x08, 0x96, 0x01, 0x12, 0x02, 'h', 'i'}
+	hasher := protohasher.New(crypto.SHA256)
+	logVerifier := NewLogVerifier(hasher, nil, crypto.SHA256)
+	leaf, err := logVerifier.BuildLeaf(value)
+	if err != nil {
+		t.Fatalf("BuildLeaf(): %v", err)
+	}
+	redacted, err := hasher.RedactLeaf(value, []int32{2})
+	if err != nil {
+		t.Fatalf("RedactLeaf(): %v", err)
+	}
+
+	for _, test := range []struct {
+		desc    string
+		leaf    *trillian.LogLeaf
+		wantErr bool
+	}{
+		{desc: "original", leaf: leaf},
+		{desc: "redacted", leaf: &trillian.LogLeaf{LeafValue: redacted, MerkleLeafHash: leaf.MerkleLeafHash}},
+		{desc: "otherValue", leaf: &trillian.LogLeaf{LeafValue: []byte{0x08, 0x01}, MerkleLeafHash: leaf.MerkleLeafHash}, wantErr: true},
+		{desc: "malformedValue", leaf: &trillian.LogLeaf{LeafValue: []byte{0x80}, MerkleLeafHash: leaf.MerkleLeafHash}, wantErr: true},
+		{desc: "nil", wantErr: true},
+	} {
+		err := logVerifier.VerifyLeafHash(test.leaf)
+		if got, want := err != nil, test.wantErr; got != want {
+			t.Errorf("%v: VerifyLeafHash(): %v, wantErr %v", test.desc, err, want)
+		}
+	}
+}
@@ -0,0 +1,291 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/client/backoff"
+	"github.com/google/trillian/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MapClient represents a client for a given Trillian map instance.
+type MapClient struct {
+	*MapVerifier
+	MapID int64
+	// ProofFormat selects the inclusion proof encoding requested from the map.
+	ProofFormat trillian.MapProofFormat
+	client      trillian.TrillianMapClient
+
+	rootMu sync.Mutex
+	root   types.MapRootV1
+}
+
+// NewMapClient returns a new MapClient.
+func NewMapClient(mapID int64, client trillian.TrillianMapClient, verifier *MapVerifier) *MapClient {
+	return &MapClient{
+		MapVerifier: verifier,
+		MapID:       mapID,
+		client:      client,
+	}
+}
+
+// NewMapClientFromTree creates a new MapClient given a tree config.
+func NewMapClientFromTree(client trillian.TrillianMapClient, config *trillian.Tree) (*MapClient, error) {
+	verifier, err := NewMapVerifierFromTree(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewMapClient(config.GetTreeId(), client, verifier), nil
+}
+
+// Root returns a copy of the currently trusted map root.
+func (c *MapClient) Root() *types.MapRootV1 {
+	c.rootMu.Lock()
+	defer c.rootMu.Unlock()
+	ret := c.root
+	return &ret
+}
+
+// UpdateRoot retrieves the current SignedMapRoot, verifies its signature, and
+// updates the currently trusted root if the new root is more recent.
+func (c *MapClient) UpdateRoot(ctx context.Context) (*types.MapRootV1, error) {
+	resp, err := c.client.GetSignedMapRoot(ctx,
+		&trillian.GetSignedMapRootRequest{MapId: c.MapID})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.verifyAndTrustRoot(resp.GetMapRoot()); err != nil {
+		return nil, err
+	}
+	return c.Root(), nil
+}
+
+// GetAndVerifyMapRootByRevision fetches and verifies the SignedMapRoot at the
+// requested revision. The trusted root is updated if revision is newer than it.
+func (c *MapClient) GetAndVerifyMapRootByRevision(ctx context.Context, revision int64) (*types.MapRootV1, error) {
+	resp, err := c.client.GetSignedMapRootByRevision(ctx,
+		&trillian.GetSignedMapRootByRevisionRequest{
+			MapId:    c.MapID,
+			Revision: revision,
+		})
+	if err != nil {
+		return nil, err
+	}
+	mapRoot, err := c.verifyAndTrustRoot(resp.GetMapRoot())
+	if err != nil {
+		return nil, err
+	}
+	if got, want := mapRoot.Revision, uint64(revision); got != want {
+		return nil, fmt.Errorf("map root revision: %v, want %v", got, want)
+	}
+	return mapRoot, nil
+}
+
+// WaitForRootUpdate repeatedly fetches the latest root until its revision is
+// >= waitForRevision or until ctx times out.
+func (c *MapClient) WaitForRootUpdate(ctx context.Context, waitForRevision uint64) (*types.MapRootV1, error) {
+	b := &backoff.Backoff{
+		Min:    100 * time.Millisecond,
+		Max:    10 * time.Second,
+		Factor: 2,
+		Jitter: true,
+	}
+	for {
+		root, err := c.UpdateRoot(ctx)
+		switch x := status.Code(err); x {
+		case codes.OK:
+			if root.Revision >= waitForRevision {
+				return root, nil
+			}
+		case codes.Unavailable, codes.NotFound, codes.FailedPrecondition: // Retry.
+		default:
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, status.Errorf(codes.DeadlineExceeded, "%v", ctx.Err())
+		case <-time.After(b.Duration()):
+		}
+	}
+}
+
+// GetAndVerifyMapLeaf fetches a single leaf at the latest revision and
+// verifies its inclusion proof.
+func (c *MapClient) GetAndVerifyMapLeaf(ctx context.Context, index []byte) (*trillian.MapLeaf, *types.MapRootV1, error) {
+	leaves, root, err := c.GetAndVerifyMapLeaves(ctx, [][]byte{index})
+	if err != nil {
+		return nil, nil, err
+	}
+	return leaves[0], root, nil
+}
+
+// GetAndVerifyMapLeaves fetches the requested leaves at the latest revision
+// and verifies their inclusion proofs against the returned map root.
+// Leaves are returned in the same order as indexes.
+func (c *MapClient) GetAndVerifyMapLeaves(ctx context.Context, indexes [][]byte) ([]*trillian.MapLeaf, *types.MapRootV1, error) {
+	resp, err := c.client.GetLeaves(ctx, &trillian.GetMapLeavesRequest{
+		MapId:       c.MapID,
+		Index:       indexes,
+		ProofFormat: c.ProofFormat,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.verifyGetMapLeavesResponse(indexes, -1, resp)
+}
+
+// GetAndVerifyMapLeavesByRevision fetches the requested leaves at revision
+// and verifies their inclusion proofs against the returned map root.
+// Leaves are returned in the same order as indexes.
+func (c *MapClient) GetAndVerifyMapLeavesByRevision(ctx context.Context, revision int64, indexes [][]byte) ([]*trillian.MapLeaf, *types.MapRootV1, error) {
+	if revision < 0 {
+		return nil, nil, fmt.Errorf("map revision %d must be >= 0", revision)
+	}
+	resp, err := c.client.GetLeavesByRevision(ctx, &trillian.GetMapLeavesByRevisionRequest{
+		MapId:       c.MapID,
+		Index:       indexes,
+		Revision:    revision,
+		ProofFormat: c.ProofFormat,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.verifyGetMapLeavesResponse(indexes, revision, resp)
+}
+
+// BatchGetAndVerifyMapLeaves fetches an arbitrary number of leaves using
+// requests of at most batchSize indexes each. All batches are pinned to the
+// revision of the latest map root, so the results are mutually consistent.
+// Leaves are returned in the same order as indexes.
+func (c *MapClient) BatchGetAndVerifyMapLeaves(ctx context.Context, indexes [][]byte, batchSize int) ([]*trillian.MapLeaf, *types.MapRootV1, error) {
+	if batchSize <= 0 {
+		return nil, nil, fmt.Errorf("batchSize %d must be > 0", batchSize)
+	}
+	root, err := c.UpdateRoot(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("UpdateRoot(): %v", err)
+	}
+	leaves := make([]*trillian.MapLeaf, 0, len(indexes))
+	for start := 0; start < len(indexes); start += batchSize {
+		end := start + batchSize
+		if end > len(indexes) {
+			end = len(indexes)
+		}
+		batch, _, err := c.GetAndVerifyMapLeavesByRevision(ctx, int64(root.Revision), indexes[start:end])
+		if err != nil {
+			return nil, nil, fmt.Errorf("GetAndVerifyMapLeavesByRevision(%v, [%d:%d]): %v", root.Revision, start, end, err)
+		}
+		leaves = append(leaves, batch...)
+	}
+	return leaves, root, nil
+}
+
+// SetLeaves writes leaves to the map, verifies the signature on the resulting
+// map root, and updates the trusted root.
+func (c *MapClient) SetLeaves(ctx context.Context, leaves []*trillian.MapLeaf, metadata []byte) (*types.MapRootV1, error) {
+	resp, err := c.client.SetLeaves(ctx, &trillian.SetMapLeavesRequest{
+		MapId:    c.MapID,
+		Leaves:   leaves,
+		Metadata: metadata,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c.verifyAndTrustRoot(resp.GetMapRoot())
+}
+
+// SetLeavesAndWait writes leaves to the map and blocks until a map root with
+// the resulting revision is being served.
+// Best practice is to call this method with a context that will timeout.
+func (c *MapClient) SetLeavesAndWait(ctx context.Context, leaves []*trillian.MapLeaf, metadata []byte) (*types.MapRootV1, error) {
+	root, err := c.SetLeaves(ctx, leaves, metadata)
+	if err != nil {
+		return nil, fmt.Errorf("SetLeaves(): %v", err)
+	}
+	if _, err := c.WaitForRootUpdate(ctx, root.Revision); err != nil {
+		return nil, fmt.Errorf("WaitForRootUpdate(%v): %v", root.Revision, err)
+	}
+	return root, nil
+}
+
+// verifyGetMapLeavesResponse verifies the map root and every inclusion proof
+// in resp, and checks that exactly the requested indexes were returned.
+// Pass a negative revision to skip the revision check.
+func (c *MapClient) verifyGetMapLeavesResponse(indexes [][]byte, revision int64, resp *trillian.GetMapLeavesResponse) ([]*trillian.MapLeaf, *types.MapRootV1, error) {
+	mapRoot, err := c.verifyAndTrustRoot(resp.GetMapRoot())
+	if err != nil {
+		return nil, nil, err
+	}
+	if revision >= 0 && mapRoot.Revision != uint64(revision) {
+		return nil, nil, fmt.Errorf("map root revision: %v, want %v", mapRoot.Revision, revision)
+	}
+
+	inclusions := resp.GetMapLeafInclusion()
+	if got, want := len(inclusions), len(indexes); got != want {
+		return nil, nil, fmt.Errorf("len(MapLeafInclusion): %v, want %v", got, want)
+	}
+	multi := resp.GetMultiInclusion()
+	leaves := make([]*trillian.MapLeaf, 0, len(inclusions))
+	for i, inc := range inclusions {
+		if got, want := inc.GetLeaf().GetIndex(), indexes[i]; !bytes.Equal(got, want) {
+			return nil, nil, fmt.Errorf("MapLeafInclusion[%d].Leaf.Index: %x, want %x", i, got, want)
+		}
+		if multi == nil {
+			if err := c.VerifyMapLeafInclusionHash(mapRoot.RootHash, inc); err != nil {
+				return nil, nil, fmt.Errorf("VerifyMapLeafInclusionHash(%x): %v", indexes[i], err)
+			}
+		}
+		leaves = append(leaves, inc.GetLeaf())
+	}
+	if multi != nil {
+		if err := c.VerifyMapLeavesMultiInclusionHash(mapRoot.RootHash, inclusions, multi); err != nil {
+			return nil, nil, fmt.Errorf("VerifyMapLeavesMultiInclusionHash(): %v", err)
+		}
+	}
+	return leaves, mapRoot, nil
+}
+
+// verifyAndTrustRoot verifies the signature on smr, checks that it does not
+// contradict the currently trusted root, and trusts it if it is newer.
+// Revisions never go backwards: older roots are verified and returned, but
+// do not replace the trusted root.
+func (c *MapClient) verifyAndTrustRoot(smr *trillian.SignedMapRoot) (*types.MapRootV1, error) {
+	mapRoot, err := c.VerifySignedMapRoot(smr)
+	if err != nil {
+		return nil, err
+	}
+
+	c.rootMu.Lock()
+	defer c.rootMu.Unlock()
+	trusted := c.root
+	switch {
+	case trusted.RootHash == nil || mapRoot.Revision > trusted.Revision:
+		c.root = *mapRoot
+	case mapRoot.Revision == trusted.Revision && !bytes.Equal(mapRoot.RootHash, trusted.RootHash):
+		return nil, fmt.Errorf("map root hash at revision %v: %x, trusted %x",
+			mapRoot.Revision, mapRoot.RootHash, trusted.RootHash)
+	}
+	return mapRoot, nil
+}
@@ -0,0 +1,147 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/crypto/keys/pem"
+	"github.com/google/trillian/merkle/maphasher"
+	"github.com/google/trillian/storage/testdb"
+	"github.com/google/trillian/testonly"
+	"github.com/google/trillian/testonly/integration"
+	"github.com/google/trillian/types"
+
+	tcrypto "github.com/google/trillian/crypto"
+	stestonly "github.com/google/trillian/storage/testonly"
+)
+
+func TestMapClientTrustsRootsMonotonically(t *testing.T) {
+	key, err := pem.UnmarshalPrivateKey(testonly.DemoPrivateKey, testonly.DemoPrivateKeyPass)
+	if err != nil {
+		t.Fatalf("Failed to open test key, err=%v", err)
+	}
+	signer := tcrypto.NewSigner(0, key, crypto.SHA256)
+	c := NewMapClient(0, nil, &MapVerifier{
+		Hasher:  maphasher.Default,
+		PubKey:  key.Public(),
+		SigHash: crypto.SHA256,
+	})
+
+	sign := func(rev uint64, hash string) *trillian.SignedMapRoot {
+		t.Helper()
+		smr, err := signer.SignMapRoot(&types.MapRootV1{Revision: rev, RootHash: []byte(hash)})
+		if err != nil {
+			t.Fatalf("SignMapRoot(): %v", err)
+		}
+		return smr
+	}
+
+	for _, tc := range []struct {
+		desc        string
+		smr         *trillian.SignedMapRoot
+		wantErr     bool
+		wantTrusted uint64
+	}{
+		{desc: "first", smr: sign(2, "two"), wantTrusted: 2},
+		{desc: "newer", smr: sign(5, "five"), wantTrusted: 5},
+		{desc: "older", smr: sign(3, "three"), wantTrusted: 5},
+		{desc: "same", smr: sign(5, "five"), wantTrusted: 5},
+		{desc: "fork", smr: sign(5, "evil"), wantErr: true, wantTrusted: 5},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := c.verifyAndTrustRoot(tc.smr)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("verifyAndTrustRoot(): %v, wantErr %v", err, tc.wantErr)
+			}
+			if got := c.Root().Revision; got != tc.wantTrusted {
+				t.Errorf("Root().Revision: %v, want %v", got, tc.wantTrusted)
+			}
+		})
+	}
+}
+
+func TestMapClientSetAndGetLeaves(t *testing.T) {
+	testdb.SkipIfNoMySQL(t)
+	ctx := context.Background()
+	env, err := integration.NewMapEnv(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer env.Close()
+
+	tree, err := CreateAndInitTree(ctx,
+		&trillian.CreateTreeRequest{Tree: stestonly.MapTree},
+		env.Admin, env.Map, nil)
+	if err != nil {
+		t.Fatalf("Failed to create map: %v", err)
+	}
+	client, err := NewMapClientFromTree(env.Map, tree)
+	if err != nil {
+		t.Fatalf("NewMapClientFromTree(): %v", err)
+	}
+
+	index := func(s string) []byte {
+		h := sha256.Sum256([]byte(s))
+		return h[:]
+	}
+	leaves := []*trillian.MapLeaf{
+		{Index: index("A"), LeafValue: []byte("A")},
+		{Index: index("B"), LeafValue: []byte("B")},
+		{Index: index("C"), LeafValue: []byte("C")},
+	}
+	root, err := client.SetLeavesAndWait(ctx, leaves, []byte("meta"))
+	if err != nil {
+		t.Fatalf("SetLeavesAndWait(): %v", err)
+	}
+	if got, want := root.Revision, uint64(1); got != want {
+		t.Errorf("SetLeavesAndWait().Revision: %v, want %v", got, want)
+	}
+
+	indexes := [][]byte{index("A"), index("B"), index("C"), index("absent")}
+	got, _, err := client.BatchGetAndVerifyMapLeaves(ctx, indexes, 2)
+	if err != nil {
+		t.Fatalf("BatchGetAndVerifyMapLeaves(): %v", err)
+	}
+	for i, l := range got {
+		var want []byte
+		if i < len(leaves) {
+			want = leaves[i].LeafValue
+		}
+		if !bytes.Equal(l.LeafValue, want) {
+			t.Errorf("leaf[%d].LeafValue: %s, want %s", i, l.LeafValue, want)
+		}
+	}
+
+	client.ProofFormat = trillian.MapProofFormat_MAP_PROOF_FORMAT_COMPRESSED
+	if _, _, err := client.GetAndVerifyMapLeaves(ctx, indexes); err != nil {
+		t.Errorf("GetAndVerifyMapLeaves(compressed): %v", err)
+	}
+	client.ProofFormat = trillian.MapProofFormat_MAP_PROOF_FORMAT_MULTI
+	if _, _, err := client.GetAndVerifyMapLeaves(ctx, indexes); err != nil {
+		t.Errorf("GetAndVerifyMapLeaves(multi): %v", err)
+	}
+	if _, _, err := client.GetAndVerifyMapLeavesByRevision(ctx, 0, indexes[:1]); err != nil {
+		t.Errorf("GetAndVerifyMapLeavesByRevision(0): %v", err)
+	}
+	if got, want := client.Root().Revision, uint64(1); got != want {
+		t.Errorf("Root().Revision after reading revision 0: %v, want %v", got, want)
+	}
+}
@@ -78,13 +78,38 @@ func (m *MapVerifier) VerifyMapLeafInclusion(smr *trillian.SignedMapRoot, leafPr
 }
 
 // VerifyMapLeafInclusionHash verifies a MapLeafInclusion response against a root hash.
+// Both full and compressed proofs are accepted.
 func (m *MapVerifier) VerifyMapLeafInclusionHash(rootHash []byte, leafProof *trillian.MapLeafInclusion) error {
 	index := leafProof.GetLeaf().GetIndex()
 	leaf := leafProof.GetLeaf().GetLeafValue()
 	proof := leafProof.GetInclusion()
+	if bitmap := leafProof.GetInclusionBitmap(); len(bitmap) > 0 {
+		return merkle.VerifyCompressedMapInclusionProof(m.MapID, index, leaf, rootHash, bitmap, proof, m.Hasher)
+	}
 	return merkle.VerifyMapInclusionProof(m.MapID, index, leaf, rootHash, proof, m.Hasher)
 }
 
+// VerifyMapLeavesMultiInclusionHash verifies the leaves of a set of
+// MapLeafInclusion responses against a root hash, using a multi-proof covering
+// all of them.
+func (m *MapVerifier) VerifyMapLeavesMultiInclusionHash(rootHash []byte, leafProofs []*trillian.MapLeafInclusion, multi *trillian.MapMultiInclusionProof) error {
+	indices := make([][]byte, 0, len(leafProofs))
+	leaves := make([][]byte, 0, len(leafProofs))
+	for _, lp := range leafProofs {
+		indices = append(indices, lp.GetLeaf().GetIndex())
+		leaves = append(leaves, lp.GetLeaf().GetLeafValue())
+	}
+	sibs, err := merkle.MapMultiProofSiblings(indices, m.Hasher.BitLen())
+	if err != nil {
+		return err
+	}
+	proof, err := merkle.DecompressMapInclusionProof(multi.GetBitmap(), multi.GetHashes(), len(sibs))
+	if err != nil {
+		return fmt.Errorf("DecompressMapInclusionProof(): %v", err)
+	}
+	return merkle.VerifyMapMultiInclusionProof(m.MapID, indices, leaves, rootHash, proof, m.Hasher)
+}
+
 // VerifySignedMapRoot verifies the signature on the SignedMapRoot.
 func (m *MapVerifier) VerifySignedMapRoot(smr *trillian.SignedMapRoot) (*types.MapRootV1, error) {
 	return tcrypto.VerifySignedMapRoot(m.PubKey, m.SigHash, smr)
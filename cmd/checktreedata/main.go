@@ -0,0 +1,109 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main contains the implementation and entry point for the
+// checktreedata command, which verifies that no data of hard-deleted trees
+// remains in storage once it's purged.
+//
+// Example usage:
+// $ ./checktreedata --mysql_uri=...
+// $ ./checktreedata --mysql_uri=... --purge
+//
+// The command lists the trees whose data is pending purge, and the rows of
+// tree data that belong to trees that neither exist nor are pending purge,
+// exiting with an error if there are any. With --purge, pending purges are
+// completed first. Requires storage that implements storage.TreePurger.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian/cmd"
+	"github.com/google/trillian/monitoring"
+	"github.com/google/trillian/server"
+	"github.com/google/trillian/server/admin"
+	"github.com/google/trillian/storage"
+)
+
+var (
+	purge      = flag.Bool("purge", false, "If true, the data of trees pending purge is purged before checking")
+	chunkSize  = flag.Int64("chunk_size", server.DefaultTreePurgeChunkSize, "Maximum number of rows deleted per transaction when purging")
+	configFile = flag.String("config", "", "Config file containing flags, file contents can be overridden by command line flags")
+)
+
+// checkTreeData reports the trees of purger pending purge and any orphaned
+// tree data to w, after purging pending trees if purge is true. An error is
+// returned if there's orphaned data.
+func checkTreeData(ctx context.Context, w io.Writer, purger storage.TreePurger, purge bool, chunkSize int64) error {
+	if purge {
+		p := admin.NewTreeDataPurger(purger, chunkSize, 0 /* minRunInterval */, nil /* mf */)
+		count, err := p.RunOnce(ctx)
+		fmt.Fprintf(w, "purged %v trees\n", count)
+		if err != nil {
+			return err
+		}
+	}
+
+	pending, err := purger.PendingPurges(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list trees pending purge: %v", err)
+	}
+	for _, id := range pending {
+		fmt.Fprintf(w, "tree %v: pending purge\n", id)
+	}
+
+	orphans, err := purger.FindOrphanedTreeData(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to find orphaned tree data: %v", err)
+	}
+	for _, o := range orphans {
+		fmt.Fprintf(w, "tree %v: %v orphaned rows in %v\n", o.TreeID, o.Rows, o.Table)
+	}
+	if len(orphans) > 0 {
+		return errors.New("found orphaned tree data")
+	}
+	fmt.Fprintln(w, "no orphaned tree data found")
+	return nil
+}
+
+func main() {
+	flag.Parse()
+
+	if *configFile != "" {
+		if err := cmd.ParseFlagFile(*configFile); err != nil {
+			glog.Exitf("Failed to load flags from config file %q: %s", *configFile, err)
+		}
+	}
+
+	ctx := context.Background()
+	sp, err := server.NewStorageProviderFromFlags(monitoring.InertMetricFactory{})
+	if err != nil {
+		glog.Exitf("Failed to get storage provider: %v", err)
+	}
+	defer sp.Close()
+
+	purger, ok := sp.AdminStorage().(storage.TreePurger)
+	if !ok {
+		glog.Exit("Storage doesn't support purging tree data")
+	}
+	if err := checkTreeData(ctx, os.Stdout, purger, *purge, *chunkSize); err != nil {
+		glog.Exitf("Check failed: %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/trillian/storage"
+)
+
+// fakePurger is a storage.TreePurger whose pending trees are purged in a
+// single call.
+type fakePurger struct {
+	pending []int64
+	orphans []storage.TreeDataRows
+}
+
+func (f *fakePurger) PendingPurges(ctx context.Context) ([]int64, error) {
+	return f.pending, nil
+}
+
+func (f *fakePurger) PurgeTreeData(ctx context.Context, treeID int64, maxRows int64) (*storage.PurgeProgress, error) {
+	for i, id := range f.pending {
+		if id == treeID {
+			f.pending = append(f.pending[:i], f.pending[i+1:]...)
+			break
+		}
+	}
+	return &storage.PurgeProgress{Done: true}, nil
+}
+
+func (f *fakePurger) FindOrphanedTreeData(ctx context.Context) ([]storage.TreeDataRows, error) {
+	return f.orphans, nil
+}
+
+func TestCheckTreeData(t *testing.T) {
+	ctx := context.Background()
+	orphans := []storage.TreeDataRows{{TreeID: 3, Table: "LeafData", Rows: 10}}
+
+	for _, test := range []struct {
+		desc       string
+		pending    []int64
+		orphans    []storage.TreeDataRows
+		purge      bool
+		wantErr    bool
+		wantOutput []string
+	}{
+		{
+			desc:       "clean",
+			wantOutput: []string{"no orphaned tree data found"},
+		},
+		{
+			desc:       "pending",
+			pending:    []int64{1, 2},
+			wantOutput: []string{"tree 1: pending purge", "tree 2: pending purge", "no orphaned tree data found"},
+		},
+		{
+			desc:       "purged",
+			pending:    []int64{1, 2},
+			purge:      true,
+			wantOutput: []string{"purged 2 trees", "no orphaned tree data found"},
+		},
+		{
+			desc:       "orphans",
+			orphans:    orphans,
+			wantErr:    true,
+			wantOutput: []string{"tree 3: 10 orphaned rows in LeafData"},
+		},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			purger := &fakePurger{pending: test.pending, orphans: test.orphans}
+			var buf bytes.Buffer
+			err := checkTreeData(ctx, &buf, purger, test.purge, 10 /* chunkSize */)
+			if gotErr := err != nil; gotErr != test.wantErr {
+				t.Errorf("checkTreeData() returned err = %v, wantErr = %v", err, test.wantErr)
+			}
+			output := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			if got, want := strings.Join(output, "\n"), strings.Join(test.wantOutput, "\n"); got != want {
+				t.Errorf("checkTreeData() output:\n%v\nwant:\n%v", got, want)
+			}
+		})
+	}
+}
@@ -145,6 +145,10 @@ func newRequest() (*trillian.CreateTreeRequest, error) {
 			ctr.KeySpec.Params = &keyspb.Specification_RsaParams{
 				RsaParams: &keyspb.Specification_RSA{},
 			}
+		case sigpb.DigitallySigned_ED25519:
+			ctr.KeySpec.Params = &keyspb.Specification_Ed25519Params{
+				Ed25519Params: &keyspb.Specification_Ed25519{},
+			}
 		default:
 			return nil, fmt.Errorf("unsupported signature algorithm: %v", sa)
 		}
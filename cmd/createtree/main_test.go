@@ -27,6 +27,7 @@ import (
 	"github.com/golang/protobuf/ptypes/any"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/google/trillian"
+	"github.com/google/trillian/crypto/keyspb"
 	"github.com/google/trillian/crypto/sigpb"
 	"github.com/google/trillian/testonly"
 	"github.com/google/trillian/util/flagsaver"
@@ -211,3 +212,47 @@ func resetFlags() {
 		f.Value.Set(f.DefValue)
 	})
 }
+
+func TestNewRequestKeySpec(t *testing.T) {
+	for _, tc := range []struct {
+		sigAlg  sigpb.DigitallySigned_SignatureAlgorithm
+		want    *keyspb.Specification
+		wantErr bool
+	}{
+		{
+			sigAlg: sigpb.DigitallySigned_ECDSA,
+			want:   &keyspb.Specification{Params: &keyspb.Specification_EcdsaParams{EcdsaParams: &keyspb.Specification_ECDSA{}}},
+		},
+		{
+			sigAlg: sigpb.DigitallySigned_RSA,
+			want:   &keyspb.Specification{Params: &keyspb.Specification_RsaParams{RsaParams: &keyspb.Specification_RSA{}}},
+		},
+		{
+			sigAlg: sigpb.DigitallySigned_ED25519,
+			want:   &keyspb.Specification{Params: &keyspb.Specification_Ed25519Params{Ed25519Params: &keyspb.Specification_Ed25519{}}},
+		},
+		{
+			sigAlg:  sigpb.DigitallySigned_ANONYMOUS,
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.sigAlg.String(), func(t *testing.T) {
+			defer flagsaver.Save().Restore()
+			*signatureAlgorithm = tc.sigAlg.String()
+
+			req, err := newRequest()
+			if hasErr := err != nil; hasErr != tc.wantErr {
+				t.Fatalf("newRequest() '%v', wantErr = %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := req.Tree.SignatureAlgorithm; got != tc.sigAlg {
+				t.Errorf("newRequest().Tree.SignatureAlgorithm = %v, want %v", got, tc.sigAlg)
+			}
+			if got := req.KeySpec; !proto.Equal(got, tc.want) {
+				t.Errorf("newRequest().KeySpec = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main contains the implementation and entry point for the
+// encrypttreekeys command, which encrypts the private keys of existing trees
+// directly through their storage.
+//
+// Example usage:
+// $ ./encrypttreekeys --mysql_uri=... --kek_files=kek.hex --dry_run
+// $ ./encrypttreekeys --mysql_uri=... --kek_files=kek.hex
+//
+// Only private keys stored in plaintext, as keyspb.PrivateKey protos, are
+// encrypted, including those of multi_sig signers; keys held elsewhere (PEM
+// files, PKCS #11 modules, remote signers) and keys that are already encrypted
+// are left unchanged. The servers must be given the same --kek_files before
+// the command is run, so that they can use the encrypted keys.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/google/trillian"
+	"github.com/google/trillian/cmd"
+	"github.com/google/trillian/crypto/keys/envelope"
+	"github.com/google/trillian/crypto/keyspb"
+	"github.com/google/trillian/monitoring"
+	"github.com/google/trillian/server"
+	"github.com/google/trillian/storage"
+
+	envelopeproto "github.com/google/trillian/crypto/keys/envelope/proto"
+
+	// Register key ProtoHandlers
+	_ "github.com/google/trillian/crypto/keys/der/proto"
+	_ "github.com/google/trillian/crypto/keys/pem/proto"
+	_ "github.com/google/trillian/crypto/keys/pkcs11/proto"
+)
+
+var (
+	dryRun     = flag.Bool("dry_run", false, "If true, the trees whose keys would be encrypted are listed, but not updated")
+	configFile = flag.String("config", "", "Config file containing flags, file contents can be overridden by command line flags")
+)
+
+// isPlaintext returns true if privateKey holds the key in plaintext.
+func isPlaintext(privateKey *any.Any) bool {
+	return privateKey != nil && ptypes.Is(privateKey, &keyspb.PrivateKey{})
+}
+
+// hasPlaintextKeys returns true if tree holds any private key in plaintext.
+func hasPlaintextKeys(tree *trillian.Tree) bool {
+	if isPlaintext(tree.PrivateKey) {
+		return true
+	}
+	for _, ts := range tree.GetMultiSig().GetSigners() {
+		if isPlaintext(ts.PrivateKey) {
+			return true
+		}
+	}
+	return false
+}
+
+// encryptTrees encrypts the plaintext private keys of all trees in as with
+// kek, writing a line about each tree it changes to w. If dryRun is true, the
+// trees aren't updated.
+func encryptTrees(ctx context.Context, w io.Writer, as storage.AdminStorage, kek envelope.KEKProvider, dryRun bool) error {
+	return as.ReadWriteTransaction(ctx, func(ctx context.Context, tx storage.AdminTX) error {
+		trees, err := tx.ListTrees(ctx, true /* includeDeleted */)
+		if err != nil {
+			return fmt.Errorf("failed to list trees: %v", err)
+		}
+		for _, tree := range trees {
+			if !hasPlaintextKeys(tree) {
+				continue
+			}
+			if dryRun {
+				fmt.Fprintf(w, "dry run: private keys of tree %v would be encrypted\n", tree.TreeId)
+				continue
+			}
+			if err := encryptTree(ctx, kek, tree); err != nil {
+				return fmt.Errorf("failed to encrypt private keys of tree %v: %v", tree.TreeId, err)
+			}
+			if _, err := tx.UpdateTree(ctx, tree.TreeId, func(t *trillian.Tree) {
+				t.PrivateKey = tree.PrivateKey
+				t.MultiSig = tree.MultiSig
+			}); err != nil {
+				return fmt.Errorf("failed to update tree %v: %v", tree.TreeId, err)
+			}
+			fmt.Fprintf(w, "private keys of tree %v encrypted\n", tree.TreeId)
+		}
+		return nil
+	})
+}
+
+// encryptTree encrypts the plaintext private keys of tree in place, each bound
+// to its public key.
+func encryptTree(ctx context.Context, kek envelope.KEKProvider, tree *trillian.Tree) error {
+	var err error
+	if tree.PrivateKey, err = envelope.EncryptAny(ctx, kek, tree.PrivateKey, tree.PublicKey.GetDer()); err != nil {
+		return err
+	}
+	for i, ts := range tree.GetMultiSig().GetSigners() {
+		if ts.PrivateKey, err = envelope.EncryptAny(ctx, kek, ts.PrivateKey, ts.PublicKey.GetDer()); err != nil {
+			return fmt.Errorf("multi_sig signer %v: %v", i, err)
+		}
+	}
+	return nil
+}
+
+func main() {
+	flag.Parse()
+
+	if *configFile != "" {
+		if err := cmd.ParseFlagFile(*configFile); err != nil {
+			glog.Exitf("Failed to load flags from config file %q: %s", *configFile, err)
+		}
+	}
+
+	kek, err := envelopeproto.Provider()
+	if err != nil {
+		glog.Exitf("Failed to load key encryption keys: %v", err)
+	}
+	if kek == nil {
+		glog.Exit("Empty --kek_files, please provide the key encryption keys")
+	}
+
+	ctx := context.Background()
+	sp, err := server.NewStorageProviderFromFlags(monitoring.InertMetricFactory{})
+	if err != nil {
+		glog.Exitf("Failed to get storage provider: %v", err)
+	}
+	defer sp.Close()
+
+	if err := encryptTrees(ctx, os.Stdout, sp.AdminStorage(), kek, *dryRun); err != nil {
+		glog.Exitf("Failed to encrypt private keys: %v", err)
+	}
+}
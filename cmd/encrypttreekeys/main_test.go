@@ -0,0 +1,172 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/google/trillian"
+	"github.com/google/trillian/crypto/keys/envelope"
+	"github.com/google/trillian/crypto/keyspb"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/testonly"
+
+	ttestonly "github.com/google/trillian/testonly"
+)
+
+func TestEncryptTrees(t *testing.T) {
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "encrypttreekeys")
+	if err != nil {
+		t.Fatalf("TempDir(): %v", err)
+	}
+	defer os.RemoveAll(dir)
+	kekPath := filepath.Join(dir, "kek")
+	if err := ioutil.WriteFile(kekPath, []byte("000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"), 0600); err != nil {
+		t.Fatalf("WriteFile(): %v", err)
+	}
+	kek, err := envelope.NewFileKEKProvider(kekPath)
+	if err != nil {
+		t.Fatalf("NewFileKEKProvider(): %v", err)
+	}
+
+	keyProto := &keyspb.PrivateKey{Der: []byte("key")}
+	plaintextTree := proto.Clone(testonly.LogTree).(*trillian.Tree)
+	plaintextTree.TreeId = 1
+	plaintextTree.PrivateKey = ttestonly.MustMarshalAny(t, keyProto)
+	pemTree := proto.Clone(testonly.LogTree).(*trillian.Tree)
+	pemTree.TreeId = 2
+	pemTree.PrivateKey = ttestonly.MustMarshalAny(t, &keyspb.PEMKeyFile{Path: "key.pem"})
+	encryptedTree := proto.Clone(testonly.LogTree).(*trillian.Tree)
+	encryptedTree.TreeId = 3
+	encryptedTree.PrivateKey = ttestonly.MustMarshalAny(t, &keyspb.EncryptedPrivateKey{KekId: "kek"})
+	multiSigTree := proto.Clone(pemTree).(*trillian.Tree)
+	multiSigTree.TreeId = 4
+	multiSigTree.MultiSig = &trillian.MultiSigConfig{
+		Threshold: 1,
+		Signers: []*trillian.TreeSigner{{
+			PrivateKey: plaintextTree.PrivateKey,
+			PublicKey:  &keyspb.PublicKey{Der: []byte("signer public key")},
+		}},
+	}
+
+	// checkEncrypted checks that privateKey is keyProto encrypted with kek,
+	// and bound to publicKey.
+	checkEncrypted := func(ctx context.Context, desc string, privateKey *any.Any, publicKey *keyspb.PublicKey) {
+		var encrypted keyspb.EncryptedPrivateKey
+		if err := ptypes.UnmarshalAny(privateKey, &encrypted); err != nil {
+			t.Errorf("%v: private key isn't an EncryptedPrivateKey: %v", desc, err)
+			return
+		}
+		got, err := envelope.Decrypt(ctx, kek, &encrypted, publicKey.GetDer())
+		if err != nil {
+			t.Errorf("%v: Decrypt(): %v", desc, err)
+			return
+		}
+		if !proto.Equal(got, keyProto) {
+			t.Errorf("%v: private key decrypts to %v, want %v", desc, got, keyProto)
+		}
+	}
+
+	for _, test := range []struct {
+		desc        string
+		dryRun      bool
+		listErr     error
+		updateErr   error
+		wantUpdates []*trillian.Tree
+		wantErr     bool
+		wantOutput  []string
+	}{
+		{
+			desc:        "encrypt",
+			wantUpdates: []*trillian.Tree{plaintextTree, multiSigTree},
+			wantOutput: []string{
+				"private keys of tree 1 encrypted",
+				"private keys of tree 4 encrypted",
+			},
+		},
+		{
+			desc:   "dryRun",
+			dryRun: true,
+			wantOutput: []string{
+				"dry run: private keys of tree 1 would be encrypted",
+				"dry run: private keys of tree 4 would be encrypted",
+			},
+		},
+		{desc: "listErr", listErr: errors.New("ListTrees() failed"), wantErr: true},
+		{
+			desc:        "updateErr",
+			wantUpdates: []*trillian.Tree{plaintextTree},
+			updateErr:   errors.New("UpdateTree() failed"),
+			wantErr:     true,
+		},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tx := storage.NewMockAdminTX(ctrl)
+			tx.EXPECT().Close().MaxTimes(1).Return(nil)
+			if !test.wantErr {
+				tx.EXPECT().Commit().Return(nil)
+			}
+			as := &testonly.FakeAdminStorage{TX: []storage.AdminTX{tx}}
+
+			var trees []*trillian.Tree
+			for _, tree := range []*trillian.Tree{plaintextTree, pemTree, encryptedTree, multiSigTree} {
+				trees = append(trees, proto.Clone(tree).(*trillian.Tree))
+			}
+			tx.EXPECT().ListTrees(gomock.Any(), true).Return(trees, test.listErr)
+			for _, tree := range test.wantUpdates {
+				tree := tree
+				storedTree := proto.Clone(tree).(*trillian.Tree)
+				tx.EXPECT().UpdateTree(gomock.Any(), tree.TreeId, gomock.Any()).Do(func(ctx context.Context, treeID int64, updateFn func(*trillian.Tree)) {
+					updateFn(storedTree)
+					if isPlaintext(tree.PrivateKey) {
+						checkEncrypted(ctx, fmt.Sprintf("tree %v", treeID), storedTree.PrivateKey, storedTree.PublicKey)
+					} else if !proto.Equal(storedTree.PrivateKey, tree.PrivateKey) {
+						t.Errorf("tree %v: private key changed to %v, want unchanged", treeID, storedTree.PrivateKey)
+					}
+					for i, ts := range storedTree.GetMultiSig().GetSigners() {
+						checkEncrypted(ctx, fmt.Sprintf("tree %v multi_sig signer %v", treeID, i), ts.PrivateKey, ts.PublicKey)
+					}
+				}).Return(storedTree, test.updateErr)
+			}
+
+			var buf bytes.Buffer
+			err := encryptTrees(ctx, &buf, as, kek, test.dryRun)
+			if gotErr := err != nil; gotErr != test.wantErr {
+				t.Fatalf("encryptTrees(): %v, wantErr %v", err, test.wantErr)
+			}
+			for _, want := range test.wantOutput {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("encryptTrees() output %q doesn't contain %q", buf.String(), want)
+				}
+			}
+		})
+	}
+}
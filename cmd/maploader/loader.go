@@ -0,0 +1,319 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian"
+	"github.com/google/trillian/merkle"
+	"github.com/google/trillian/merkle/hashers"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/trees"
+	"github.com/google/trillian/types"
+)
+
+// topSubtreeDepth is the depth of the subtree at the top of the map. It
+// matches the layout used by merkle.SparseMerkleTreeWriter, so the leaves
+// under each of its bottom nodes are loaded and hashed together.
+const topSubtreeDepth = 8
+
+// leafReader reads map leaves from a file of sorted (index, value) pairs.
+type leafReader struct {
+	s         *bufio.Scanner
+	indexSize int
+	line      int
+	prev      []byte
+}
+
+func newLeafReader(r io.Reader, indexSize int) *leafReader {
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 64*1024*1024)
+	return &leafReader{s: s, indexSize: indexSize}
+}
+
+// next returns the next leaf, or io.EOF once all the leaves have been read.
+func (r *leafReader) next() (*trillian.MapLeaf, error) {
+	for r.s.Scan() {
+		r.line++
+		fields := strings.Fields(r.s.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: got %d fields, want index and value", r.line, len(fields))
+		}
+		index, err := hex.DecodeString(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid index: %v", r.line, err)
+		}
+		if got, want := len(index), r.indexSize; got != want {
+			return nil, fmt.Errorf("line %d: len(index): %d, want %d", r.line, got, want)
+		}
+		if r.prev != nil && bytes.Compare(index, r.prev) <= 0 {
+			return nil, fmt.Errorf("line %d: index %x does not sort after %x", r.line, index, r.prev)
+		}
+		value, err := base64.StdEncoding.DecodeString(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid value: %v", r.line, err)
+		}
+		if len(value) == 0 {
+			return nil, fmt.Errorf("line %d: empty value", r.line)
+		}
+		r.prev = index
+		return &trillian.MapLeaf{Index: index, LeafValue: value}, nil
+	}
+	if err := r.s.Err(); err != nil {
+		return nil, fmt.Errorf("line %d: %v", r.line, err)
+	}
+	return nil, io.EOF
+}
+
+// loader writes the leaves of a newly initialised map directly to storage.
+type loader struct {
+	ms     storage.MapStorage
+	tree   *trillian.Tree
+	hasher hashers.MapHasher
+	// workers is the number of goroutines used to calculate each subtree.
+	workers int
+	// verifyEvery controls which leaves are kept in samples for verify.
+	verifyEvery int
+
+	// count is the number of leaves loaded.
+	count int
+	// samples holds the leaves which verify checks.
+	samples []*trillian.MapLeaf
+}
+
+// load reads the leaves from the file returned by open, and writes them, the
+// nodes of the tree above them and a signed root to a single new revision of
+// the map. open may be called more than once if the storage retries the
+// transaction.
+func (l *loader) load(ctx context.Context, open func() (io.ReadCloser, error), metadata []byte) (*trillian.SignedMapRoot, error) {
+	ctx = trees.NewContext(ctx, l.tree)
+	var newRoot *trillian.SignedMapRoot
+	err := l.ms.ReadWriteTransaction(ctx, l.tree, func(ctx context.Context, tx storage.MapTreeTX) error {
+		l.count = 0
+		l.samples = nil
+
+		latest, err := tx.LatestSignedMapRoot(ctx)
+		if err != nil {
+			return fmt.Errorf("LatestSignedMapRoot(): %v", err)
+		}
+		var latestRoot types.MapRootV1
+		if err := latestRoot.UnmarshalBinary(latest.MapRoot); err != nil {
+			return fmt.Errorf("UnmarshalBinary(): %v", err)
+		}
+		if latestRoot.Revision != 0 {
+			return fmt.Errorf("map is at revision %d, want a newly initialised map", latestRoot.Revision)
+		}
+
+		f, err := open()
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		// Leaves are sorted, so all the leaves under each node at the bottom of
+		// the top subtree are read consecutively.
+		r := newLeafReader(f, l.hasher.Size())
+		var roots []merkle.HStar2LeafHash
+		var leaves []*trillian.MapLeaf
+		flush := func() error {
+			if len(leaves) == 0 {
+				return nil
+			}
+			prefix := leaves[0].Index[:topSubtreeDepth/8]
+			root, err := l.writeSubtree(ctx, tx, prefix, leaves)
+			if err != nil {
+				return fmt.Errorf("writeSubtree(%x): %v", prefix, err)
+			}
+			roots = append(roots, merkle.HStar2LeafHash{
+				Index:    storage.NewNodeIDFromPrefixSuffix(prefix, storage.Suffix{}, l.hasher.BitLen()).BigInt(),
+				LeafHash: root,
+			})
+			glog.V(1).Infof("%v: Loaded %d leaves under %x", l.tree.TreeId, len(leaves), prefix)
+			leaves = nil
+			return nil
+		}
+		for {
+			leaf, err := r.next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			if len(leaves) > 0 && !bytes.HasPrefix(leaf.Index, leaves[0].Index[:topSubtreeDepth/8]) {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+			leaves = append(leaves, leaf)
+		}
+		if err := flush(); err != nil {
+			return err
+		}
+		if l.count == 0 {
+			return errors.New("no leaves to load")
+		}
+
+		rootHash, err := l.writeTop(ctx, tx, roots)
+		if err != nil {
+			return err
+		}
+		signer, err := trees.Signer(ctx, l.tree)
+		if err != nil {
+			return fmt.Errorf("trees.Signer(): %v", err)
+		}
+		newRoot, err = signer.SignMapRoot(&types.MapRootV1{
+			RootHash:       rootHash,
+			TimestampNanos: uint64(time.Now().UnixNano()),
+			Revision:       uint64(tx.WriteRevision()),
+			Metadata:       metadata,
+		})
+		if err != nil {
+			return fmt.Errorf("SignMapRoot(): %v", err)
+		}
+		return tx.StoreSignedMapRoot(ctx, *newRoot)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newRoot, nil
+}
+
+// writeSubtree stores leaves, which all start with prefix, together with the
+// nodes of the subtree under prefix, and returns the subtree root hash.
+func (l *loader) writeSubtree(ctx context.Context, tx storage.MapTreeTX, prefix []byte, leaves []*trillian.MapLeaf) ([]byte, error) {
+	rev := tx.WriteRevision()
+	hs2Leaves := make([]merkle.HStar2LeafHash, 0, len(leaves))
+	nodes := make([]storage.Node, 0, len(leaves)*2)
+	for _, leaf := range leaves {
+		leafHash, err := l.hasher.HashLeaf(l.tree.TreeId, leaf.Index, leaf.LeafValue)
+		if err != nil {
+			return nil, fmt.Errorf("HashLeaf(): %v", err)
+		}
+		leaf.LeafHash = leafHash
+		if err := tx.Set(ctx, leaf.Index, *leaf); err != nil {
+			return nil, err
+		}
+
+		nodeID := storage.NewNodeIDFromHash(leaf.Index)
+		hs2Leaves = append(hs2Leaves, merkle.HStar2LeafHash{Index: nodeID.BigInt(), LeafHash: leafHash})
+		nodes = append(nodes, storage.Node{NodeID: nodeID, Hash: leafHash, NodeRevision: rev})
+
+		if l.verifyEvery > 0 && l.count%l.verifyEvery == 0 {
+			l.samples = append(l.samples, leaf)
+		}
+		l.count++
+	}
+
+	// The map is empty below the new leaves, so there are no nodes to get.
+	var nodesMu sync.Mutex
+	hs2 := merkle.NewParallelHStar2(l.tree.TreeId, l.hasher, l.workers)
+	root, err := hs2.HStar2Nodes(prefix, l.hasher.BitLen()-len(prefix)*8, hs2Leaves, nil,
+		func(depth int, index *big.Int, h []byte) error {
+			nodesMu.Lock()
+			defer nodesMu.Unlock()
+			nodeID := storage.NewNodeIDFromBigInt(depth, index, l.hasher.BitLen())
+			nodes = append(nodes, storage.Node{NodeID: nodeID, Hash: h, NodeRevision: rev})
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("HStar2Nodes(): %v", err)
+	}
+	if err := tx.SetMerkleNodes(ctx, nodes); err != nil {
+		return nil, fmt.Errorf("SetMerkleNodes(): %v", err)
+	}
+	return root, nil
+}
+
+// writeTop stores the nodes of the top subtree, whose leaves are the roots of
+// the subtrees written by writeSubtree, and returns the map root hash.
+func (l *loader) writeTop(ctx context.Context, tx storage.MapTreeTX, roots []merkle.HStar2LeafHash) ([]byte, error) {
+	rev := tx.WriteRevision()
+	var nodes []storage.Node
+	hs2 := merkle.NewHStar2(l.tree.TreeId, l.hasher)
+	root, err := hs2.HStar2Nodes(nil, topSubtreeDepth, roots, nil,
+		func(depth int, index *big.Int, h []byte) error {
+			nodeID := storage.NewNodeIDFromBigInt(depth, index, l.hasher.BitLen())
+			nodes = append(nodes, storage.Node{NodeID: nodeID, Hash: h, NodeRevision: rev})
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("HStar2Nodes(): %v", err)
+	}
+	if err := tx.SetMerkleNodes(ctx, nodes); err != nil {
+		return nil, fmt.Errorf("SetMerkleNodes(): %v", err)
+	}
+	return root, nil
+}
+
+// verify checks that the stored map matches smr, and that the sampled leaves
+// have valid inclusion proofs from the stored tree.
+func (l *loader) verify(ctx context.Context, smr *trillian.SignedMapRoot) error {
+	var root types.MapRootV1
+	if err := root.UnmarshalBinary(smr.MapRoot); err != nil {
+		return fmt.Errorf("UnmarshalBinary(): %v", err)
+	}
+	rev := int64(root.Revision)
+
+	tx, err := l.ms.SnapshotForTree(ctx, l.tree)
+	if err != nil {
+		return err
+	}
+	defer tx.Close()
+
+	r := merkle.NewSparseMerkleTreeReader(rev, l.hasher, tx)
+	storedRoot, err := r.RootAtRevision(ctx, rev)
+	if err != nil {
+		return fmt.Errorf("RootAtRevision(%d): %v", rev, err)
+	}
+	if got, want := storedRoot, root.RootHash; !bytes.Equal(got, want) {
+		return fmt.Errorf("stored root hash: %x, want %x", got, want)
+	}
+
+	for _, leaf := range l.samples {
+		proof, err := r.InclusionProof(ctx, rev, leaf.Index)
+		if err != nil {
+			return fmt.Errorf("InclusionProof(%x): %v", leaf.Index, err)
+		}
+		if err := merkle.VerifyMapInclusionProof(l.tree.TreeId, leaf.Index, leaf.LeafValue, root.RootHash, proof, l.hasher); err != nil {
+			return fmt.Errorf("VerifyMapInclusionProof(%x): %v", leaf.Index, err)
+		}
+		stored, err := tx.Get(ctx, rev, [][]byte{leaf.Index})
+		if err != nil {
+			return fmt.Errorf("Get(%x): %v", leaf.Index, err)
+		}
+		if len(stored) != 1 || !bytes.Equal(stored[0].LeafValue, leaf.LeafValue) {
+			return fmt.Errorf("stored leaf %x does not match the loaded value", leaf.Index)
+		}
+	}
+	glog.Infof("%v: Verified %d of %d leaves at revision %d", l.tree.TreeId, len(l.samples), l.count, rev)
+	return tx.Commit()
+}
@@ -0,0 +1,183 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/client"
+	"github.com/google/trillian/merkle/hashers"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/mysql"
+	"github.com/google/trillian/storage/testdb"
+	"github.com/google/trillian/testonly"
+	"github.com/google/trillian/testonly/integration"
+
+	stestonly "github.com/google/trillian/storage/testonly"
+)
+
+func TestLeafReader(t *testing.T) {
+	a := strings.Repeat("00", 31) + "0a"
+	b := strings.Repeat("00", 31) + "0b"
+	v := base64.StdEncoding.EncodeToString([]byte("value"))
+
+	for _, tc := range []struct {
+		desc    string
+		input   string
+		want    int
+		wantErr bool
+	}{
+		{desc: "empty", input: "", want: 0},
+		{desc: "sorted", input: a + " " + v + "\n\n" + b + "\t" + v + "\n", want: 2},
+		{desc: "unsorted", input: b + " " + v + "\n" + a + " " + v + "\n", want: 1, wantErr: true},
+		{desc: "duplicate", input: a + " " + v + "\n" + a + " " + v + "\n", want: 1, wantErr: true},
+		{desc: "short index", input: "0a " + v + "\n", wantErr: true},
+		{desc: "bad hex", input: "zz" + a[2:] + " " + v + "\n", wantErr: true},
+		{desc: "bad base64", input: a + " !!!\n", wantErr: true},
+		{desc: "missing value", input: a + "\n", wantErr: true},
+		{desc: "extra field", input: a + " " + v + " " + v + "\n", wantErr: true},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			r := newLeafReader(strings.NewReader(tc.input), 32)
+			var got int
+			var err error
+			for {
+				var leaf *trillian.MapLeaf
+				if leaf, err = r.next(); err != nil {
+					break
+				}
+				if got, want := leaf.LeafValue, []byte("value"); !bytes.Equal(got, want) {
+					t.Errorf("next(): value %q, want %q", got, want)
+				}
+				got++
+			}
+			if gotErr := err != io.EOF; gotErr != tc.wantErr {
+				t.Errorf("next(): %v, wantErr %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("read %d leaves, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLoadMatchesSetLeaves(t *testing.T) {
+	testdb.SkipIfNoMySQL(t)
+	ctx := context.Background()
+	env, err := integration.NewMapEnv(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer env.Close()
+
+	var leaves []*trillian.MapLeaf
+	for i := 0; i < 500; i++ {
+		leaves = append(leaves, &trillian.MapLeaf{
+			Index:     testonly.HashKey(fmt.Sprintf("key-%d", i)),
+			LeafValue: []byte(fmt.Sprintf("value-%d", i)),
+		})
+	}
+	sort.Slice(leaves, func(i, j int) bool { return bytes.Compare(leaves[i].Index, leaves[j].Index) < 0 })
+	var input bytes.Buffer
+	for _, l := range leaves {
+		fmt.Fprintf(&input, "%x %s\n", l.Index, base64.StdEncoding.EncodeToString(l.LeafValue))
+	}
+
+	newMap := func() *client.MapClient {
+		tree, err := client.CreateAndInitTree(ctx,
+			&trillian.CreateTreeRequest{Tree: stestonly.MapTree},
+			env.Admin, env.Map, nil)
+		if err != nil {
+			t.Fatalf("Failed to create map: %v", err)
+		}
+		c, err := client.NewMapClientFromTree(env.Map, tree)
+		if err != nil {
+			t.Fatalf("NewMapClientFromTree(): %v", err)
+		}
+		return c
+	}
+
+	// Populate one map through the map server, and the other with the loader.
+	setMap := newMap()
+	wantRoot, err := setMap.SetLeavesAndWait(ctx, leaves, nil)
+	if err != nil {
+		t.Fatalf("SetLeavesAndWait(): %v", err)
+	}
+
+	loadMap := newMap()
+	tree, err := storage.GetTree(ctx, mysql.NewAdminStorage(env.DB), loadMap.MapID)
+	if err != nil {
+		t.Fatalf("GetTree(): %v", err)
+	}
+	hasher, err := hashers.NewMapHasher(tree.HashStrategy)
+	if err != nil {
+		t.Fatalf("NewMapHasher(): %v", err)
+	}
+	l := &loader{
+		ms:          mysql.NewMapStorage(env.DB),
+		tree:        tree,
+		hasher:      hasher,
+		workers:     4,
+		verifyEvery: 7,
+	}
+	open := func() (io.ReadCloser, error) { return ioutil.NopCloser(bytes.NewReader(input.Bytes())), nil }
+	smr, err := l.load(ctx, open, []byte("loaded"))
+	if err != nil {
+		t.Fatalf("load(): %v", err)
+	}
+	if err := l.verify(ctx, smr); err != nil {
+		t.Errorf("verify(): %v", err)
+	}
+	if got, want := l.count, len(leaves); got != want {
+		t.Errorf("loaded %d leaves, want %d", got, want)
+	}
+
+	root, err := loadMap.UpdateRoot(ctx)
+	if err != nil {
+		t.Fatalf("UpdateRoot(): %v", err)
+	}
+	if got, want := root.RootHash, wantRoot.RootHash; !bytes.Equal(got, want) {
+		t.Errorf("loaded root hash: %x, want %x", got, want)
+	}
+	if got, want := root.Revision, uint64(1); got != want {
+		t.Errorf("loaded root revision: %d, want %d", got, want)
+	}
+
+	// The map server must serve verifiable proofs for the loaded leaves.
+	indexes := [][]byte{leaves[0].Index, leaves[len(leaves)/2].Index, testonly.HashKey("missing")}
+	got, _, err := loadMap.GetAndVerifyMapLeaves(ctx, indexes)
+	if err != nil {
+		t.Fatalf("GetAndVerifyMapLeaves(): %v", err)
+	}
+	for i, leaf := range got[:2] {
+		if !bytes.Equal(leaf.LeafValue, leaves[i*len(leaves)/2].LeafValue) {
+			t.Errorf("GetAndVerifyMapLeaves(): leaf %x = %q, want %q", leaf.Index, leaf.LeafValue, leaves[i*len(leaves)/2].LeafValue)
+		}
+	}
+
+	// A map which already has leaves can not be bulk loaded.
+	if _, err := l.load(ctx, open, nil); err == nil {
+		t.Error("load(): nil on a populated map, want error")
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main contains the implementation and entry point for the maploader
+// command, which performs the initial population of a map directly through
+// its storage.
+//
+// Example usage:
+// $ ./maploader --mysql_uri=... --map_id=mapid --input=leaves.txt
+//
+// The input file holds one leaf per line, as a hex encoded index followed by
+// whitespace and the base64 encoded leaf value. Lines must be sorted by index,
+// and each index may appear only once.
+//
+// All the leaves are written in a single new revision, so the map must have
+// been initialised (e.g. by createtree), and must not have had any leaves set
+// since. The map server should not be writing to the map while the loader is
+// running. Once loaded, a sample of the leaves is checked against inclusion
+// proofs from the stored tree.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian"
+	"github.com/google/trillian/cmd"
+	"github.com/google/trillian/merkle/hashers"
+	"github.com/google/trillian/monitoring"
+	"github.com/google/trillian/server"
+	"github.com/google/trillian/trees"
+
+	// Register key ProtoHandlers
+	_ "github.com/google/trillian/crypto/keys/der/proto"
+	_ "github.com/google/trillian/crypto/keys/pem/proto"
+	_ "github.com/google/trillian/crypto/keys/pkcs11/proto"
+	// Load hashers
+	_ "github.com/google/trillian/merkle/coniks"
+	_ "github.com/google/trillian/merkle/maphasher"
+)
+
+var (
+	mapID       = flag.Int64("map_id", 0, "Trillian MapID to load the leaves into")
+	inputFile   = flag.String("input", "", "File of sorted (index, value) pairs to load")
+	metadata    = flag.String("metadata", "", "Metadata to include in the signed map root")
+	workers     = flag.Int("workers", runtime.NumCPU(), "Number of goroutines used to calculate the tree")
+	verifyEvery = flag.Int("verify_every", 1000, "Verify the inclusion proof of every Nth loaded leaf; zero disables verification")
+
+	configFile = flag.String("config", "", "Config file containing flags, file contents can be overridden by command line flags")
+)
+
+func main() {
+	flag.Parse()
+
+	if *configFile != "" {
+		if err := cmd.ParseFlagFile(*configFile); err != nil {
+			glog.Exitf("Failed to load flags from config file %q: %s", *configFile, err)
+		}
+	}
+	if *inputFile == "" {
+		glog.Exit("Empty --input, please provide the file of leaves to load")
+	}
+
+	ctx := context.Background()
+	sp, err := server.NewStorageProviderFromFlags(monitoring.InertMetricFactory{})
+	if err != nil {
+		glog.Exitf("Failed to get storage provider: %v", err)
+	}
+	defer sp.Close()
+
+	tree, err := trees.GetTree(ctx, sp.AdminStorage(), *mapID, trees.NewGetOpts(trees.UpdateMap, trillian.TreeType_MAP))
+	if err != nil {
+		glog.Exitf("Failed to get map %d: %v", *mapID, err)
+	}
+	hasher, err := hashers.NewMapHasher(tree.HashStrategy)
+	if err != nil {
+		glog.Exitf("Failed to create map hasher: %v", err)
+	}
+
+	l := &loader{
+		ms:          sp.MapStorage(),
+		tree:        tree,
+		hasher:      hasher,
+		workers:     *workers,
+		verifyEvery: *verifyEvery,
+	}
+	open := func() (io.ReadCloser, error) { return os.Open(*inputFile) }
+	root, err := l.load(ctx, open, []byte(*metadata))
+	if err != nil {
+		glog.Exitf("Failed to load map %d: %v", *mapID, err)
+	}
+	if err := l.verify(ctx, root); err != nil {
+		glog.Exitf("Failed to verify map %d: %v", *mapID, err)
+	}
+	fmt.Printf("Loaded %d leaves into map %d\n", l.count, *mapID)
+}
@@ -0,0 +1,178 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// The remote_signer binary runs a reference implementation of the RemoteSigner
+// gRPC service, which holds private keys on behalf of Trillian servers. Keys are
+// read from PEM files at startup.
+//
+// Example usage:
+// $ ./remote_signer --rpc_endpoint=localhost:8099 --keys=log1=/path/to/key.pem
+//
+// Trees can then use a keyspb.RemoteSignerConfig private key with address
+// "localhost:8099" and key_id "log1".
+//
+// Any client that can connect to --rpc_endpoint can sign with the keys, unless
+// --tls_client_ca_file is set, in which case clients must present a
+// certificate issued by that CA (see the --remote_signer_tls_cert_file and
+// --remote_signer_tls_key_file flags of Trillian servers). Without it, the
+// server must only be reachable by trusted clients, e.g. on an isolated
+// network.
+package main
+
+import (
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian/cmd"
+	"github.com/google/trillian/crypto/keys/pem"
+	"github.com/google/trillian/crypto/keys/remote"
+	"github.com/google/trillian/crypto/keys/remote/remotepb"
+	"github.com/google/trillian/monitoring"
+	"github.com/google/trillian/monitoring/prometheus"
+	"github.com/google/trillian/util"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+var (
+	rpcEndpoint  = flag.String("rpc_endpoint", "localhost:8099", "Endpoint for RPC requests (host:port)")
+	httpEndpoint = flag.String("http_endpoint", "localhost:8100", "Endpoint for HTTP metrics (host:port, empty means disabled)")
+	tlsCertFile  = flag.String("tls_cert_file", "", "Path to the TLS server certificate. If unset, the server will use unsecured connections.")
+	tlsKeyFile   = flag.String("tls_key_file", "", "Path to the TLS server key. If unset, the server will use unsecured connections.")
+	tlsClientCA  = flag.String("tls_client_ca_file", "", "Path to the CA certificate used to authenticate clients, which must then present a certificate it issued. Requires --tls_cert_file and --tls_key_file. If unset, any client that can connect may sign with the keys, so the server must be isolated from untrusted networks.")
+	keyFiles     = flag.String("keys", "", "Comma-separated list of keys to serve, each in the form key_id=path, where path is a PEM-encoded private key file")
+	keyPassword  = flag.String("key_password", "", "Password for decrypting the private key files, if they are encrypted")
+
+	configFile = flag.String("config", "", "Config file containing flags, file contents can be overridden by command line flags")
+)
+
+// loadKeys reads the private keys listed in spec, which has the format of the
+// --keys flag.
+func loadKeys(spec, password string) (map[string]crypto.Signer, error) {
+	keys := make(map[string]crypto.Signer)
+	for _, entry := range strings.Split(spec, ",") {
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("malformed key %q, want key_id=path", entry)
+		}
+		keyID, path := parts[0], parts[1]
+		if _, ok := keys[keyID]; ok {
+			return nil, fmt.Errorf("duplicate key ID %q", keyID)
+		}
+		key, err := pem.ReadPrivateKeyFile(path, password)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key %q: %v", keyID, err)
+		}
+		keys[keyID] = key
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no keys provided, please set --keys")
+	}
+	return keys, nil
+}
+
+// serverCredentials returns TLS credentials for the server certificate and
+// key in certFile and keyFile. If clientCAFile is set, clients must present a
+// certificate issued by the CA certificate it holds.
+func serverCredentials(certFile, keyFile, clientCAFile string) (credentials.TransportCredentials, error) {
+	if clientCAFile == "" {
+		return credentials.NewServerTLSFromFile(certFile, keyFile)
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	caPEM, err := ioutil.ReadFile(clientCAFile)
+	if err != nil {
+		return nil, err
+	}
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("no certificates found in %v", clientCAFile)
+	}
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	}), nil
+}
+
+func main() {
+	flag.Parse()
+
+	if *configFile != "" {
+		if err := cmd.ParseFlagFile(*configFile); err != nil {
+			glog.Exitf("Failed to load flags from config file %q: %s", *configFile, err)
+		}
+	}
+
+	keys, err := loadKeys(*keyFiles, *keyPassword)
+	if err != nil {
+		glog.Exitf("Failed to load keys: %v", err)
+	}
+
+	mf := prometheus.MetricFactory{}
+	stats := monitoring.NewRPCStatsInterceptor(util.SystemTimeSource{}, "remote_signer", mf)
+	opts := []grpc.ServerOption{grpc.UnaryInterceptor(stats.Interceptor())}
+	// Let serverCredentials handle the error case when only one of the flags is set.
+	if *tlsCertFile != "" || *tlsKeyFile != "" {
+		creds, err := serverCredentials(*tlsCertFile, *tlsKeyFile, *tlsClientCA)
+		if err != nil {
+			glog.Exitf("Failed to load TLS credentials: %v", err)
+		}
+		opts = append(opts, grpc.Creds(creds))
+	} else if *tlsClientCA != "" {
+		glog.Exit("--tls_client_ca_file requires --tls_cert_file and --tls_key_file")
+	}
+	if *tlsClientCA == "" {
+		glog.Warningf("--tls_client_ca_file is unset: any client that can connect to %v can sign with the keys", *rpcEndpoint)
+	}
+	srv := grpc.NewServer(opts...)
+	remotepb.RegisterRemoteSignerServer(srv, remote.NewServer(keys))
+
+	if *httpEndpoint != "" {
+		http.Handle("/metrics", promhttp.Handler())
+		http.HandleFunc("/healthz", func(rw http.ResponseWriter, req *http.Request) {
+			rw.Write([]byte("ok"))
+		})
+		if err := util.StartHTTPServer(*httpEndpoint, *tlsCertFile, *tlsKeyFile); err != nil {
+			glog.Exitf("Failed to start HTTP server on %v: %v", *httpEndpoint, err)
+		}
+	}
+
+	glog.Infof("RPC server starting on %v, serving %v keys", *rpcEndpoint, len(keys))
+	lis, err := net.Listen("tcp", *rpcEndpoint)
+	if err != nil {
+		glog.Exitf("Failed to listen on %v: %v", *rpcEndpoint, err)
+	}
+	go util.AwaitSignal(srv.Stop)
+
+	if err := srv.Serve(lis); err != nil {
+		glog.Errorf("RPC server terminated: %v", err)
+	}
+	glog.Infof("Stopping server, about to exit")
+	glog.Flush()
+}
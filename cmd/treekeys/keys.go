@@ -0,0 +1,192 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/google/trillian"
+	"github.com/google/trillian/crypto/keys/der"
+	"github.com/google/trillian/crypto/keyspb"
+	"github.com/google/trillian/trees"
+	"github.com/letsencrypt/pkcs11key"
+	"google.golang.org/genproto/protobuf/field_mask"
+
+	tpem "github.com/google/trillian/crypto/keys/pem"
+)
+
+var (
+	privateKeyFormat = flag.String("private_key_format", "", "Type of protobuf message describing the private key to verify or migrate to (PrivateKey, PEMKeyFile, or PKCS11ConfigFile)")
+	pemKeyPath       = flag.String("pem_key_path", "", "Path to the private key PEM file")
+	pemKeyPass       = flag.String("pem_key_password", "", "Password of the private key PEM file")
+	pkcs11ConfigPath = flag.String("pkcs11_config_path", "", "Path to the PKCS #11 key configuration file")
+)
+
+// privateKeyFromFlags returns the private key proto described by
+// --private_key_format and the flags of that format.
+func privateKeyFromFlags() (*any.Any, error) {
+	var pb proto.Message
+	switch *privateKeyFormat {
+	case "PEMKeyFile":
+		if *pemKeyPath == "" {
+			return nil, errors.New("empty pem_key_path")
+		}
+		if *pemKeyPass == "" {
+			return nil, fmt.Errorf("empty password for PEM key file %q", *pemKeyPath)
+		}
+		pb = &keyspb.PEMKeyFile{Path: *pemKeyPath, Password: *pemKeyPass}
+	case "PrivateKey":
+		if *pemKeyPath == "" {
+			return nil, errors.New("empty pem_key_path")
+		}
+		key, err := tpem.ReadPrivateKeyFile(*pemKeyPath, *pemKeyPass)
+		if err != nil {
+			return nil, fmt.Errorf("error reading private key file: %v", err)
+		}
+		keyDER, err := der.MarshalPrivateKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling private key as DER: %v", err)
+		}
+		pb = &keyspb.PrivateKey{Der: keyDER}
+	case "PKCS11ConfigFile":
+		if *pkcs11ConfigPath == "" {
+			return nil, errors.New("empty PKCS11 config file path")
+		}
+		configBytes, err := ioutil.ReadFile(*pkcs11ConfigPath)
+		if err != nil {
+			return nil, fmt.Errorf("error reading PKCS#11 config file: %v", err)
+		}
+		var config pkcs11key.Config
+		if err := json.Unmarshal(configBytes, &config); err != nil {
+			return nil, fmt.Errorf("error parsing PKCS#11 config file: %v", err)
+		}
+		pubKeyPEM, err := ioutil.ReadFile(config.PublicKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("error reading PKCS#11 public key file: %v", err)
+		}
+		pb = &keyspb.PKCS11Config{
+			TokenLabel: config.TokenLabel,
+			Pin:        config.PIN,
+			PublicKey:  string(pubKeyPEM),
+		}
+	default:
+		return nil, fmt.Errorf("private_key_format must be one of PrivateKey, PEMKeyFile or PKCS11ConfigFile, got %q", *privateKeyFormat)
+	}
+	return ptypes.MarshalAny(pb)
+}
+
+// fingerprint returns a short identifier of the DER-encoded public key.
+func fingerprint(keyDER []byte) string {
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(keyDER))
+}
+
+// inspect writes a description of the current and previous keys of tree to w.
+func inspect(w io.Writer, tree *trillian.Tree) error {
+	if tree.PublicKey == nil {
+		return fmt.Errorf("tree %v has no public key", tree.TreeId)
+	}
+	fmt.Fprintf(w, "tree_id: %v\n", tree.TreeId)
+	fmt.Fprintf(w, "signature_algorithm: %v\n", tree.SignatureAlgorithm)
+	fmt.Fprintf(w, "key_version: %v\n", tree.KeyVersion)
+	fmt.Fprintf(w, "public_key: %v\n", fingerprint(tree.PublicKey.Der))
+	for _, k := range tree.PreviousKeys {
+		retireTime, err := ptypes.Timestamp(k.RetireTime)
+		if err != nil {
+			return fmt.Errorf("previous key %v: invalid retire_time: %v", k.KeyVersion, err)
+		}
+		fmt.Fprintf(w, "previous_key: version=%v public_key=%v retire_time=%v\n", k.KeyVersion, fingerprint(k.PublicKey.GetDer()), retireTime)
+	}
+	for i, s := range tree.GetMultiSig().GetSigners() {
+		fmt.Fprintf(w, "co_signer: index=%v public_key=%v\n", i, fingerprint(s.PublicKey.GetDer()))
+	}
+	return nil
+}
+
+// export writes the public key of tree with the given version to w in PEM
+// format. A negative version selects the current key.
+func export(w io.Writer, tree *trillian.Tree, version int64) error {
+	publicKey := tree.PublicKey
+	if version >= 0 && version != tree.KeyVersion {
+		publicKey = nil
+		for _, k := range tree.PreviousKeys {
+			if k.KeyVersion == version {
+				publicKey = k.PublicKey
+			}
+		}
+	}
+	if publicKey == nil {
+		return fmt.Errorf("tree %v has no public key with version %v", tree.TreeId, version)
+	}
+	return pem.Encode(w, &pem.Block{Type: "PUBLIC KEY", Bytes: publicKey.Der})
+}
+
+// verify checks that privateKey is usable as the private key of tree, and
+// that it matches the tree's public key.
+func verify(ctx context.Context, tree *trillian.Tree, privateKey *any.Any) error {
+	if tree.PublicKey == nil {
+		return fmt.Errorf("tree %v has no public key", tree.TreeId)
+	}
+	// Co-signer keys aren't returned by the admin server, and aren't needed.
+	t := proto.Clone(tree).(*trillian.Tree)
+	t.PrivateKey = privateKey
+	t.MultiSig = nil
+	signer, err := trees.Signer(ctx, t)
+	if err != nil {
+		return fmt.Errorf("failed to load private key: %v", err)
+	}
+	publicKey, err := der.MarshalPublicKey(signer.Public())
+	if err != nil {
+		return fmt.Errorf("failed to marshal public key: %v", err)
+	}
+	if !bytes.Equal(publicKey, tree.PublicKey.Der) {
+		return fmt.Errorf("private key has public key %v, but tree %v has public key %v", fingerprint(publicKey), tree.TreeId, fingerprint(tree.PublicKey.Der))
+	}
+	return nil
+}
+
+// migrate replaces the private_key of tree with privateKey, which must be the
+// same key as the tree's current one. If dryRun is true, only the checks are
+// performed.
+func migrate(ctx context.Context, w io.Writer, client trillian.TrillianAdminClient, tree *trillian.Tree, privateKey *any.Any, dryRun bool) error {
+	if err := verify(ctx, tree, privateKey); err != nil {
+		return err
+	}
+	if dryRun {
+		fmt.Fprintf(w, "dry run: private key of tree %v would be replaced with a %v\n", tree.TreeId, privateKey.TypeUrl)
+		return nil
+	}
+
+	req := &trillian.UpdateTreeRequest{
+		Tree:       &trillian.Tree{TreeId: tree.TreeId, PrivateKey: privateKey},
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"private_key"}},
+	}
+	if _, err := client.UpdateTree(ctx, req); err != nil {
+		return fmt.Errorf("failed to UpdateTree(%v): %v", tree.TreeId, err)
+	}
+	fmt.Fprintf(w, "private key of tree %v replaced with a %v\n", tree.TreeId, privateKey.TypeUrl)
+	return nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main contains the implementation and entry point for the treekeys
+// command, which manages the signing keys of trees.
+//
+// Example usage:
+// $ ./treekeys --admin_server=host:port --tree_id=123 inspect
+// $ ./treekeys --admin_server=host:port --tree_id=123 export > pubkey.pem
+// $ ./treekeys --admin_server=host:port --tree_id=123 \
+//     --private_key_format=PEMKeyFile --pem_key_path=key.pem \
+//     --pem_key_password=pass verify
+// $ ./treekeys --admin_server=host:port --tree_id=123 \
+//     --private_key_format=PKCS11ConfigFile --pkcs11_config_path=conf.json \
+//     --dry_run migrate
+//
+// The commands are:
+//   inspect: describes the current and previous keys of the tree.
+//   export:  writes a public key of the tree to stdout in PEM format.
+//   verify:  checks that the private key described by --private_key_format
+//            and its flags matches the public key of the tree.
+//   migrate: replaces the tree's private_key with the one described by
+//            --private_key_format and its flags, which must be the same key
+//            stored differently. The key is verified first; with --dry_run,
+//            the tree isn't updated.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian"
+	"google.golang.org/grpc"
+
+	_ "github.com/google/trillian/crypto/keys/der/proto"    // PrivateKey proto handler
+	_ "github.com/google/trillian/crypto/keys/pem/proto"    // PEMKeyFile proto handler
+	_ "github.com/google/trillian/crypto/keys/pkcs11/proto" // PKCS11Config proto handler
+)
+
+var (
+	adminServerAddr = flag.String("admin_server", "", "Address of the gRPC Trillian Admin Server (host:port)")
+	rpcDeadline     = flag.Duration("rpc_deadline", time.Second*10, "Deadline for RPC requests")
+	treeID          = flag.Int64("tree_id", 0, "The ID of the tree whose keys are managed")
+	keyVersion      = flag.Int64("key_version", -1, "Version of the public key to export; -1 means the current key")
+	dryRun          = flag.Bool("dry_run", false, "If true, migrate verifies the new private key but doesn't update the tree")
+
+	errAdminAddrNotSet = errors.New("empty --admin_server, please provide the Admin server host:port")
+)
+
+// run executes command against the tree identified by --tree_id, writing its
+// output to stdout.
+func run(ctx context.Context, command string) error {
+	if *adminServerAddr == "" {
+		return errAdminAddrNotSet
+	}
+	if *treeID == 0 {
+		return errors.New("empty --tree_id")
+	}
+
+	conn, err := grpc.Dial(*adminServerAddr, grpc.WithInsecure())
+	if err != nil {
+		return fmt.Errorf("failed to dial %v: %v", *adminServerAddr, err)
+	}
+	defer conn.Close()
+	client := trillian.NewTrillianAdminClient(conn)
+
+	tree, err := client.GetTree(ctx, &trillian.GetTreeRequest{TreeId: *treeID})
+	if err != nil {
+		return fmt.Errorf("failed to GetTree(%v): %v", *treeID, err)
+	}
+
+	switch command {
+	case "inspect":
+		return inspect(os.Stdout, tree)
+	case "export":
+		return export(os.Stdout, tree, *keyVersion)
+	case "verify":
+		privateKey, err := privateKeyFromFlags()
+		if err != nil {
+			return err
+		}
+		if err := verify(ctx, tree, privateKey); err != nil {
+			return err
+		}
+		fmt.Printf("private key matches the public key of tree %v\n", tree.TreeId)
+		return nil
+	case "migrate":
+		privateKey, err := privateKeyFromFlags()
+		if err != nil {
+			return err
+		}
+		return migrate(ctx, os.Stdout, client, tree, privateKey, *dryRun)
+	default:
+		return fmt.Errorf("unknown command %q, want one of inspect, export, verify or migrate", command)
+	}
+}
+
+func main() {
+	flag.Parse()
+	if flag.NArg() != 1 {
+		glog.Exitf("Usage: %s [flags] inspect|export|verify|migrate", os.Args[0])
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *rpcDeadline)
+	defer cancel()
+	if err := run(ctx, flag.Arg(0)); err != nil {
+		glog.Exitf("%v failed: %v", flag.Arg(0), err)
+	}
+}
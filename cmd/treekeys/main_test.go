@@ -0,0 +1,235 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/google/trillian"
+	"github.com/google/trillian/crypto/keys/der"
+	"github.com/google/trillian/crypto/keys/pem"
+	"github.com/google/trillian/crypto/keyspb"
+	"github.com/google/trillian/storage/testonly"
+	"github.com/google/trillian/util/flagsaver"
+
+	ttestonly "github.com/google/trillian/testonly"
+)
+
+const (
+	// logKeyPath holds the private key of testonly.LogTree.
+	logKeyPath = "../../testdata/log-rpc-server.privkey.pem"
+	mapKeyPath = "../../testdata/map-rpc-server.privkey.pem"
+	keyPass    = "towel"
+)
+
+// logTree returns testonly.LogTree as returned by the admin server, with a
+// previous key.
+func logTree(t *testing.T) *trillian.Tree {
+	t.Helper()
+	mapKey, err := pem.ReadPrivateKeyFile(mapKeyPath, keyPass)
+	if err != nil {
+		t.Fatalf("ReadPrivateKeyFile(): %v", err)
+	}
+	mapPublicKey, err := der.ToPublicProto(mapKey.Public())
+	if err != nil {
+		t.Fatalf("ToPublicProto(): %v", err)
+	}
+
+	tree := proto.Clone(testonly.LogTree).(*trillian.Tree)
+	tree.TreeId = 12345
+	tree.PrivateKey = nil
+	tree.KeyVersion = 1
+	tree.PreviousKeys = []*trillian.TreeKey{
+		{KeyVersion: 0, PublicKey: mapPublicKey, RetireTime: ptypes.TimestampNow()},
+	}
+	return tree
+}
+
+func TestInspect(t *testing.T) {
+	tree := logTree(t)
+	var buf bytes.Buffer
+	if err := inspect(&buf, tree); err != nil {
+		t.Fatalf("inspect(): %v", err)
+	}
+	for _, want := range []string{
+		"tree_id: 12345\n",
+		"signature_algorithm: ECDSA\n",
+		"key_version: 1\n",
+		"public_key: " + fingerprint(tree.PublicKey.Der) + "\n",
+		"previous_key: version=0 public_key=" + fingerprint(tree.PreviousKeys[0].PublicKey.Der),
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("inspect() output %q doesn't contain %q", buf.String(), want)
+		}
+	}
+}
+
+func TestExport(t *testing.T) {
+	tree := logTree(t)
+	for _, test := range []struct {
+		desc    string
+		version int64
+		want    *keyspb.PublicKey
+		wantErr bool
+	}{
+		{desc: "current", version: -1, want: tree.PublicKey},
+		{desc: "currentVersion", version: 1, want: tree.PublicKey},
+		{desc: "previousVersion", version: 0, want: tree.PreviousKeys[0].PublicKey},
+		{desc: "unknownVersion", version: 2, wantErr: true},
+	} {
+		var buf bytes.Buffer
+		err := export(&buf, tree, test.version)
+		if gotErr := err != nil; gotErr != test.wantErr {
+			t.Errorf("%v: export(): %v, wantErr %v", test.desc, err, test.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		got, err := pem.UnmarshalPublicKey(buf.String())
+		if err != nil {
+			t.Errorf("%v: UnmarshalPublicKey(): %v", test.desc, err)
+			continue
+		}
+		gotDER, err := der.MarshalPublicKey(got)
+		if err != nil {
+			t.Errorf("%v: MarshalPublicKey(): %v", test.desc, err)
+			continue
+		}
+		if !bytes.Equal(gotDER, test.want.Der) {
+			t.Errorf("%v: export() = %v, want %v", test.desc, fingerprint(gotDER), fingerprint(test.want.Der))
+		}
+	}
+}
+
+func TestPrivateKeyFromFlags(t *testing.T) {
+	ctx := context.Background()
+	tree := logTree(t)
+	for _, test := range []struct {
+		desc                     string
+		format, path, password   string
+		wantFlagsErr, wantVerify bool
+	}{
+		{desc: "PEMKeyFile", format: "PEMKeyFile", path: logKeyPath, password: keyPass, wantVerify: true},
+		{desc: "PrivateKey", format: "PrivateKey", path: logKeyPath, password: keyPass, wantVerify: true},
+		{desc: "otherKey", format: "PrivateKey", path: mapKeyPath, password: keyPass},
+		{desc: "wrongPassword", format: "PEMKeyFile", path: logKeyPath, password: "llama"},
+		{desc: "noPassword", format: "PEMKeyFile", path: logKeyPath, wantFlagsErr: true},
+		{desc: "noPath", format: "PrivateKey", password: keyPass, wantFlagsErr: true},
+		{desc: "unknownFormat", format: "Llama", wantFlagsErr: true},
+	} {
+		func() {
+			defer flagsaver.Save().Restore()
+			*privateKeyFormat = test.format
+			*pemKeyPath = test.path
+			*pemKeyPass = test.password
+
+			privateKey, err := privateKeyFromFlags()
+			if gotErr := err != nil; gotErr != test.wantFlagsErr {
+				t.Errorf("%v: privateKeyFromFlags(): %v, wantErr %v", test.desc, err, test.wantFlagsErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			err = verify(ctx, tree, privateKey)
+			if got := err == nil; got != test.wantVerify {
+				t.Errorf("%v: verify(): %v, want success %v", test.desc, err, test.wantVerify)
+			}
+		}()
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	for _, test := range []struct {
+		desc       string
+		keyPath    string
+		dryRun     bool
+		wantUpdate bool
+		updateErr  error
+		wantErr    bool
+	}{
+		{desc: "migrate", keyPath: logKeyPath, wantUpdate: true},
+		{desc: "dryRun", keyPath: logKeyPath, dryRun: true},
+		{desc: "otherKey", keyPath: mapKeyPath, wantErr: true},
+		{desc: "updateErr", keyPath: logKeyPath, wantUpdate: true, updateErr: errors.New("UpdateTree() failed"), wantErr: true},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s, stopFakeServer, err := ttestonly.NewMockServer(ctrl)
+			if err != nil {
+				t.Fatalf("Error starting fake server: %v", err)
+			}
+			defer stopFakeServer()
+			defer flagsaver.Save().Restore()
+			*adminServerAddr = s.Addr
+			*treeID = 12345
+			*dryRun = test.dryRun
+			*privateKeyFormat = "PEMKeyFile"
+			*pemKeyPath = test.keyPath
+			*pemKeyPass = keyPass
+
+			tree := logTree(t)
+			s.Admin.EXPECT().GetTree(gomock.Any(), gomock.Any()).Return(tree, nil)
+			if test.wantUpdate {
+				s.Admin.EXPECT().UpdateTree(gomock.Any(), gomock.Any()).Do(func(_ context.Context, req *trillian.UpdateTreeRequest) {
+					if got, want := req.GetUpdateMask().GetPaths(), []string{"private_key"}; len(got) != 1 || got[0] != want[0] {
+						t.Errorf("UpdateTree(): update_mask %v, want %v", got, want)
+					}
+					var keyProto keyspb.PEMKeyFile
+					if err := ptypes.UnmarshalAny(req.GetTree().GetPrivateKey(), &keyProto); err != nil {
+						t.Errorf("UpdateTree(): private_key: %v", err)
+					}
+					if got, want := keyProto.Path, test.keyPath; got != want {
+						t.Errorf("UpdateTree(): private_key path %q, want %q", got, want)
+					}
+				}).Return(tree, test.updateErr)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			err = run(ctx, "migrate")
+			if gotErr := err != nil; gotErr != test.wantErr {
+				t.Errorf("run(migrate): %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	defer flagsaver.Save().Restore()
+	ctx := context.Background()
+
+	*treeID = 12345
+	if err := run(ctx, "inspect"); err != errAdminAddrNotSet {
+		t.Errorf("run() without --admin_server: %v, want %v", err, errAdminAddrNotSet)
+	}
+
+	*adminServerAddr = "localhost:0"
+	*treeID = 0
+	if err := run(ctx, "inspect"); err == nil {
+		t.Error("run() without --tree_id: nil err, want error")
+	}
+}
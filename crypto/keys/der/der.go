@@ -23,6 +23,7 @@ import (
 
 	"github.com/google/trillian/crypto/keys"
 	"github.com/google/trillian/crypto/keyspb"
+	"golang.org/x/crypto/ed25519"
 )
 
 // FromProto takes a PrivateKey protobuf message and returns the private key contained within.
@@ -68,6 +69,11 @@ func UnmarshalPrivateKey(keyDER []byte) (crypto.Signer, error) {
 		return key1, nil
 	}
 
+	// Ed25519 keys are PKCS #8 encoded, but not supported by x509.
+	if key, err := parseEd25519PrivateKey(keyDER); err == nil {
+		return key, nil
+	}
+
 	key2, err2 := x509.ParsePKCS8PrivateKey(keyDER)
 	if err2 == nil {
 		switch key2 := key2.(type) {
@@ -89,6 +95,10 @@ func UnmarshalPrivateKey(keyDER []byte) (crypto.Signer, error) {
 
 // UnmarshalPublicKey reads a DER-encoded public key.
 func UnmarshalPublicKey(keyDER []byte) (crypto.PublicKey, error) {
+	if key, err := parseEd25519PublicKey(keyDER); err == nil {
+		return key, nil
+	}
+
 	key, err := x509.ParsePKIXPublicKey(keyDER)
 	if err != nil {
 		return nil, fmt.Errorf("der: could not parse public key as PKIX (%v)", err)
@@ -97,8 +107,12 @@ func UnmarshalPublicKey(keyDER []byte) (crypto.PublicKey, error) {
 	return key, nil
 }
 
-// MarshalPublicKey serializes an RSA or ECDSA public key as DER.
+// MarshalPublicKey serializes an RSA, ECDSA or Ed25519 public key as DER.
 func MarshalPublicKey(pubKey crypto.PublicKey) ([]byte, error) {
+	if pubKey, ok := pubKey.(ed25519.PublicKey); ok {
+		return marshalEd25519PublicKey(pubKey)
+	}
+
 	der, err := x509.MarshalPKIXPublicKey(pubKey)
 	if err != nil {
 		return nil, fmt.Errorf("der: could not marshal public key as PKIX (%v)", err)
@@ -107,13 +121,15 @@ func MarshalPublicKey(pubKey crypto.PublicKey) ([]byte, error) {
 	return der, nil
 }
 
-// MarshalPrivateKey serializes an RSA or ECDSA private key as DER.
+// MarshalPrivateKey serializes an RSA, ECDSA or Ed25519 private key as DER.
 func MarshalPrivateKey(key crypto.Signer) ([]byte, error) {
 	switch key := key.(type) {
 	case *ecdsa.PrivateKey:
 		return x509.MarshalECPrivateKey(key)
 	case *rsa.PrivateKey:
 		return x509.MarshalPKCS1PrivateKey(key), nil
+	case ed25519.PrivateKey:
+		return marshalEd25519PrivateKey(key)
 	}
 
 	return nil, fmt.Errorf("der: unsupported key type: %T", key)
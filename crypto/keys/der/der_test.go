@@ -17,11 +17,13 @@ package der_test
 import (
 	"bytes"
 	"encoding/base64"
+	"reflect"
 	"testing"
 
 	. "github.com/google/trillian/crypto/keys/der"
 	"github.com/google/trillian/crypto/keys/testonly"
 	"github.com/google/trillian/crypto/keyspb"
+	"golang.org/x/crypto/ed25519"
 )
 
 const (
@@ -29,6 +31,11 @@ const (
 	privKeyBase64 = "MIGHAgEAMBMGByqGSM49AgEGCCqGSM49AwEHBG0wawIBAQQgS81mfpvtTmaINn+gtrYXn4XpxxgE655GLSKsA3hhjHmhRANCAASwBWDdgHS04V/cN0LZgc8vZaK4I1HWLLCoaOO27Z0B1aS1aqBE7g1Oo8ldSCBJAvee866kcHhZkVniPdCG2ZZG"
 	// ECDSA public key in DER format, base64-encoded.
 	pubKeyBase64 = "MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEsAVg3YB0tOFf3DdC2YHPL2WiuCNR1iywqGjjtu2dAdWktWqgRO4NTqPJXUggSQL3nvOupHB4WZFZ4j3QhtmWRg=="
+
+	// Ed25519 private key in PKCS #8 DER format, base64-encoded (RFC 8410 §10.3).
+	ed25519PrivKeyBase64 = "MC4CAQAwBQYDK2VwBCIEINTuctv5E1hK1bbY8fdp+K06/nwoy/HU++CXqI9EdVhC"
+	// Ed25519 public key in PKIX DER format, base64-encoded (RFC 8410 §10.1).
+	ed25519PubKeyBase64 = "MCowBQYDK2VwAyEAGb9ECWmEzf6FQbrBZ9w7lshQhqowtrbLDFw4rXAxZuE="
 )
 
 func TestFromProto(t *testing.T) {
@@ -94,6 +101,12 @@ func TestNewProtoFromSpec(t *testing.T) {
 				Params: &keyspb.Specification_RsaParams{},
 			},
 		},
+		{
+			desc: "Ed25519",
+			keySpec: &keyspb.Specification{
+				Params: &keyspb.Specification_Ed25519Params{},
+			},
+		},
 		{
 			desc:    "No params",
 			keySpec: &keyspb.Specification{},
@@ -179,3 +192,49 @@ func TestFromToPublicProto(t *testing.T) {
 		t.Errorf("MarshalPublicKey(): %x, want %x", got, want)
 	}
 }
+
+func TestEd25519Keys(t *testing.T) {
+	privDER, err := base64.StdEncoding.DecodeString(ed25519PrivKeyBase64)
+	if err != nil {
+		t.Fatalf("Could not decode test key: %v", err)
+	}
+	pubDER, err := base64.StdEncoding.DecodeString(ed25519PubKeyBase64)
+	if err != nil {
+		t.Fatalf("Could not decode test key: %v", err)
+	}
+
+	key, err := UnmarshalPrivateKey(privDER)
+	if err != nil {
+		t.Fatalf("UnmarshalPrivateKey(): %v", err)
+	}
+	if _, ok := key.(ed25519.PrivateKey); !ok {
+		t.Fatalf("UnmarshalPrivateKey(): %T, want ed25519.PrivateKey", key)
+	}
+	if err := testonly.SignAndVerify(key, key.Public()); err != nil {
+		t.Errorf("SignAndVerify() = %q, want nil", err)
+	}
+
+	privDER2, err := MarshalPrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalPrivateKey(): %v", err)
+	}
+	if got, want := privDER2, privDER; !bytes.Equal(got, want) {
+		t.Errorf("MarshalPrivateKey(): %x, want %x", got, want)
+	}
+
+	pubDER2, err := MarshalPublicKey(key.Public())
+	if err != nil {
+		t.Fatalf("MarshalPublicKey(): %v", err)
+	}
+	if got, want := pubDER2, pubDER; !bytes.Equal(got, want) {
+		t.Errorf("MarshalPublicKey(): %x, want %x", got, want)
+	}
+
+	pub, err := UnmarshalPublicKey(pubDER)
+	if err != nil {
+		t.Fatalf("UnmarshalPublicKey(): %v", err)
+	}
+	if got, want := pub, key.Public(); !reflect.DeepEqual(got, want) {
+		t.Errorf("UnmarshalPublicKey(): %x, want %x", got, want)
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package der
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/ed25519"
+)
+
+// The crypto/x509 package does not support Ed25519 keys, so they are encoded
+// here as described in RFC 8410: private keys in PKCS #8 form, and public keys
+// in PKIX form.
+
+// oidEd25519 is the algorithm identifier of Ed25519 keys (RFC 8410 §3).
+var oidEd25519 = asn1.ObjectIdentifier{1, 3, 101, 112}
+
+// pkcs8 is the ASN.1 structure of a PKCS #8 private key (RFC 5208 §5).
+type pkcs8 struct {
+	Version    int
+	Algo       pkix.AlgorithmIdentifier
+	PrivateKey []byte
+}
+
+// publicKeyInfo is the ASN.1 structure of a PKIX public key (RFC 5280 §4.1).
+type publicKeyInfo struct {
+	Algo      pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+// marshalEd25519PrivateKey serializes an Ed25519 private key in PKCS #8 form.
+func marshalEd25519PrivateKey(key ed25519.PrivateKey) ([]byte, error) {
+	// The private key is wrapped in an OCTET STRING (CurvePrivateKey) before
+	// being placed in the PKCS #8 privateKey OCTET STRING.
+	seed, err := asn1.Marshal(key.Seed())
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(pkcs8{
+		Algo:       pkix.AlgorithmIdentifier{Algorithm: oidEd25519},
+		PrivateKey: seed,
+	})
+}
+
+// parseEd25519PrivateKey reads an Ed25519 private key in PKCS #8 form.
+func parseEd25519PrivateKey(keyDER []byte) (ed25519.PrivateKey, error) {
+	var key pkcs8
+	if rest, err := asn1.Unmarshal(keyDER, &key); err != nil {
+		return nil, err
+	} else if len(rest) != 0 {
+		return nil, errors.New("trailing data after private key")
+	}
+	if !key.Algo.Algorithm.Equal(oidEd25519) {
+		return nil, fmt.Errorf("not an Ed25519 key: algorithm %v", key.Algo.Algorithm)
+	}
+	var seed []byte
+	if rest, err := asn1.Unmarshal(key.PrivateKey, &seed); err != nil {
+		return nil, err
+	} else if len(rest) != 0 {
+		return nil, errors.New("trailing data after Ed25519 private key")
+	}
+	if got, want := len(seed), ed25519.SeedSize; got != want {
+		return nil, fmt.Errorf("Ed25519 private key len: %d, want %d", got, want)
+	}
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
+// marshalEd25519PublicKey serializes an Ed25519 public key in PKIX form.
+func marshalEd25519PublicKey(pubKey ed25519.PublicKey) ([]byte, error) {
+	return asn1.Marshal(publicKeyInfo{
+		Algo:      pkix.AlgorithmIdentifier{Algorithm: oidEd25519},
+		PublicKey: asn1.BitString{Bytes: pubKey, BitLength: 8 * len(pubKey)},
+	})
+}
+
+// parseEd25519PublicKey reads an Ed25519 public key in PKIX form.
+func parseEd25519PublicKey(keyDER []byte) (ed25519.PublicKey, error) {
+	var key publicKeyInfo
+	if rest, err := asn1.Unmarshal(keyDER, &key); err != nil {
+		return nil, err
+	} else if len(rest) != 0 {
+		return nil, errors.New("trailing data after public key")
+	}
+	if !key.Algo.Algorithm.Equal(oidEd25519) {
+		return nil, fmt.Errorf("not an Ed25519 key: algorithm %v", key.Algo.Algorithm)
+	}
+	if got, want := len(key.PublicKey.Bytes), ed25519.PublicKeySize; got != want || key.PublicKey.BitLength != 8*want {
+		return nil, fmt.Errorf("Ed25519 public key len: %d, want %d", got, want)
+	}
+	return ed25519.PublicKey(key.PublicKey.Bytes), nil
+}
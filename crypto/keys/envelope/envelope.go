@@ -0,0 +1,161 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package envelope provides envelope encryption of private keys, so that they
+// aren't stored in plaintext alongside the trees they sign for.
+//
+// A keyspb.PrivateKey is encrypted with a random data encryption key (DEK),
+// using AES-256-GCM. The DEK is then encrypted with a key encryption key (KEK)
+// obtained from a KEKProvider, and stored with the ciphertext in a
+// keyspb.EncryptedPrivateKey. The KEK itself is kept elsewhere, e.g. in a
+// local file or a key management service.
+//
+// The ciphertext is bound to the public key of the encrypted key, which must
+// be given to decrypt it. An encrypted key copied from one tree, or signer,
+// to another therefore fails to decrypt, rather than signing for a key it
+// doesn't match.
+package envelope
+
+import (
+	"context"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/google/trillian/crypto/keys/der"
+	"github.com/google/trillian/crypto/keyspb"
+)
+
+// dekSize is the size of data encryption keys, which are AES-256 keys.
+const dekSize = 32
+
+// KEKProvider encrypts and decrypts data encryption keys with key encryption
+// keys.
+type KEKProvider interface {
+	// WrapKey encrypts dek with the current KEK, and returns it along with
+	// the identifier of that KEK.
+	WrapKey(ctx context.Context, dek []byte) (kekID string, wrapped []byte, err error)
+	// UnwrapKey decrypts a DEK that was encrypted with the KEK identified by
+	// kekID.
+	UnwrapKey(ctx context.Context, kekID string, wrapped []byte) ([]byte, error)
+}
+
+// Encrypt returns key encrypted with a new DEK, which is wrapped by kek.
+// publicKeyDER is the DER-encoded public key of key, which is authenticated
+// along with the ciphertext.
+func Encrypt(ctx context.Context, kek KEKProvider, key *keyspb.PrivateKey, publicKeyDER []byte) (*keyspb.EncryptedPrivateKey, error) {
+	dek := make([]byte, dekSize)
+	if _, err := rand.Read(dek); err != nil {
+		return nil, fmt.Errorf("envelope: failed to generate DEK: %v", err)
+	}
+	aead, err := newAEAD(dek)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := seal(aead, key.GetDer(), publicKeyDER)
+	if err != nil {
+		return nil, err
+	}
+	kekID, wrapped, err := kek.WrapKey(ctx, dek)
+	if err != nil {
+		return nil, fmt.Errorf("envelope: failed to wrap DEK: %v", err)
+	}
+	return &keyspb.EncryptedPrivateKey{
+		KekId:      kekID,
+		WrappedDek: wrapped,
+		Ciphertext: ciphertext,
+	}, nil
+}
+
+// Decrypt returns the private key in key, using kek to unwrap its DEK.
+// publicKeyDER must be the public key that was given to Encrypt.
+func Decrypt(ctx context.Context, kek KEKProvider, key *keyspb.EncryptedPrivateKey, publicKeyDER []byte) (*keyspb.PrivateKey, error) {
+	dek, err := kek.UnwrapKey(ctx, key.GetKekId(), key.GetWrappedDek())
+	if err != nil {
+		return nil, fmt.Errorf("envelope: failed to unwrap DEK: %v", err)
+	}
+	aead, err := newAEAD(dek)
+	if err != nil {
+		return nil, err
+	}
+	keyDER, err := open(aead, key.GetCiphertext(), publicKeyDER)
+	if err != nil {
+		return nil, err
+	}
+	return &keyspb.PrivateKey{Der: keyDER}, nil
+}
+
+// NewSigner returns the crypto.Signer for the private key in key, whose public
+// key is publicKeyDER.
+func NewSigner(ctx context.Context, kek KEKProvider, key *keyspb.EncryptedPrivateKey, publicKeyDER []byte) (crypto.Signer, error) {
+	pb, err := Decrypt(ctx, kek, key, publicKeyDER)
+	if err != nil {
+		return nil, err
+	}
+	return der.FromProto(pb)
+}
+
+// EncryptAny returns privateKey encrypted with Encrypt if it holds a
+// keyspb.PrivateKey. Other kinds of private key protos, which don't hold key
+// material, are returned unchanged.
+func EncryptAny(ctx context.Context, kek KEKProvider, privateKey *any.Any, publicKeyDER []byte) (*any.Any, error) {
+	var key keyspb.PrivateKey
+	if privateKey == nil || !ptypes.Is(privateKey, &key) {
+		return privateKey, nil
+	}
+	if err := ptypes.UnmarshalAny(privateKey, &key); err != nil {
+		return nil, fmt.Errorf("envelope: failed to unmarshal private key: %v", err)
+	}
+	encrypted, err := Encrypt(ctx, kek, &key, publicKeyDER)
+	if err != nil {
+		return nil, err
+	}
+	return ptypes.MarshalAny(encrypted)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("envelope: %v", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts plaintext with a random nonce, which is prepended to the
+// returned ciphertext.
+func seal(aead cipher.AEAD, plaintext, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("envelope: failed to generate nonce: %v", err)
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// open decrypts a ciphertext returned by seal.
+func open(aead cipher.AEAD, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errors.New("envelope: ciphertext too short")
+	}
+	nonce, ciphertext := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, additionalData)
+	if err != nil {
+		return nil, fmt.Errorf("envelope: failed to decrypt: %v", err)
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,227 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package envelope
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/google/trillian/crypto/keys/der"
+	"github.com/google/trillian/crypto/keys/testonly"
+	"github.com/google/trillian/crypto/keyspb"
+)
+
+const (
+	kek1 = "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"
+	kek2 = "1f1e1d1c1b1a191817161514131211100f0e0d0c0b0a09080706050403020100"
+)
+
+// writeKEKs writes each of keks to a file in dir, and returns their paths.
+func writeKEKs(t *testing.T, dir string, keks ...string) []string {
+	t.Helper()
+	var paths []string
+	for i, kek := range keks {
+		path := filepath.Join(dir, fmt.Sprintf("kek%d", i))
+		if err := ioutil.WriteFile(path, []byte(kek+"\n"), 0600); err != nil {
+			t.Fatalf("WriteFile(%v): %v", path, err)
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// newPrivateKey returns a new private key, and the DER encoding of its public
+// key.
+func newPrivateKey(t *testing.T) (*keyspb.PrivateKey, []byte) {
+	t.Helper()
+	key, err := der.NewProtoFromSpec(&keyspb.Specification{
+		Params: &keyspb.Specification_EcdsaParams{EcdsaParams: &keyspb.Specification_ECDSA{}},
+	})
+	if err != nil {
+		t.Fatalf("NewProtoFromSpec(): %v", err)
+	}
+	signer, err := der.FromProto(key)
+	if err != nil {
+		t.Fatalf("FromProto(): %v", err)
+	}
+	publicKeyDER, err := der.MarshalPublicKey(signer.Public())
+	if err != nil {
+		t.Fatalf("MarshalPublicKey(): %v", err)
+	}
+	return key, publicKeyDER
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "envelope")
+	if err != nil {
+		t.Fatalf("TempDir(): %v", err)
+	}
+	defer os.RemoveAll(dir)
+	paths := writeKEKs(t, dir, kek1, kek2)
+
+	oldKEK, err := NewFileKEKProvider(paths[0])
+	if err != nil {
+		t.Fatalf("NewFileKEKProvider(): %v", err)
+	}
+	// Rotated KEKs: kek2 is current, but kek1 can still decrypt.
+	newKEK, err := NewFileKEKProvider(paths[1], paths[0])
+	if err != nil {
+		t.Fatalf("NewFileKEKProvider(): %v", err)
+	}
+	otherKEK, err := NewFileKEKProvider(paths[1])
+	if err != nil {
+		t.Fatalf("NewFileKEKProvider(): %v", err)
+	}
+
+	key, publicKeyDER := newPrivateKey(t)
+	_, otherPublicKeyDER := newPrivateKey(t)
+	encrypted, err := Encrypt(ctx, oldKEK, key, publicKeyDER)
+	if err != nil {
+		t.Fatalf("Encrypt(): %v", err)
+	}
+	if bytes.Contains(encrypted.Ciphertext, key.Der) {
+		t.Error("Encrypt() ciphertext contains the private key")
+	}
+
+	for _, test := range []struct {
+		desc      string
+		kek       KEKProvider
+		key       *keyspb.EncryptedPrivateKey
+		publicKey []byte
+		wantErr   bool
+	}{
+		{desc: "sameKEK", kek: oldKEK, key: encrypted, publicKey: publicKeyDER},
+		{desc: "rotatedKEK", kek: newKEK, key: encrypted, publicKey: publicKeyDER},
+		{desc: "unknownKEK", kek: otherKEK, key: encrypted, publicKey: publicKeyDER, wantErr: true},
+		{desc: "wrongPublicKey", kek: oldKEK, key: encrypted, publicKey: otherPublicKeyDER, wantErr: true},
+		{desc: "noPublicKey", kek: oldKEK, key: encrypted, wantErr: true},
+		{
+			desc: "wrongKEKID",
+			kek:  newKEK,
+			key: func() *keyspb.EncryptedPrivateKey {
+				k := proto.Clone(encrypted).(*keyspb.EncryptedPrivateKey)
+				k.KekId = newKEK.currentID
+				return k
+			}(),
+			publicKey: publicKeyDER,
+			wantErr:   true,
+		},
+		{
+			desc: "corruptCiphertext",
+			kek:  oldKEK,
+			key: func() *keyspb.EncryptedPrivateKey {
+				k := proto.Clone(encrypted).(*keyspb.EncryptedPrivateKey)
+				k.Ciphertext[len(k.Ciphertext)-1] ^= 1
+				return k
+			}(),
+			publicKey: publicKeyDER,
+			wantErr:   true,
+		},
+		{desc: "empty", kek: oldKEK, key: &keyspb.EncryptedPrivateKey{}, publicKey: publicKeyDER, wantErr: true},
+	} {
+		got, err := Decrypt(ctx, test.kek, test.key, test.publicKey)
+		if gotErr := err != nil; gotErr != test.wantErr {
+			t.Errorf("%v: Decrypt(): %v, wantErr %v", test.desc, err, test.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if !proto.Equal(got, key) {
+			t.Errorf("%v: Decrypt() returned a different key", test.desc)
+		}
+	}
+
+	signer, err := NewSigner(ctx, newKEK, encrypted, publicKeyDER)
+	if err != nil {
+		t.Fatalf("NewSigner(): %v", err)
+	}
+	if err := testonly.SignAndVerify(signer, signer.Public()); err != nil {
+		t.Errorf("SignAndVerify(): %v", err)
+	}
+}
+
+func TestEncryptAny(t *testing.T) {
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "envelope")
+	if err != nil {
+		t.Fatalf("TempDir(): %v", err)
+	}
+	defer os.RemoveAll(dir)
+	kek, err := NewFileKEKProvider(writeKEKs(t, dir, kek1)...)
+	if err != nil {
+		t.Fatalf("NewFileKEKProvider(): %v", err)
+	}
+
+	key, publicKeyDER := newPrivateKey(t)
+	keyAny, err := ptypes.MarshalAny(key)
+	if err != nil {
+		t.Fatalf("MarshalAny(): %v", err)
+	}
+	got, err := EncryptAny(ctx, kek, keyAny, publicKeyDER)
+	if err != nil {
+		t.Fatalf("EncryptAny(PrivateKey): %v", err)
+	}
+	var encrypted keyspb.EncryptedPrivateKey
+	if err := ptypes.UnmarshalAny(got, &encrypted); err != nil {
+		t.Fatalf("EncryptAny(PrivateKey) returned %v, want an EncryptedPrivateKey: %v", got.TypeUrl, err)
+	}
+	decrypted, err := Decrypt(ctx, kek, &encrypted, publicKeyDER)
+	if err != nil {
+		t.Fatalf("Decrypt(): %v", err)
+	}
+	if !proto.Equal(decrypted, key) {
+		t.Error("Decrypt(EncryptAny()) returned a different key")
+	}
+
+	pemAny, err := ptypes.MarshalAny(&keyspb.PEMKeyFile{Path: "key.pem", Password: "pass"})
+	if err != nil {
+		t.Fatalf("MarshalAny(): %v", err)
+	}
+	if got, err := EncryptAny(ctx, kek, pemAny, nil); err != nil || got != pemAny {
+		t.Errorf("EncryptAny(PEMKeyFile) = (%v, %v), want the input unchanged", got, err)
+	}
+}
+
+func TestNewFileKEKProviderErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "envelope")
+	if err != nil {
+		t.Fatalf("TempDir(): %v", err)
+	}
+	defer os.RemoveAll(dir)
+	paths := writeKEKs(t, dir, kek1, "not hex", "0102")
+
+	for _, test := range []struct {
+		desc  string
+		paths []string
+	}{
+		{desc: "noFiles"},
+		{desc: "missingFile", paths: []string{filepath.Join(dir, "missing")}},
+		{desc: "notHex", paths: []string{paths[0], paths[1]}},
+		{desc: "wrongSize", paths: []string{paths[2]}},
+	} {
+		if _, err := NewFileKEKProvider(test.paths...); err == nil {
+			t.Errorf("%v: NewFileKEKProvider(): nil err, want error", test.desc)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package envelope
+
+import (
+	"bytes"
+	"context"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+)
+
+// FileKEKProvider is a KEKProvider whose KEKs are AES-256 keys stored in local
+// files, hex-encoded, e.g. as generated by "openssl rand -hex 32".
+type FileKEKProvider struct {
+	currentID string
+	keks      map[string]cipher.AEAD
+}
+
+// NewFileKEKProvider returns a FileKEKProvider using the KEKs in the files at
+// paths. The first KEK wraps new DEKs; the others can only unwrap them, which
+// allows KEKs to be rotated.
+func NewFileKEKProvider(paths ...string) (*FileKEKProvider, error) {
+	if len(paths) == 0 {
+		return nil, errors.New("envelope: no KEK files")
+	}
+	p := &FileKEKProvider{keks: make(map[string]cipher.AEAD)}
+	for i, path := range paths {
+		contents, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("envelope: failed to read KEK: %v", err)
+		}
+		kek, err := hex.DecodeString(string(bytes.TrimSpace(contents)))
+		if err != nil {
+			return nil, fmt.Errorf("envelope: KEK in %q is not hex-encoded: %v", path, err)
+		}
+		if got, want := len(kek), dekSize; got != want {
+			return nil, fmt.Errorf("envelope: KEK in %q is %v bytes, want %v", path, got, want)
+		}
+		aead, err := newAEAD(kek)
+		if err != nil {
+			return nil, err
+		}
+		id := kekID(kek)
+		if i == 0 {
+			p.currentID = id
+		}
+		p.keks[id] = aead
+	}
+	return p, nil
+}
+
+// kekID returns the identifier of kek, which is derived from the key so that
+// it doesn't depend on where the key is stored.
+func kekID(kek []byte) string {
+	hash := sha256.Sum256(kek)
+	return "sha256:" + hex.EncodeToString(hash[:8])
+}
+
+// WrapKey implements KEKProvider.
+func (p *FileKEKProvider) WrapKey(ctx context.Context, dek []byte) (string, []byte, error) {
+	wrapped, err := seal(p.keks[p.currentID], dek, []byte(p.currentID))
+	if err != nil {
+		return "", nil, err
+	}
+	return p.currentID, wrapped, nil
+}
+
+// UnwrapKey implements KEKProvider.
+func (p *FileKEKProvider) UnwrapKey(ctx context.Context, kekID string, wrapped []byte) ([]byte, error) {
+	aead, ok := p.keks[kekID]
+	if !ok {
+		return nil, fmt.Errorf("envelope: unknown KEK %q", kekID)
+	}
+	return open(aead, wrapped, []byte(kekID))
+}
@@ -0,0 +1,75 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package proto registers an envelope keys.ProtoHandler using keys.RegisterHandler.
+// This handler will use a keyspb.EncryptedPrivateKey protobuf message to get a
+// crypto.Signer, decrypting it with the KEKs in --kek_files. The public key of
+// the encrypted key must be added to the context with keys.WithPublicKey.
+package proto
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/trillian/crypto/keys"
+	"github.com/google/trillian/crypto/keys/envelope"
+	"github.com/google/trillian/crypto/keyspb"
+)
+
+var (
+	kekFiles = flag.String("kek_files", "", "Comma-separated paths of files holding hex-encoded AES-256 key encryption keys. The first one encrypts new private keys; all of them can decrypt. If unset, private keys are stored unencrypted.")
+
+	providerOnce sync.Once
+	provider     envelope.KEKProvider
+	providerErr  error
+)
+
+// Provider returns the KEKProvider configured by --kek_files, or nil if the
+// flag is unset.
+func Provider() (envelope.KEKProvider, error) {
+	providerOnce.Do(func() {
+		if *kekFiles == "" {
+			return
+		}
+		p, err := envelope.NewFileKEKProvider(strings.Split(*kekFiles, ",")...)
+		if err != nil {
+			providerErr = err
+			return
+		}
+		provider = p
+	})
+	return provider, providerErr
+}
+
+func init() {
+	keys.RegisterHandler(&keyspb.EncryptedPrivateKey{}, func(ctx context.Context, pb proto.Message) (crypto.Signer, error) {
+		if pb, ok := pb.(*keyspb.EncryptedPrivateKey); ok {
+			kek, err := Provider()
+			if err != nil {
+				return nil, err
+			}
+			if kek == nil {
+				return nil, errors.New("envelope: private key is encrypted, but --kek_files is unset")
+			}
+			return envelope.NewSigner(ctx, kek, pb, keys.PublicKeyFromContext(ctx))
+		}
+		return nil, fmt.Errorf("envelope: got %T, want *keyspb.EncryptedPrivateKey", pb)
+	})
+}
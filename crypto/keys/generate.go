@@ -25,6 +25,7 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/google/trillian/crypto/keyspb"
+	"golang.org/x/crypto/ed25519"
 )
 
 const (
@@ -60,6 +61,12 @@ func NewFromSpec(spec *keyspb.Specification) (crypto.Signer, error) {
 		}
 
 		return rsa.GenerateKey(rand.Reader, bits)
+	case *keyspb.Specification_Ed25519Params:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		return key, nil
 	default:
 		return nil, fmt.Errorf("unsupported keygen params type: %T", params)
 	}
@@ -60,6 +60,12 @@ func TestNewFromSpec(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "Ed25519",
+			keySpec: &keyspb.Specification{
+				Params: &keyspb.Specification_Ed25519Params{},
+			},
+		},
 		{
 			desc: "RSA with negative key size",
 			keySpec: &keyspb.Specification{
@@ -30,6 +30,24 @@ type ProtoHandler func(context.Context, proto.Message) (crypto.Signer, error)
 // handlers convert a protobuf message into a crypto.Signer.
 var handlers = make(map[string]ProtoHandler)
 
+// publicKeyKey is the context key for the public key added by WithPublicKey.
+type publicKeyKey struct{}
+
+// WithPublicKey returns a ctx with the DER-encoded public key of the private
+// key about to be obtained through NewSigner. ProtoHandlers of private keys
+// that are bound to their public key, e.g. encrypted keys that authenticate
+// it, get it with PublicKeyFromContext.
+func WithPublicKey(ctx context.Context, publicKeyDER []byte) context.Context {
+	return context.WithValue(ctx, publicKeyKey{}, publicKeyDER)
+}
+
+// PublicKeyFromContext returns the public key within ctx, or nil if there is
+// none.
+func PublicKeyFromContext(ctx context.Context) []byte {
+	publicKeyDER, _ := ctx.Value(publicKeyKey{}).([]byte)
+	return publicKeyDER
+}
+
 // RegisterHandler enables transformation of protobuf messages of the same
 // type as keyProto into crypto.Signer by invoking the provided handler.
 // The keyProto need only be an empty example of the type of protobuf message that
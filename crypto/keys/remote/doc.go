@@ -0,0 +1,22 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package remote provides access to private keys held by a signing service that
+// implements the remotepb.RemoteSigner gRPC interface, so that Trillian servers
+// never handle the private keys themselves.
+//
+// Signer is a crypto.Signer that delegates signing to such a service, and Server
+// is a reference implementation of the service backed by local keys (see
+// cmd/remote_signer).
+package remote
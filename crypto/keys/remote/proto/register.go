@@ -0,0 +1,125 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package proto registers a remote signer keys.ProtoHandler using keys.RegisterHandler.
+// This handler will use a keyspb.RemoteSignerConfig protobuf message to get a crypto.Signer.
+package proto
+
+import (
+	"context"
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/trillian/crypto/keys"
+	"github.com/google/trillian/crypto/keys/remote"
+	"github.com/google/trillian/crypto/keys/remote/remotepb"
+	"github.com/google/trillian/crypto/keyspb"
+	"github.com/google/trillian/monitoring/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+var (
+	timeout     = flag.Duration("remote_signer_timeout", remote.DefaultTimeout, "Deadline of each RPC to remote signing services")
+	maxAttempts = flag.Int("remote_signer_max_attempts", remote.DefaultMaxAttempts, "Number of times an RPC to a remote signing service is tried before giving up")
+	retryDelay  = flag.Duration("remote_signer_retry_delay", remote.DefaultRetryDelay, "Pause before the first retry of an RPC to a remote signing service, doubled for each subsequent retry")
+	tlsCAFile   = flag.String("remote_signer_tls_ca_file", "", "Path to the CA certificate used to authenticate remote signing services. If unset, unsecured connections are used.")
+	tlsCertFile = flag.String("remote_signer_tls_cert_file", "", "Path to the TLS client certificate presented to remote signing services that authenticate clients. Requires --remote_signer_tls_ca_file.")
+	tlsKeyFile  = flag.String("remote_signer_tls_key_file", "", "Path to the TLS client key for --remote_signer_tls_cert_file.")
+
+	// conns holds a connection to each signing service, shared by all the
+	// Signers that use it. Connections are never closed.
+	connsMu sync.Mutex
+	conns   = make(map[string]*grpc.ClientConn)
+)
+
+func init() {
+	keys.RegisterHandler(&keyspb.RemoteSignerConfig{}, func(ctx context.Context, pb proto.Message) (crypto.Signer, error) {
+		if cfg, ok := pb.(*keyspb.RemoteSignerConfig); ok {
+			opts := remote.Options{
+				Timeout:       *timeout,
+				MaxAttempts:   *maxAttempts,
+				RetryDelay:    *retryDelay,
+				MetricFactory: prometheus.MetricFactory{},
+			}
+			if cfg.GetAddress() == "" {
+				return nil, fmt.Errorf("remote: no signing service address")
+			}
+			conn, err := dial(cfg.GetAddress())
+			if err != nil {
+				return nil, err
+			}
+			return remote.NewSigner(ctx, remotepb.NewRemoteSignerClient(conn), cfg.GetKeyId(), opts)
+		}
+		return nil, fmt.Errorf("remote: got %T, want *keyspb.RemoteSignerConfig", pb)
+	})
+}
+
+// dial returns the connection to the signing service at addr, dialing it if
+// there isn't one yet.
+func dial(addr string) (*grpc.ClientConn, error) {
+	connsMu.Lock()
+	defer connsMu.Unlock()
+	if conn, ok := conns[addr]; ok {
+		return conn, nil
+	}
+
+	dialOpt := grpc.WithInsecure()
+	if *tlsCAFile != "" {
+		creds, err := clientCredentials(*tlsCAFile, *tlsCertFile, *tlsKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("remote: failed to load TLS credentials: %v", err)
+		}
+		dialOpt = grpc.WithTransportCredentials(creds)
+	} else if *tlsCertFile != "" || *tlsKeyFile != "" {
+		return nil, fmt.Errorf("remote: a TLS client certificate requires --remote_signer_tls_ca_file")
+	}
+	conn, err := grpc.Dial(addr, dialOpt)
+	if err != nil {
+		return nil, fmt.Errorf("remote: failed to dial %v: %v", addr, err)
+	}
+	conns[addr] = conn
+	return conn, nil
+}
+
+// clientCredentials returns TLS credentials that authenticate signing services
+// with the CA certificate in caFile. If certFile or keyFile is set, the client
+// authenticates itself with the certificate and key they hold.
+func clientCredentials(caFile, certFile, keyFile string) (credentials.TransportCredentials, error) {
+	if certFile == "" && keyFile == "" {
+		return credentials.NewClientTLSFromFile(caFile, "")
+	}
+	caPEM, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return nil, err
+	}
+	rootCAs := x509.NewCertPool()
+	if !rootCAs.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("no certificates found in %v", caFile)
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      rootCAs,
+	}), nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proto
+
+import (
+	"context"
+	"crypto"
+	"net"
+	"testing"
+
+	"github.com/google/trillian/crypto/keys"
+	"github.com/google/trillian/crypto/keys/pem"
+	"github.com/google/trillian/crypto/keys/remote"
+	"github.com/google/trillian/crypto/keys/remote/remotepb"
+	"github.com/google/trillian/crypto/keys/testonly"
+	"github.com/google/trillian/crypto/keyspb"
+	"google.golang.org/grpc"
+
+	ttestonly "github.com/google/trillian/testonly"
+)
+
+func TestProtoHandler(t *testing.T) {
+	ctx := context.Background()
+	key, err := pem.UnmarshalPrivateKey(ttestonly.DemoPrivateKey, ttestonly.DemoPrivateKeyPass)
+	if err != nil {
+		t.Fatalf("Failed to open test key: %v", err)
+	}
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("Listen(): %v", err)
+	}
+	srv := grpc.NewServer()
+	remotepb.RegisterRemoteSignerServer(srv, remote.NewServer(map[string]crypto.Signer{"key1": key, "key2": key}))
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	for _, test := range []struct {
+		desc     string
+		keyProto *keyspb.RemoteSignerConfig
+		wantErr  bool
+	}{
+		{desc: "key1", keyProto: &keyspb.RemoteSignerConfig{Address: lis.Addr().String(), KeyId: "key1"}},
+		{desc: "key2", keyProto: &keyspb.RemoteSignerConfig{Address: lis.Addr().String(), KeyId: "key2"}},
+		{desc: "unknownKey", keyProto: &keyspb.RemoteSignerConfig{Address: lis.Addr().String(), KeyId: "key3"}, wantErr: true},
+		{desc: "noAddress", keyProto: &keyspb.RemoteSignerConfig{KeyId: "key1"}, wantErr: true},
+	} {
+		signer, err := keys.NewSigner(ctx, test.keyProto)
+		if gotErr := err != nil; gotErr != test.wantErr {
+			t.Errorf("%v: NewSigner(_, %#v) = (_, %v), wantErr %v", test.desc, test.keyProto, err, test.wantErr)
+			continue
+		} else if gotErr {
+			continue
+		}
+
+		if err := testonly.SignAndVerify(signer, signer.Public()); err != nil {
+			t.Errorf("%v: SignAndVerify() = %q, want nil", test.desc, err)
+		}
+	}
+
+	// All the signers share one connection to the signing service.
+	connsMu.Lock()
+	defer connsMu.Unlock()
+	if got, want := len(conns), 1; got != want {
+		t.Errorf("%v connections to signing services, want %v", got, want)
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remote
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+
+	"github.com/google/trillian/crypto/keys/der"
+	"github.com/google/trillian/crypto/keys/remote/remotepb"
+	"github.com/google/trillian/crypto/sigpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server implements remotepb.RemoteSignerServer using local crypto.Signers.
+type Server struct {
+	keys map[string]crypto.Signer
+}
+
+// NewServer returns a Server that signs with keys, which are indexed by key ID.
+func NewServer(keys map[string]crypto.Signer) *Server {
+	return &Server{keys: keys}
+}
+
+// GetPublicKey implements RemoteSignerServer.GetPublicKey.
+func (s *Server) GetPublicKey(ctx context.Context, req *remotepb.GetPublicKeyRequest) (*remotepb.GetPublicKeyResponse, error) {
+	key, err := s.key(req.GetKeyId())
+	if err != nil {
+		return nil, err
+	}
+	pubKeyDER, err := der.MarshalPublicKey(key.Public())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal public key %q: %v", req.GetKeyId(), err)
+	}
+	return &remotepb.GetPublicKeyResponse{Der: pubKeyDER}, nil
+}
+
+// Sign implements RemoteSignerServer.Sign.
+func (s *Server) Sign(ctx context.Context, req *remotepb.SignRequest) (*remotepb.SignResponse, error) {
+	key, err := s.key(req.GetKeyId())
+	if err != nil {
+		return nil, err
+	}
+
+	var hash crypto.Hash
+	switch req.GetHashAlgorithm() {
+	case sigpb.DigitallySigned_NONE:
+		hash = 0
+	case sigpb.DigitallySigned_SHA256:
+		hash = crypto.SHA256
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported hash algorithm: %v", req.GetHashAlgorithm())
+	}
+	if hash != 0 && len(req.GetDigest()) != hash.Size() {
+		return nil, status.Errorf(codes.InvalidArgument, "digest is %v bytes, want %v", len(req.GetDigest()), hash.Size())
+	}
+
+	sig, err := key.Sign(rand.Reader, req.GetDigest(), hash)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to sign with key %q: %v", req.GetKeyId(), err)
+	}
+	return &remotepb.SignResponse{Signature: sig}, nil
+}
+
+func (s *Server) key(keyID string) (crypto.Signer, error) {
+	if keyID == "" {
+		return nil, status.Error(codes.InvalidArgument, "key_id is required")
+	}
+	key, ok := s.keys[keyID]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "key %q not found", keyID)
+	}
+	return key, nil
+}
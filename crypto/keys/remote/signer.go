@@ -0,0 +1,189 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remote
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian/crypto/keys/der"
+	"github.com/google/trillian/crypto/keys/remote/remotepb"
+	"github.com/google/trillian/crypto/sigpb"
+	"github.com/google/trillian/monitoring"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DefaultTimeout is the default deadline of each RPC to the signing service.
+	DefaultTimeout = 5 * time.Second
+	// DefaultMaxAttempts is the default number of times an RPC is tried.
+	DefaultMaxAttempts = 3
+	// DefaultRetryDelay is the default pause before the first retry.
+	DefaultRetryDelay = 100 * time.Millisecond
+)
+
+var (
+	timeNow   = time.Now
+	timeSleep = time.Sleep
+
+	metricsOnce  sync.Once
+	rpcCounter   monitoring.Counter
+	rpcLatency   monitoring.Histogram
+	retryCounter monitoring.Counter
+)
+
+func initMetrics(mf monitoring.MetricFactory) {
+	if mf == nil {
+		mf = monitoring.InertMetricFactory{}
+	}
+	rpcCounter = mf.NewCounter("remote_signer_rpcs", "Number of RPCs made to remote signing services", "method", "code")
+	rpcLatency = mf.NewHistogram("remote_signer_rpc_latency", "Latency of RPCs made to remote signing services in seconds", "method")
+	retryCounter = mf.NewCounter("remote_signer_retries", "Number of RPCs to remote signing services that were retried", "method")
+}
+
+// Options configures how a Signer talks to its signing service.
+type Options struct {
+	// Timeout is the deadline of each RPC attempt.
+	// If zero, DefaultTimeout is used.
+	Timeout time.Duration
+	// MaxAttempts is the number of times an RPC is tried before giving up.
+	// Only transient errors (e.g. Unavailable) are retried.
+	// If zero, DefaultMaxAttempts is used.
+	MaxAttempts int
+	// RetryDelay is the pause before the first retry. It doubles with each
+	// subsequent retry.
+	// If zero, DefaultRetryDelay is used.
+	RetryDelay time.Duration
+	// MetricFactory is used to create metrics the first time a Signer is created.
+	// If nil, metrics are not exported.
+	MetricFactory monitoring.MetricFactory
+}
+
+func (o Options) withDefaults() Options {
+	if o.Timeout == 0 {
+		o.Timeout = DefaultTimeout
+	}
+	if o.MaxAttempts == 0 {
+		o.MaxAttempts = DefaultMaxAttempts
+	}
+	if o.RetryDelay == 0 {
+		o.RetryDelay = DefaultRetryDelay
+	}
+	return o
+}
+
+// Signer is a crypto.Signer whose private key is held by a signing service.
+type Signer struct {
+	client remotepb.RemoteSignerClient
+	keyID  string
+	pubKey crypto.PublicKey
+	opts   Options
+}
+
+// NewSigner returns a Signer for key keyID of the signing service reached via
+// client. The key's public key is fetched from the service before returning.
+func NewSigner(ctx context.Context, client remotepb.RemoteSignerClient, keyID string, opts Options) (*Signer, error) {
+	if keyID == "" {
+		return nil, fmt.Errorf("remote: no key ID")
+	}
+	metricsOnce.Do(func() { initMetrics(opts.MetricFactory) })
+
+	s := &Signer{client: client, keyID: keyID, opts: opts.withDefaults()}
+	var resp *remotepb.GetPublicKeyResponse
+	err := s.call(ctx, "GetPublicKey", func(ctx context.Context) error {
+		var err error
+		resp, err = client.GetPublicKey(ctx, &remotepb.GetPublicKeyRequest{KeyId: keyID})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("remote: failed to get public key %q: %v", keyID, err)
+	}
+	if s.pubKey, err = der.UnmarshalPublicKey(resp.GetDer()); err != nil {
+		return nil, fmt.Errorf("remote: failed to parse public key %q: %v", keyID, err)
+	}
+	return s, nil
+}
+
+// Public returns the public key of the remote private key.
+func (s *Signer) Public() crypto.PublicKey {
+	return s.pubKey
+}
+
+// Sign asks the signing service to sign digest. The rand argument is ignored,
+// since the signing service provides its own randomness.
+func (s *Signer) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	if _, ok := opts.(*rsa.PSSOptions); ok {
+		return nil, fmt.Errorf("remote: RSA-PSS signatures not supported")
+	}
+	var hash sigpb.DigitallySigned_HashAlgorithm
+	switch h := opts.HashFunc(); h {
+	case 0:
+		hash = sigpb.DigitallySigned_NONE
+	case crypto.SHA256:
+		hash = sigpb.DigitallySigned_SHA256
+	default:
+		return nil, fmt.Errorf("remote: unsupported hash function: %v", h)
+	}
+
+	req := &remotepb.SignRequest{KeyId: s.keyID, Digest: digest, HashAlgorithm: hash}
+	var resp *remotepb.SignResponse
+	err := s.call(context.Background(), "Sign", func(ctx context.Context) error {
+		var err error
+		resp, err = s.client.Sign(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("remote: failed to sign with key %q: %v", s.keyID, err)
+	}
+	return resp.GetSignature(), nil
+}
+
+// call invokes rpc until it succeeds, fails with a permanent error, or
+// s.opts.MaxAttempts is reached. Each attempt is bounded by s.opts.Timeout.
+func (s *Signer) call(ctx context.Context, method string, rpc func(context.Context) error) error {
+	delay := s.opts.RetryDelay
+	for attempt := 1; ; attempt++ {
+		start := timeNow()
+		attemptCtx, cancel := context.WithTimeout(ctx, s.opts.Timeout)
+		err := rpc(attemptCtx)
+		cancel()
+		rpcLatency.Observe(timeNow().Sub(start).Seconds(), method)
+		rpcCounter.Inc(method, status.Code(err).String())
+
+		if err == nil || attempt >= s.opts.MaxAttempts || !isTransient(err) || ctx.Err() != nil {
+			return err
+		}
+		glog.Warningf("remote: %v attempt %v for key %q failed, retrying in %v: %v", method, attempt, s.keyID, delay, err)
+		retryCounter.Inc(method)
+		timeSleep(delay)
+		delay *= 2
+	}
+}
+
+// isTransient returns true if err may go away if the RPC is retried.
+func isTransient(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	}
+	return false
+}
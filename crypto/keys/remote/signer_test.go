@@ -0,0 +1,243 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remote
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/trillian/crypto/keys"
+	"github.com/google/trillian/crypto/keys/pem"
+	"github.com/google/trillian/crypto/keys/remote/remotepb"
+	"github.com/google/trillian/crypto/keys/testonly"
+	"github.com/google/trillian/crypto/keyspb"
+	"github.com/google/trillian/crypto/sigpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	ttestonly "github.com/google/trillian/testonly"
+)
+
+// startServer runs a Server for keys, and returns a client connected to it.
+func startServer(t *testing.T, keys map[string]crypto.Signer) (remotepb.RemoteSignerClient, func()) {
+	t.Helper()
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("Listen(): %v", err)
+	}
+	srv := grpc.NewServer()
+	remotepb.RegisterRemoteSignerServer(srv, NewServer(keys))
+	go srv.Serve(lis)
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		srv.Stop()
+		t.Fatalf("Dial(): %v", err)
+	}
+	return remotepb.NewRemoteSignerClient(conn), func() {
+		conn.Close()
+		srv.Stop()
+	}
+}
+
+func TestSigner(t *testing.T) {
+	ecdsaKey, err := pem.UnmarshalPrivateKey(ttestonly.DemoPrivateKey, ttestonly.DemoPrivateKeyPass)
+	if err != nil {
+		t.Fatalf("Failed to open test key: %v", err)
+	}
+	ed25519Key, err := keys.NewFromSpec(&keyspb.Specification{
+		Params: &keyspb.Specification_Ed25519Params{Ed25519Params: &keyspb.Specification_Ed25519{}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	client, stop := startServer(t, map[string]crypto.Signer{"ecdsa": ecdsaKey, "ed25519": ed25519Key})
+	defer stop()
+
+	ctx := context.Background()
+	for _, test := range []struct {
+		keyID   string
+		want    crypto.Signer
+		wantErr bool
+	}{
+		{keyID: "ecdsa", want: ecdsaKey},
+		{keyID: "ed25519", want: ed25519Key},
+		{keyID: "unknown", wantErr: true},
+		{keyID: "", wantErr: true},
+	} {
+		signer, err := NewSigner(ctx, client, test.keyID, Options{})
+		if gotErr := err != nil; gotErr != test.wantErr {
+			t.Errorf("NewSigner(%q): %v, wantErr %v", test.keyID, err, test.wantErr)
+			continue
+		} else if gotErr {
+			continue
+		}
+
+		// The remote key must verify with the local public key.
+		if err := testonly.SignAndVerify(signer, test.want.Public()); err != nil {
+			t.Errorf("%v: SignAndVerify(): %v", test.keyID, err)
+		}
+	}
+}
+
+func TestServerRejectsBadRequests(t *testing.T) {
+	key, err := pem.UnmarshalPrivateKey(ttestonly.DemoPrivateKey, ttestonly.DemoPrivateKeyPass)
+	if err != nil {
+		t.Fatalf("Failed to open test key: %v", err)
+	}
+	s := NewServer(map[string]crypto.Signer{"key": key})
+
+	ctx := context.Background()
+	for _, test := range []struct {
+		desc     string
+		req      *remotepb.SignRequest
+		wantCode codes.Code
+	}{
+		{
+			desc:     "unknownKey",
+			req:      &remotepb.SignRequest{KeyId: "unknown", Digest: make([]byte, 32), HashAlgorithm: sigpb.DigitallySigned_SHA256},
+			wantCode: codes.NotFound,
+		},
+		{
+			desc:     "shortDigest",
+			req:      &remotepb.SignRequest{KeyId: "key", Digest: make([]byte, 31), HashAlgorithm: sigpb.DigitallySigned_SHA256},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			desc:     "unknownHash",
+			req:      &remotepb.SignRequest{KeyId: "key", Digest: make([]byte, 32), HashAlgorithm: sigpb.DigitallySigned_HashAlgorithm(100)},
+			wantCode: codes.InvalidArgument,
+		},
+	} {
+		if _, err := s.Sign(ctx, test.req); status.Code(err) != test.wantCode {
+			t.Errorf("%v: Sign(): %v, want code %v", test.desc, err, test.wantCode)
+		}
+	}
+}
+
+// failingSigner is a crypto.Signer whose Sign always fails.
+type failingSigner struct {
+	crypto.Signer
+}
+
+func (failingSigner) Sign(io.Reader, []byte, crypto.SignerOpts) ([]byte, error) {
+	return nil, errors.New("signing failed")
+}
+
+func TestServerSigningFails(t *testing.T) {
+	key, err := pem.UnmarshalPrivateKey(ttestonly.DemoPrivateKey, ttestonly.DemoPrivateKeyPass)
+	if err != nil {
+		t.Fatalf("Failed to open test key: %v", err)
+	}
+	s := NewServer(map[string]crypto.Signer{"key": failingSigner{key}})
+
+	req := &remotepb.SignRequest{KeyId: "key", Digest: make([]byte, 32), HashAlgorithm: sigpb.DigitallySigned_SHA256}
+	if _, err := s.Sign(context.Background(), req); status.Code(err) != codes.Internal {
+		t.Errorf("Sign(): %v, want code %v", err, codes.Internal)
+	}
+}
+
+// flakyClient fails the first failures calls to each method with err.
+type flakyClient struct {
+	remotepb.RemoteSignerClient
+	failures int
+	err      error
+	calls    map[string]int
+}
+
+func (c *flakyClient) fail(method string) error {
+	c.calls[method]++
+	if c.calls[method] <= c.failures {
+		return c.err
+	}
+	return nil
+}
+
+func (c *flakyClient) GetPublicKey(ctx context.Context, req *remotepb.GetPublicKeyRequest, opts ...grpc.CallOption) (*remotepb.GetPublicKeyResponse, error) {
+	if err := c.fail("GetPublicKey"); err != nil {
+		return nil, err
+	}
+	return c.RemoteSignerClient.GetPublicKey(ctx, req, opts...)
+}
+
+func (c *flakyClient) Sign(ctx context.Context, req *remotepb.SignRequest, opts ...grpc.CallOption) (*remotepb.SignResponse, error) {
+	if err := c.fail("Sign"); err != nil {
+		return nil, err
+	}
+	return c.RemoteSignerClient.Sign(ctx, req, opts...)
+}
+
+func TestSignerRetries(t *testing.T) {
+	defer func(f func(time.Duration)) { timeSleep = f }(timeSleep)
+	var slept []time.Duration
+	timeSleep = func(d time.Duration) { slept = append(slept, d) }
+
+	key, err := pem.UnmarshalPrivateKey(ttestonly.DemoPrivateKey, ttestonly.DemoPrivateKeyPass)
+	if err != nil {
+		t.Fatalf("Failed to open test key: %v", err)
+	}
+	client, stop := startServer(t, map[string]crypto.Signer{"key": key})
+	defer stop()
+
+	ctx := context.Background()
+	opts := Options{MaxAttempts: 3, RetryDelay: time.Second}
+	for _, test := range []struct {
+		desc      string
+		failures  int
+		err       error
+		wantCalls int
+		wantErr   bool
+	}{
+		{desc: "noFailures", wantCalls: 1},
+		{desc: "transientFailures", failures: 2, err: status.Error(codes.Unavailable, "down"), wantCalls: 3},
+		{desc: "tooManyFailures", failures: 3, err: status.Error(codes.Unavailable, "down"), wantCalls: 3, wantErr: true},
+		{desc: "deadlineExceeded", failures: 1, err: status.Error(codes.DeadlineExceeded, "slow"), wantCalls: 2},
+		{desc: "permanentFailure", failures: 1, err: status.Error(codes.PermissionDenied, "no"), wantCalls: 1, wantErr: true},
+		{desc: "nonStatusFailure", failures: 1, err: errors.New("boom"), wantCalls: 1, wantErr: true},
+	} {
+		slept = nil
+		flaky := &flakyClient{RemoteSignerClient: client, failures: test.failures, err: test.err, calls: make(map[string]int)}
+		signer, err := NewSigner(ctx, flaky, "key", opts)
+		if gotErr := err != nil; gotErr != test.wantErr {
+			t.Errorf("%v: NewSigner(): %v, wantErr %v", test.desc, err, test.wantErr)
+			continue
+		}
+		if got := flaky.calls["GetPublicKey"]; got != test.wantCalls {
+			t.Errorf("%v: GetPublicKey called %v times, want %v", test.desc, got, test.wantCalls)
+		}
+		for i, d := range slept {
+			if want := opts.RetryDelay << uint(i); d != want {
+				t.Errorf("%v: retry %v slept %v, want %v", test.desc, i, d, want)
+			}
+		}
+		if err != nil {
+			continue
+		}
+
+		// Sign retries in the same way.
+		if err := testonly.SignAndVerify(signer, key.Public()); err != nil {
+			t.Errorf("%v: SignAndVerify(): %v", test.desc, err)
+		}
+		if got := flaky.calls["Sign"]; got != test.wantCalls {
+			t.Errorf("%v: Sign called %v times, want %v", test.desc, got, test.wantCalls)
+		}
+	}
+}
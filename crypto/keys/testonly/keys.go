@@ -20,7 +20,6 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha256"
-	"crypto/x509"
 	"encoding/asn1"
 	"errors"
 	"fmt"
@@ -30,6 +29,7 @@ import (
 	"github.com/google/trillian/crypto/keys/der"
 	"github.com/google/trillian/crypto/keys/pem"
 	"github.com/google/trillian/crypto/keyspb"
+	"golang.org/x/crypto/ed25519"
 )
 
 // MustMarshalPublicPEMToDER reads a PEM-encoded public key and returns it in DER encoding.
@@ -40,7 +40,7 @@ func MustMarshalPublicPEMToDER(keyPEM string) []byte {
 		panic(err)
 	}
 
-	keyDER, err := x509.MarshalPKIXPublicKey(key)
+	keyDER, err := der.MarshalPublicKey(key)
 	if err != nil {
 		panic(err)
 	}
@@ -65,6 +65,19 @@ func MustMarshalPrivatePEMToDER(keyPEM, password string) []byte {
 // SignAndVerify exercises a signer by using it to generate a signature, and
 // then verifies that this signature is correct.
 func SignAndVerify(signer crypto.Signer, pubKey crypto.PublicKey) error {
+	if pubKey, ok := pubKey.(ed25519.PublicKey); ok {
+		// Ed25519 signs the message itself, rather than a digest.
+		msg := []byte("test")
+		signature, err := signer.Sign(rand.Reader, msg, crypto.Hash(0))
+		if err != nil {
+			return err
+		}
+		if !ed25519.Verify(pubKey, msg, signature) {
+			return errors.New("Ed25519 signature failed verification")
+		}
+		return nil
+	}
+
 	hasher := crypto.SHA256
 	digest := sha256.Sum256([]byte("test"))
 	signature, err := signer.Sign(rand.Reader, digest[:], hasher)
@@ -122,6 +135,11 @@ func CheckKeyMatchesSpec(key crypto.PrivateKey, spec *keyspb.Specification) erro
 			return checkRsaKeyMatchesParams(key, params.RsaParams)
 		}
 		return fmt.Errorf("%T, want *rsa.PrivateKey", key)
+	case *keyspb.Specification_Ed25519Params:
+		if _, ok := key.(ed25519.PrivateKey); ok {
+			return nil
+		}
+		return fmt.Errorf("%T, want ed25519.PrivateKey", key)
 	}
 
 	return fmt.Errorf("%T is not a supported keyspb.Specification.Params type", spec.Params)
@@ -13,6 +13,8 @@ It has these top-level messages:
 	PrivateKey
 	PublicKey
 	PKCS11Config
+	RemoteSignerConfig
+	EncryptedPrivateKey
 */
 package keyspb
 
@@ -68,6 +70,7 @@ type Specification struct {
 	// Types that are valid to be assigned to Params:
 	//	*Specification_EcdsaParams
 	//	*Specification_RsaParams
+	//	*Specification_Ed25519Params
 	Params isSpecification_Params `protobuf_oneof:"params"`
 }
 
@@ -84,9 +87,13 @@ type Specification_EcdsaParams struct {
 type Specification_RsaParams struct {
 	RsaParams *Specification_RSA `protobuf:"bytes,2,opt,name=rsa_params,json=rsaParams,oneof"`
 }
+type Specification_Ed25519Params struct {
+	Ed25519Params *Specification_Ed25519 `protobuf:"bytes,3,opt,name=ed25519_params,json=ed25519Params,oneof"`
+}
 
-func (*Specification_EcdsaParams) isSpecification_Params() {}
-func (*Specification_RsaParams) isSpecification_Params()   {}
+func (*Specification_EcdsaParams) isSpecification_Params()   {}
+func (*Specification_RsaParams) isSpecification_Params()     {}
+func (*Specification_Ed25519Params) isSpecification_Params() {}
 
 func (m *Specification) GetParams() isSpecification_Params {
 	if m != nil {
@@ -109,11 +116,19 @@ func (m *Specification) GetRsaParams() *Specification_RSA {
 	return nil
 }
 
+func (m *Specification) GetEd25519Params() *Specification_Ed25519 {
+	if x, ok := m.GetParams().(*Specification_Ed25519Params); ok {
+		return x.Ed25519Params
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*Specification) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _Specification_OneofMarshaler, _Specification_OneofUnmarshaler, _Specification_OneofSizer, []interface{}{
 		(*Specification_EcdsaParams)(nil),
 		(*Specification_RsaParams)(nil),
+		(*Specification_Ed25519Params)(nil),
 	}
 }
 
@@ -131,6 +146,11 @@ func _Specification_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.RsaParams); err != nil {
 			return err
 		}
+	case *Specification_Ed25519Params:
+		b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Ed25519Params); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("Specification.Params has unexpected type %T", x)
@@ -157,6 +177,14 @@ func _Specification_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.
 		err := b.DecodeMessage(msg)
 		m.Params = &Specification_RsaParams{msg}
 		return true, err
+	case 3: // params.ed25519_params
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Specification_Ed25519)
+		err := b.DecodeMessage(msg)
+		m.Params = &Specification_Ed25519Params{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -176,6 +204,11 @@ func _Specification_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(2<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *Specification_Ed25519Params:
+		s := proto.Size(x.Ed25519Params)
+		n += proto.SizeVarint(3<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -222,6 +255,15 @@ func (m *Specification_RSA) GetBits() int32 {
 	return 0
 }
 
+// Ed25519 defines parameters for an Ed25519 key.
+type Specification_Ed25519 struct {
+}
+
+func (m *Specification_Ed25519) Reset()                    { *m = Specification_Ed25519{} }
+func (m *Specification_Ed25519) String() string            { return proto.CompactTextString(m) }
+func (*Specification_Ed25519) ProtoMessage()               {}
+func (*Specification_Ed25519) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0, 2} }
+
 // PEMKeyFile identifies a private key stored in a PEM-encoded file.
 type PEMKeyFile struct {
 	// File path of the private key.
@@ -323,45 +365,122 @@ func (m *PKCS11Config) GetPublicKey() string {
 	return ""
 }
 
+// RemoteSignerConfig identifies a private key held by a remote signing service
+// implementing the RemoteSigner gRPC service (see crypto/keys/remote/remotepb).
+type RemoteSignerConfig struct {
+	// Address of the signing service (host:port).
+	Address string `protobuf:"bytes,1,opt,name=address" json:"address,omitempty"`
+	// Identifier of the key within the signing service.
+	KeyId string `protobuf:"bytes,2,opt,name=key_id,json=keyId" json:"key_id,omitempty"`
+}
+
+func (m *RemoteSignerConfig) Reset()                    { *m = RemoteSignerConfig{} }
+func (m *RemoteSignerConfig) String() string            { return proto.CompactTextString(m) }
+func (*RemoteSignerConfig) ProtoMessage()               {}
+func (*RemoteSignerConfig) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *RemoteSignerConfig) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *RemoteSignerConfig) GetKeyId() string {
+	if m != nil {
+		return m.KeyId
+	}
+	return ""
+}
+
+// EncryptedPrivateKey is a PrivateKey encrypted at rest using envelope
+// encryption: the key is encrypted with a random data encryption key (DEK),
+// which is itself encrypted ("wrapped") with a key encryption key (KEK) that
+// isn't stored alongside the tree.
+type EncryptedPrivateKey struct {
+	// Identifier of the KEK that wrapped the DEK.
+	KekId string `protobuf:"bytes,1,opt,name=kek_id,json=kekId" json:"kek_id,omitempty"`
+	// The DEK, wrapped with the KEK.
+	WrappedDek []byte `protobuf:"bytes,2,opt,name=wrapped_dek,json=wrappedDek,proto3" json:"wrapped_dek,omitempty"`
+	// The DER-encoded private key, encrypted with the DEK.
+	Ciphertext []byte `protobuf:"bytes,3,opt,name=ciphertext,proto3" json:"ciphertext,omitempty"`
+}
+
+func (m *EncryptedPrivateKey) Reset()                    { *m = EncryptedPrivateKey{} }
+func (m *EncryptedPrivateKey) String() string            { return proto.CompactTextString(m) }
+func (*EncryptedPrivateKey) ProtoMessage()               {}
+func (*EncryptedPrivateKey) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *EncryptedPrivateKey) GetKekId() string {
+	if m != nil {
+		return m.KekId
+	}
+	return ""
+}
+
+func (m *EncryptedPrivateKey) GetWrappedDek() []byte {
+	if m != nil {
+		return m.WrappedDek
+	}
+	return nil
+}
+
+func (m *EncryptedPrivateKey) GetCiphertext() []byte {
+	if m != nil {
+		return m.Ciphertext
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Specification)(nil), "keyspb.Specification")
 	proto.RegisterType((*Specification_ECDSA)(nil), "keyspb.Specification.ECDSA")
 	proto.RegisterType((*Specification_RSA)(nil), "keyspb.Specification.RSA")
+	proto.RegisterType((*Specification_Ed25519)(nil), "keyspb.Specification.Ed25519")
 	proto.RegisterType((*PEMKeyFile)(nil), "keyspb.PEMKeyFile")
 	proto.RegisterType((*PrivateKey)(nil), "keyspb.PrivateKey")
 	proto.RegisterType((*PublicKey)(nil), "keyspb.PublicKey")
 	proto.RegisterType((*PKCS11Config)(nil), "keyspb.PKCS11Config")
+	proto.RegisterType((*RemoteSignerConfig)(nil), "keyspb.RemoteSignerConfig")
+	proto.RegisterType((*EncryptedPrivateKey)(nil), "keyspb.EncryptedPrivateKey")
 	proto.RegisterEnum("keyspb.Specification_ECDSA_Curve", Specification_ECDSA_Curve_name, Specification_ECDSA_Curve_value)
 }
 
 func init() { proto.RegisterFile("crypto/keyspb/keyspb.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 403 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x92, 0x5f, 0x6f, 0xd3, 0x30,
-	0x14, 0xc5, 0xd7, 0x76, 0xad, 0x9a, 0xdb, 0x0e, 0x05, 0x3f, 0x6d, 0x41, 0xe3, 0x4f, 0x9e, 0x26,
-	0x1e, 0x12, 0x35, 0x63, 0x30, 0x21, 0x1e, 0xe8, 0xb2, 0x4c, 0x48, 0x1d, 0x52, 0xe4, 0x30, 0x1e,
-	0x78, 0x09, 0x4e, 0xea, 0x65, 0x56, 0xb3, 0xd8, 0x72, 0xdc, 0xa1, 0xf0, 0xa1, 0xf8, 0x8c, 0x28,
-	0x37, 0x19, 0x68, 0xd2, 0xb4, 0x27, 0x9f, 0x6b, 0x9f, 0x9f, 0xcf, 0xbd, 0xd2, 0x05, 0x27, 0xd7,
-	0x8d, 0x32, 0xd2, 0xdf, 0xf0, 0xa6, 0x56, 0x59, 0x7f, 0x78, 0x4a, 0x4b, 0x23, 0xc9, 0xa4, 0xab,
-	0xdc, 0x3f, 0x43, 0xd8, 0x4b, 0x14, 0xcf, 0xc5, 0xb5, 0xc8, 0x99, 0x11, 0xb2, 0x22, 0x9f, 0x61,
-	0xce, 0xf3, 0x75, 0xcd, 0x52, 0xc5, 0x34, 0xbb, 0xad, 0xf7, 0x07, 0xaf, 0x07, 0x47, 0xb3, 0xe0,
-	0x85, 0xd7, 0xe3, 0x0f, 0xcc, 0x5e, 0x14, 0x9e, 0x27, 0xcb, 0x2f, 0x3b, 0x74, 0x86, 0x48, 0x8c,
-	0x04, 0xf9, 0x08, 0xa0, 0xff, 0xf3, 0x43, 0xe4, 0x0f, 0x1e, 0xe7, 0x29, 0xd2, 0x96, 0xbe, 0x67,
-	0x9d, 0xdf, 0x30, 0xc6, 0x3f, 0xc9, 0x07, 0x18, 0xe7, 0x5b, 0x7d, 0xc7, 0x31, 0xff, 0x59, 0xf0,
-	0xe6, 0x89, 0x7c, 0x2f, 0x6c, 0x8d, 0xb4, 0xf3, 0xbb, 0xa7, 0x30, 0xc6, 0x9a, 0x3c, 0x87, 0xbd,
-	0xf3, 0xe8, 0x62, 0x79, 0x75, 0xf9, 0x2d, 0x0d, 0xaf, 0xe8, 0xf7, 0xc8, 0xde, 0x21, 0x53, 0xd8,
-	0x8d, 0x83, 0x93, 0xf7, 0xf6, 0x00, 0xd5, 0xf1, 0xe9, 0x3b, 0x7b, 0x88, 0xea, 0x24, 0x58, 0xd8,
-	0x23, 0xe7, 0x00, 0x46, 0x34, 0x59, 0x12, 0x02, 0xbb, 0x99, 0x30, 0xdd, 0xe0, 0x63, 0x8a, 0xfa,
-	0x6c, 0x0a, 0x93, 0x6e, 0x1c, 0xf7, 0x13, 0x40, 0x1c, 0x7d, 0x5d, 0xf1, 0xe6, 0x42, 0x94, 0xbc,
-	0xf5, 0x2a, 0x66, 0x6e, 0xd0, 0x6b, 0x51, 0xd4, 0xc4, 0x81, 0xa9, 0x62, 0x75, 0xfd, 0x4b, 0xea,
-	0x35, 0x0e, 0x6f, 0xd1, 0x7f, 0xb5, 0xfb, 0x12, 0x20, 0xd6, 0xe2, 0x8e, 0x19, 0xbe, 0xe2, 0x0d,
-	0xb1, 0x61, 0xb4, 0xe6, 0x1a, 0xe1, 0x39, 0x6d, 0xa5, 0x7b, 0x08, 0x56, 0xbc, 0xcd, 0x4a, 0x91,
-	0x3f, 0xfe, 0xfc, 0x13, 0xe6, 0xf1, 0x2a, 0x4c, 0x16, 0x8b, 0x50, 0x56, 0xd7, 0xa2, 0x20, 0xaf,
-	0x60, 0x66, 0xe4, 0x86, 0x57, 0x69, 0xc9, 0x32, 0x5e, 0xf6, 0x5d, 0x00, 0x5e, 0x5d, 0xb6, 0x37,
-	0xed, 0x17, 0x4a, 0x54, 0x7d, 0x1b, 0xad, 0x24, 0x87, 0x00, 0x0a, 0x13, 0xd2, 0x0d, 0x6f, 0xf6,
-	0x47, 0xf8, 0x60, 0xa9, 0xfb, 0xcc, 0xb3, 0xb7, 0x3f, 0x8e, 0x0a, 0x61, 0x6e, 0xb6, 0x99, 0x97,
-	0xcb, 0x5b, 0xbf, 0x90, 0xb2, 0x28, 0xb9, 0x6f, 0xb4, 0x28, 0x4b, 0xc1, 0x2a, 0xff, 0xc1, 0x42,
-	0x65, 0x13, 0x5c, 0xa5, 0xe3, 0xbf, 0x01, 0x00, 0x00, 0xff, 0xff, 0xf1, 0x64, 0xdf, 0x9a, 0x68,
-	0x02, 0x00, 0x00,
+	// 522 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x93, 0x4f, 0x6f, 0xda, 0x4c,
+	0x10, 0xc6, 0x43, 0x08, 0x49, 0x3c, 0x40, 0xc4, 0xbb, 0xaf, 0x2a, 0x25, 0x54, 0xa4, 0xad, 0x4f,
+	0x51, 0x0f, 0x20, 0x48, 0x69, 0xd3, 0xaa, 0x87, 0x12, 0x30, 0x4a, 0x44, 0x2a, 0x59, 0x4b, 0xd3,
+	0x43, 0x2f, 0xae, 0xff, 0x4c, 0x60, 0x65, 0x63, 0xaf, 0xd6, 0x4b, 0x52, 0xf7, 0xd6, 0x6f, 0x5e,
+	0x79, 0x6c, 0xd2, 0x44, 0x4a, 0x7b, 0x62, 0x66, 0x78, 0x7e, 0x33, 0xf3, 0xac, 0x77, 0xa1, 0xed,
+	0xab, 0x4c, 0xea, 0xa4, 0x17, 0x62, 0x96, 0x4a, 0xaf, 0xfc, 0xe9, 0x4a, 0x95, 0xe8, 0x84, 0xed,
+	0x16, 0x99, 0xf9, 0xab, 0x0a, 0xcd, 0xb9, 0x44, 0x5f, 0xdc, 0x08, 0xdf, 0xd5, 0x22, 0x89, 0xd9,
+	0x27, 0x68, 0xa0, 0x1f, 0xa4, 0xae, 0x23, 0x5d, 0xe5, 0xae, 0xd2, 0xc3, 0xca, 0xcb, 0xca, 0x49,
+	0x7d, 0xf0, 0xbc, 0x5b, 0xe2, 0x8f, 0xc4, 0x5d, 0x6b, 0x3c, 0x99, 0x8f, 0x2e, 0xb6, 0x78, 0x9d,
+	0x10, 0x9b, 0x08, 0xf6, 0x01, 0x40, 0xfd, 0xe1, 0xb7, 0x89, 0x3f, 0x7a, 0x9a, 0xe7, 0x44, 0x1b,
+	0xea, 0x9e, 0x9d, 0xc2, 0x01, 0x06, 0x83, 0xe1, 0xb0, 0xff, 0x7e, 0xc3, 0x57, 0x89, 0xef, 0xfc,
+	0x65, 0x7e, 0xa1, 0xbd, 0xd8, 0xe2, 0xcd, 0x12, 0x2b, 0xfa, 0xb4, 0x7f, 0x42, 0x8d, 0x76, 0x63,
+	0xef, 0xa0, 0xe6, 0xaf, 0xd5, 0x2d, 0x92, 0x8f, 0x83, 0xc1, 0xab, 0x7f, 0xf8, 0xe8, 0x8e, 0x73,
+	0x21, 0x2f, 0xf4, 0xe6, 0x19, 0xd4, 0x28, 0x67, 0xff, 0x41, 0x73, 0x62, 0x4d, 0x47, 0xd7, 0x57,
+	0x5f, 0x9c, 0xf1, 0x35, 0xff, 0x6a, 0xb5, 0xb6, 0xd8, 0x3e, 0xec, 0xd8, 0x83, 0xe1, 0xdb, 0x56,
+	0x85, 0xa2, 0xd3, 0xb3, 0x37, 0xad, 0x6d, 0x8a, 0x86, 0x83, 0x7e, 0xab, 0xda, 0x3e, 0x82, 0x2a,
+	0x9f, 0x8f, 0x18, 0x83, 0x1d, 0x4f, 0xe8, 0xe2, 0x00, 0x6b, 0x9c, 0xe2, 0xb6, 0x01, 0x7b, 0xe5,
+	0xca, 0xe7, 0xfb, 0xb0, 0x5b, 0x38, 0x34, 0x3f, 0x02, 0xd8, 0xd6, 0xe7, 0x19, 0x66, 0x53, 0x11,
+	0x61, 0x8e, 0x49, 0x57, 0x2f, 0x09, 0x33, 0x38, 0xc5, 0xac, 0x0d, 0xfb, 0xd2, 0x4d, 0xd3, 0xbb,
+	0x44, 0x05, 0x74, 0x9e, 0x06, 0xbf, 0xcf, 0xcd, 0x63, 0x00, 0x5b, 0x89, 0x5b, 0x57, 0xe3, 0x0c,
+	0x33, 0xd6, 0x82, 0x6a, 0x80, 0x8a, 0xe0, 0x06, 0xcf, 0x43, 0xb3, 0x03, 0x86, 0xbd, 0xf6, 0x22,
+	0xe1, 0x3f, 0xfd, 0xf7, 0x77, 0x68, 0xd8, 0xb3, 0xf1, 0xbc, 0xdf, 0x1f, 0x27, 0xf1, 0x8d, 0x58,
+	0xb0, 0x17, 0x50, 0xd7, 0x49, 0x88, 0xb1, 0x13, 0xb9, 0x1e, 0x46, 0xe5, 0x16, 0x40, 0xa5, 0xab,
+	0xbc, 0x92, 0xb7, 0x90, 0x22, 0x2e, 0xd7, 0xc8, 0x43, 0xd6, 0x01, 0x90, 0x34, 0xc1, 0x09, 0x31,
+	0xa3, 0xef, 0x65, 0x70, 0x43, 0x6e, 0x66, 0x9a, 0x16, 0x30, 0x8e, 0xab, 0x44, 0xe3, 0x5c, 0x2c,
+	0x62, 0x54, 0xe5, 0x9c, 0x43, 0xd8, 0x73, 0x83, 0x40, 0x61, 0x9a, 0x96, 0x33, 0x36, 0x29, 0x7b,
+	0x06, 0xf9, 0xe5, 0x74, 0xc4, 0xc6, 0x6a, 0x2d, 0xc4, 0xec, 0x32, 0x30, 0x57, 0xf0, 0xbf, 0x15,
+	0xd3, 0x8d, 0xc6, 0xe0, 0x81, 0x61, 0x52, 0x87, 0xb9, 0xba, 0xb2, 0x51, 0x87, 0x97, 0x41, 0x6e,
+	0xe3, 0x4e, 0xb9, 0x52, 0x62, 0xe0, 0x04, 0x18, 0x52, 0xa7, 0x06, 0x87, 0xb2, 0x34, 0xc1, 0x90,
+	0x1d, 0x03, 0xf8, 0x42, 0x2e, 0x51, 0x69, 0xfc, 0xa1, 0x69, 0xe9, 0x06, 0x7f, 0x50, 0x39, 0x7f,
+	0xfd, 0xed, 0x64, 0x21, 0xf4, 0x72, 0xed, 0x75, 0xfd, 0x64, 0xd5, 0x5b, 0x24, 0xc9, 0x22, 0xc2,
+	0x9e, 0x56, 0x22, 0x8a, 0x84, 0x1b, 0xf7, 0x1e, 0xbd, 0x2c, 0x6f, 0x97, 0xde, 0xd4, 0xe9, 0xef,
+	0x01, 0x00, 0xae, 0x62, 0x01, 0x1d, 0x71, 0x03, 0x00, 0x00,
 }
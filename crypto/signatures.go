@@ -19,18 +19,41 @@ import (
 	"crypto/ecdsa"
 	"crypto/rsa"
 
+	"github.com/google/trillian/crypto/keys/der"
 	"github.com/google/trillian/crypto/sigpb"
+	"golang.org/x/crypto/ed25519"
 )
 
 // SignatureAlgorithm returns the algorithm used for this public key.
-// Only ECDSA and RSA keys are supported. Other key types will return sigpb.DigitallySigned_ANONYMOUS.
+// Only ECDSA, RSA and Ed25519 keys are supported. Other key types will return sigpb.DigitallySigned_ANONYMOUS.
 func SignatureAlgorithm(k gocrypto.PublicKey) sigpb.DigitallySigned_SignatureAlgorithm {
 	switch k.(type) {
 	case *ecdsa.PublicKey:
 		return sigpb.DigitallySigned_ECDSA
 	case *rsa.PublicKey:
 		return sigpb.DigitallySigned_RSA
+	case ed25519.PublicKey:
+		return sigpb.DigitallySigned_ED25519
 	}
 
 	return sigpb.DigitallySigned_ANONYMOUS
 }
+
+// noteEd25519 is the algorithm byte of Ed25519 keys in signed notes.
+const noteEd25519 = 0x01
+
+// NoteKeyID returns the ID of pub from which its key hash in signed notes is
+// computed (see types.NoteKeyHash).
+//
+// Ed25519 keys are identified as in the note format of Go's checksum database
+// (golang.org/x/mod/sumdb/note), by the algorithm byte 0x01 followed by the
+// 32-byte key, so checkpoints signed by them can be verified by verifiers of
+// that format. The format defines no algorithm byte for other keys, so they're
+// identified by their DER encoding instead, and checkpoints signed by them can
+// only be verified by VerifyCheckpoint.
+func NoteKeyID(pub gocrypto.PublicKey) ([]byte, error) {
+	if key, ok := pub.(ed25519.PublicKey); ok {
+		return append([]byte{noteEd25519}, key...), nil
+	}
+	return der.MarshalPublicKey(pub)
+}
@@ -18,10 +18,12 @@ package crypto
 import (
 	"crypto"
 	"crypto/rand"
+	"fmt"
 
 	"github.com/golang/glog"
 	"github.com/google/trillian"
 	"github.com/google/trillian/types"
+	"golang.org/x/crypto/ed25519"
 )
 
 // Signer is responsible for signing log-related data and producing the appropriate
@@ -30,6 +32,12 @@ type Signer struct {
 	KeyHint []byte
 	Hash    crypto.Hash
 	Signer  crypto.Signer
+	// CoSigners are the additional signers of a multi-signature tree, in the
+	// order of MultiSigConfig.signers. Log roots carry a signature from each
+	// co-signer that is available, and fail to be signed if fewer than
+	// CoSignThreshold are.
+	CoSigners       []crypto.Signer
+	CoSignThreshold int
 }
 
 // NewSigner returns a new signer. The signer will set the KeyHint field, when available, with KeyID.
@@ -56,12 +64,43 @@ func (s *Signer) Public() crypto.PublicKey {
 }
 
 // Sign obtains a signature after first hashing the input data.
+// Ed25519 keys sign the input data itself, as PureEdDSA hashes internally.
 func (s *Signer) Sign(data []byte) ([]byte, error) {
+	return s.signWith(s.Signer, data)
+}
+
+// signWith signs data with signer, using the hash of s.
+func (s *Signer) signWith(signer crypto.Signer, data []byte) ([]byte, error) {
+	if _, ok := signer.Public().(ed25519.PublicKey); ok {
+		return signer.Sign(rand.Reader, data, crypto.Hash(0))
+	}
+
 	h := s.Hash.New()
 	h.Write(data)
 	digest := h.Sum(nil)
 
-	return s.Signer.Sign(rand.Reader, digest, s.Hash)
+	return signer.Sign(rand.Reader, digest, s.Hash)
+}
+
+// coSign signs data with each of s.CoSigners. Failures are tolerated as long as
+// at least s.CoSignThreshold signatures are produced.
+func (s *Signer) coSign(data []byte) ([]*trillian.RootSignature, error) {
+	if len(s.CoSigners) == 0 {
+		return nil, nil
+	}
+	var signatures []*trillian.RootSignature
+	for i, signer := range s.CoSigners {
+		signature, err := s.signWith(signer, data)
+		if err != nil {
+			glog.Warningf("%v: co-signer %v failed to sign: %v", s.KeyHint, i, err)
+			continue
+		}
+		signatures = append(signatures, &trillian.RootSignature{SignerIndex: int32(i), Signature: signature})
+	}
+	if len(signatures) < s.CoSignThreshold {
+		return nil, fmt.Errorf("%v of %v co-signers signed, want at least %v", len(signatures), len(s.CoSigners), s.CoSignThreshold)
+	}
+	return signatures, nil
 }
 
 // SignLogRoot returns a complete SignedLogRoot (including signature).
@@ -75,11 +114,17 @@ func (s *Signer) SignLogRoot(r *types.LogRootV1) (*trillian.SignedLogRoot, error
 		glog.Warningf("%v: signer failed to sign log root: %v", s.KeyHint, err)
 		return nil, err
 	}
+	signatures, err := s.coSign(logRoot)
+	if err != nil {
+		glog.Warningf("%v: signer failed to co-sign log root: %v", s.KeyHint, err)
+		return nil, err
+	}
 
 	return &trillian.SignedLogRoot{
 		KeyHint:          s.KeyHint,
 		LogRoot:          logRoot,
 		LogRootSignature: signature,
+		Signatures:       signatures,
 		// TODO(gbelvin): Remove deprecated fields
 		TimestampNanos: int64(r.TimestampNanos),
 		RootHash:       r.RootHash,
@@ -88,6 +133,29 @@ func (s *Signer) SignLogRoot(r *types.LogRootV1) (*trillian.SignedLogRoot, error
 	}, nil
 }
 
+// SignCheckpoint returns c as a signed note, with a signature line named after
+// the origin of c.
+func (s *Signer) SignCheckpoint(c *types.Checkpoint) ([]byte, error) {
+	text, err := c.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	keyID, err := NoteKeyID(s.Public())
+	if err != nil {
+		return nil, err
+	}
+	signature, err := s.Sign(text)
+	if err != nil {
+		glog.Warningf("%v: signer failed to sign checkpoint: %v", s.KeyHint, err)
+		return nil, err
+	}
+	return types.MarshalNote(text, []types.NoteSignature{{
+		Name:      c.Origin,
+		KeyHash:   types.NoteKeyHash(c.Origin, keyID),
+		Signature: signature,
+	}})
+}
+
 // SignMapRoot hashes and signs the supplied (to-be) SignedMapRoot and returns a signature.
 func (s *Signer) SignMapRoot(r *types.MapRootV1) (*trillian.SignedMapRoot, error) {
 	rootBytes, err := r.MarshalBinary()
@@ -106,3 +174,19 @@ func (s *Signer) SignMapRoot(r *types.MapRootV1) (*trillian.SignedMapRoot, error
 		Signature: signature,
 	}, nil
 }
+
+// SignKeyRotation returns the serialization of the supplied key rotation
+// statement, and its signature.
+func (s *Signer) SignKeyRotation(r *types.KeyRotationV1) ([]byte, []byte, error) {
+	rotation, err := r.MarshalBinary()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	signature, err := s.Sign(rotation)
+	if err != nil {
+		glog.Warningf("%v: signer failed to sign key rotation: %v", s.KeyHint, err)
+		return nil, nil, err
+	}
+	return rotation, signature, nil
+}
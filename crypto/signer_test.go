@@ -16,13 +16,18 @@ package crypto
 
 import (
 	"crypto"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
 	"errors"
+	"reflect"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/trillian/crypto/keys/pem"
 	"github.com/google/trillian/testonly"
 	"github.com/google/trillian/types"
+	"golang.org/x/crypto/ed25519"
 )
 
 const message string = "testing"
@@ -113,6 +118,63 @@ func TestSignLogRoot(t *testing.T) {
 	}
 }
 
+func TestSignLogRootMultiSig(t *testing.T) {
+	key, err := pem.UnmarshalPrivateKey(testonly.DemoPrivateKey, testonly.DemoPrivateKeyPass)
+	if err != nil {
+		t.Fatalf("Failed to open test key, err=%v", err)
+	}
+	var coSigners []crypto.Signer
+	var pubs []crypto.PublicKey
+	for _, keyPEM := range []string{privPEM, ed25519PrivPEM, privPEM} {
+		coSigner, err := pem.UnmarshalPrivateKey(keyPEM, "")
+		if err != nil {
+			t.Fatalf("Failed to open test key, err=%v", err)
+		}
+		coSigners = append(coSigners, coSigner)
+		pubs = append(pubs, coSigner.Public())
+	}
+	failing := testonly.NewSignerWithErr(coSigners[0], errors.New("cosignfail"))
+
+	root := &types.LogRootV1{TimestampNanos: 2267709, RootHash: []byte("Islington"), TreeSize: 2}
+	for _, test := range []struct {
+		desc       string
+		coSigners  []crypto.Signer
+		threshold  int
+		wantErr    bool
+		wantSigned []int32
+	}{
+		{desc: "allSign", coSigners: coSigners, threshold: 2, wantSigned: []int32{0, 1, 2}},
+		{desc: "oneFails", coSigners: []crypto.Signer{failing, coSigners[1], coSigners[2]}, threshold: 2, wantSigned: []int32{1, 2}},
+		{desc: "tooManyFail", coSigners: []crypto.Signer{failing, failing, coSigners[2]}, threshold: 2, wantErr: true},
+	} {
+		signer := NewSigner(0, key, crypto.SHA256)
+		signer.CoSigners = test.coSigners
+		signer.CoSignThreshold = test.threshold
+
+		slr, err := signer.SignLogRoot(root)
+		if gotErr := err != nil; gotErr != test.wantErr {
+			t.Errorf("%v: SignLogRoot(): %v, wantErr %v", test.desc, err, test.wantErr)
+			continue
+		} else if gotErr {
+			continue
+		}
+
+		var signed []int32
+		for _, sig := range slr.Signatures {
+			signed = append(signed, sig.SignerIndex)
+		}
+		if !reflect.DeepEqual(signed, test.wantSigned) {
+			t.Errorf("%v: signed by %v, want %v", test.desc, signed, test.wantSigned)
+		}
+		if _, err := VerifySignedLogRoot(key.Public(), crypto.SHA256, slr); err != nil {
+			t.Errorf("%v: VerifySignedLogRoot(): %v", test.desc, err)
+		}
+		if _, err := VerifySignedLogRootThreshold(pubs, test.threshold, crypto.SHA256, slr); err != nil {
+			t.Errorf("%v: VerifySignedLogRootThreshold(): %v", test.desc, err)
+		}
+	}
+}
+
 func TestSignMapRoot(t *testing.T) {
 	key, err := pem.UnmarshalPrivateKey(testonly.DemoPrivateKey, testonly.DemoPrivateKeyPass)
 	if err != nil {
@@ -137,3 +199,97 @@ func TestSignMapRoot(t *testing.T) {
 		}
 	}
 }
+
+func TestSignKeyRotation(t *testing.T) {
+	key, err := pem.UnmarshalPrivateKey(testonly.DemoPrivateKey, testonly.DemoPrivateKeyPass)
+	if err != nil {
+		t.Fatalf("Failed to open test key, err=%v", err)
+	}
+	signer := NewSigner(0, key, crypto.SHA256)
+
+	want := &types.KeyRotationV1{TreeID: 6962, KeyVersion: 1, PublicKey: []byte("der"), TimestampNanos: 2267709}
+	rotation, sig, err := signer.SignKeyRotation(want)
+	if err != nil {
+		t.Fatalf("SignKeyRotation(): %v", err)
+	}
+	got, err := VerifyKeyRotation(key.Public(), crypto.SHA256, rotation, sig)
+	if err != nil {
+		t.Fatalf("VerifyKeyRotation(): %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("VerifyKeyRotation(): %+v, want %+v", got, want)
+	}
+
+	// The statement must not verify if it was modified.
+	rotation[len(rotation)-1] ^= 1
+	if _, err := VerifyKeyRotation(key.Public(), crypto.SHA256, rotation, sig); err == nil {
+		t.Error("VerifyKeyRotation(modified statement): nil, want err")
+	}
+}
+
+func TestSignCheckpoint(t *testing.T) {
+	key, err := pem.UnmarshalPrivateKey(testonly.DemoPrivateKey, testonly.DemoPrivateKeyPass)
+	if err != nil {
+		t.Fatalf("Failed to open test key, err=%v", err)
+	}
+	signer := NewSigner(0, key, crypto.SHA256)
+
+	want := &types.Checkpoint{Origin: "example.com/log", TreeSize: 6962, RootHash: []byte("root hash")}
+	note, err := signer.SignCheckpoint(want)
+	if err != nil {
+		t.Fatalf("SignCheckpoint(): %v", err)
+	}
+	got, err := VerifyCheckpoint(key.Public(), crypto.SHA256, want.Origin, note)
+	if err != nil {
+		t.Fatalf("VerifyCheckpoint(): %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("VerifyCheckpoint(): %+v, want %+v", got, want)
+	}
+
+	if _, err := VerifyCheckpoint(key.Public(), crypto.SHA256, "example.com/other", note); err == nil {
+		t.Error("VerifyCheckpoint(wrong origin): nil, want err")
+	}
+
+	// The checkpoint must not verify if its text was modified.
+	modified := append([]byte(nil), note...)
+	modified[len(want.Origin)+1] ^= 1
+	if _, err := VerifyCheckpoint(key.Public(), crypto.SHA256, want.Origin, modified); err == nil {
+		t.Error("VerifyCheckpoint(modified checkpoint): nil, want err")
+	}
+}
+
+// TestSignCheckpointEd25519 checks that checkpoints signed by Ed25519 keys
+// follow the note format of Go's checksum database.
+func TestSignCheckpointEd25519(t *testing.T) {
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+	signer := NewSigner(0, key, crypto.SHA256)
+	c := &types.Checkpoint{Origin: "example.com/log", TreeSize: 6962, RootHash: []byte("root hash")}
+	note, err := signer.SignCheckpoint(c)
+	if err != nil {
+		t.Fatalf("SignCheckpoint(): %v", err)
+	}
+	text, sigs, err := types.ParseNote(note)
+	if err != nil {
+		t.Fatalf("ParseNote(): %v", err)
+	}
+	if got, want := len(sigs), 1; got != want {
+		t.Fatalf("ParseNote(): %v signatures, want %v", got, want)
+	}
+
+	// The key hash covers the name, a newline, the Ed25519 algorithm byte and
+	// the key.
+	h := sha256.New()
+	h.Write([]byte(c.Origin + "\n"))
+	h.Write([]byte{0x01})
+	h.Write(pub)
+	if got, want := sigs[0].KeyHash, binary.BigEndian.Uint32(h.Sum(nil)); got != want {
+		t.Errorf("key hash %08x, want %08x", got, want)
+	}
+	if !ed25519.Verify(pub, text, sigs[0].Signature) {
+		t.Error("ed25519.Verify(): false, want true")
+	}
+}
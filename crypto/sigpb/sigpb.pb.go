@@ -71,17 +71,21 @@ const (
 	DigitallySigned_RSA DigitallySigned_SignatureAlgorithm = 1
 	// ECDSA signature scheme.
 	DigitallySigned_ECDSA DigitallySigned_SignatureAlgorithm = 3
+	// Ed25519 signature scheme (PureEdDSA, RFC 8032).
+	DigitallySigned_ED25519 DigitallySigned_SignatureAlgorithm = 7
 )
 
 var DigitallySigned_SignatureAlgorithm_name = map[int32]string{
 	0: "ANONYMOUS",
 	1: "RSA",
 	3: "ECDSA",
+	7: "ED25519",
 }
 var DigitallySigned_SignatureAlgorithm_value = map[string]int32{
 	"ANONYMOUS": 0,
 	"RSA":       1,
 	"ECDSA":     3,
+	"ED25519":   7,
 }
 
 func (x DigitallySigned_SignatureAlgorithm) String() string {
@@ -136,22 +140,23 @@ func init() {
 func init() { proto.RegisterFile("crypto/sigpb/sigpb.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 267 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x90, 0x41, 0x4b, 0xc3, 0x40,
-	0x10, 0x85, 0x9b, 0xa6, 0xad, 0x66, 0x30, 0x35, 0x8c, 0x97, 0x1c, 0x3c, 0x94, 0xa0, 0xd8, 0x5e,
-	0x12, 0xa8, 0xa8, 0xe7, 0x68, 0x0b, 0x05, 0x31, 0x81, 0x2c, 0x1e, 0xec, 0x45, 0x36, 0x35, 0xec,
-	0x2e, 0x6c, 0xb3, 0x21, 0xbb, 0x3d, 0xf4, 0x9f, 0xf9, 0xf3, 0x84, 0x94, 0xda, 0x68, 0xe9, 0x65,
-	0xe0, 0x3d, 0xde, 0x7c, 0x33, 0x3c, 0xf0, 0x57, 0xf5, 0xb6, 0x32, 0x2a, 0xd2, 0x82, 0x55, 0xf9,
-	0x6e, 0x86, 0x55, 0xad, 0x8c, 0xc2, 0x7e, 0x23, 0x82, 0xef, 0x2e, 0x5c, 0xce, 0x04, 0x13, 0x86,
-	0x4a, 0xb9, 0x25, 0x82, 0x95, 0xc5, 0x17, 0xbe, 0xc2, 0x90, 0x53, 0xcd, 0x3f, 0xa9, 0x64, 0xaa,
-	0x16, 0x86, 0xaf, 0x7d, 0x6b, 0x64, 0x8d, 0x87, 0xd3, 0x9b, 0x70, 0x07, 0xf8, 0x97, 0x0f, 0x17,
-	0x54, 0xf3, 0x78, 0x9f, 0xcd, 0x5c, 0xde, 0x96, 0xb8, 0x84, 0x2b, 0x2d, 0x58, 0x49, 0xcd, 0xa6,
-	0x2e, 0x5a, 0xc4, 0x6e, 0x43, 0x9c, 0x9c, 0x20, 0x92, 0xfd, 0xc6, 0x01, 0x8b, 0xfa, 0xc8, 0xc3,
-	0x6b, 0x70, 0x7e, 0x5d, 0xdf, 0x1e, 0x59, 0xe3, 0x8b, 0xec, 0x60, 0x04, 0xb7, 0xe0, 0xfe, 0xf9,
-	0x0c, 0xcf, 0xa1, 0x97, 0xa4, 0xc9, 0xdc, 0xeb, 0x20, 0xc0, 0x80, 0x2c, 0xe2, 0xe9, 0xc3, 0xa3,
-	0xd7, 0x0b, 0x9e, 0x00, 0x8f, 0xcf, 0xa1, 0x0b, 0x4e, 0x9c, 0xa4, 0xc9, 0xc7, 0x5b, 0xfa, 0x4e,
-	0xbc, 0x0e, 0x9e, 0x81, 0x9d, 0x91, 0xd8, 0xb3, 0xd0, 0x81, 0xfe, 0xfc, 0x65, 0x46, 0x62, 0xcf,
-	0x7e, 0x9e, 0x2c, 0xef, 0x98, 0x30, 0x7c, 0x93, 0x87, 0x2b, 0xb5, 0x8e, 0x98, 0x52, 0x4c, 0x16,
-	0x91, 0xa9, 0x85, 0x94, 0x82, 0x96, 0x51, 0xbb, 0xf8, 0x7c, 0xd0, 0x74, 0x7e, 0xff, 0x13, 0x00,
-	0x00, 0xff, 0xff, 0x44, 0x04, 0xa5, 0x73, 0x8f, 0x01, 0x00, 0x00,
+	// 277 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x91, 0xcf, 0x4b, 0xfb, 0x40,
+	0x10, 0xc5, 0x9b, 0xfe, 0xfc, 0x76, 0xbe, 0xb6, 0x2e, 0xe3, 0xa5, 0x07, 0x0f, 0xa5, 0x28, 0xb6,
+	0x97, 0x04, 0x23, 0x11, 0x3c, 0x46, 0x13, 0x28, 0x88, 0x09, 0x64, 0xf1, 0x60, 0x2f, 0xb2, 0xa9,
+	0x61, 0x77, 0x61, 0x9b, 0x0d, 0xc9, 0xf6, 0xd0, 0x3f, 0xd0, 0xff, 0x4b, 0x48, 0xa9, 0x8d, 0x16,
+	0x2f, 0x03, 0xef, 0xf1, 0xe6, 0x33, 0x03, 0x0f, 0x26, 0xeb, 0x72, 0x57, 0x18, 0xed, 0x54, 0x92,
+	0x17, 0xe9, 0x7e, 0xda, 0x45, 0xa9, 0x8d, 0xc6, 0x5e, 0x2d, 0x66, 0x9f, 0x6d, 0x38, 0x0f, 0x24,
+	0x97, 0x86, 0x29, 0xb5, 0xa3, 0x92, 0xe7, 0xd9, 0x07, 0x3e, 0xc3, 0x58, 0xb0, 0x4a, 0xbc, 0x33,
+	0xc5, 0x75, 0x29, 0x8d, 0xd8, 0x4c, 0xac, 0xa9, 0x35, 0x1f, 0xbb, 0x57, 0xf6, 0x1e, 0xf0, 0x2b,
+	0x6f, 0x2f, 0x59, 0x25, 0xfc, 0x43, 0x36, 0x19, 0x89, 0xa6, 0xc4, 0x15, 0x5c, 0x54, 0x92, 0xe7,
+	0xcc, 0x6c, 0xcb, 0xac, 0x41, 0x6c, 0xd7, 0xc4, 0xc5, 0x1f, 0x44, 0x7a, 0xd8, 0x38, 0x62, 0xb1,
+	0x3a, 0xf1, 0xf0, 0x12, 0x86, 0xdf, 0xee, 0xa4, 0x33, 0xb5, 0xe6, 0x67, 0xc9, 0xd1, 0x98, 0x5d,
+	0xc3, 0xe8, 0xc7, 0x67, 0xf8, 0x0f, 0xba, 0x51, 0x1c, 0x85, 0xa4, 0x85, 0x00, 0x7d, 0xba, 0xf4,
+	0x5d, 0xef, 0x9e, 0x74, 0x67, 0x01, 0xe0, 0xe9, 0x39, 0x1c, 0xc1, 0xd0, 0x8f, 0xe2, 0xe8, 0xed,
+	0x25, 0x7e, 0xa5, 0xa4, 0x85, 0x03, 0xe8, 0x24, 0xd4, 0x27, 0x16, 0x0e, 0xa1, 0x17, 0x3e, 0x05,
+	0xd4, 0x27, 0x1d, 0xfc, 0x0f, 0x83, 0x30, 0x70, 0x3d, 0xef, 0xf6, 0x81, 0x0c, 0x1e, 0x17, 0xab,
+	0x1b, 0x2e, 0x8d, 0xd8, 0xa6, 0xf6, 0x5a, 0x6f, 0x1c, 0xae, 0x35, 0x57, 0x99, 0x63, 0x4a, 0xa9,
+	0x94, 0x64, 0xb9, 0xd3, 0x6c, 0x21, 0xed, 0xd7, 0x05, 0xdc, 0x7d, 0x0d, 0x00, 0x58, 0x38, 0x57,
+	0xe8, 0x9c, 0x01, 0x00, 0x00,
 }
@@ -25,6 +25,7 @@ import (
 
 	"github.com/google/trillian"
 	"github.com/google/trillian/types"
+	"golang.org/x/crypto/ed25519"
 )
 
 var errVerify = errors.New("signature verification failed")
@@ -42,6 +43,70 @@ func VerifySignedLogRoot(pub crypto.PublicKey, hash crypto.Hash, r *trillian.Sig
 	return &logRoot, nil
 }
 
+// VerifySignedLogRootThreshold verifies that the SignedLogRoot carries valid
+// signatures from at least threshold of the signers of a multi-signature tree,
+// whose public keys are given in MultiSigConfig.signers order, and returns its
+// contents. LogRootSignature is not checked: use VerifySignedLogRoot for that.
+func VerifySignedLogRootThreshold(pubs []crypto.PublicKey, threshold int, hash crypto.Hash, r *trillian.SignedLogRoot) (*types.LogRootV1, error) {
+	if threshold < 1 || threshold > len(pubs) {
+		return nil, fmt.Errorf("invalid threshold %v for %v signers", threshold, len(pubs))
+	}
+
+	// Each signer counts once, however many signatures claim to be theirs.
+	valid := make(map[int32]bool)
+	for _, sig := range r.Signatures {
+		i := sig.GetSignerIndex()
+		if i < 0 || int(i) >= len(pubs) || valid[i] {
+			continue
+		}
+		if err := Verify(pubs[i], hash, r.LogRoot, sig.GetSignature()); err == nil {
+			valid[i] = true
+		}
+	}
+	if len(valid) < threshold {
+		return nil, fmt.Errorf("%v valid signatures, want at least %v: %v", len(valid), threshold, errVerify)
+	}
+
+	var logRoot types.LogRootV1
+	if err := logRoot.UnmarshalBinary(r.LogRoot); err != nil {
+		return nil, err
+	}
+	return &logRoot, nil
+}
+
+// VerifyCheckpoint verifies that note is a checkpoint of the log named origin,
+// signed by pub under that name, and returns the checkpoint. Signature lines of
+// other signers, such as witnesses, are ignored.
+func VerifyCheckpoint(pub crypto.PublicKey, hash crypto.Hash, origin string, note []byte) (*types.Checkpoint, error) {
+	text, sigs, err := types.ParseNote(note)
+	if err != nil {
+		return nil, err
+	}
+	var c types.Checkpoint
+	if err := c.UnmarshalText(text); err != nil {
+		return nil, err
+	}
+	if c.Origin != origin {
+		return nil, fmt.Errorf("checkpoint origin %q, want %q", c.Origin, origin)
+	}
+
+	keyID, err := NoteKeyID(pub)
+	if err != nil {
+		return nil, err
+	}
+	keyHash := types.NoteKeyHash(origin, keyID)
+	for _, sig := range sigs {
+		if sig.Name != origin || sig.KeyHash != keyHash {
+			continue
+		}
+		if err := Verify(pub, hash, text, sig.Signature); err != nil {
+			return nil, err
+		}
+		return &c, nil
+	}
+	return nil, fmt.Errorf("no signature from key %08x of %q: %v", keyHash, origin, errVerify)
+}
+
 // VerifySignedMapRoot verifies the signature on the SignedMapRoot.
 // VerifySignedMapRoot returns MapRootV1 to encourage safe API use.
 // It should be the only function available to clients that returns MapRootV1.
@@ -56,12 +121,30 @@ func VerifySignedMapRoot(pub crypto.PublicKey, hash crypto.Hash, smr *trillian.S
 	return &root, nil
 }
 
+// VerifyKeyRotation verifies the signature on a key rotation statement, which
+// must have been made by pub, and returns its contents.
+func VerifyKeyRotation(pub crypto.PublicKey, hash crypto.Hash, rotation, sig []byte) (*types.KeyRotationV1, error) {
+	if err := Verify(pub, hash, rotation, sig); err != nil {
+		return nil, err
+	}
+	var r types.KeyRotationV1
+	if err := r.UnmarshalBinary(rotation); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
 // Verify cryptographically verifies the output of Signer.
 func Verify(pub crypto.PublicKey, hasher crypto.Hash, data, sig []byte) error {
 	if sig == nil {
 		return errors.New("signature is nil")
 	}
 
+	// Ed25519 signatures are over the data itself, rather than a digest.
+	if pub, ok := pub.(ed25519.PublicKey); ok {
+		return verifyEd25519(pub, data, sig)
+	}
+
 	h := hasher.New()
 	h.Write(data)
 	digest := h.Sum(nil)
@@ -83,6 +166,13 @@ func verifyRSA(pub *rsa.PublicKey, hashed, sig []byte, hasher crypto.Hash, opts
 	return rsa.VerifyPKCS1v15(pub, hasher, hashed, sig)
 }
 
+func verifyEd25519(pub ed25519.PublicKey, data, sig []byte) error {
+	if !ed25519.Verify(pub, data, sig) {
+		return errVerify
+	}
+	return nil
+}
+
 func verifyECDSA(pub *ecdsa.PublicKey, hashed, sig []byte) error {
 	var ecdsaSig struct {
 		R, S *big.Int
@@ -17,7 +17,9 @@
 package extension
 
 import (
+	"github.com/google/trillian/audit"
 	"github.com/google/trillian/crypto/keys"
+	"github.com/google/trillian/crypto/keys/envelope"
 	"github.com/google/trillian/monitoring"
 	"github.com/google/trillian/quota"
 	"github.com/google/trillian/storage"
@@ -43,6 +45,12 @@ type Registry struct {
 	// NewKeyProto creates a new private key based on a key specification.
 	// It returns a proto that can be passed to a keys.ProtoHandler to get a crypto.Signer.
 	NewKeyProto keys.ProtoGenerator
+	// KEKProvider, if set, is used to encrypt private keys before they're
+	// persisted to AdminStorage.
+	KEKProvider envelope.KEKProvider
+	// AuditLog, if set, records the changes made to trees through the admin
+	// server.
+	AuditLog audit.Log
 	// SetProcessStatus sets the current process status for diagnostic purposes.
 	SetProcessStatus func(string)
 }
@@ -18,8 +18,10 @@ package log
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -33,11 +35,15 @@ import (
 	"github.com/google/trillian/storage"
 	"github.com/google/trillian/types"
 	"github.com/google/trillian/util"
+	"go.opencensus.io/trace"
 
 	tcrypto "github.com/google/trillian/crypto"
 )
 
-const logIDLabel = "logid"
+const (
+	logIDLabel    = "logid"
+	traceSpanRoot = "github.com/google/trillian/log"
+)
 
 var (
 	once                   sync.Once
@@ -307,6 +313,9 @@ func (s *preorderedLogSequencingTask) update(ctx context.Context, leaves []*tril
 // IntegrateBatch wraps up all the operations needed to take a batch of queued
 // or sequenced leaves and integrate them into the tree.
 func (s Sequencer) IntegrateBatch(ctx context.Context, tree *trillian.Tree, limit int, guardWindow, maxRootDurationInterval time.Duration) (int, error) {
+	ctx, span := spanFor(ctx, "IntegrateBatch")
+	defer span.End()
+	span.AddAttributes(trace.Int64Attribute("log_id", tree.TreeId))
 	start := s.timeSource.Now()
 	label := strconv.FormatInt(tree.TreeId, 10)
 
@@ -361,6 +370,21 @@ func (s Sequencer) IntegrateBatch(ctx context.Context, tree *trillian.Tree, limi
 			return err
 		}
 		numLeaves = len(sequencedLeaves)
+		// Record the batch on the span, so that the integration of leaves can be
+		// found from the leaf_identity_hashes attribute of their QueueLeaves
+		// spans. A single attribute is used as spans keep a limited number of
+		// annotations.
+		if span.IsRecordingEvents() && numLeaves > 0 {
+			hashes := make([]string, 0, numLeaves)
+			for _, leaf := range sequencedLeaves {
+				hashes = append(hashes, hex.EncodeToString(leaf.LeafIdentityHash))
+			}
+			span.AddAttributes(
+				trace.Int64Attribute("num_leaves", int64(numLeaves)),
+				trace.Int64Attribute("first_leaf_index", sequencedLeaves[0].LeafIndex),
+				trace.StringAttribute("leaf_identity_hashes", strings.Join(hashes, ",")),
+			)
+		}
 
 		// We need to create a signed root if entries were added or the latest root
 		// is too old.
@@ -483,6 +507,9 @@ func (s Sequencer) IntegrateBatch(ctx context.Context, tree *trillian.Tree, limi
 
 // SignRoot wraps up all the operations for creating a new log signed root.
 func (s Sequencer) SignRoot(ctx context.Context, tree *trillian.Tree) error {
+	ctx, span := spanFor(ctx, "SignRoot")
+	defer span.End()
+	span.AddAttributes(trace.Int64Attribute("log_id", tree.TreeId))
 	return s.logStorage.ReadWriteTransaction(ctx, tree, func(ctx context.Context, tx storage.LogTreeTX) error {
 		// Get the latest known root from storage
 		sth, err := tx.LatestSignedLogRoot(ctx)
@@ -524,3 +551,7 @@ func (s Sequencer) SignRoot(ctx context.Context, tree *trillian.Tree) error {
 		return nil
 	})
 }
+
+func spanFor(ctx context.Context, name string) (context.Context, *trace.Span) {
+	return trace.StartSpan(ctx, fmt.Sprintf("%s.%s", traceSpanRoot, name))
+}
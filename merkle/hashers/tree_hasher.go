@@ -33,6 +33,14 @@ type LogHasher interface {
 	Size() int
 }
 
+// LeafRedactor is implemented by LogHashers whose leaves have parts that can
+// be removed without changing the leaf hash.
+type LeafRedactor interface {
+	// RedactLeaf returns a form of leaf with the given fields removed, which
+	// has the same hash as leaf. Fields that leaf doesn't have are ignored.
+	RedactLeaf(leaf []byte, fields []int32) ([]byte, error)
+}
+
 // MapHasher provides the hash functions needed to compute sparse merkle trees.
 type MapHasher interface {
 	// HashEmpty returns the hash of an empty branch at a given depth.
@@ -0,0 +1,152 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merkle
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/google/trillian/merkle/hashers"
+	"github.com/google/trillian/storage"
+)
+
+// partitionsPerWorker is the number of partitions ParallelHStar2 aims to
+// create for each worker, so that unevenly sized partitions still keep all of
+// the workers busy.
+const partitionsPerWorker = 8
+
+// maxPartitionBits limits the number of index bits used for partitioning, and
+// hence the number of partition roots held in memory at once.
+const maxPartitionBits = 16
+
+// ParallelHStar2 calculates the same sparse Merkle tree hashes as HStar2, but
+// partitions the leaves by index prefix and calculates the subtree under each
+// partition concurrently.
+//
+// At most workers partitions are calculated at any time, so memory use is
+// bounded by the leaves passed in plus the recursion state of each worker.
+type ParallelHStar2 struct {
+	hs2     HStar2
+	workers int
+}
+
+// NewParallelHStar2 creates a new ParallelHStar2 tree calculator based on the
+// passed in MapHasher, which uses up to workers goroutines.
+func NewParallelHStar2(treeID int64, hasher hashers.MapHasher, workers int) *ParallelHStar2 {
+	if workers < 1 {
+		workers = 1
+	}
+	return &ParallelHStar2{
+		hs2:     NewHStar2(treeID, hasher),
+		workers: workers,
+	}
+}
+
+// HStar2Root calculates the root of a sparse Merkle tree of a given depth
+// which contains the given set of non-null leaves.
+func (p *ParallelHStar2) HStar2Root(depth int, values []HStar2LeafHash) ([]byte, error) {
+	sort.Sort(ByIndex{values})
+	return p.hStar2(0, depth, values, smtZero, nil, nil)
+}
+
+// HStar2Nodes behaves like HStar2.HStar2Nodes. Get and set may be called
+// concurrently, and so must be safe for concurrent use. The order in which
+// set is called is unspecified, but every node is set exactly once.
+func (p *ParallelHStar2) HStar2Nodes(prefix []byte, subtreeDepth int, values []HStar2LeafHash,
+	get SparseGetNodeFunc, set SparseSetNodeFunc) ([]byte, error) {
+	depth := len(prefix) * 8
+	totalDepth := depth + subtreeDepth
+	if totalDepth > p.hs2.hasher.BitLen() {
+		return nil, ErrSubtreeOverrun
+	}
+	sort.Sort(ByIndex{values})
+	offset := storage.NewNodeIDFromPrefixSuffix(prefix, storage.Suffix{}, p.hs2.hasher.BitLen()).BigInt()
+	return p.hStar2(depth, totalDepth, values, offset, get, set)
+}
+
+// partitionBits returns the number of levels below depth at which to
+// partition the leaves.
+func (p *ParallelHStar2) partitionBits(depth, maxDepth int) int {
+	bits := 0
+	for 1<<uint(bits) < p.workers*partitionsPerWorker {
+		bits++
+	}
+	if bits > maxPartitionBits {
+		bits = maxPartitionBits
+	}
+	if bits > maxDepth-depth {
+		bits = maxDepth - depth
+	}
+	return bits
+}
+
+// hStar2 calculates the subtree roots of the partitions of values
+// concurrently, and then combines them with a sequential calculation of the
+// levels above the partitions. values must be sorted.
+func (p *ParallelHStar2) hStar2(depth, maxDepth int, values []HStar2LeafHash, offset *big.Int,
+	get SparseGetNodeFunc, set SparseSetNodeFunc) ([]byte, error) {
+	bits := p.partitionBits(depth, maxDepth)
+	if p.workers == 1 || bits == 0 || len(values) <= 1 {
+		return p.hs2.hStar2b(depth, maxDepth, values, offset, get, set)
+	}
+	partDepth := depth + bits
+	partHeight := uint(p.hs2.hasher.BitLen() - partDepth)
+
+	// Split the sorted values into contiguous runs sharing a partition prefix.
+	type partition struct {
+		offset *big.Int
+		values []HStar2LeafHash
+		root   []byte
+		err    error
+	}
+	var parts []*partition
+	for start := 0; start < len(values); {
+		partOffset := new(big.Int).Rsh(values[start].Index, partHeight)
+		partOffset.Lsh(partOffset, partHeight)
+		next := new(big.Int).Add(partOffset, new(big.Int).Lsh(smtOne, partHeight))
+		end := start + sort.Search(len(values)-start, func(i int) bool {
+			return values[start+i].Index.Cmp(next) >= 0
+		})
+		parts = append(parts, &partition{offset: partOffset, values: values[start:end]})
+		start = end
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, p.workers)
+	for _, part := range parts {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(part *partition) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			part.root, part.err = p.hs2.hStar2b(partDepth, maxDepth, part.values, part.offset, get, set)
+		}(part)
+	}
+	wg.Wait()
+
+	// The partition roots are the leaves of the levels above them.
+	roots := make([]HStar2LeafHash, 0, len(parts))
+	for _, part := range parts {
+		if part.err != nil {
+			return nil, fmt.Errorf("partition %x: %v", part.offset.Bytes(), part.err)
+		}
+		roots = append(roots, HStar2LeafHash{Index: part.offset, LeafHash: part.root})
+	}
+	return p.hs2.hStar2b(depth, partDepth, roots, offset, get, set)
+}
@@ -0,0 +1,184 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merkle
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/google/trillian/merkle/coniks"
+	"github.com/google/trillian/merkle/hashers"
+	"github.com/google/trillian/merkle/maphasher"
+	"github.com/google/trillian/testonly"
+)
+
+// parallelTestLeaves returns n leaves whose indices all start with prefix.
+func parallelTestLeaves(t testing.TB, hasher hashers.MapHasher, prefix []byte, n int) []HStar2LeafHash {
+	t.Helper()
+	iv := make([][]byte, 0, 2*n)
+	for i := 0; i < n; i++ {
+		index := testonly.HashKey(fmt.Sprintf("key-%d", i))
+		copy(index, prefix)
+		iv = append(iv, index, []byte(fmt.Sprintf("value-%d", i)))
+	}
+	leaves, err := createHStar2Leaves(treeID, hasher, iv...)
+	if err != nil {
+		t.Fatalf("createHStar2Leaves(): %v", err)
+	}
+	return leaves
+}
+
+// nodeRecorder collects the nodes passed to a SparseSetNodeFunc.
+type nodeRecorder struct {
+	mu    sync.Mutex
+	nodes map[string][]byte
+}
+
+func (r *nodeRecorder) set(t *testing.T) SparseSetNodeFunc {
+	r.nodes = make(map[string][]byte)
+	return func(depth int, index *big.Int, h []byte) error {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		key := fmt.Sprintf("%d/%x", depth, index.Bytes())
+		if _, ok := r.nodes[key]; ok {
+			t.Errorf("node %s set more than once", key)
+		}
+		r.nodes[key] = h
+		return nil
+	}
+}
+
+func TestParallelHStar2MatchesHStar2(t *testing.T) {
+	for _, hasher := range []hashers.MapHasher{maphasher.Default, coniks.Default} {
+		for _, tc := range []struct {
+			prefix       []byte
+			subtreeDepth int
+			numLeaves    int
+		}{
+			{prefix: nil, subtreeDepth: 256, numLeaves: 0},
+			{prefix: nil, subtreeDepth: 256, numLeaves: 1},
+			{prefix: nil, subtreeDepth: 256, numLeaves: 1000},
+			{prefix: []byte{0x42}, subtreeDepth: 248, numLeaves: 1000},
+			{prefix: []byte{0x42, 0x17}, subtreeDepth: 8, numLeaves: 200},
+		} {
+			leaves := parallelTestLeaves(t, hasher, tc.prefix, tc.numLeaves)
+			if tc.subtreeDepth < 248 {
+				// Move the leaves up to the bottom of the subtree.
+				shift := uint(hasher.BitLen() - len(tc.prefix)*8 - tc.subtreeDepth)
+				for i := range leaves {
+					leaves[i].Index.Rsh(leaves[i].Index, shift)
+					leaves[i].Index.Lsh(leaves[i].Index, shift)
+				}
+				leaves = dedupLeaves(leaves)
+			}
+
+			hs2 := NewHStar2(treeID, hasher)
+			var want nodeRecorder
+			wantRoot, err := hs2.HStar2Nodes(tc.prefix, tc.subtreeDepth, leaves, nil, want.set(t))
+			if err != nil {
+				t.Fatalf("HStar2Nodes(): %v", err)
+			}
+
+			for _, workers := range []int{1, 2, 7, 32} {
+				t.Run(fmt.Sprintf("%T/%x/%d/%d/%d", hasher, tc.prefix, tc.subtreeDepth, tc.numLeaves, workers), func(t *testing.T) {
+					p := NewParallelHStar2(treeID, hasher, workers)
+					var got nodeRecorder
+					gotRoot, err := p.HStar2Nodes(tc.prefix, tc.subtreeDepth, leaves, nil, got.set(t))
+					if err != nil {
+						t.Fatalf("HStar2Nodes(): %v", err)
+					}
+					if !bytes.Equal(gotRoot, wantRoot) {
+						t.Errorf("HStar2Nodes(): %x, want %x", gotRoot, wantRoot)
+					}
+					if got, want := len(got.nodes), len(want.nodes); got != want {
+						t.Errorf("set %d nodes, want %d", got, want)
+					}
+					for k, h := range want.nodes {
+						if !bytes.Equal(got.nodes[k], h) {
+							t.Errorf("node %s: %x, want %x", k, got.nodes[k], h)
+						}
+					}
+				})
+			}
+		}
+	}
+}
+
+// dedupLeaves drops all but the first of any leaves sharing an index.
+func dedupLeaves(leaves []HStar2LeafHash) []HStar2LeafHash {
+	seen := make(map[string]bool)
+	r := leaves[:0]
+	for _, l := range leaves {
+		if k := l.Index.String(); !seen[k] {
+			seen[k] = true
+			r = append(r, l)
+		}
+	}
+	return r
+}
+
+func TestParallelHStar2Root(t *testing.T) {
+	for i, x := range simpleTestVector {
+		iv := make([][]byte, 0, 2*(i+1))
+		for _, v := range simpleTestVector[:i+1] {
+			iv = append(iv, v.index, v.value)
+		}
+		values, err := createHStar2Leaves(treeID, maphasher.Default, iv...)
+		if err != nil {
+			t.Fatalf("createHStar2Leaves(): %v", err)
+		}
+		p := NewParallelHStar2(treeID, maphasher.Default, 4)
+		root, err := p.HStar2Root(maphasher.Default.BitLen(), values)
+		if err != nil {
+			t.Errorf("HStar2Root(%v): %v", i, err)
+			continue
+		}
+		if got, want := root, x.root; !bytes.Equal(got, want) {
+			t.Errorf("HStar2Root(%v): %x, want %x", i, got, want)
+		}
+	}
+}
+
+func benchmarkHStar2Root(b *testing.B, numLeaves int, calc func(values []HStar2LeafHash) ([]byte, error)) {
+	leaves := parallelTestLeaves(b, maphasher.Default, nil, numLeaves)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := calc(leaves); err != nil {
+			b.Fatalf("HStar2Root(): %v", err)
+		}
+	}
+}
+
+func BenchmarkHStar2Root(b *testing.B) {
+	for _, n := range []int{1000, 10000} {
+		b.Run(fmt.Sprintf("sequential/%d", n), func(b *testing.B) {
+			hs2 := NewHStar2(treeID, maphasher.Default)
+			benchmarkHStar2Root(b, n, func(v []HStar2LeafHash) ([]byte, error) {
+				return hs2.HStar2Root(maphasher.Default.BitLen(), v)
+			})
+		})
+		for _, workers := range []int{2, 4, 8, 16} {
+			b.Run(fmt.Sprintf("parallel-%d/%d", workers, n), func(b *testing.B) {
+				p := NewParallelHStar2(treeID, maphasher.Default, workers)
+				benchmarkHStar2Root(b, n, func(v []HStar2LeafHash) ([]byte, error) {
+					return p.HStar2Root(maphasher.Default.BitLen(), v)
+				})
+			})
+		}
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merkle
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/google/trillian/merkle/hashers"
+	"github.com/google/trillian/storage"
+)
+
+// A map multi-proof proves the inclusion of several leaves under one root
+// while sending each sibling node at most once. Siblings which lie on the path
+// from the root to another requested leaf are omitted altogether, as the
+// verifier recomputes them.
+//
+// The proof entries are ordered by a depth-first, left-to-right walk of the
+// tree spanned by the sorted, de-duplicated requested indices: whenever a
+// node on that walk has exactly one child containing requested indices, the
+// hash of the other child is the next proof entry. As for single-leaf proofs,
+// empty entries denote default (empty) subtrees.
+
+// canonicalIndices returns a sorted copy of indices with duplicates removed.
+func canonicalIndices(indices [][]byte) [][]byte {
+	sorted := make([][]byte, len(indices))
+	copy(sorted, indices)
+	sort.Slice(sorted, func(i, j int) bool { return bytes.Compare(sorted[i], sorted[j]) < 0 })
+	unique := sorted[:0]
+	for i, index := range sorted {
+		if i == 0 || !bytes.Equal(index, sorted[i-1]) {
+			unique = append(unique, index)
+		}
+	}
+	return unique
+}
+
+// bitAtDepth returns the bit of index which selects the child at depth+1.
+func bitAtDepth(index []byte, depth int) uint {
+	return uint(index[depth/8]>>uint(7-depth%8)) & 0x01
+}
+
+// splitAtDepth returns the position of the first index with a 1 bit at depth.
+// indices must be sorted and share a common prefix of depth bits.
+func splitAtDepth(indices [][]byte, depth int) int {
+	return sort.Search(len(indices), func(i int) bool { return bitAtDepth(indices[i], depth) == 1 })
+}
+
+// childNodeID returns the ID of the child at depth+1 of the node at depth on
+// the path to index, whose last bit is bit.
+func childNodeID(index []byte, depth int, bit uint) storage.NodeID {
+	nID := storage.NewNodeIDFromHash(index)
+	child := nID.Copy().MaskLeft(depth + 1)
+	if bitAtDepth(index, depth) != bit {
+		child.Neighbor()
+	}
+	return *child
+}
+
+// MapMultiProofSiblings returns the IDs of the nodes making up a multi-proof
+// for indices, in proof order.
+func MapMultiProofSiblings(indices [][]byte, bitLen int) ([]storage.NodeID, error) {
+	for _, index := range indices {
+		if got, want := len(index)*8, bitLen; got != want {
+			return nil, fmt.Errorf("index len: %d, want %d", got, want)
+		}
+	}
+	var sibs []storage.NodeID
+	var walk func(indices [][]byte, depth int)
+	walk = func(indices [][]byte, depth int) {
+		if depth == bitLen {
+			return
+		}
+		split := splitAtDepth(indices, depth)
+		left, right := indices[:split], indices[split:]
+		switch {
+		case len(left) == 0:
+			sibs = append(sibs, childNodeID(right[0], depth, 0))
+		case len(right) == 0:
+			sibs = append(sibs, childNodeID(left[0], depth, 1))
+		}
+		if len(left) > 0 {
+			walk(left, depth+1)
+		}
+		if len(right) > 0 {
+			walk(right, depth+1)
+		}
+	}
+	if canonical := canonicalIndices(indices); len(canonical) > 0 {
+		walk(canonical, 0)
+	}
+	return sibs, nil
+}
+
+// VerifyMapMultiInclusionProof verifies that the passed in expectedRoot can be
+// reconstructed from leaves, stored at the corresponding indices, and a
+// multi-proof as returned by SparseMerkleTreeReader.MultiInclusionProof.
+// Empty leaves denote indices which are not set in the map.
+//
+// Returns nil on a successful verification, and an error otherwise.
+func VerifyMapMultiInclusionProof(treeID int64, indices, leaves [][]byte, expectedRoot []byte, proof [][]byte, h hashers.MapHasher) error {
+	if got, want := len(leaves), len(indices); got != want {
+		return fmt.Errorf("leaves len: %d, want %d", got, want)
+	}
+	bitLen := h.BitLen()
+	values := make(map[string][]byte)
+	for i, index := range indices {
+		if got, want := len(index)*8, bitLen; got != want {
+			return fmt.Errorf("index len: %d, want %d", got, want)
+		}
+		if v, ok := values[string(index)]; ok && !bytes.Equal(v, leaves[i]) {
+			return fmt.Errorf("conflicting leaves for index %x", index)
+		}
+		values[string(index)] = leaves[i]
+	}
+	for i, element := range proof {
+		if got, wanta, wantb := len(element), 0, h.Size(); got != wanta && got != wantb {
+			return fmt.Errorf("proof[%d] len: %d, want %d or %d", i, got, wanta, wantb)
+		}
+	}
+
+	next := 0
+	// emptyOr returns hash, or the empty hash of the child of the node at
+	// depth on the path to index, whose last bit is bit, if hash is nil.
+	emptyOr := func(hash, index []byte, depth int, bit uint) []byte {
+		if len(hash) != 0 {
+			return hash
+		}
+		child := childNodeID(index, depth, bit)
+		return h.HashEmpty(treeID, child.Path, bitLen-depth-1)
+	}
+	// walk returns the hash of the node at depth which is the common ancestor
+	// of indices, or nil if that subtree is empty.
+	var walk func(indices [][]byte, depth int) ([]byte, error)
+	walk = func(indices [][]byte, depth int) ([]byte, error) {
+		if depth == bitLen {
+			index := indices[0]
+			if leaf := values[string(index)]; len(leaf) != 0 {
+				return h.HashLeaf(treeID, index, leaf)
+			}
+			return nil, nil
+		}
+
+		split := splitAtDepth(indices, depth)
+		var l, r []byte
+		if len(indices[:split]) == 0 || len(indices[split:]) == 0 {
+			if next >= len(proof) {
+				return nil, fmt.Errorf("proof too short: %d entries", len(proof))
+			}
+			if split == 0 {
+				l = proof[next]
+			} else {
+				r = proof[next]
+			}
+			next++
+		}
+		var err error
+		if split > 0 {
+			if l, err = walk(indices[:split], depth+1); err != nil {
+				return nil, err
+			}
+		}
+		if split < len(indices) {
+			if r, err = walk(indices[split:], depth+1); err != nil {
+				return nil, err
+			}
+		}
+
+		// Keep empty branches unhashed until they meet a non-empty neighbor,
+		// as HashEmpty(height+1) != HashChildren(HashEmpty(height), HashEmpty(height)).
+		if len(l) == 0 && len(r) == 0 {
+			return nil, nil
+		}
+		l = emptyOr(l, indices[0], depth, 0)
+		r = emptyOr(r, indices[0], depth, 1)
+		return h.HashChildren(l, r), nil
+	}
+
+	if len(indices) == 0 {
+		if len(proof) != 0 {
+			return fmt.Errorf("proof too long: %d entries, used 0", len(proof))
+		}
+		return nil
+	}
+	root, err := walk(canonicalIndices(indices), 0)
+	if err != nil {
+		return err
+	}
+	if next != len(proof) {
+		return fmt.Errorf("proof too long: %d entries, used %d", len(proof), next)
+	}
+	if len(root) == 0 {
+		root = h.HashEmpty(treeID, make([]byte, bitLen/8), bitLen)
+	}
+
+	if got, want := root, expectedRoot; !bytes.Equal(got, want) {
+		return fmt.Errorf("calculated root: %x, want \n%x", got, want)
+	}
+	return nil
+}
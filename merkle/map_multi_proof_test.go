@@ -0,0 +1,157 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merkle
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/trillian/merkle/maphasher"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/testonly"
+)
+
+// multiProofTestTree holds the non-empty internal nodes of a small map, keyed
+// by NodeID.String().
+type multiProofTestTree struct {
+	root  []byte
+	nodes map[string][]byte
+}
+
+func newMultiProofTestTree(t *testing.T, kv map[string]string) *multiProofTestTree {
+	t.Helper()
+	h := maphasher.Default
+	iv := make([][]byte, 0, 2*len(kv))
+	for k, v := range kv {
+		iv = append(iv, testonly.HashKey(k), []byte(v))
+	}
+	leaves, err := createHStar2Leaves(treeID, h, iv...)
+	if err != nil {
+		t.Fatalf("createHStar2Leaves(): %v", err)
+	}
+	tree := &multiProofTestTree{nodes: make(map[string][]byte)}
+	hs2 := NewHStar2(treeID, h)
+	tree.root, err = hs2.HStar2Nodes(nil, h.BitLen(), leaves, nil,
+		func(depth int, index *big.Int, hash []byte) error {
+			nID := storage.NewNodeIDFromBigInt(depth, index, h.BitLen())
+			tree.nodes[nID.String()] = hash
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("HStar2Nodes(): %v", err)
+	}
+	return tree
+}
+
+// storedNodes returns the nodes of the tree which are in ids.
+func (tree *multiProofTestTree) storedNodes(ids []storage.NodeID) []storage.Node {
+	var nodes []storage.Node
+	for _, id := range ids {
+		if hash, ok := tree.nodes[id.String()]; ok {
+			nodes = append(nodes, storage.Node{NodeID: id, Hash: hash})
+		}
+	}
+	return nodes
+}
+
+func TestMultiInclusionProof(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	kv := make(map[string]string)
+	for i := 0; i < 20; i++ {
+		kv[fmt.Sprintf("key-%d", i)] = fmt.Sprintf("value-%d", i)
+	}
+	tree := newMultiProofTestTree(t, kv)
+	h := maphasher.Default
+
+	for _, tc := range []struct {
+		desc string
+		keys []string
+	}{
+		{desc: "single", keys: []string{"key-0"}},
+		{desc: "several", keys: []string{"key-3", "key-1", "key-17", "key-8"}},
+		{desc: "duplicates", keys: []string{"key-4", "key-4", "key-5"}},
+		{desc: "absent", keys: []string{"key-2", "missing-1", "missing-2"}},
+		{desc: "all absent", keys: []string{"missing-1"}},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			indices := make([][]byte, 0, len(tc.keys))
+			values := make([][]byte, 0, len(tc.keys))
+			for _, k := range tc.keys {
+				indices = append(indices, testonly.HashKey(k))
+				var v []byte
+				if s, ok := kv[k]; ok {
+					v = []byte(s)
+				}
+				values = append(values, v)
+			}
+
+			sibs, err := MapMultiProofSiblings(indices, h.BitLen())
+			if err != nil {
+				t.Fatalf("MapMultiProofSiblings(): %v", err)
+			}
+			const rev = 100
+			r, tx := getSparseMerkleTreeReaderWithMockTX(mockCtrl, rev)
+			tx.EXPECT().GetMerkleNodes(ctx, int64(rev), sibs).Return(tree.storedNodes(sibs), nil)
+
+			proof, err := r.MultiInclusionProof(ctx, rev, indices)
+			if err != nil {
+				t.Fatalf("MultiInclusionProof(): %v", err)
+			}
+			if err := VerifyMapMultiInclusionProof(treeID, indices, values, tree.root, proof, h); err != nil {
+				t.Errorf("VerifyMapMultiInclusionProof(): %v", err)
+			}
+
+			// The proof must be no longer than the sum of the individual proofs.
+			if got, max := len(proof), len(tc.keys)*h.BitLen(); got > max {
+				t.Errorf("len(proof): %d, want <= %d", got, max)
+			}
+
+			// Tampering with the values or the proof must be detected.
+			badValues := append([][]byte{}, values...)
+			badValues[0] = []byte("tampered")
+			if err := VerifyMapMultiInclusionProof(treeID, indices, badValues, tree.root, proof, h); err == nil {
+				t.Error("VerifyMapMultiInclusionProof(tampered value): nil, want error")
+			}
+			if err := VerifyMapMultiInclusionProof(treeID, indices, values, tree.root, proof[1:], h); err == nil {
+				t.Error("VerifyMapMultiInclusionProof(short proof): nil, want error")
+			}
+			if err := VerifyMapMultiInclusionProof(treeID, indices, values, tree.root, append(proof, nil), h); err == nil {
+				t.Error("VerifyMapMultiInclusionProof(long proof): nil, want error")
+			}
+		})
+	}
+}
+
+func TestMultiInclusionProofSharesSiblings(t *testing.T) {
+	h := maphasher.Default
+	// Two indices which differ only in their last bit share all but one
+	// sibling, and are each other's sibling at the bottom level.
+	a := make([]byte, h.Size())
+	b := make([]byte, h.Size())
+	b[len(b)-1] = 0x01
+	sibs, err := MapMultiProofSiblings([][]byte{a, b}, h.BitLen())
+	if err != nil {
+		t.Fatalf("MapMultiProofSiblings(): %v", err)
+	}
+	if got, want := len(sibs), h.BitLen()-1; got != want {
+		t.Errorf("len(MapMultiProofSiblings()): %d, want %d", got, want)
+	}
+}
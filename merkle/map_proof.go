@@ -0,0 +1,67 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merkle
+
+import (
+	"fmt"
+)
+
+// CompressMapInclusionProof converts a full sparse Merkle tree inclusion
+// proof, as returned by SparseMerkleTreeReader.InclusionProof, into a bitmap of
+// the non-empty proof entries and a slice holding only those entries.
+// Bit i of the bitmap is stored in byte i/8 under the mask 1<<(i%8).
+func CompressMapInclusionProof(proof [][]byte) ([]byte, [][]byte) {
+	bitmap := make([]byte, (len(proof)+7)/8)
+	hashes := make([][]byte, 0)
+	for i, p := range proof {
+		if len(p) == 0 {
+			continue
+		}
+		bitmap[i/8] |= 1 << uint(i%8)
+		hashes = append(hashes, p)
+	}
+	return bitmap, hashes
+}
+
+// DecompressMapInclusionProof reverses CompressMapInclusionProof, returning a
+// full proof of length bitLen with nil entries for the empty subtrees.
+func DecompressMapInclusionProof(bitmap []byte, hashes [][]byte, bitLen int) ([][]byte, error) {
+	if got, want := len(bitmap), (bitLen+7)/8; got != want {
+		return nil, fmt.Errorf("bitmap len: %d, want %d", got, want)
+	}
+	proof := make([][]byte, bitLen)
+	next := 0
+	for i := range proof {
+		if bitmap[i/8]&(1<<uint(i%8)) == 0 {
+			continue
+		}
+		if next >= len(hashes) {
+			return nil, fmt.Errorf("bitmap has more bits set than the %d hashes supplied", len(hashes))
+		}
+		if len(hashes[next]) == 0 {
+			return nil, fmt.Errorf("hashes[%d] is empty", next)
+		}
+		proof[i] = hashes[next]
+		next++
+	}
+	if next != len(hashes) {
+		return nil, fmt.Errorf("bitmap has %d bits set, but %d hashes supplied", next, len(hashes))
+	}
+	// Any padding bits beyond bitLen must be zero.
+	if rem := bitLen % 8; rem != 0 && bitmap[len(bitmap)-1]>>uint(rem) != 0 {
+		return nil, fmt.Errorf("bitmap has bits set beyond bit %d", bitLen)
+	}
+	return proof, nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merkle
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/trillian/merkle/coniks"
+)
+
+func TestMapInclusionProofCompressionRoundTrip(t *testing.T) {
+	h1, h2, h3 := []byte("hash-one"), []byte("hash-two"), []byte("hash-three")
+	for _, tc := range []struct {
+		desc       string
+		proof      [][]byte
+		wantBitmap []byte
+		wantHashes [][]byte
+	}{
+		{
+			desc:       "all empty",
+			proof:      make([][]byte, 16),
+			wantBitmap: []byte{0x00, 0x00},
+			wantHashes: [][]byte{},
+		},
+		{
+			desc:       "sparse",
+			proof:      [][]byte{h1, nil, nil, nil, nil, nil, nil, nil, nil, h2, nil, nil, nil, nil, nil, h3},
+			wantBitmap: []byte{0x01, 0x82},
+			wantHashes: [][]byte{h1, h2, h3},
+		},
+		{
+			desc:       "odd length",
+			proof:      [][]byte{nil, nil, h1, nil, nil},
+			wantBitmap: []byte{0x04},
+			wantHashes: [][]byte{h1},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			bitmap, hashes := CompressMapInclusionProof(tc.proof)
+			if !reflect.DeepEqual(bitmap, tc.wantBitmap) {
+				t.Errorf("CompressMapInclusionProof() bitmap: %x, want %x", bitmap, tc.wantBitmap)
+			}
+			if !reflect.DeepEqual(hashes, tc.wantHashes) {
+				t.Errorf("CompressMapInclusionProof() hashes: %x, want %x", hashes, tc.wantHashes)
+			}
+			proof, err := DecompressMapInclusionProof(bitmap, hashes, len(tc.proof))
+			if err != nil {
+				t.Fatalf("DecompressMapInclusionProof(): %v", err)
+			}
+			if !reflect.DeepEqual(proof, tc.proof) {
+				t.Errorf("DecompressMapInclusionProof(): %x, want %x", proof, tc.proof)
+			}
+		})
+	}
+}
+
+func TestDecompressMapInclusionProofErrors(t *testing.T) {
+	h := []byte("hash")
+	for _, tc := range []struct {
+		desc   string
+		bitmap []byte
+		hashes [][]byte
+		bitLen int
+	}{
+		{desc: "short bitmap", bitmap: []byte{0x01}, hashes: [][]byte{h}, bitLen: 16},
+		{desc: "too few hashes", bitmap: []byte{0x03}, hashes: [][]byte{h}, bitLen: 8},
+		{desc: "too many hashes", bitmap: []byte{0x01}, hashes: [][]byte{h, h}, bitLen: 8},
+		{desc: "empty hash", bitmap: []byte{0x01}, hashes: [][]byte{{}}, bitLen: 8},
+		{desc: "padding bits set", bitmap: []byte{0x20}, hashes: nil, bitLen: 5},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			if _, err := DecompressMapInclusionProof(tc.bitmap, tc.hashes, tc.bitLen); err == nil {
+				t.Error("DecompressMapInclusionProof(): nil, want error")
+			}
+		})
+	}
+}
+
+func TestVerifyCompressedMapInclusionProof(t *testing.T) {
+	// Test vector copied from TestConiksHasherTestVectors.
+	h := coniks.Default
+	index := []byte{0xb7, 0x57, 0x2d, 0xf6, 0xe1, 0x9, 0x1f, 0xc0, 0x6, 0x9e, 0x4, 0xbf, 0x80, 0x98, 0x75, 0x25, 0xe7, 0x7a, 0xc9, 0xa6, 0xc2, 0x94, 0xd2, 0x8d, 0xb7, 0xf4, 0xe3, 0x60, 0x25, 0x1d, 0x83, 0xbf}
+	proof := append(make([][]byte, 255), []byte{
+		92, 215, 13, 113, 97, 138, 214, 158, 13, 29, 227, 67, 236, 34, 215, 4, 76, 188, 79, 247, 149, 223, 227, 147, 86, 214, 90, 126, 192, 212, 113, 64,
+	})
+	root := []byte{0x2c, 0x27, 0x03, 0xe0, 0x34, 0xf4, 0x00, 0x2f, 0x94, 0x1d, 0xfc, 0xea, 0x7a, 0x4e, 0x16, 0x03, 0xee, 0x8b, 0x4e, 0xe3, 0x75, 0xbd, 0xf8, 0x72, 0x5e, 0xb8, 0xaf, 0x04, 0xbf, 0xa3, 0xd1, 0x56}
+	treeID := int64(2595744899657020594)
+
+	bitmap, hashes := CompressMapInclusionProof(proof)
+	if got, want := len(hashes), 1; got != want {
+		t.Fatalf("len(hashes): %d, want %d", got, want)
+	}
+	if err := VerifyCompressedMapInclusionProof(treeID, index, nil, root, bitmap, hashes, h); err != nil {
+		t.Errorf("VerifyCompressedMapInclusionProof(): %v", err)
+	}
+
+	// Moving the only hash to another level must fail verification.
+	bitmap[31], bitmap[0] = 0, 1
+	if err := VerifyCompressedMapInclusionProof(treeID, index, nil, root, bitmap, hashes, h); err == nil {
+		t.Error("VerifyCompressedMapInclusionProof(moved hash): nil, want error")
+	}
+}
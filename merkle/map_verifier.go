@@ -92,3 +92,13 @@ func VerifyMapInclusionProof(treeID int64, index, leaf, expectedRoot []byte, pro
 	}
 	return nil
 }
+
+// VerifyCompressedMapInclusionProof decodes a proof produced by
+// CompressMapInclusionProof and verifies it with VerifyMapInclusionProof.
+func VerifyCompressedMapInclusionProof(treeID int64, index, leaf, expectedRoot []byte, bitmap []byte, hashes [][]byte, h hashers.MapHasher) error {
+	proof, err := DecompressMapInclusionProof(bitmap, hashes, h.BitLen())
+	if err != nil {
+		return fmt.Errorf("DecompressMapInclusionProof(): %v", err)
+	}
+	return VerifyMapInclusionProof(treeID, index, leaf, expectedRoot, proof, h)
+}
@@ -0,0 +1,356 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package protohasher provides hashing of protobuf-encoded leaves, which
+// allows individual fields of a leaf to be proven while others are redacted.
+//
+// The hash of a leaf is computed from the hash of each of its top-level fields:
+//   fieldHash = H(0x02 || varint(field number) || wire type || value)
+//   objectHash = H(0x03 || varint(field number) || fieldHash || ...)
+//   leafHash = H(0x00 || objectHash)
+// Fields are ordered by field number, keeping the encoded order of repeated
+// fields. The object hash repeats each field's number, so that the number of a
+// redacted field, whose hash can't be checked, is still committed to. Varint
+// values are hashed as 8-byte big endian integers, so that the hash does not
+// depend on how they were encoded. Length-delimited values, including nested
+// messages, are hashed as opaque bytes.
+//
+// Since the hasher has no access to the schema of the leaves, packed and
+// unpacked encodings of the same repeated field hash differently.
+//
+// A leaf can be stored in a redacted form, which has the same hash, where some
+// of its fields are replaced by their hash. Redacted leaves start with a 0x00
+// byte, which is never valid protobuf, followed by each field as
+//   varint(field number) || 0x00 || fieldHash
+// if it is redacted, or
+//   varint(field number) || 0x01 || varint(length) || encoding
+// if it is not.
+package protohasher
+
+import (
+	"crypto"
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/merkle/hashers"
+	"github.com/google/trillian/merkle/rfc6962"
+)
+
+func init() {
+	hashers.RegisterLogHasher(trillian.HashStrategy_PROTO_RFC6962_SHA256, New(crypto.SHA256))
+}
+
+// Domain separation prefixes, which follow those of RFC6962.
+const (
+	FieldHashPrefix  = 2
+	ObjectHashPrefix = 3
+)
+
+// Markers of the redacted leaf encoding.
+const (
+	redactedLeafPrefix = 0x00
+	redactedField      = 0x00
+	revealedField      = 0x01
+)
+
+// Protobuf wire types.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// Hasher is a LogHasher for protobuf-encoded leaves. Nodes are hashed as in
+// RFC6962.
+type Hasher struct {
+	*rfc6962.Hasher
+}
+
+// New returns a Hasher that uses the hash function h.
+func New(h crypto.Hash) *Hasher {
+	return &Hasher{Hasher: rfc6962.New(h)}
+}
+
+// Field is a top-level field of a leaf that can be disclosed on its own. A
+// revealed field carries its wire encoding, tag included; a redacted field
+// carries only its hash.
+type Field struct {
+	Number   int32
+	Encoding []byte
+	Hash     []byte
+}
+
+// field is a decoded top-level field of a leaf.
+type field struct {
+	number   int32
+	wireType byte
+	value    []byte
+	encoding []byte
+}
+
+// HashLeaf returns the Merkle leaf hash of leaf, which must be a protobuf
+// encoded message or a redacted leaf.
+func (h *Hasher) HashLeaf(leaf []byte) ([]byte, error) {
+	if IsRedacted(leaf) {
+		fields, err := h.UnmarshalRedacted(leaf)
+		if err != nil {
+			return nil, err
+		}
+		return h.HashRedacted(fields)
+	}
+	fields, err := parseFields(leaf)
+	if err != nil {
+		return nil, err
+	}
+	hashed := make([]Field, 0, len(fields))
+	for _, f := range fields {
+		hashed = append(hashed, Field{Number: f.number, Hash: h.hashField(f)})
+	}
+	return h.hashObject(hashed)
+}
+
+// Redact splits leaf into its fields, in the order they are hashed. The fields
+// for which reveal returns true keep their encoding; the others are replaced
+// by their hash.
+func (h *Hasher) Redact(leaf []byte, reveal func(number int32) bool) ([]Field, error) {
+	fields, err := parseFields(leaf)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]Field, 0, len(fields))
+	for _, f := range fields {
+		if reveal(f.number) {
+			ret = append(ret, Field{Number: f.number, Encoding: f.encoding})
+		} else {
+			ret = append(ret, Field{Number: f.number, Hash: h.hashField(f)})
+		}
+	}
+	return ret, nil
+}
+
+// HashRedacted returns the Merkle leaf hash of the leaf that fields were
+// obtained from by Redact, without needing the redacted values. The numbers of
+// redacted fields are covered by the hash, so they can be trusted if it
+// matches.
+func (h *Hasher) HashRedacted(fields []Field) ([]byte, error) {
+	hashed := make([]Field, 0, len(fields))
+	for i, f := range fields {
+		if f.Number < 1 || f.Number > 1<<29-1 {
+			return nil, fmt.Errorf("invalid field number %v", f.Number)
+		}
+		if i > 0 && f.Number < fields[i-1].Number {
+			return nil, fmt.Errorf("field %v out of order after field %v", f.Number, fields[i-1].Number)
+		}
+		if f.Encoding == nil {
+			if got, want := len(f.Hash), h.Size(); got != want {
+				return nil, fmt.Errorf("field %v: hash is %v bytes, want %v", f.Number, got, want)
+			}
+			hashed = append(hashed, Field{Number: f.Number, Hash: f.Hash})
+			continue
+		}
+		parsed, err := parseFields(f.Encoding)
+		if err != nil {
+			return nil, fmt.Errorf("field %v: %v", f.Number, err)
+		}
+		if len(parsed) != 1 || parsed[0].number != f.Number {
+			return nil, fmt.Errorf("field %v: encoding is not a single field %v", f.Number, f.Number)
+		}
+		hashed = append(hashed, Field{Number: f.Number, Hash: h.hashField(parsed[0])})
+	}
+	return h.hashObject(hashed)
+}
+
+// RedactLeaf returns the redacted form of leaf, which may itself be redacted,
+// with the given fields replaced by their hash. It implements
+// hashers.LeafRedactor.
+func (h *Hasher) RedactLeaf(leaf []byte, fields []int32) ([]byte, error) {
+	redact := make(map[int32]bool)
+	for _, n := range fields {
+		redact[n] = true
+	}
+
+	var parts []Field
+	var err error
+	if IsRedacted(leaf) {
+		parts, err = h.UnmarshalRedacted(leaf)
+	} else {
+		parts, err = h.Redact(leaf, func(int32) bool { return true })
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i, f := range parts {
+		if f.Encoding == nil || !redact[f.Number] {
+			continue
+		}
+		parsed, err := parseFields(f.Encoding)
+		if err != nil {
+			return nil, fmt.Errorf("field %v: %v", f.Number, err)
+		}
+		if len(parsed) != 1 {
+			return nil, fmt.Errorf("field %v: encoding is not a single field", f.Number)
+		}
+		parts[i] = Field{Number: f.Number, Hash: h.hashField(parsed[0])}
+	}
+	return MarshalRedacted(parts), nil
+}
+
+// IsRedacted returns whether leaf is in the redacted form.
+func IsRedacted(leaf []byte) bool {
+	return len(leaf) > 0 && leaf[0] == redactedLeafPrefix
+}
+
+// MarshalRedacted returns the redacted leaf consisting of fields.
+func MarshalRedacted(fields []Field) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	ret := []byte{redactedLeafPrefix}
+	for _, f := range fields {
+		n := binary.PutUvarint(buf[:], uint64(f.Number))
+		ret = append(ret, buf[:n]...)
+		if f.Encoding == nil {
+			ret = append(ret, redactedField)
+			ret = append(ret, f.Hash...)
+			continue
+		}
+		ret = append(ret, revealedField)
+		n = binary.PutUvarint(buf[:], uint64(len(f.Encoding)))
+		ret = append(ret, buf[:n]...)
+		ret = append(ret, f.Encoding...)
+	}
+	return ret
+}
+
+// UnmarshalRedacted returns the fields of the redacted leaf b.
+func (h *Hasher) UnmarshalRedacted(b []byte) ([]Field, error) {
+	if !IsRedacted(b) {
+		return nil, fmt.Errorf("leaf is not redacted")
+	}
+	b = b[1:]
+	var fields []Field
+	for len(b) > 0 {
+		number, n := binary.Uvarint(b)
+		if n <= 0 || number < 1 || number > 1<<29-1 {
+			return nil, fmt.Errorf("malformed redacted field number")
+		}
+		b = b[n:]
+		if len(b) == 0 {
+			return nil, fmt.Errorf("field %v: truncated redacted field", number)
+		}
+		kind := b[0]
+		b = b[1:]
+		switch kind {
+		case redactedField:
+			if len(b) < h.Size() {
+				return nil, fmt.Errorf("field %v: truncated hash", number)
+			}
+			fields = append(fields, Field{Number: int32(number), Hash: b[:h.Size()]})
+			b = b[h.Size():]
+		case revealedField:
+			l, n := binary.Uvarint(b)
+			if n <= 0 || l > uint64(len(b)-n) {
+				return nil, fmt.Errorf("field %v: malformed encoding length", number)
+			}
+			fields = append(fields, Field{Number: int32(number), Encoding: b[n : n+int(l)]})
+			b = b[n+int(l):]
+		default:
+			return nil, fmt.Errorf("field %v: unknown redaction marker %v", number, kind)
+		}
+	}
+	return fields, nil
+}
+
+func (h *Hasher) hashField(f field) []byte {
+	var number [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(number[:], uint64(f.number))
+
+	hash := h.New()
+	hash.Write([]byte{FieldHashPrefix})
+	hash.Write(number[:n])
+	hash.Write([]byte{f.wireType})
+	hash.Write(f.value)
+	return hash.Sum(nil)
+}
+
+// hashObject returns the leaf hash of the object made of fields, which must
+// hold their hashes.
+func (h *Hasher) hashObject(fields []Field) ([]byte, error) {
+	var number [binary.MaxVarintLen64]byte
+	hash := h.New()
+	hash.Write([]byte{ObjectHashPrefix})
+	for _, f := range fields {
+		n := binary.PutUvarint(number[:], uint64(f.Number))
+		hash.Write(number[:n])
+		hash.Write(f.Hash)
+	}
+	return h.Hasher.HashLeaf(hash.Sum(nil))
+}
+
+// parseFields decodes the top-level fields of the protobuf message b, and
+// returns them ordered by field number.
+func parseFields(b []byte) ([]field, error) {
+	var fields []field
+	for len(b) > 0 {
+		start := b
+		tag, n := binary.Uvarint(b)
+		if n <= 0 {
+			return nil, fmt.Errorf("malformed field tag")
+		}
+		b = b[n:]
+		number, wireType := tag>>3, byte(tag&7)
+		if number < 1 || number > 1<<29-1 {
+			return nil, fmt.Errorf("invalid field number %v", number)
+		}
+
+		var value []byte
+		switch wireType {
+		case wireVarint:
+			v, n := binary.Uvarint(b)
+			if n <= 0 {
+				return nil, fmt.Errorf("field %v: malformed varint", number)
+			}
+			b = b[n:]
+			value = make([]byte, 8)
+			binary.BigEndian.PutUint64(value, v)
+		case wireFixed64:
+			if len(b) < 8 {
+				return nil, fmt.Errorf("field %v: truncated fixed64", number)
+			}
+			value, b = b[:8], b[8:]
+		case wireFixed32:
+			if len(b) < 4 {
+				return nil, fmt.Errorf("field %v: truncated fixed32", number)
+			}
+			value, b = b[:4], b[4:]
+		case wireBytes:
+			l, n := binary.Uvarint(b)
+			if n <= 0 || l > uint64(len(b)-n) {
+				return nil, fmt.Errorf("field %v: malformed length-delimited value", number)
+			}
+			value, b = b[n:n+int(l)], b[n+int(l):]
+		default:
+			return nil, fmt.Errorf("field %v: unsupported wire type %v", number, wireType)
+		}
+		fields = append(fields, field{
+			number:   int32(number),
+			wireType: wireType,
+			value:    value,
+			encoding: start[:len(start)-len(b)],
+		})
+	}
+	sort.SliceStable(fields, func(i, j int) bool { return fields[i].number < fields[j].number })
+	return fields, nil
+}
@@ -0,0 +1,275 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protohasher
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/hex"
+	"reflect"
+	"testing"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/merkle/hashers"
+)
+
+// leaf encodes {1: 150, 2: "hi"}.
+var leaf = []byte{0x08, 0x96, 0x01, 0x12, 0x02, 'h', 'i'}
+
+func TestHashLeaf(t *testing.T) {
+	hasher, err := hashers.NewLogHasher(trillian.HashStrategy_PROTO_RFC6962_SHA256)
+	if err != nil {
+		t.Fatalf("NewLogHasher(): %v", err)
+	}
+
+	for _, tc := range []struct {
+		desc string
+		leaf []byte
+		want string
+	}{
+		// Computed from the definition in the package comment.
+		{desc: "empty", leaf: []byte{}, want: "36e4970e7c84e559ed7290304c3c0672289a6918e7746d48397b0e122db7748f"},
+		{desc: "fields", leaf: leaf, want: "f6f8abd7548db1239bd8ba1383495dc4f438d3f0b91b203db723e217090490fe"},
+		{desc: "reordered", leaf: []byte{0x12, 0x02, 'h', 'i', 0x08, 0x96, 0x01}, want: "f6f8abd7548db1239bd8ba1383495dc4f438d3f0b91b203db723e217090490fe"},
+		{desc: "overlongVarint", leaf: []byte{0x08, 0x96, 0x81, 0x00, 0x12, 0x02, 'h', 'i'}, want: "f6f8abd7548db1239bd8ba1383495dc4f438d3f0b91b203db723e217090490fe"},
+	} {
+		got, err := hasher.HashLeaf(tc.leaf)
+		if err != nil {
+			t.Errorf("%v: HashLeaf(): %v", tc.desc, err)
+			continue
+		}
+		if got := hex.EncodeToString(got); got != tc.want {
+			t.Errorf("%v: HashLeaf(): %v, want %v", tc.desc, got, tc.want)
+		}
+	}
+}
+
+func TestHashLeafDistinguishes(t *testing.T) {
+	hasher := New(crypto.SHA256)
+	want, err := hasher.HashLeaf(leaf)
+	if err != nil {
+		t.Fatalf("HashLeaf(): %v", err)
+	}
+	for _, tc := range []struct {
+		desc string
+		leaf []byte
+	}{
+		{desc: "otherValue", leaf: []byte{0x08, 0x97, 0x01, 0x12, 0x02, 'h', 'i'}},
+		{desc: "otherNumber", leaf: []byte{0x18, 0x96, 0x01, 0x12, 0x02, 'h', 'i'}},
+		{desc: "otherWireType", leaf: []byte{0x0d, 0x96, 0x00, 0x00, 0x00, 0x12, 0x02, 'h', 'i'}},
+		{desc: "missingField", leaf: []byte{0x08, 0x96, 0x01}},
+	} {
+		got, err := hasher.HashLeaf(tc.leaf)
+		if err != nil {
+			t.Errorf("%v: HashLeaf(): %v", tc.desc, err)
+			continue
+		}
+		if bytes.Equal(got, want) {
+			t.Errorf("%v: HashLeaf() = %x, same as original leaf", tc.desc, got)
+		}
+	}
+}
+
+func TestHashLeafErrors(t *testing.T) {
+	hasher := New(crypto.SHA256)
+	for _, tc := range []struct {
+		desc string
+		leaf []byte
+	}{
+		{desc: "truncatedTag", leaf: []byte{0x80}},
+		{desc: "fieldZero", leaf: []byte{0x00, 0x01}},
+		{desc: "truncatedVarint", leaf: []byte{0x08, 0x96}},
+		{desc: "truncatedFixed32", leaf: []byte{0x0d, 0x01, 0x02}},
+		{desc: "truncatedFixed64", leaf: []byte{0x09, 0x01, 0x02, 0x03, 0x04}},
+		{desc: "truncatedBytes", leaf: []byte{0x12, 0x05, 'h', 'i'}},
+		{desc: "group", leaf: []byte{0x0b, 0x0c}},
+		{desc: "json", leaf: []byte(`{"foo": "bar"}`)},
+	} {
+		if _, err := hasher.HashLeaf(tc.leaf); err == nil {
+			t.Errorf("%v: HashLeaf(): nil err, want error", tc.desc)
+		}
+	}
+}
+
+func TestRedact(t *testing.T) {
+	hasher := New(crypto.SHA256)
+	want, err := hasher.HashLeaf(leaf)
+	if err != nil {
+		t.Fatalf("HashLeaf(): %v", err)
+	}
+
+	for _, tc := range []struct {
+		desc   string
+		reveal func(int32) bool
+	}{
+		{desc: "revealAll", reveal: func(int32) bool { return true }},
+		{desc: "redactAll", reveal: func(int32) bool { return false }},
+		{desc: "revealField2", reveal: func(n int32) bool { return n == 2 }},
+	} {
+		fields, err := hasher.Redact(leaf, tc.reveal)
+		if err != nil {
+			t.Errorf("%v: Redact(): %v", tc.desc, err)
+			continue
+		}
+		for _, f := range fields {
+			if got, want := f.Encoding != nil, tc.reveal(f.Number); got != want {
+				t.Errorf("%v: field %v revealed: %v, want %v", tc.desc, f.Number, got, want)
+			}
+		}
+		got, err := hasher.HashRedacted(fields)
+		if err != nil {
+			t.Errorf("%v: HashRedacted(): %v", tc.desc, err)
+			continue
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%v: HashRedacted(): %x, want %x", tc.desc, got, want)
+		}
+	}
+}
+
+func TestHashRedactedErrors(t *testing.T) {
+	hasher := New(crypto.SHA256)
+	fields, err := hasher.Redact(leaf, func(n int32) bool { return n == 2 })
+	if err != nil {
+		t.Fatalf("Redact(): %v", err)
+	}
+
+	for _, tc := range []struct {
+		desc   string
+		fields []Field
+	}{
+		{desc: "outOfOrder", fields: []Field{fields[1], fields[0]}},
+		{desc: "shortHash", fields: []Field{{Number: 1, Hash: []byte("short")}, fields[1]}},
+		{desc: "fieldZero", fields: []Field{{Number: 0, Hash: fields[0].Hash}, fields[1]}},
+		{desc: "wrongNumber", fields: []Field{fields[0], {Number: 3, Encoding: fields[1].Encoding}}},
+		{desc: "twoFields", fields: []Field{fields[0], {Number: 2, Encoding: append(append([]byte(nil), fields[1].Encoding...), fields[1].Encoding...)}}},
+		{desc: "badEncoding", fields: []Field{fields[0], {Number: 2, Encoding: []byte{0x12, 0x05}}}},
+	} {
+		if _, err := hasher.HashRedacted(tc.fields); err == nil {
+			t.Errorf("%v: HashRedacted(): nil err, want error", tc.desc)
+		}
+	}
+
+	// A revealed field that was tampered with changes the hash.
+	want, err := hasher.HashLeaf(leaf)
+	if err != nil {
+		t.Fatalf("HashLeaf(): %v", err)
+	}
+	tampered := []Field{fields[0], {Number: 2, Encoding: []byte{0x12, 0x02, 'h', 'o'}}}
+	got, err := hasher.HashRedacted(tampered)
+	if err != nil {
+		t.Fatalf("HashRedacted(tampered): %v", err)
+	}
+	if bytes.Equal(got, want) {
+		t.Errorf("HashRedacted(tampered) = %x, want a different hash", got)
+	}
+
+	// So does renumbering a redacted field.
+	renumbered := []Field{{Number: 2, Hash: fields[0].Hash}, fields[1]}
+	if got, err = hasher.HashRedacted(renumbered); err != nil {
+		t.Fatalf("HashRedacted(renumbered): %v", err)
+	}
+	if bytes.Equal(got, want) {
+		t.Errorf("HashRedacted(renumbered) = %x, want a different hash", got)
+	}
+}
+
+func TestRedactLeaf(t *testing.T) {
+	hasher := New(crypto.SHA256)
+	var _ hashers.LeafRedactor = hasher
+	want, err := hasher.HashLeaf(leaf)
+	if err != nil {
+		t.Fatalf("HashLeaf(): %v", err)
+	}
+
+	for _, tc := range []struct {
+		desc         string
+		fields       []int32
+		wantRevealed []int32
+	}{
+		{desc: "none", wantRevealed: []int32{1, 2}},
+		{desc: "field1", fields: []int32{1}, wantRevealed: []int32{2}},
+		{desc: "all", fields: []int32{1, 2}},
+		{desc: "missingField", fields: []int32{1, 7}, wantRevealed: []int32{2}},
+	} {
+		redacted, err := hasher.RedactLeaf(leaf, tc.fields)
+		if err != nil {
+			t.Errorf("%v: RedactLeaf(): %v", tc.desc, err)
+			continue
+		}
+		if !IsRedacted(redacted) {
+			t.Errorf("%v: IsRedacted(%x): false, want true", tc.desc, redacted)
+		}
+		got, err := hasher.HashLeaf(redacted)
+		if err != nil {
+			t.Errorf("%v: HashLeaf(redacted): %v", tc.desc, err)
+			continue
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%v: HashLeaf(redacted): %x, want %x", tc.desc, got, want)
+		}
+
+		fields, err := hasher.UnmarshalRedacted(redacted)
+		if err != nil {
+			t.Errorf("%v: UnmarshalRedacted(): %v", tc.desc, err)
+			continue
+		}
+		var revealed []int32
+		for _, f := range fields {
+			if f.Encoding != nil {
+				revealed = append(revealed, f.Number)
+			}
+		}
+		if !reflect.DeepEqual(revealed, tc.wantRevealed) {
+			t.Errorf("%v: revealed fields %v, want %v", tc.desc, revealed, tc.wantRevealed)
+		}
+	}
+
+	// Redacting a redacted leaf further keeps its hash.
+	once, err := hasher.RedactLeaf(leaf, []int32{1})
+	if err != nil {
+		t.Fatalf("RedactLeaf(): %v", err)
+	}
+	twice, err := hasher.RedactLeaf(once, []int32{2})
+	if err != nil {
+		t.Fatalf("RedactLeaf(redacted): %v", err)
+	}
+	all, err := hasher.RedactLeaf(leaf, []int32{1, 2})
+	if err != nil {
+		t.Fatalf("RedactLeaf(): %v", err)
+	}
+	if !bytes.Equal(twice, all) {
+		t.Errorf("RedactLeaf(redacted): %x, want %x", twice, all)
+	}
+}
+
+func TestHashLeafRedactedErrors(t *testing.T) {
+	hasher := New(crypto.SHA256)
+	for _, tc := range []struct {
+		desc string
+		leaf []byte
+	}{
+		{desc: "missingMarker", leaf: []byte{0x00, 0x01}},
+		{desc: "unknownMarker", leaf: []byte{0x00, 0x01, 0x02}},
+		{desc: "fieldZero", leaf: []byte{0x00, 0x00, 0x01, 0x00}},
+		{desc: "truncatedHash", leaf: []byte{0x00, 0x01, 0x00, 0x01, 0x02}},
+		{desc: "truncatedEncoding", leaf: []byte{0x00, 0x02, 0x01, 0x04, 0x12, 0x02}},
+		{desc: "badEncoding", leaf: []byte{0x00, 0x02, 0x01, 0x02, 0x12, 0x02}},
+	} {
+		if _, err := hasher.HashLeaf(tc.leaf); err == nil {
+			t.Errorf("%v: HashLeaf(): nil err, want error", tc.desc)
+		}
+	}
+}
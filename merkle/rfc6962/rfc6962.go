@@ -18,13 +18,19 @@ package rfc6962
 import (
 	"crypto"
 	_ "crypto/sha256" // SHA256 is the default algorithm.
+	_ "crypto/sha512" // SHA512_256 for RFC6962_SHA512_256.
 
 	"github.com/google/trillian"
 	"github.com/google/trillian/merkle/hashers"
+	_ "golang.org/x/crypto/blake2b" // BLAKE2b_256 for RFC6962_BLAKE2B_256.
+	_ "golang.org/x/crypto/sha3"    // SHA3_256 for RFC6962_SHA3_256.
 )
 
 func init() {
 	hashers.RegisterLogHasher(trillian.HashStrategy_RFC6962_SHA256, New(crypto.SHA256))
+	hashers.RegisterLogHasher(trillian.HashStrategy_RFC6962_SHA512_256, New(crypto.SHA512_256))
+	hashers.RegisterLogHasher(trillian.HashStrategy_RFC6962_SHA3_256, New(crypto.SHA3_256))
+	hashers.RegisterLogHasher(trillian.HashStrategy_RFC6962_BLAKE2B_256, New(crypto.BLAKE2b_256))
 }
 
 // Domain separation prefixes
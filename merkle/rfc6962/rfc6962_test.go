@@ -19,6 +19,8 @@ import (
 	"testing"
 
 	_ "github.com/golang/glog"
+	"github.com/google/trillian"
+	"github.com/google/trillian/merkle/hashers"
 )
 
 func TestRfc6962Hasher(t *testing.T) {
@@ -63,3 +65,63 @@ func TestRfc6962Hasher(t *testing.T) {
 		}
 	}
 }
+
+func TestRfc6962HashStrategies(t *testing.T) {
+	for _, tc := range []struct {
+		strategy  trillian.HashStrategy
+		wantEmpty string
+		wantLeaf  string
+		wantNode  string
+	}{
+		// echo -n | openssl dgst -sha512-256
+		// echo -n 004C313233343536 | xxd -r -p | openssl dgst -sha512-256
+		// echo -n 014E3132334E343536 | xxd -r -p | openssl dgst -sha512-256
+		{
+			strategy:  trillian.HashStrategy_RFC6962_SHA512_256,
+			wantEmpty: "c672b8d1ef56ed28ab87c3622c5114069bdd3ad7b8f9737498d0c01ecef0967a",
+			wantLeaf:  "ddc60d56df2a66360865a5cd33971e54bfb0152be673d3d5dbdacc723bd2f707",
+			wantNode:  "6bb47abbd0e3fbbee3dd02dd54844122c6aae6feccf6461a2488cd171aa9a233",
+		},
+		// As above, with openssl dgst -sha3-256.
+		{
+			strategy:  trillian.HashStrategy_RFC6962_SHA3_256,
+			wantEmpty: "a7ffc6f8bf1ed76651c14756a061d662f580ff4de43b49fa82d80a4b80f8434a",
+			wantLeaf:  "091a7e2331ff57bae64ce796530fc0356b5b6ab4448f3e20b05a99503e19ad73",
+			wantNode:  "1eff624cef338bdba2600ebffc1c2149451993edc82785393d0cf5668d8ae5df",
+		},
+		// As above, with b2sum -l 256.
+		{
+			strategy:  trillian.HashStrategy_RFC6962_BLAKE2B_256,
+			wantEmpty: "0e5751c026e543b2e8ab2eb06099daa1d1e5df47778f7787faab45cdf12fe3a8",
+			wantLeaf:  "76ad9a1dbf9de24cf6eb6caa7367663fd059b30b158516221ac5a9dae37d3a93",
+			wantNode:  "1f3a1bd7b4b02b7f27f867cd82a5a631cbd354278b3f09d41bb8be73dcdf0af8",
+		},
+	} {
+		hasher, err := hashers.NewLogHasher(tc.strategy)
+		if err != nil {
+			t.Errorf("NewLogHasher(%v): %v", tc.strategy, err)
+			continue
+		}
+		if got, want := hasher.Size(), 32; got != want {
+			t.Errorf("%v: Size(): %v, want %v", tc.strategy, got, want)
+		}
+		leafHash, err := hasher.HashLeaf([]byte("L123456"))
+		if err != nil {
+			t.Errorf("%v: HashLeaf(): %v", tc.strategy, err)
+			continue
+		}
+		for _, c := range []struct {
+			desc string
+			got  []byte
+			want string
+		}{
+			{desc: "Empty", got: hasher.EmptyRoot(), want: tc.wantEmpty},
+			{desc: "Leaf", got: leafHash, want: tc.wantLeaf},
+			{desc: "Node", got: hasher.HashChildren([]byte("N123"), []byte("N456")), want: tc.wantNode},
+		} {
+			if got, want := hex.EncodeToString(c.got), c.want; got != want {
+				t.Errorf("%v %v: got %v, want %v", tc.strategy, c.desc, got, want)
+			}
+		}
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"runtime"
 	"sync"
 
 	"github.com/golang/glog"
@@ -85,6 +86,10 @@ type Subtree interface {
 // getSubtreeFunc is essentially a factory method for getting child subtrees.
 type getSubtreeFunc func(ctx context.Context, prefix []byte) (Subtree, error)
 
+// parallelHStar2MinLeaves is the number of leaves in a single subtree update
+// above which its nodes are calculated with ParallelHStar2.
+const parallelHStar2MinLeaves = 4096
+
 // subtreeWriter knows how to calculate and store nodes for a subtree.
 type subtreeWriter struct {
 	treeID int64
@@ -229,9 +234,19 @@ func (s *subtreeWriter) buildSubtree(ctx context.Context) {
 
 		// calculate new root, and intermediate nodes:
 		hs2 := NewHStar2(s.treeID, s.hasher)
+		hStar2Nodes := hs2.HStar2Nodes
+		if len(leaves) >= parallelHStar2MinLeaves {
+			hStar2Nodes = NewParallelHStar2(s.treeID, s.hasher, runtime.NumCPU()).HStar2Nodes
+		}
+		// txMu serializes the callbacks below, which may be called concurrently
+		// by ParallelHStar2, as neither tx nor nodesToStore are safe for
+		// concurrent use.
+		var txMu sync.Mutex
 		var err error
-		root, err = hs2.HStar2Nodes(s.prefix, s.subtreeDepth, leaves,
+		root, err = hStar2Nodes(s.prefix, s.subtreeDepth, leaves,
 			func(depth int, index *big.Int) ([]byte, error) {
+				txMu.Lock()
+				defer txMu.Unlock()
 				nodeID := storage.NewNodeIDFromBigInt(depth, index, s.hasher.BitLen())
 				glog.V(4).Infof("buildSubtree.get(%x, %d) nid: %x, %v",
 					index.Bytes(), depth, nodeID.Path, nodeID.PrefixLenBits)
@@ -256,6 +271,8 @@ func (s *subtreeWriter) buildSubtree(ctx context.Context) {
 				if depth == len(s.prefix)*8 && len(s.prefix) > 0 {
 					return nil
 				}
+				txMu.Lock()
+				defer txMu.Unlock()
 				nodeID := storage.NewNodeIDFromBigInt(depth, index, s.hasher.BitLen())
 				glog.V(4).Infof("buildSubtree.set(%x, %v) nid: %x, %v : %x",
 					index.Bytes(), depth, nodeID.Path, nodeID.PrefixLenBits, h)
@@ -418,6 +435,44 @@ func (s SparseMerkleTreeReader) InclusionProof(ctx context.Context, rev int64, i
 	return r, nil
 }
 
+// MultiInclusionProof returns a single proof of inclusion for all of the
+// requested indices, in which sibling nodes shared between their paths are
+// present only once. See VerifyMapMultiInclusionProof for the format.
+func (s SparseMerkleTreeReader) MultiInclusionProof(ctx context.Context, rev int64, indices [][]byte) ([][]byte, error) {
+	sibs, err := MapMultiProofSiblings(indices, s.hasher.BitLen())
+	if err != nil {
+		return nil, err
+	}
+	if len(sibs) == 0 {
+		return [][]byte{}, nil
+	}
+	nodes, err := s.tx.GetMerkleNodes(ctx, rev, sibs)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeMap := make(map[string]*storage.Node)
+	for _, n := range nodes {
+		n := n // need this or we'll end up with the same node hash repeated in the map
+		nodeMap[n.NodeID.String()] = &n
+	}
+
+	// As for InclusionProof, siblings missing from storage are left empty so
+	// the client will use the null hash.
+	r := make([][]byte, len(sibs))
+	for i, proofID := range sibs {
+		if pNode := nodeMap[proofID.String()]; pNode != nil {
+			r[i] = pNode.Hash
+			delete(nodeMap, proofID.String())
+		}
+	}
+
+	if remaining := len(nodeMap); remaining != 0 {
+		return nil, fmt.Errorf("failed to consume all returned nodes; got %d nodes, but %d remain(s) unused", len(nodes), remaining)
+	}
+	return r, nil
+}
+
 // SetLeaves adds a batch of leaves to the in-flight tree update.
 func (s *SparseMerkleTreeWriter) SetLeaves(ctx context.Context, leaves []HashKeyValue) error {
 	for _, l := range leaves {
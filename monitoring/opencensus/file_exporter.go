@@ -0,0 +1,106 @@
+// Copyright 2018 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencensus
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"go.opencensus.io/trace"
+)
+
+// spanRecord is the JSON form of a span written by fileExporter.
+type spanRecord struct {
+	Name         string                 `json:"name"`
+	TraceID      string                 `json:"trace_id"`
+	SpanID       string                 `json:"span_id"`
+	ParentSpanID string                 `json:"parent_span_id,omitempty"`
+	Start        time.Time              `json:"start"`
+	End          time.Time              `json:"end"`
+	Attributes   map[string]interface{} `json:"attributes,omitempty"`
+	Annotations  []annotationRecord     `json:"annotations,omitempty"`
+	StatusCode   int32                  `json:"status_code,omitempty"`
+	Status       string                 `json:"status,omitempty"`
+}
+
+// annotationRecord is the JSON form of a span annotation.
+type annotationRecord struct {
+	Time       time.Time              `json:"time"`
+	Message    string                 `json:"message"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// fileExporter is a trace.Exporter that writes spans to a file as JSON, one
+// per line, so that they can be inspected without a tracing backend.
+type fileExporter struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+	// closer closes the file, and is nil for stdout.
+	closer io.Closer
+}
+
+// newFileExporter returns a fileExporter that appends spans to the file at
+// path, or writes them to stdout if path is "-".
+func newFileExporter(path string) (*fileExporter, error) {
+	if path == "-" {
+		return &fileExporter{enc: json.NewEncoder(os.Stdout)}, nil
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &fileExporter{enc: json.NewEncoder(f), closer: f}, nil
+}
+
+// ExportSpan writes s to the file.
+func (e *fileExporter) ExportSpan(s *trace.SpanData) {
+	r := spanRecord{
+		Name:        s.Name,
+		TraceID:     s.TraceID.String(),
+		SpanID:      s.SpanID.String(),
+		Start:       s.StartTime,
+		End:         s.EndTime,
+		Attributes:  s.Attributes,
+		Annotations: make([]annotationRecord, 0, len(s.Annotations)),
+		StatusCode:  s.Status.Code,
+		Status:      s.Status.Message,
+	}
+	if s.ParentSpanID != (trace.SpanID{}) {
+		r.ParentSpanID = s.ParentSpanID.String()
+	}
+	for _, a := range s.Annotations {
+		r.Annotations = append(r.Annotations, annotationRecord{Time: a.Time, Message: a.Message, Attributes: a.Attributes})
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err := e.enc.Encode(r); err != nil {
+		glog.Warningf("Failed to write span %v: %v", s.Name, err)
+	}
+}
+
+// Close closes the file, if it isn't stdout.
+func (e *fileExporter) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closer == nil {
+		return nil
+	}
+	return e.closer.Close()
+}
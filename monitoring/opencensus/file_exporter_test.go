@@ -0,0 +1,123 @@
+// Copyright 2018 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencensus
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.opencensus.io/trace"
+)
+
+func TestFileExporter(t *testing.T) {
+	dir, err := ioutil.TempDir("", "trace")
+	if err != nil {
+		t.Fatalf("TempDir(): %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "spans.json")
+
+	e, err := newFileExporter(path)
+	if err != nil {
+		t.Fatalf("newFileExporter(): %v", err)
+	}
+	start := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	root := &trace.SpanData{
+		SpanContext: trace.SpanContext{
+			TraceID: trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+			SpanID:  trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+		},
+		Name:       "root",
+		StartTime:  start,
+		EndTime:    start.Add(time.Second),
+		Attributes: map[string]interface{}{"log_id": "12345"},
+	}
+	child := &trace.SpanData{
+		SpanContext: trace.SpanContext{
+			TraceID: root.TraceID,
+			SpanID:  trace.SpanID{8, 7, 6, 5, 4, 3, 2, 1},
+		},
+		ParentSpanID: root.SpanID,
+		Name:         "child",
+		StartTime:    start,
+		EndTime:      start.Add(time.Millisecond),
+		Annotations: []trace.Annotation{
+			{Time: start, Message: "sequenced leaf", Attributes: map[string]interface{}{"leaf_identity_hash": "0102"}},
+		},
+		Status: trace.Status{Code: 5, Message: "not found"},
+	}
+	e.ExportSpan(child)
+	e.ExportSpan(root)
+	if err := e.Close(); err != nil {
+		t.Fatalf("Close(): %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Open(): %v", err)
+	}
+	defer f.Close()
+	var got []spanRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var r spanRecord
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			t.Fatalf("Unmarshal(%s): %v", scanner.Bytes(), err)
+		}
+		got = append(got, r)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("Scan(): %v", err)
+	}
+
+	want := []spanRecord{
+		{
+			Name:         "child",
+			TraceID:      "0102030405060708090a0b0c0d0e0f10",
+			SpanID:       "0807060504030201",
+			ParentSpanID: "0102030405060708",
+			Start:        start,
+			End:          start.Add(time.Millisecond),
+			Annotations: []annotationRecord{
+				{Time: start, Message: "sequenced leaf", Attributes: map[string]interface{}{"leaf_identity_hash": "0102"}},
+			},
+			StatusCode: 5,
+			Status:     "not found",
+		},
+		{
+			Name:       "root",
+			TraceID:    "0102030405060708090a0b0c0d0e0f10",
+			SpanID:     "0102030405060708",
+			Start:      start,
+			End:        start.Add(time.Second),
+			Attributes: map[string]interface{}{"log_id": "12345"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("exported spans = %+v, want %+v", got, want)
+	}
+}
+
+func TestEnableTracing_UnknownExporter(t *testing.T) {
+	if _, err := EnableTracing(TraceOptions{Exporter: "zipkin"}); err == nil {
+		t.Error("EnableTracing(zipkin) = nil, want error")
+	}
+}
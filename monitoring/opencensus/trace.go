@@ -12,12 +12,18 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
+// Package opencensus enables tracing of Trillian servers with OpenCensus,
+// exporting spans to Stackdriver, to a Jaeger agent or collector, or to a file.
 package opencensus
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 
+	"github.com/golang/glog"
+	"go.opencensus.io/exporter/jaeger"
 	"go.opencensus.io/exporter/stackdriver"
 	"go.opencensus.io/plugin/ocgrpc"
 	"go.opencensus.io/plugin/ochttp"
@@ -26,6 +32,98 @@ import (
 	"google.golang.org/grpc"
 )
 
+// Names of the supported trace exporters.
+const (
+	// StackdriverExporter exports spans to Stackdriver Trace.
+	StackdriverExporter = "stackdriver"
+	// JaegerExporter exports spans to a Jaeger agent or collector, or to any
+	// collector accepting the Jaeger protocols, e.g. the OpenTelemetry one.
+	JaegerExporter = "jaeger"
+	// FileExporter writes spans to a file as JSON, one per line.
+	FileExporter = "file"
+)
+
+// TraceOptions configures the export of traces.
+type TraceOptions struct {
+	// Exporter is the name of the exporter to use. Defaults to
+	// StackdriverExporter.
+	Exporter string
+	// ProjectID is passed to the Stackdriver client. It can be empty for GCP,
+	// but might need to be set for other cloud platforms.
+	ProjectID string
+	// ServiceName identifies the process in Jaeger traces.
+	ServiceName string
+	// Endpoint is the host:port of the Jaeger agent to send spans to over UDP,
+	// or the base URL of the Jaeger collector to send them to over HTTP, e.g.
+	// http://localhost:14268.
+	Endpoint string
+	// Path is the file the file exporter appends spans to, or "-" for stdout.
+	Path string
+	// Percent is the percentage of requests to trace, between 0 and 100. Note
+	// that 0 does not disable tracing entirely but causes the default
+	// configuration to be used.
+	Percent int
+}
+
+// EnableTracing registers the exporter selected by opts, and applies its
+// sampling configuration. The returned function flushes any buffered spans,
+// and should be called before the process exits.
+func EnableTracing(opts TraceOptions) (func(), error) {
+	if err := applyConfig(opts.Percent); err != nil {
+		return nil, err
+	}
+	switch opts.Exporter {
+	case "", StackdriverExporter:
+		sde, err := stackdriver.NewExporter(stackdriver.Options{ProjectID: opts.ProjectID})
+		if err != nil {
+			return nil, err
+		}
+		view.RegisterExporter(sde)
+		trace.RegisterExporter(sde)
+		return sde.Flush, nil
+	case JaegerExporter:
+		jo := jaeger.Options{
+			ServiceName: opts.ServiceName,
+			OnError:     func(err error) { glog.Warningf("Failed to export spans to Jaeger: %v", err) },
+		}
+		if strings.HasPrefix(opts.Endpoint, "http://") || strings.HasPrefix(opts.Endpoint, "https://") {
+			jo.Endpoint = opts.Endpoint
+		} else {
+			jo.AgentEndpoint = opts.Endpoint
+		}
+		je, err := jaeger.NewExporter(jo)
+		if err != nil {
+			return nil, err
+		}
+		trace.RegisterExporter(je)
+		return je.Flush, nil
+	case FileExporter:
+		fe, err := newFileExporter(opts.Path)
+		if err != nil {
+			return nil, err
+		}
+		trace.RegisterExporter(fe)
+		return func() {
+			trace.UnregisterExporter(fe)
+			if err := fe.Close(); err != nil {
+				glog.Warningf("Failed to close trace file: %v", err)
+			}
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q", opts.Exporter)
+	}
+}
+
+// RPCServerOptions returns options that make a GRPC server trace requests, and
+// count them. Spans are exported by the exporter registered by EnableTracing.
+func RPCServerOptions() ([]grpc.ServerOption, error) {
+	// Register the views to collect server request count.
+	if err := view.Register(ocgrpc.DefaultServerViews...); err != nil {
+		return nil, err
+	}
+	return []grpc.ServerOption{grpc.StatsHandler(&ocgrpc.ServerHandler{})}, nil
+}
+
 // EnableRPCServerTracing turns on Stackdriver tracing. The returned
 // options must be passed to the GRPC server. The supplied
 // projectID can be nil for GCP but might need to be set for other
@@ -33,18 +131,10 @@ import (
 // of traced requests can be set between 0 and 100. Note that 0 does not
 // disable tracing entirely but causes the default configuration to be used.
 func EnableRPCServerTracing(projectID string, percent int) ([]grpc.ServerOption, error) {
-	if err := exporter(projectID); err != nil {
-		return nil, err
-	}
-	if err := applyConfig(percent); err != nil {
-		return nil, err
-	}
-	// Register the views to collect server request count.
-	if err := view.Register(ocgrpc.DefaultServerViews...); err != nil {
+	if _, err := EnableTracing(TraceOptions{ProjectID: projectID, Percent: percent}); err != nil {
 		return nil, err
 	}
-
-	return []grpc.ServerOption{grpc.StatsHandler(&ocgrpc.ServerHandler{})}, nil
+	return RPCServerOptions()
 }
 
 // EnableHTTPServerTracing turns on Stackdriver tracing for HTTP requests
@@ -55,10 +145,7 @@ func EnableRPCServerTracing(projectID string, percent int) ([]grpc.ServerOption,
 // does not disable tracing entirely but causes the default configuration to be
 // used.
 func EnableHTTPServerTracing(projectID string, percent int) (http.Handler, error) {
-	if err := exporter(projectID); err != nil {
-		return nil, err
-	}
-	if err := applyConfig(percent); err != nil {
+	if _, err := EnableTracing(TraceOptions{ProjectID: projectID, Percent: percent}); err != nil {
 		return nil, err
 	}
 	if err := view.Register(ochttp.DefaultServerViews...); err != nil {
@@ -67,17 +154,6 @@ func EnableHTTPServerTracing(projectID string, percent int) (http.Handler, error
 	return &ochttp.Handler{}, nil
 }
 
-func exporter(projectID string) error {
-	sde, err := stackdriver.NewExporter(stackdriver.Options{ProjectID: projectID})
-	if err != nil {
-		return err
-	}
-	view.RegisterExporter(sde)
-	trace.RegisterExporter(sde)
-
-	return nil
-}
-
 func applyConfig(percent int) error {
 	switch {
 	case percent == 0:
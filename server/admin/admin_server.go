@@ -17,21 +17,38 @@ package admin
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"fmt"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
 	"github.com/google/trillian"
+	"github.com/google/trillian/audit"
 	"github.com/google/trillian/crypto/keys/der"
+	"github.com/google/trillian/crypto/keys/envelope"
+	"github.com/google/trillian/crypto/keyspb"
 	"github.com/google/trillian/extension"
 	"github.com/google/trillian/merkle/hashers"
+	"github.com/google/trillian/monitoring"
 	"github.com/google/trillian/storage"
 	"github.com/google/trillian/trees"
+	"github.com/google/trillian/types"
 	"google.golang.org/genproto/protobuf/field_mask"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
-	_ "github.com/google/trillian/merkle/rfc6962" // Make hashers available
+	_ "github.com/google/trillian/merkle/protohasher" // Make hashers available
+	_ "github.com/google/trillian/merkle/rfc6962"     // Make hashers available
+)
+
+var (
+	auditMetricsOnce  sync.Once
+	auditAppendErrors monitoring.Counter
 )
 
 // Server is an implementation of trillian.TrillianAdminServer.
@@ -57,17 +74,92 @@ func (s *Server) IsHealthy() error {
 	return s.registry.AdminStorage.CheckDatabaseAccessible(context.Background())
 }
 
+// maxPageSize is the largest page_size accepted by ListTrees and
+// ListTreeAuditEntries; larger values are reduced to it.
+const maxPageSize = 1000
+
 // ListTrees implements trillian.TrillianAdminServer.ListTrees.
 func (s *Server) ListTrees(ctx context.Context, req *trillian.ListTreesRequest) (*trillian.ListTreesResponse, error) {
 	// TODO(codingllama): This needs access control
-	resp, err := storage.ListTrees(ctx, s.registry.AdminStorage, req.GetShowDeleted())
+	if !isPagedOrFiltered(req) {
+		resp, err := storage.ListTrees(ctx, s.registry.AdminStorage, req.GetShowDeleted())
+		if err != nil {
+			return nil, err
+		}
+		for _, tree := range resp {
+			redact(tree)
+		}
+		return &trillian.ListTreesResponse{Tree: resp}, nil
+	}
+
+	pageSize := int(req.GetPageSize())
+	switch {
+	case pageSize < 0:
+		return nil, status.Errorf(codes.InvalidArgument, "page_size must not be negative, got %v", pageSize)
+	case pageSize > maxPageSize:
+		pageSize = maxPageSize
+	}
+	afterTreeID, err := decodePageToken(req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	opts := storage.ListTreesOptions{
+		IncludeDeleted: req.GetShowDeleted(),
+		AfterTreeID:    afterTreeID,
+		TreeTypes:      req.GetTreeTypes(),
+		TreeStates:     req.GetTreeStates(),
+		Labels:         req.GetLabelSelector(),
+	}
+	if pageSize > 0 {
+		// Ask for one more tree than needed, so we know whether there's a next page.
+		opts.Limit = pageSize + 1
+	}
+	trees, err := storage.ListTreesPage(ctx, s.registry.AdminStorage, opts)
 	if err != nil {
 		return nil, err
 	}
-	for _, tree := range resp {
+
+	resp := &trillian.ListTreesResponse{}
+	if pageSize > 0 && len(trees) > pageSize {
+		trees = trees[:pageSize]
+		resp.NextPageToken = encodePageToken(trees[pageSize-1].TreeId)
+	}
+	for _, tree := range trees {
 		redact(tree)
 	}
-	return &trillian.ListTreesResponse{Tree: resp}, nil
+	resp.Tree = trees
+	return resp, nil
+}
+
+// isPagedOrFiltered returns true if req uses any of the pagination or
+// filtering fields of ListTreesRequest.
+func isPagedOrFiltered(req *trillian.ListTreesRequest) bool {
+	return req.GetPageSize() != 0 || req.GetPageToken() != "" || len(req.GetTreeTypes()) > 0 ||
+		len(req.GetTreeStates()) > 0 || len(req.GetLabelSelector()) > 0
+}
+
+// encodePageToken returns an opaque page token that resumes listing at pos:
+// after the tree with ID pos for ListTrees, or from position pos of the audit
+// log for ListTreeAuditEntries.
+func encodePageToken(pos int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(pos, 10)))
+}
+
+// decodePageToken returns the position encoded in token by encodePageToken,
+// or zero if token is empty.
+func decodePageToken(token string) (int64, error) {
+	if token == "" {
+		return 0, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid page_token: %q", token)
+	}
+	pos, err := strconv.ParseInt(string(b), 10, 64)
+	if err != nil || pos <= 0 {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid page_token: %q", token)
+	}
+	return pos, nil
 }
 
 // GetTree implements trillian.TrillianAdminServer.GetTree.
@@ -88,6 +180,9 @@ func (s *Server) CreateTree(ctx context.Context, req *trillian.CreateTreeRequest
 	if err := s.validateAllowedTreeType(tree.TreeType); err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
+	if err := s.checkTreeFeatures(tree); err != nil {
+		return nil, err
+	}
 	switch tree.TreeType {
 	case trillian.TreeType_LOG, trillian.TreeType_PREORDERED_LOG:
 		if _, err := hashers.NewLogHasher(tree.HashStrategy); err != nil {
@@ -151,17 +246,45 @@ func (s *Server) CreateTree(ctx context.Context, req *trillian.CreateTreeRequest
 		tree.PublicKey = publicKey
 	}
 
+	// Likewise for the signers of multi-signature trees. Storage checks that
+	// any provided public keys match.
+	for i, ts := range tree.GetMultiSig().GetSigners() {
+		if ts.PublicKey != nil {
+			continue
+		}
+		if ts.PublicKey, err = der.ToPublicProto(signer.CoSigners[i].Public()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to marshal public key of multi_sig signer %v: %v", i, err.Error())
+		}
+	}
+
+	// Encrypt the private keys, now that they've been checked.
+	if tree.PrivateKey, err = s.encryptPrivateKey(ctx, tree.PrivateKey, tree.PublicKey); err != nil {
+		return nil, err
+	}
+	for _, ts := range tree.GetMultiSig().GetSigners() {
+		if ts.PrivateKey, err = s.encryptPrivateKey(ctx, ts.PrivateKey, ts.PublicKey); err != nil {
+			return nil, err
+		}
+	}
+
 	// Clear generated fields, storage must set those
 	tree.TreeId = 0
 	tree.CreateTime = nil
 	tree.UpdateTime = nil
 	tree.Deleted = false
 	tree.DeleteTime = nil
+	tree.KeyVersion = 0
+	tree.PreviousKeys = nil
+	tree.TransitionStatus = nil
+	if tree.ScheduledTransition != nil {
+		tree.TransitionStatus = newTransitionStatus(trillian.TreeTransitionStatus_PENDING, tree.ScheduledTransition.TargetState)
+	}
 
 	createdTree, err := storage.CreateTree(ctx, s.registry.AdminStorage, tree)
 	if err != nil {
 		return nil, err
 	}
+	s.recordChange(ctx, "CreateTree", nil /* before */, createdTree)
 	return redact(createdTree), nil
 }
 
@@ -177,6 +300,29 @@ func (s *Server) validateAllowedTreeType(tt trillian.TreeType) error {
 	return fmt.Errorf("tree type %s not allowed by this server", tt)
 }
 
+// checkTreeFeatures returns an error if tree uses a feature that isn't
+// supported by the admin storage.
+func (s *Server) checkTreeFeatures(tree *trillian.Tree) error {
+	if fc, ok := s.registry.AdminStorage.(storage.TreeFeatureChecker); ok {
+		return fc.CheckTreeFeatures(tree)
+	}
+	return nil
+}
+
+// encryptPrivateKey encrypts privateKey with the registry's KEKProvider, if
+// there is one, so that it isn't stored in plaintext. The encrypted key can
+// only be decrypted along with publicKey, which must be its public key.
+func (s *Server) encryptPrivateKey(ctx context.Context, privateKey *any.Any, publicKey *keyspb.PublicKey) (*any.Any, error) {
+	if s.registry.KEKProvider == nil {
+		return privateKey, nil
+	}
+	encrypted, err := envelope.EncryptAny(ctx, s.registry.KEKProvider, privateKey, publicKey.GetDer())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encrypt private key: %v", err.Error())
+	}
+	return encrypted, nil
+}
+
 // UpdateTree implements trillian.TrillianAdminServer.UpdateTree.
 func (s *Server) UpdateTree(ctx context.Context, req *trillian.UpdateTreeRequest) (*trillian.Tree, error) {
 	tree := req.GetTree()
@@ -184,20 +330,40 @@ func (s *Server) UpdateTree(ctx context.Context, req *trillian.UpdateTreeRequest
 	if tree == nil {
 		return nil, status.Errorf(codes.InvalidArgument, "a tree is required")
 	}
-	// Apply the mask to a couple of empty trees just to check that the paths are correct.
-	if err := applyUpdateMask(&trillian.Tree{}, &trillian.Tree{}, mask); err != nil {
+	// Apply the mask to an empty tree to check that the paths are correct, and
+	// that storage supports the fields being set.
+	masked := &trillian.Tree{}
+	if err := applyUpdateMask(tree, masked, mask); err != nil {
+		return nil, err
+	}
+	if err := s.checkTreeFeatures(masked); err != nil {
 		return nil, err
 	}
 
+	var before *trillian.Tree
+	var encryptErr error
 	updatedTree, err := storage.UpdateTree(ctx, s.registry.AdminStorage, tree.TreeId, func(other *trillian.Tree) {
+		before = proto.Clone(other).(*trillian.Tree)
 		if err := applyUpdateMask(tree, other, mask); err != nil {
 			// Should never happen (famous last words).
 			glog.Errorf("Error applying mask on tree update: %v", err)
 		}
+		// The new private key is encrypted here, as it's bound to the stored
+		// public key. If that fails, clearing the key fails the update rather
+		// than storing it in plaintext.
+		if !proto.Equal(other.PrivateKey, before.PrivateKey) {
+			if other.PrivateKey, encryptErr = s.encryptPrivateKey(ctx, other.PrivateKey, other.PublicKey); encryptErr != nil {
+				other.PrivateKey = nil
+			}
+		}
 	})
+	if encryptErr != nil {
+		return nil, encryptErr
+	}
 	if err != nil {
 		return nil, err
 	}
+	s.recordChange(ctx, "UpdateTree", before, updatedTree)
 	return redact(updatedTree), nil
 }
 
@@ -221,6 +387,16 @@ func applyUpdateMask(from, to *trillian.Tree, mask *field_mask.FieldMask) error
 			to.MaxRootDuration = from.MaxRootDuration
 		case "private_key":
 			to.PrivateKey = from.PrivateKey
+		case "labels":
+			to.Labels = from.Labels
+		case "scheduled_transition":
+			switch {
+			case from.ScheduledTransition != nil:
+				to.TransitionStatus = newTransitionStatus(trillian.TreeTransitionStatus_PENDING, from.ScheduledTransition.TargetState)
+			case to.ScheduledTransition != nil:
+				to.TransitionStatus = newTransitionStatus(trillian.TreeTransitionStatus_CANCELLED, to.ScheduledTransition.TargetState)
+			}
+			to.ScheduledTransition = from.ScheduledTransition
 		default:
 			return status.Errorf(codes.InvalidArgument, "invalid update_mask path: %q", path)
 		}
@@ -228,26 +404,322 @@ func applyUpdateMask(from, to *trillian.Tree, mask *field_mask.FieldMask) error
 	return nil
 }
 
+// newTransitionStatus returns a TreeTransitionStatus that entered phase now.
+func newTransitionStatus(phase trillian.TreeTransitionStatus_Phase, target trillian.TreeState) *trillian.TreeTransitionStatus {
+	return &trillian.TreeTransitionStatus{
+		Phase:       phase,
+		TargetState: target,
+		UpdateTime:  ptypes.TimestampNow(),
+	}
+}
+
 // DeleteTree implements trillian.TrillianAdminServer.DeleteTree.
 func (s *Server) DeleteTree(ctx context.Context, req *trillian.DeleteTreeRequest) (*trillian.Tree, error) {
-	tree, err := storage.SoftDeleteTree(ctx, s.registry.AdminStorage, req.GetTreeId())
+	var before, tree *trillian.Tree
+	err := s.registry.AdminStorage.ReadWriteTransaction(ctx, func(ctx context.Context, tx storage.AdminTX) (err error) {
+		if before, err = s.readForAudit(ctx, tx, req.GetTreeId()); err != nil {
+			return err
+		}
+		tree, err = tx.SoftDeleteTree(ctx, req.GetTreeId())
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
+	s.recordChange(ctx, "DeleteTree", before, tree)
 	return redact(tree), nil
 }
 
 // UndeleteTree implements trillian.TrillianAdminServer.UndeleteTree.
 func (s *Server) UndeleteTree(ctx context.Context, req *trillian.UndeleteTreeRequest) (*trillian.Tree, error) {
-	tree, err := storage.UndeleteTree(ctx, s.registry.AdminStorage, req.GetTreeId())
+	var before, tree *trillian.Tree
+	err := s.registry.AdminStorage.ReadWriteTransaction(ctx, func(ctx context.Context, tx storage.AdminTX) (err error) {
+		if before, err = s.readForAudit(ctx, tx, req.GetTreeId()); err != nil {
+			return err
+		}
+		tree, err = tx.UndeleteTree(ctx, req.GetTreeId())
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
+	s.recordChange(ctx, "UndeleteTree", before, tree)
 	return redact(tree), nil
 }
 
+// RotateTreeKey implements trillian.TrillianAdminServer.RotateTreeKey.
+func (s *Server) RotateTreeKey(ctx context.Context, req *trillian.RotateTreeKeyRequest) (*trillian.Tree, error) {
+	privateKey := req.GetPrivateKey()
+
+	// If a key specification was provided, generate a new key.
+	if req.KeySpec != nil {
+		if privateKey != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "the private_key and key_spec fields are mutually exclusive")
+		}
+		if s.registry.NewKeyProto == nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "key generation is not enabled")
+		}
+
+		keyProto, err := s.registry.NewKeyProto(ctx, req.KeySpec)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to generate private key: %v", err.Error())
+		}
+
+		privateKey, err = ptypes.MarshalAny(keyProto)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to marshal private key: %v", err.Error())
+		}
+	}
+
+	if privateKey == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "private_key or key_spec is required")
+	}
+
+	// Storage that doesn't support rotation rejects any rotated key version.
+	if err := s.checkTreeFeatures(&trillian.Tree{KeyVersion: 1}); err != nil {
+		return nil, err
+	}
+
+	var transitionPeriod time.Duration
+	if req.TransitionPeriod != nil {
+		var err error
+		if transitionPeriod, err = ptypes.Duration(req.TransitionPeriod); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "transition_period malformed: %v", err)
+		} else if transitionPeriod < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "transition_period negative: %v", transitionPeriod)
+		}
+	}
+
+	var before, rotatedTree *trillian.Tree
+	err := s.registry.AdminStorage.ReadWriteTransaction(ctx, func(ctx context.Context, tx storage.AdminTX) error {
+		tree, err := tx.GetTree(ctx, req.GetTreeId())
+		if err != nil {
+			return err
+		}
+		before = proto.Clone(tree).(*trillian.Tree)
+		oldSigner, err := trees.Signer(ctx, tree)
+		if err != nil {
+			return status.Errorf(codes.FailedPrecondition, "failed to create signer for tree: %v", err.Error())
+		}
+
+		// Check that the new key is valid, and of the tree's signature algorithm.
+		newTree := *tree
+		newTree.PrivateKey = privateKey
+		newTree.KeyVersion = tree.KeyVersion + 1
+		newSigner, err := trees.Signer(ctx, &newTree)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "failed to create signer for new key: %v", err.Error())
+		}
+		publicKey, err := der.ToPublicProto(newSigner.Public())
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "failed to marshal public key: %v", err.Error())
+		}
+
+		storedKey, err := s.encryptPrivateKey(ctx, privateKey, publicKey)
+		if err != nil {
+			return err
+		}
+
+		// The key being replaced vouches for the new key.
+		now := time.Now()
+		rotation, signature, err := oldSigner.SignKeyRotation(&types.KeyRotationV1{
+			TreeID:         uint64(tree.TreeId),
+			KeyVersion:     uint64(newTree.KeyVersion),
+			PublicKey:      publicKey.Der,
+			TimestampNanos: uint64(now.UnixNano()),
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to sign key rotation: %v", err.Error())
+		}
+		retireTime, err := ptypes.TimestampProto(now.Add(transitionPeriod))
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "transition_period too long: %v", err)
+		}
+		replacedKey := &trillian.TreeKey{
+			KeyVersion:        tree.KeyVersion,
+			PublicKey:         tree.PublicKey,
+			RetireTime:        retireTime,
+			Rotation:          rotation,
+			RotationSignature: signature,
+		}
+
+		rotatedTree, err = tx.UpdateTree(ctx, tree.TreeId, func(tree *trillian.Tree) {
+			previousKeys := make([]*trillian.TreeKey, 0, len(tree.PreviousKeys)+1)
+			tree.PreviousKeys = append(append(previousKeys, tree.PreviousKeys...), replacedKey)
+			tree.KeyVersion = newTree.KeyVersion
+			tree.PrivateKey = storedKey
+			tree.PublicKey = publicKey
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.recordChange(ctx, "RotateTreeKey", before, rotatedTree)
+	return redact(rotatedTree), nil
+}
+
+// RedactLeaf implements trillian.TrillianAdminServer.RedactLeaf.
+func (s *Server) RedactLeaf(ctx context.Context, req *trillian.RedactLeafRequest) (*trillian.LogLeaf, error) {
+	if s.registry.LogStorage == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "log storage is not configured")
+	}
+	if req.GetLeafIndex() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid leaf_index: %v", req.GetLeafIndex())
+	}
+	if len(req.GetRedactedFields()) == 0 && !req.GetClearExtraData() {
+		return nil, status.Errorf(codes.InvalidArgument, "redacted_fields or clear_extra_data is required")
+	}
+
+	opts := trees.NewGetOpts(trees.Query, trillian.TreeType_LOG, trillian.TreeType_PREORDERED_LOG)
+	tree, err := trees.GetTree(ctx, s.registry.AdminStorage, req.GetLogId(), opts)
+	if err != nil {
+		return nil, err
+	}
+	hasher, err := hashers.NewLogHasher(tree.HashStrategy)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "failed to create hasher for tree: %v", err.Error())
+	}
+	redactor, ok := hasher.(hashers.LeafRedactor)
+	if !ok && len(req.GetRedactedFields()) > 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "hash strategy %v does not support redaction", tree.HashStrategy)
+	}
+
+	var leaf *trillian.LogLeaf
+	err = s.registry.LogStorage.ReadWriteTransaction(ctx, tree, func(ctx context.Context, tx storage.LogTreeTX) error {
+		leaves, err := tx.GetLeavesByIndex(ctx, []int64{req.GetLeafIndex()})
+		if err != nil {
+			return err
+		}
+		if len(leaves) != 1 {
+			return status.Errorf(codes.NotFound, "leaf %v not found", req.GetLeafIndex())
+		}
+		leaf = leaves[0]
+
+		if len(req.GetRedactedFields()) > 0 {
+			value, err := redactor.RedactLeaf(leaf.LeafValue, req.GetRedactedFields())
+			if err != nil {
+				return status.Errorf(codes.InvalidArgument, "failed to redact leaf: %v", err)
+			}
+			// Never store a value that would break the leaf's inclusion proofs.
+			hash, err := hasher.HashLeaf(value)
+			if err != nil {
+				return status.Errorf(codes.Internal, "failed to hash redacted leaf: %v", err)
+			}
+			if !bytes.Equal(hash, leaf.MerkleLeafHash) {
+				return status.Errorf(codes.FailedPrecondition, "redacted leaf hash %x doesn't match leaf hash %x", hash, leaf.MerkleLeafHash)
+			}
+			leaf.LeafValue = value
+		}
+		if req.GetClearExtraData() {
+			leaf.ExtraData = nil
+		}
+		return tx.UpdateLeafData(ctx, leaf.LeafIndex, leaf.LeafValue, leaf.ExtraData)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.recordRedaction(ctx, tree, req)
+	return leaf, nil
+}
+
+// ListTreeAuditEntries implements trillian.TrillianAdminServer.ListTreeAuditEntries.
+func (s *Server) ListTreeAuditEntries(ctx context.Context, req *trillian.ListTreeAuditEntriesRequest) (*trillian.ListTreeAuditEntriesResponse, error) {
+	if s.registry.AuditLog == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "audit log is not configured")
+	}
+	pageSize := int(req.GetPageSize())
+	switch {
+	case pageSize < 0:
+		return nil, status.Errorf(codes.InvalidArgument, "page_size must not be negative, got %v", pageSize)
+	case pageSize == 0, pageSize > maxPageSize:
+		pageSize = maxPageSize
+	}
+	start, err := decodePageToken(req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	entries, next, err := s.registry.AuditLog.List(ctx, req.GetTreeId(), start, pageSize)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to read audit log: %v", err)
+	}
+	resp := &trillian.ListTreeAuditEntriesResponse{Entries: entries}
+	if next != 0 {
+		resp.NextPageToken = encodePageToken(next)
+	}
+	return resp, nil
+}
+
+// readForAudit returns the tree as read by tx if an audit log is configured,
+// so that it can be recorded as the state before a change, or nil otherwise.
+func (s *Server) readForAudit(ctx context.Context, tx storage.AdminTX, treeID int64) (*trillian.Tree, error) {
+	if s.registry.AuditLog == nil {
+		return nil, nil
+	}
+	return tx.GetTree(ctx, treeID)
+}
+
+// recordChange appends an entry for a change made to a tree by method to the
+// audit log, if one is configured. before is nil for created trees.
+// before and after aren't modified; the recorded copies are redacted.
+func (s *Server) recordChange(ctx context.Context, method string, before, after *trillian.Tree) {
+	if s.registry.AuditLog == nil {
+		return
+	}
+	entry := &trillian.TreeAuditEntry{
+		TreeId:    after.TreeId,
+		Principal: audit.Principal(ctx),
+		Method:    method,
+		After:     redact(proto.Clone(after).(*trillian.Tree)),
+		Timestamp: ptypes.TimestampNow(),
+	}
+	if before != nil {
+		entry.Before = redact(proto.Clone(before).(*trillian.Tree))
+	}
+	s.appendAuditEntry(ctx, entry)
+}
+
+// recordRedaction appends an entry for the redaction of a leaf of tree made
+// by req to the audit log, if one is configured.
+func (s *Server) recordRedaction(ctx context.Context, tree *trillian.Tree, req *trillian.RedactLeafRequest) {
+	if s.registry.AuditLog == nil {
+		return
+	}
+	s.appendAuditEntry(ctx, &trillian.TreeAuditEntry{
+		TreeId:    tree.TreeId,
+		Principal: audit.Principal(ctx),
+		Method:    "RedactLeaf",
+		Before:    redact(proto.Clone(tree).(*trillian.Tree)),
+		After:     redact(proto.Clone(tree).(*trillian.Tree)),
+		Timestamp: ptypes.TimestampNow(),
+		Redaction: proto.Clone(req).(*trillian.RedactLeafRequest),
+	})
+}
+
+// appendAuditEntry appends entry, recording a change that's already been
+// committed, to the audit log.
+// Failures aren't returned to the caller, as failing the RPC would lead to
+// the committed change being retried. They're logged and counted by the
+// audit_log_append_errors metric instead, which should be alerted on.
+func (s *Server) appendAuditEntry(ctx context.Context, entry *trillian.TreeAuditEntry) {
+	auditMetricsOnce.Do(func() {
+		mf := s.registry.MetricFactory
+		if mf == nil {
+			mf = monitoring.InertMetricFactory{}
+		}
+		auditAppendErrors = mf.NewCounter("audit_log_append_errors", "Number of committed tree changes that couldn't be recorded in the audit log", "method")
+	})
+	if err := s.registry.AuditLog.Append(ctx, entry); err != nil {
+		glog.Errorf("%v of tree %v not recorded in the audit log: %v", entry.Method, entry.TreeId, err)
+		auditAppendErrors.Inc(entry.Method)
+	}
+}
+
 // redact removes sensitive information from t. Returns t for convenience.
 func redact(t *trillian.Tree) *trillian.Tree {
 	t.PrivateKey = nil
+	for _, ts := range t.GetMultiSig().GetSigners() {
+		ts.PrivateKey = nil
+	}
 	return t
 }
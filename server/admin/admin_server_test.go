@@ -15,14 +15,18 @@
 package admin
 
 import (
+	"bytes"
 	"context"
 	"crypto"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"net"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -30,21 +34,28 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/google/trillian"
+	"github.com/google/trillian/audit"
 	"github.com/google/trillian/crypto/keys"
 	"github.com/google/trillian/crypto/keys/der"
+	"github.com/google/trillian/crypto/keys/envelope"
 	"github.com/google/trillian/crypto/keyspb"
 	"github.com/google/trillian/crypto/sigpb"
 	"github.com/google/trillian/extension"
+	"github.com/google/trillian/merkle/protohasher"
 	"github.com/google/trillian/storage"
 	"github.com/google/trillian/storage/testonly"
+	"github.com/google/trillian/types"
 	"github.com/kylelemons/godebug/pretty"
 	"google.golang.org/genproto/protobuf/field_mask"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 
+	tcrypto "github.com/google/trillian/crypto"
 	ttestonly "github.com/google/trillian/testonly"
 )
 
@@ -96,6 +107,13 @@ func TestServer_BeginError(t *testing.T) {
 				return err
 			},
 		},
+		{
+			desc: "RotateTreeKey",
+			fn: func(ctx context.Context, s *Server) error {
+				_, err := s.RotateTreeKey(ctx, &trillian.RotateTreeKeyRequest{TreeId: 12345, PrivateKey: validTree.PrivateKey})
+				return err
+			},
+		},
 	}
 
 	ctx := context.Background()
@@ -194,6 +212,137 @@ func TestServer_ListTrees(t *testing.T) {
 	}
 }
 
+func TestServer_ListTreesPaged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var trees []*trillian.Tree
+	for id := int64(1); id <= 3; id++ {
+		tree := proto.Clone(testonly.LogTree).(*trillian.Tree)
+		tree.TreeId = id
+		tree.Labels = map[string]string{"tenant": "llamas"}
+		trees = append(trees, tree)
+	}
+
+	tests := []struct {
+		desc      string
+		req       *trillian.ListTreesRequest
+		wantOpts  storage.ListTreesOptions
+		listTrees []*trillian.Tree
+		wantIDs   []int64
+		wantNext  bool
+	}{
+		{
+			desc:      "firstPage",
+			req:       &trillian.ListTreesRequest{PageSize: 2},
+			wantOpts:  storage.ListTreesOptions{Limit: 3},
+			listTrees: trees,
+			wantIDs:   []int64{1, 2},
+			wantNext:  true,
+		},
+		{
+			desc:      "lastPage",
+			req:       &trillian.ListTreesRequest{PageSize: 2, PageToken: encodePageToken(2)},
+			wantOpts:  storage.ListTreesOptions{AfterTreeID: 2, Limit: 3},
+			listTrees: trees[2:],
+			wantIDs:   []int64{3},
+		},
+		{
+			desc:      "exactPage",
+			req:       &trillian.ListTreesRequest{PageSize: 3},
+			wantOpts:  storage.ListTreesOptions{Limit: 4},
+			listTrees: trees,
+			wantIDs:   []int64{1, 2, 3},
+		},
+		{
+			desc:      "hugePage",
+			req:       &trillian.ListTreesRequest{PageSize: maxPageSize * 2},
+			wantOpts:  storage.ListTreesOptions{Limit: maxPageSize + 1},
+			listTrees: trees,
+			wantIDs:   []int64{1, 2, 3},
+		},
+		{
+			desc: "filters",
+			req: &trillian.ListTreesRequest{
+				ShowDeleted:   true,
+				TreeTypes:     []trillian.TreeType{trillian.TreeType_LOG},
+				TreeStates:    []trillian.TreeState{trillian.TreeState_ACTIVE},
+				LabelSelector: map[string]string{"tenant": "llamas"},
+			},
+			wantOpts: storage.ListTreesOptions{
+				IncludeDeleted: true,
+				TreeTypes:      []trillian.TreeType{trillian.TreeType_LOG},
+				TreeStates:     []trillian.TreeState{trillian.TreeState_ACTIVE},
+				Labels:         map[string]string{"tenant": "llamas"},
+			},
+			listTrees: trees,
+			wantIDs:   []int64{1, 2, 3},
+		},
+	}
+
+	ctx := context.Background()
+	for _, test := range tests {
+		setup := setupAdminServer(
+			ctrl,
+			nil,  /* keygen */
+			true, /* snapshot */
+			true, /* shouldCommit */
+			false /* commitErr */)
+
+		var listTrees []*trillian.Tree
+		for _, tree := range test.listTrees {
+			listTrees = append(listTrees, proto.Clone(tree).(*trillian.Tree))
+		}
+		tx := setup.snapshotTX
+		tx.EXPECT().ListTreesPage(gomock.Any(), test.wantOpts).Return(listTrees, nil)
+
+		resp, err := setup.server.ListTrees(ctx, test.req)
+		if err != nil {
+			t.Errorf("%v: ListTrees() returned err = %v", test.desc, err)
+			continue
+		}
+		var gotIDs []int64
+		for _, tree := range resp.Tree {
+			if tree.PrivateKey != nil {
+				t.Errorf("%v: ListTrees() returned tree %v with a private key", test.desc, tree.TreeId)
+			}
+			gotIDs = append(gotIDs, tree.TreeId)
+		}
+		if !reflect.DeepEqual(gotIDs, test.wantIDs) {
+			t.Errorf("%v: ListTrees() returned tree IDs %v, want %v", test.desc, gotIDs, test.wantIDs)
+		}
+		if gotNext := resp.NextPageToken != ""; gotNext != test.wantNext {
+			t.Errorf("%v: ListTrees() returned next_page_token %q, want next page = %v", test.desc, resp.NextPageToken, test.wantNext)
+		}
+		if test.wantNext {
+			if id, err := decodePageToken(resp.NextPageToken); err != nil || id != test.wantIDs[len(test.wantIDs)-1] {
+				t.Errorf("%v: decodePageToken(%q) = (%v, %v), want (%v, nil)", test.desc, resp.NextPageToken, id, err, test.wantIDs[len(test.wantIDs)-1])
+			}
+		}
+	}
+}
+
+func TestServer_ListTreesPagedErrors(t *testing.T) {
+	tests := []struct {
+		desc string
+		req  *trillian.ListTreesRequest
+	}{
+		{desc: "negativePageSize", req: &trillian.ListTreesRequest{PageSize: -1}},
+		{desc: "notBase64Token", req: &trillian.ListTreesRequest{PageToken: "!!"}},
+		{desc: "notNumberToken", req: &trillian.ListTreesRequest{PageToken: base64.RawURLEncoding.EncodeToString([]byte("llama"))}},
+		{desc: "negativeToken", req: &trillian.ListTreesRequest{PageToken: encodePageToken(-1)}},
+	}
+
+	ctx := context.Background()
+	s := &Server{}
+	for _, test := range tests {
+		_, err := s.ListTrees(ctx, test.req)
+		if got, want := status.Code(err), codes.InvalidArgument; got != want {
+			t.Errorf("%v: ListTrees() returned err = %v, want code %v", test.desc, err, want)
+		}
+	}
+}
+
 func TestServer_ListTreesErrors(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -337,6 +486,18 @@ func TestServer_CreateTree(t *testing.T) {
 	keySignatureMismatch := validTree
 	keySignatureMismatch.SignatureAlgorithm = sigpb.DigitallySigned_RSA
 
+	multiSigTree := validTree
+	multiSigTree.MultiSig = &trillian.MultiSigConfig{
+		Threshold: 1,
+		Signers:   []*trillian.TreeSigner{{PrivateKey: validTree.PrivateKey}},
+	}
+
+	multiSigInvalidKey := validTree
+	multiSigInvalidKey.MultiSig = &trillian.MultiSigConfig{
+		Threshold: 1,
+		Signers:   []*trillian.TreeSigner{{PrivateKey: &any.Any{TypeUrl: "urn://unknown-type"}}},
+	}
+
 	tests := []struct {
 		desc                  string
 		req                   *trillian.CreateTreeRequest
@@ -445,6 +606,16 @@ func TestServer_CreateTree(t *testing.T) {
 			req:     &trillian.CreateTreeRequest{Tree: &keySignatureMismatch},
 			wantErr: "signature not supported by signer",
 		},
+		{
+			desc:       "multiSigTree",
+			req:        &trillian.CreateTreeRequest{Tree: &multiSigTree},
+			wantCommit: true,
+		},
+		{
+			desc:    "multiSigInvalidKey",
+			req:     &trillian.CreateTreeRequest{Tree: &multiSigInvalidKey},
+			wantErr: "multi_sig signer 0",
+		},
 		{
 			desc:      "createErr",
 			req:       &trillian.CreateTreeRequest{Tree: &invalidTree},
@@ -525,6 +696,13 @@ func TestServer_CreateTree(t *testing.T) {
 			if err != nil {
 				t.Fatalf("failed to marshal test public key as protobuf: %v", err)
 			}
+			if ms := wantTree.MultiSig; ms != nil {
+				// All test signers share the tree's key.
+				wantTree.MultiSig = &trillian.MultiSigConfig{Threshold: ms.Threshold}
+				for range ms.Signers {
+					wantTree.MultiSig.Signers = append(wantTree.MultiSig.Signers, &trillian.TreeSigner{PublicKey: wantTree.PublicKey})
+				}
+			}
 			if diff := pretty.Compare(tree, &wantTree); diff != "" {
 				t.Fatalf("post-CreateTree diff (-got +want):\n%v", diff)
 			}
@@ -596,8 +774,8 @@ func TestServer_CreateTree_AllowedTreeTypes(t *testing.T) {
 	for _, test := range tests {
 		setup := setupAdminServer(
 			ctrl,
-			nil,   /* keygen */
-			false, /* snapshot */
+			nil,                       /* keygen */
+			false,                     /* snapshot */
 			test.wantCode == codes.OK, /* shouldCommit */
 			false /* commitErr */)
 		s := setup.server
@@ -618,6 +796,159 @@ func TestServer_CreateTree_AllowedTreeTypes(t *testing.T) {
 	}
 }
 
+// featureLimitedStorage is an AdminStorage that supports no multi_sig, key
+// rotation or scheduled transitions.
+type featureLimitedStorage struct {
+	storage.AdminStorage
+}
+
+func (s featureLimitedStorage) CheckTreeFeatures(tree *trillian.Tree) error {
+	if tree.MultiSig != nil || tree.KeyVersion != 0 || tree.ScheduledTransition != nil {
+		return status.Error(codes.Unimplemented, "feature not supported")
+	}
+	return nil
+}
+
+func TestServer_UnsupportedTreeFeatures(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	multiSigTree := *testonly.LogTree
+	multiSigTree.MultiSig = &trillian.MultiSigConfig{Threshold: 1}
+	transition := &trillian.ScheduledTransition{TargetState: trillian.TreeState_FROZEN, StartTime: ptypes.TimestampNow()}
+
+	ctx := context.Background()
+	for _, test := range []struct {
+		desc string
+		call func(s *Server) error
+	}{
+		{
+			desc: "createMultiSig",
+			call: func(s *Server) error {
+				_, err := s.CreateTree(ctx, &trillian.CreateTreeRequest{Tree: &multiSigTree})
+				return err
+			},
+		},
+		{
+			desc: "updateScheduledTransition",
+			call: func(s *Server) error {
+				_, err := s.UpdateTree(ctx, &trillian.UpdateTreeRequest{
+					Tree:       &trillian.Tree{TreeId: 12345, ScheduledTransition: transition},
+					UpdateMask: &field_mask.FieldMask{Paths: []string{"scheduled_transition"}},
+				})
+				return err
+			},
+		},
+		{
+			desc: "rotateKey",
+			call: func(s *Server) error {
+				_, err := s.RotateTreeKey(ctx, &trillian.RotateTreeKeyRequest{TreeId: 12345, PrivateKey: testonly.LogTree.PrivateKey})
+				return err
+			},
+		},
+	} {
+		// Storage mustn't be used, as the requests are rejected up front.
+		setup := setupAdminServer(ctrl, nil /* keygen */, false /* snapshot */, false /* shouldCommit */, false /* commitErr */)
+		s := setup.server
+		s.registry.AdminStorage = featureLimitedStorage{s.registry.AdminStorage}
+
+		if got, want := status.Code(test.call(s)), codes.Unimplemented; got != want {
+			t.Errorf("%v: got error code %v, want %v", test.desc, got, want)
+		}
+	}
+}
+
+// fakeKEKProvider is an envelope.KEKProvider that doesn't actually encrypt.
+type fakeKEKProvider struct{}
+
+func (fakeKEKProvider) WrapKey(ctx context.Context, dek []byte) (string, []byte, error) {
+	return "fake", dek, nil
+}
+
+func (fakeKEKProvider) UnwrapKey(ctx context.Context, kekID string, wrapped []byte) ([]byte, error) {
+	if kekID != "fake" {
+		return nil, fmt.Errorf("unknown KEK %q", kekID)
+	}
+	return wrapped, nil
+}
+
+// checkEncrypted checks that privateKey is keyDER encrypted with
+// fakeKEKProvider, and bound to publicKeyDER.
+func checkEncrypted(t *testing.T, desc string, privateKey *any.Any, keyDER, publicKeyDER []byte) {
+	t.Helper()
+	var encrypted keyspb.EncryptedPrivateKey
+	if err := ptypes.UnmarshalAny(privateKey, &encrypted); err != nil {
+		t.Errorf("%v: stored private key isn't an EncryptedPrivateKey: %v", desc, err)
+		return
+	}
+	decrypted, err := envelope.Decrypt(context.Background(), fakeKEKProvider{}, &encrypted, publicKeyDER)
+	if err != nil {
+		t.Errorf("%v: Decrypt(): %v", desc, err)
+		return
+	}
+	if !bytes.Equal(decrypted.Der, keyDER) {
+		t.Errorf("%v: stored private key decrypts to a different key", desc)
+	}
+}
+
+func TestServer_EncryptsPrivateKeys(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Error generating test ECDSA key: %v", err)
+	}
+	keyDER, err := der.MarshalPrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalPrivateKey(): %v", err)
+	}
+	publicKeyDER, err := der.MarshalPublicKey(key.Public())
+	if err != nil {
+		t.Fatalf("MarshalPublicKey(): %v", err)
+	}
+	// keyspb.PrivateKey is handled by the handler registered by der/proto.
+	keyProto := &keyspb.PrivateKey{Der: keyDER}
+
+	tree := proto.Clone(testonly.LogTree).(*trillian.Tree)
+	tree.PrivateKey = ttestonly.MustMarshalAny(t, keyProto)
+	tree.PublicKey = nil
+	tree.MultiSig = &trillian.MultiSigConfig{
+		Threshold: 1,
+		Signers:   []*trillian.TreeSigner{{PrivateKey: tree.PrivateKey}},
+	}
+
+	setup := setupAdminServer(ctrl, nil /* keygen */, false /* snapshot */, true /* shouldCommit */, false /* commitErr */)
+	setup.server.registry.KEKProvider = fakeKEKProvider{}
+	setup.tx.EXPECT().CreateTree(gomock.Any(), gomock.Any()).Do(func(ctx context.Context, tree *trillian.Tree) {
+		checkEncrypted(t, "CreateTree", tree.PrivateKey, keyDER, publicKeyDER)
+		checkEncrypted(t, "CreateTree multi_sig", tree.MultiSig.Signers[0].PrivateKey, keyDER, publicKeyDER)
+	}).Return(proto.Clone(tree).(*trillian.Tree), nil)
+	if _, err := setup.server.CreateTree(ctx, &trillian.CreateTreeRequest{Tree: tree}); err != nil {
+		t.Fatalf("CreateTree(): %v", err)
+	}
+
+	setup = setupAdminServer(ctrl, nil /* keygen */, false /* snapshot */, true /* shouldCommit */, false /* commitErr */)
+	setup.server.registry.KEKProvider = fakeKEKProvider{}
+	storedTree := proto.Clone(testonly.LogTree).(*trillian.Tree)
+	setup.tx.EXPECT().UpdateTree(gomock.Any(), storedTree.TreeId, gomock.Any()).Do(func(ctx context.Context, treeID int64, updateFn func(*trillian.Tree)) {
+		updateFn(storedTree)
+		checkEncrypted(t, "UpdateTree", storedTree.PrivateKey, keyDER, storedTree.PublicKey.Der)
+	}).Return(storedTree, nil)
+	req := &trillian.UpdateTreeRequest{
+		Tree:       &trillian.Tree{TreeId: storedTree.TreeId, PrivateKey: ttestonly.MustMarshalAny(t, keyProto)},
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"private_key"}},
+	}
+	if _, err := setup.server.UpdateTree(ctx, req); err != nil {
+		t.Fatalf("UpdateTree(): %v", err)
+	}
+	var reqKey keyspb.PrivateKey
+	if err := ptypes.UnmarshalAny(req.Tree.PrivateKey, &reqKey); err != nil {
+		t.Errorf("UpdateTree() modified the request's private key: %v", err)
+	}
+}
+
 func TestServer_UpdateTree(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -640,9 +971,10 @@ func TestServer_UpdateTree(t *testing.T) {
 		StorageSettings: settings,
 		MaxRootDuration: ptypes.DurationProto(2 * time.Nanosecond),
 		PrivateKey:      ttestonly.MustMarshalAny(t, &empty.Empty{}),
+		Labels:          map[string]string{"tenant": "llamas"},
 	}
 	successMask := &field_mask.FieldMask{
-		Paths: []string{"tree_state", "display_name", "description", "storage_settings", "max_root_duration", "private_key"},
+		Paths: []string{"tree_state", "display_name", "description", "storage_settings", "max_root_duration", "private_key", "labels"},
 	}
 
 	successWant := existingTree
@@ -652,6 +984,7 @@ func TestServer_UpdateTree(t *testing.T) {
 	successWant.StorageSettings = successTree.StorageSettings
 	successWant.PrivateKey = nil // redacted on responses
 	successWant.MaxRootDuration = successTree.MaxRootDuration
+	successWant.Labels = successTree.Labels
 
 	tests := []struct {
 		desc                           string
@@ -744,6 +1077,69 @@ func TestServer_UpdateTree(t *testing.T) {
 	}
 }
 
+func TestServer_UpdateTreeScheduledTransition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transition := &trillian.ScheduledTransition{
+		TargetState: trillian.TreeState_FROZEN,
+		StartTime:   ptypes.TimestampNow(),
+	}
+	mask := &field_mask.FieldMask{Paths: []string{"scheduled_transition"}}
+
+	tests := []struct {
+		desc                string
+		current, req        *trillian.ScheduledTransition
+		wantPhase           trillian.TreeTransitionStatus_Phase
+		wantStatusUnchanged bool
+	}{
+		{desc: "schedule", req: transition, wantPhase: trillian.TreeTransitionStatus_PENDING},
+		{desc: "reschedule", current: transition, req: transition, wantPhase: trillian.TreeTransitionStatus_PENDING},
+		{desc: "cancel", current: transition, wantPhase: trillian.TreeTransitionStatus_CANCELLED},
+		{desc: "clearUnset", wantStatusUnchanged: true},
+	}
+
+	ctx := context.Background()
+	for _, test := range tests {
+		setup := setupAdminServer(ctrl, nil /* keygen */, false /* snapshot */, true /* shouldCommit */, false /* commitErr */)
+
+		current := *testonly.LogTree
+		current.TreeId = 12345
+		current.ScheduledTransition = test.current
+		setup.tx.EXPECT().UpdateTree(gomock.Any(), current.TreeId, gomock.Any()).Do(func(ctx context.Context, treeID int64, updateFn func(*trillian.Tree)) {
+			updateFn(&current)
+		}).Return(&current, nil)
+
+		req := &trillian.UpdateTreeRequest{
+			Tree:       &trillian.Tree{TreeId: current.TreeId, ScheduledTransition: test.req},
+			UpdateMask: mask,
+		}
+		tree, err := setup.server.UpdateTree(ctx, req)
+		if err != nil {
+			t.Errorf("%v: UpdateTree() returned err = %v", test.desc, err)
+			continue
+		}
+		if !proto.Equal(tree.ScheduledTransition, test.req) {
+			t.Errorf("%v: UpdateTree().ScheduledTransition = %v, want %v", test.desc, tree.ScheduledTransition, test.req)
+		}
+		if test.wantStatusUnchanged {
+			if tree.TransitionStatus != nil {
+				t.Errorf("%v: UpdateTree().TransitionStatus = %v, want nil", test.desc, tree.TransitionStatus)
+			}
+			continue
+		}
+		if got, want := tree.TransitionStatus.GetPhase(), test.wantPhase; got != want {
+			t.Errorf("%v: UpdateTree().TransitionStatus.Phase = %v, want %v", test.desc, got, want)
+		}
+		if got, want := tree.TransitionStatus.GetTargetState(), transition.TargetState; got != want {
+			t.Errorf("%v: UpdateTree().TransitionStatus.TargetState = %v, want %v", test.desc, got, want)
+		}
+		if tree.TransitionStatus.GetUpdateTime() == nil {
+			t.Errorf("%v: UpdateTree().TransitionStatus.UpdateTime = nil, want non-nil", test.desc)
+		}
+	}
+}
+
 func TestServer_DeleteTree(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -793,6 +1189,163 @@ func TestServer_DeleteTree(t *testing.T) {
 	}
 }
 
+// failingAuditLog is an audit.Log whose Append always fails.
+type failingAuditLog struct {
+	audit.Log
+}
+
+func (failingAuditLog) Append(ctx context.Context, entry *trillian.TreeAuditEntry) error {
+	return errors.New("audit log unavailable")
+}
+
+func TestServer_AuditLog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storedTree := proto.Clone(testonly.LogTree).(*trillian.Tree)
+	storedTree.TreeId = 12345
+	storedTree.PrivateKey = ttestonly.MustMarshalAny(t, &keyspb.PrivateKey{Der: []byte("secret")})
+	auditLog := audit.NewMemoryLog()
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(192, 0, 2, 1), Port: 1234}})
+
+	newServer := func() (*Server, *storage.MockAdminTX) {
+		setup := setupAdminServer(
+			ctrl,
+			nil,   /* keygen */
+			false, /* snapshot */
+			true,  /* shouldCommit */
+			false /* commitErr */)
+		setup.server.registry.AuditLog = auditLog
+		return setup.server, setup.tx
+	}
+
+	// UpdateTree
+	s, tx := newServer()
+	tx.EXPECT().UpdateTree(gomock.Any(), storedTree.TreeId, gomock.Any()).Do(func(ctx context.Context, treeID int64, updateFn func(*trillian.Tree)) {
+		updateFn(storedTree)
+	}).Return(storedTree, nil)
+	if _, err := s.UpdateTree(ctx, &trillian.UpdateTreeRequest{
+		Tree:       &trillian.Tree{TreeId: storedTree.TreeId, TreeState: trillian.TreeState_FROZEN},
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"tree_state"}},
+	}); err != nil {
+		t.Fatalf("UpdateTree() returned err = %v", err)
+	}
+	frozenTree := proto.Clone(storedTree).(*trillian.Tree)
+
+	// DeleteTree
+	s, tx = newServer()
+	deletedTree := proto.Clone(frozenTree).(*trillian.Tree)
+	deletedTree.Deleted = true
+	tx.EXPECT().GetTree(gomock.Any(), storedTree.TreeId).Return(proto.Clone(frozenTree).(*trillian.Tree), nil)
+	tx.EXPECT().SoftDeleteTree(gomock.Any(), storedTree.TreeId).Return(proto.Clone(deletedTree).(*trillian.Tree), nil)
+	if _, err := s.DeleteTree(ctx, &trillian.DeleteTreeRequest{TreeId: storedTree.TreeId}); err != nil {
+		t.Fatalf("DeleteTree() returned err = %v", err)
+	}
+
+	// UndeleteTree
+	s, tx = newServer()
+	tx.EXPECT().GetTree(gomock.Any(), storedTree.TreeId).Return(proto.Clone(deletedTree).(*trillian.Tree), nil)
+	tx.EXPECT().UndeleteTree(gomock.Any(), storedTree.TreeId).Return(proto.Clone(frozenTree).(*trillian.Tree), nil)
+	if _, err := s.UndeleteTree(ctx, &trillian.UndeleteTreeRequest{TreeId: storedTree.TreeId}); err != nil {
+		t.Fatalf("UndeleteTree() returned err = %v", err)
+	}
+
+	resp, err := s.ListTreeAuditEntries(ctx, &trillian.ListTreeAuditEntriesRequest{TreeId: storedTree.TreeId})
+	if err != nil {
+		t.Fatalf("ListTreeAuditEntries() returned err = %v", err)
+	}
+	if resp.NextPageToken != "" {
+		t.Errorf("ListTreeAuditEntries().NextPageToken = %q, want none", resp.NextPageToken)
+	}
+	// Listing the entries in pages returns the same entries.
+	var paged []*trillian.TreeAuditEntry
+	for req := (&trillian.ListTreeAuditEntriesRequest{TreeId: storedTree.TreeId, PageSize: 2}); ; {
+		page, err := s.ListTreeAuditEntries(ctx, req)
+		if err != nil {
+			t.Fatalf("ListTreeAuditEntries(%v) returned err = %v", req, err)
+		}
+		paged = append(paged, page.Entries...)
+		if page.NextPageToken == "" {
+			break
+		}
+		req.PageToken = page.NextPageToken
+	}
+	if !reflect.DeepEqual(paged, resp.Entries) {
+		t.Errorf("paged ListTreeAuditEntries() = %v, want %v", paged, resp.Entries)
+	}
+	wantStates := []struct {
+		method        string
+		before, after trillian.TreeState
+		deleted       bool
+	}{
+		{method: "UpdateTree", before: trillian.TreeState_ACTIVE, after: trillian.TreeState_FROZEN},
+		{method: "DeleteTree", before: trillian.TreeState_FROZEN, after: trillian.TreeState_FROZEN, deleted: true},
+		{method: "UndeleteTree", before: trillian.TreeState_FROZEN, after: trillian.TreeState_FROZEN},
+	}
+	if got, want := len(resp.Entries), len(wantStates); got != want {
+		t.Fatalf("ListTreeAuditEntries() returned %v entries, want %v", got, want)
+	}
+	for i, want := range wantStates {
+		entry := resp.Entries[i]
+		if entry.Method != want.method || entry.TreeId != storedTree.TreeId || entry.Principal != "192.0.2.1:1234" || entry.Timestamp == nil {
+			t.Errorf("entry %v = {method: %q, tree_id: %v, principal: %q, timestamp: %v}, want {%q, %v, %q, non-nil}",
+				i, entry.Method, entry.TreeId, entry.Principal, entry.Timestamp, want.method, storedTree.TreeId, "192.0.2.1:1234")
+		}
+		if entry.Before.GetTreeState() != want.before || entry.After.GetTreeState() != want.after {
+			t.Errorf("entry %v: tree_state %v -> %v, want %v -> %v", i, entry.Before.GetTreeState(), entry.After.GetTreeState(), want.before, want.after)
+		}
+		if entry.After.GetDeleted() != want.deleted {
+			t.Errorf("entry %v: after.deleted = %v, want %v", i, entry.After.GetDeleted(), want.deleted)
+		}
+		if entry.Before.GetPrivateKey() != nil || entry.After.GetPrivateKey() != nil {
+			t.Errorf("entry %v: private key recorded in the audit log", i)
+		}
+	}
+}
+
+func TestServer_AuditLogErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	tree := proto.Clone(testonly.LogTree).(*trillian.Tree)
+	tree.TreeId = 12345
+
+	setup := setupAdminServer(
+		ctrl,
+		nil,   /* keygen */
+		false, /* snapshot */
+		true,  /* shouldCommit */
+		false /* commitErr */)
+	setup.server.registry.AuditLog = failingAuditLog{}
+	setup.tx.EXPECT().GetTree(gomock.Any(), tree.TreeId).Return(tree, nil)
+	setup.tx.EXPECT().SoftDeleteTree(gomock.Any(), tree.TreeId).Return(tree, nil)
+	// The tree is deleted, so the failure to record it mustn't fail the call.
+	_, err := setup.server.DeleteTree(ctx, &trillian.DeleteTreeRequest{TreeId: tree.TreeId})
+	if err != nil {
+		t.Errorf("DeleteTree() with failing audit log returned err = %v", err)
+	}
+	if got, want := auditAppendErrors.Value("DeleteTree"), 1.0; got != want {
+		t.Errorf("audit_log_append_errors{method=DeleteTree} = %v, want %v", got, want)
+	}
+
+	for _, req := range []*trillian.ListTreeAuditEntriesRequest{
+		{TreeId: tree.TreeId, PageSize: -1},
+		{TreeId: tree.TreeId, PageToken: "not a token"},
+	} {
+		_, err = setup.server.ListTreeAuditEntries(ctx, req)
+		if got, want := status.Code(err), codes.InvalidArgument; got != want {
+			t.Errorf("ListTreeAuditEntries(%v) returned err = %v, want code %v", req, err, want)
+		}
+	}
+
+	s := &Server{}
+	_, err = s.ListTreeAuditEntries(ctx, &trillian.ListTreeAuditEntriesRequest{TreeId: tree.TreeId})
+	if got, want := status.Code(err), codes.FailedPrecondition; got != want {
+		t.Errorf("ListTreeAuditEntries() without audit log returned err = %v, want code %v", err, want)
+	}
+}
+
 func TestServer_DeleteTreeErrors(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -810,8 +1363,8 @@ func TestServer_DeleteTreeErrors(t *testing.T) {
 	for _, test := range tests {
 		setup := setupAdminServer(
 			ctrl,
-			nil,   /* keygen */
-			false, /* snapshot */
+			nil,                   /* keygen */
+			false,                 /* snapshot */
 			test.deleteErr == nil, /* shouldCommit */
 			test.commitErr /* commitErr */)
 		req := &trillian.DeleteTreeRequest{TreeId: 10}
@@ -897,8 +1450,8 @@ func TestServer_UndeleteTreeErrors(t *testing.T) {
 	for _, test := range tests {
 		setup := setupAdminServer(
 			ctrl,
-			nil,   /* keygen */
-			false, /* snapshot */
+			nil,                     /* keygen */
+			false,                   /* snapshot */
 			test.undeleteErr == nil, /* shouldCommit */
 			test.commitErr /* commitErr */)
 		req := &trillian.UndeleteTreeRequest{TreeId: 10}
@@ -923,6 +1476,359 @@ type adminTestSetup struct {
 	server     *Server
 }
 
+func TestServer_RotateTreeKey(t *testing.T) {
+	ecdsaPrivateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Error generating test ECDSA key: %v", err)
+	}
+	rsaPrivateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Error generating test RSA key: %v", err)
+	}
+	oldPublicKey, err := der.UnmarshalPublicKey(testonly.LogTree.PublicKey.GetDer())
+	if err != nil {
+		t.Fatalf("Error unmarshaling test public key: %v", err)
+	}
+
+	// A keys.ProtoHandler will be registered that returns the new key when
+	// passed an empty proto.
+	wantKeyProto := &empty.Empty{}
+	newKey := ttestonly.MustMarshalAny(t, wantKeyProto)
+	keySpec := &keyspb.Specification{Params: &keyspb.Specification_EcdsaParams{}}
+	hour := ptypes.DurationProto(time.Hour)
+
+	tests := []struct {
+		desc                  string
+		req                   *trillian.RotateTreeKeyRequest
+		newKey                crypto.Signer
+		wantKeyGenerator      bool
+		updateErr             error
+		commitErr, wantCommit bool
+		wantErr               string
+	}{
+		{
+			desc:       "privateKey",
+			req:        &trillian.RotateTreeKeyRequest{PrivateKey: newKey, TransitionPeriod: hour},
+			newKey:     ecdsaPrivateKey,
+			wantCommit: true,
+		},
+		{
+			desc:             "keySpec",
+			req:              &trillian.RotateTreeKeyRequest{KeySpec: keySpec},
+			newKey:           ecdsaPrivateKey,
+			wantKeyGenerator: true,
+			wantCommit:       true,
+		},
+		{
+			desc:    "noKey",
+			req:     &trillian.RotateTreeKeyRequest{},
+			wantErr: "private_key or key_spec is required",
+		},
+		{
+			desc:             "keySpecAndPrivateKey",
+			req:              &trillian.RotateTreeKeyRequest{PrivateKey: newKey, KeySpec: keySpec},
+			wantKeyGenerator: true,
+			wantErr:          "mutually exclusive",
+		},
+		{
+			desc:    "keySpecButNoKeyGenerator",
+			req:     &trillian.RotateTreeKeyRequest{KeySpec: keySpec},
+			wantErr: "key generation is not enabled",
+		},
+		{
+			desc:    "negativeTransitionPeriod",
+			req:     &trillian.RotateTreeKeyRequest{PrivateKey: newKey, TransitionPeriod: ptypes.DurationProto(-time.Hour)},
+			wantErr: "transition_period negative",
+		},
+		{
+			desc:    "keySignatureMismatch",
+			req:     &trillian.RotateTreeKeyRequest{PrivateKey: newKey},
+			newKey:  rsaPrivateKey,
+			wantErr: "signature not supported by signer",
+		},
+		{
+			desc:      "updateErr",
+			req:       &trillian.RotateTreeKeyRequest{PrivateKey: newKey},
+			newKey:    ecdsaPrivateKey,
+			updateErr: errors.New("storage UpdateTree failed"),
+			wantErr:   "storage UpdateTree failed",
+		},
+		{
+			desc:       "commitErr",
+			req:        &trillian.RotateTreeKeyRequest{PrivateKey: newKey},
+			newKey:     ecdsaPrivateKey,
+			commitErr:  true,
+			wantCommit: true,
+			wantErr:    "commit error",
+		},
+	}
+
+	ctx := context.Background()
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			var keygen keys.ProtoGenerator
+			if test.wantKeyGenerator {
+				keygen = fakeKeyProtoGenerator(test.req.GetKeySpec(), wantKeyProto)
+			}
+			keys.RegisterHandler(fakeKeyProtoHandler(wantKeyProto, test.newKey))
+			defer keys.UnregisterHandler(wantKeyProto)
+
+			setup := setupAdminServer(ctrl, keygen, false /* snapshot */, test.wantCommit, test.commitErr)
+			tx := setup.tx
+			s := setup.server
+
+			existingTree := proto.Clone(testonly.LogTree).(*trillian.Tree)
+			existingTree.TreeId = 12345
+			existingTree.CreateTime = ptypes.TimestampNow()
+			existingTree.UpdateTime = existingTree.CreateTime
+			currentTree := proto.Clone(existingTree).(*trillian.Tree)
+
+			tx.EXPECT().GetTree(gomock.Any(), existingTree.TreeId).MaxTimes(1).Return(currentTree, nil)
+			tx.EXPECT().UpdateTree(gomock.Any(), existingTree.TreeId, gomock.Any()).MaxTimes(1).Do(func(ctx context.Context, treeID int64, updateFn func(*trillian.Tree)) {
+				// The storage layer validates the update, but since we're mocking it we have to do so ourselves.
+				updateFn(currentTree)
+				if err := storage.ValidateTreeForUpdate(ctx, existingTree, currentTree); err != nil {
+					t.Errorf("ValidateTreeForUpdate(): %v", err)
+				}
+			}).Return(currentTree, test.updateErr)
+
+			req := proto.Clone(test.req).(*trillian.RotateTreeKeyRequest)
+			req.TreeId = existingTree.TreeId
+			start := time.Now()
+			tree, err := s.RotateTreeKey(ctx, req)
+			switch gotErr := err != nil; {
+			case gotErr && !strings.Contains(err.Error(), test.wantErr):
+				t.Fatalf("RotateTreeKey() = (_, %q), want (_, %q)", err, test.wantErr)
+			case gotErr:
+				return
+			case test.wantErr != "":
+				t.Fatalf("RotateTreeKey() = (_, nil), want (_, %q)", test.wantErr)
+			}
+
+			wantPublicKey, err := der.ToPublicProto(test.newKey.Public())
+			if err != nil {
+				t.Fatalf("failed to marshal test public key as protobuf: %v", err)
+			}
+			switch {
+			case tree.PrivateKey != nil:
+				t.Error("RotateTreeKey() returned private_key, want redacted")
+			case tree.KeyVersion != 1:
+				t.Errorf("RotateTreeKey(): key_version = %v, want 1", tree.KeyVersion)
+			case !proto.Equal(tree.PublicKey, wantPublicKey):
+				t.Errorf("RotateTreeKey(): public_key = %v, want %v", tree.PublicKey, wantPublicKey)
+			case len(tree.PreviousKeys) != 1:
+				t.Fatalf("RotateTreeKey(): %v previous_keys, want 1", len(tree.PreviousKeys))
+			}
+
+			replaced := tree.PreviousKeys[0]
+			if got, want := replaced.KeyVersion, existingTree.KeyVersion; got != want {
+				t.Errorf("replaced key_version = %v, want %v", got, want)
+			}
+			if got, want := replaced.PublicKey, existingTree.PublicKey; !proto.Equal(got, want) {
+				t.Errorf("replaced public_key = %v, want %v", got, want)
+			}
+			retireTime, err := ptypes.Timestamp(replaced.RetireTime)
+			if err != nil {
+				t.Fatalf("replaced retire_time: %v", err)
+			}
+			var period time.Duration
+			if test.req.TransitionPeriod != nil {
+				period = time.Hour
+			}
+			if retireTime.Before(start.Add(period)) || retireTime.After(time.Now().Add(period)) {
+				t.Errorf("replaced retire_time = %v, want %v after %v", retireTime, period, start)
+			}
+
+			// The replaced key must vouch for the new one.
+			rotation, err := tcrypto.VerifyKeyRotation(oldPublicKey, crypto.SHA256, replaced.Rotation, replaced.RotationSignature)
+			if err != nil {
+				t.Fatalf("VerifyKeyRotation(): %v", err)
+			}
+			wantRotation := &types.KeyRotationV1{
+				TreeID:         uint64(existingTree.TreeId),
+				KeyVersion:     1,
+				PublicKey:      wantPublicKey.Der,
+				TimestampNanos: rotation.TimestampNanos,
+			}
+			if diff := pretty.Compare(rotation, wantRotation); diff != "" {
+				t.Errorf("rotation statement diff (-got +want):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestServer_RedactLeaf(t *testing.T) {
+	// leafValue encodes {1: 150, 2: "hi"}.
+	leafValue := []byte{0x08, 0x96, 0x01, 0x12, 0x02, 'h', 'i'}
+	hasher := protohasher.New(crypto.SHA256)
+	leafHash, err := hasher.HashLeaf(leafValue)
+	if err != nil {
+		t.Fatalf("HashLeaf(): %v", err)
+	}
+	redactedValue, err := hasher.RedactLeaf(leafValue, []int32{2})
+	if err != nil {
+		t.Fatalf("RedactLeaf(): %v", err)
+	}
+
+	protoTree := proto.Clone(testonly.LogTree).(*trillian.Tree)
+	protoTree.TreeId = 12345
+	protoTree.HashStrategy = trillian.HashStrategy_PROTO_RFC6962_SHA256
+	rfc6962Tree := proto.Clone(protoTree).(*trillian.Tree)
+	rfc6962Tree.HashStrategy = trillian.HashStrategy_RFC6962_SHA256
+
+	tests := []struct {
+		desc                 string
+		tree                 *trillian.Tree
+		req                  *trillian.RedactLeafRequest
+		leafHash             []byte
+		noLeaf               bool
+		wantValue, wantExtra []byte
+		wantUpdate           bool
+		updateErr            error
+		wantCode             codes.Code
+	}{
+		{
+			desc:       "redactField",
+			tree:       protoTree,
+			req:        &trillian.RedactLeafRequest{RedactedFields: []int32{2}},
+			wantValue:  redactedValue,
+			wantExtra:  []byte("extra"),
+			wantUpdate: true,
+		},
+		{
+			desc:       "redactFieldAndExtraData",
+			tree:       protoTree,
+			req:        &trillian.RedactLeafRequest{RedactedFields: []int32{2}, ClearExtraData: true},
+			wantValue:  redactedValue,
+			wantUpdate: true,
+		},
+		{
+			desc:       "clearExtraDataOnly",
+			tree:       rfc6962Tree,
+			req:        &trillian.RedactLeafRequest{ClearExtraData: true},
+			wantValue:  leafValue,
+			wantUpdate: true,
+		},
+		{
+			desc:     "nothingToRedact",
+			tree:     protoTree,
+			req:      &trillian.RedactLeafRequest{},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			desc:     "unsupportedHashStrategy",
+			tree:     rfc6962Tree,
+			req:      &trillian.RedactLeafRequest{RedactedFields: []int32{2}},
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			desc:     "leafNotFound",
+			tree:     protoTree,
+			req:      &trillian.RedactLeafRequest{RedactedFields: []int32{2}},
+			noLeaf:   true,
+			wantCode: codes.NotFound,
+		},
+		{
+			desc:     "hashMismatch",
+			tree:     protoTree,
+			req:      &trillian.RedactLeafRequest{RedactedFields: []int32{2}},
+			leafHash: []byte("not the leaf hash"),
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			desc:       "updateErr",
+			tree:       protoTree,
+			req:        &trillian.RedactLeafRequest{RedactedFields: []int32{2}},
+			wantValue:  redactedValue,
+			wantExtra:  []byte("extra"),
+			wantUpdate: true,
+			updateErr:  status.Errorf(codes.Unavailable, "UpdateLeafData() failed"),
+			wantCode:   codes.Unavailable,
+		},
+	}
+
+	ctx := context.Background()
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			setup := setupAdminServer(ctrl, nil /* keygen */, true /* snapshot */, test.req.GetRedactedFields() != nil || test.req.GetClearExtraData(), false /* commitErr */)
+			setup.snapshotTX.EXPECT().GetTree(gomock.Any(), test.tree.TreeId).MaxTimes(1).Return(test.tree, nil)
+			logTX := storage.NewMockLogTreeTX(ctrl)
+			setup.server.registry.LogStorage = &fakeLogStorage{tx: logTX}
+			auditLog := audit.NewMemoryLog()
+			setup.server.registry.AuditLog = auditLog
+
+			leaf := &trillian.LogLeaf{
+				LeafIndex:      7,
+				LeafValue:      leafValue,
+				ExtraData:      []byte("extra"),
+				MerkleLeafHash: leafHash,
+			}
+			if test.leafHash != nil {
+				leaf.MerkleLeafHash = test.leafHash
+			}
+			leaves := []*trillian.LogLeaf{leaf}
+			if test.noLeaf {
+				leaves = nil
+			}
+			logTX.EXPECT().GetLeavesByIndex(gomock.Any(), []int64{7}).MaxTimes(1).Return(leaves, nil)
+			if test.wantUpdate {
+				logTX.EXPECT().UpdateLeafData(gomock.Any(), int64(7), test.wantValue, test.wantExtra).Return(test.updateErr)
+			}
+
+			req := proto.Clone(test.req).(*trillian.RedactLeafRequest)
+			req.LogId = test.tree.TreeId
+			req.LeafIndex = 7
+			got, err := setup.server.RedactLeaf(ctx, req)
+			if gotCode := status.Code(err); gotCode != test.wantCode {
+				t.Fatalf("RedactLeaf() = (_, %v), want code %v", err, test.wantCode)
+			}
+			if err != nil {
+				return
+			}
+
+			want := &trillian.LogLeaf{
+				LeafIndex:      7,
+				LeafValue:      test.wantValue,
+				ExtraData:      test.wantExtra,
+				MerkleLeafHash: leafHash,
+			}
+			if !proto.Equal(got, want) {
+				t.Errorf("RedactLeaf() = %v, want %v", got, want)
+			}
+
+			entries, _, err := auditLog.List(ctx, test.tree.TreeId, 0, 0)
+			if err != nil {
+				t.Fatalf("List(): %v", err)
+			}
+			if got, want := len(entries), 1; got != want {
+				t.Fatalf("RedactLeaf() recorded %v audit entries, want %v", got, want)
+			}
+			if got, want := entries[0].Method, "RedactLeaf"; got != want {
+				t.Errorf("audit entry method = %q, want %q", got, want)
+			}
+			if !proto.Equal(entries[0].Redaction, req) {
+				t.Errorf("audit entry redaction = %v, want %v", entries[0].Redaction, req)
+			}
+		})
+	}
+}
+
+// fakeLogStorage runs read-write transactions with tx.
+type fakeLogStorage struct {
+	storage.LogStorage
+	tx storage.LogTreeTX
+}
+
+func (s *fakeLogStorage) ReadWriteTransaction(ctx context.Context, tree *trillian.Tree, f storage.LogTXFunc) error {
+	return f(ctx, s.tx)
+}
+
 // setupAdminServer configures mocks according to input parameters.
 // Storage will be set to use either snapshots or regular TXs via snapshot parameter.
 // Whether the snapshot/TX is expected to be committed (and if it should error doing so) is
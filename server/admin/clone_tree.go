@@ -0,0 +1,388 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/google/trillian"
+	"github.com/google/trillian/crypto/keys/der"
+	"github.com/google/trillian/log"
+	"github.com/google/trillian/merkle"
+	"github.com/google/trillian/merkle/hashers"
+	"github.com/google/trillian/quota"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/trees"
+	"github.com/google/trillian/types"
+	"github.com/google/trillian/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// cloneBatchSize is the number of leaves copied into a clone per
+	// transaction.
+	cloneBatchSize = 1000
+
+	// proofMaxBitLen is the maximum bit length of the node IDs of proofs.
+	proofMaxBitLen = 64
+)
+
+// CloneTree implements trillian.TrillianAdminServer.CloneTree.
+// The clone is a PREORDERED_LOG, whose leaves are copied from the source log
+// and then integrated by a sequencer, so that its subtree nodes and root are
+// rebuilt from the same leaf hashes. The clone is FROZEN, and so ignored by
+// log signers, until it's complete. If it can't be completed, or doesn't
+// match the source, it's soft-deleted.
+// A clone that shares the source's key is always left FROZEN, as an ACTIVE
+// clone could sign roots conflicting with the source's under the same key.
+func (s *Server) CloneTree(ctx context.Context, req *trillian.CloneTreeRequest) (*trillian.Tree, error) {
+	if s.registry.LogStorage == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "log storage is not configured")
+	}
+	if req.GetTreeSize() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid tree_size: %v", req.GetTreeSize())
+	}
+	if req.GetKeySpec() == nil && !req.GetFreeze() {
+		return nil, status.Errorf(codes.InvalidArgument, "key_spec is required unless freeze is set, as the clone would otherwise sign with the source's key")
+	}
+	if err := s.validateAllowedTreeType(trillian.TreeType_PREORDERED_LOG); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	opts := trees.NewGetOpts(trees.Query, trillian.TreeType_LOG, trillian.TreeType_PREORDERED_LOG)
+	source, err := trees.GetTree(ctx, s.registry.AdminStorage, req.GetTreeId(), opts)
+	if err != nil {
+		return nil, err
+	}
+	// Storage may return a shared copy of the tree.
+	source = proto.Clone(source).(*trillian.Tree)
+	hasher, err := hashers.NewLogHasher(source.HashStrategy)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "failed to create hasher for tree: %v", err.Error())
+	}
+
+	sourceRoot, err := s.latestLogRoot(ctx, source)
+	if err == storage.ErrTreeNeedsInit {
+		return nil, status.Errorf(codes.FailedPrecondition, "log %v is not initialised", source.TreeId)
+	} else if err != nil {
+		return nil, err
+	}
+	size := req.GetTreeSize()
+	if size == 0 {
+		size = int64(sourceRoot.TreeSize)
+	} else if size > int64(sourceRoot.TreeSize) {
+		return nil, status.Errorf(codes.InvalidArgument, "tree_size %v exceeds the size of the latest signed root (%v)", size, sourceRoot.TreeSize)
+	}
+
+	clone, err := s.newClone(ctx, source, req)
+	if err != nil {
+		return nil, err
+	}
+	clone, err = storage.CreateTree(ctx, s.registry.AdminStorage, clone)
+	if err != nil {
+		return nil, err
+	}
+
+	built, err := s.buildClone(ctx, source, clone, hasher, size, sourceRoot)
+	if err != nil {
+		glog.Warningf("%v: failed to clone log %v: %v", clone.TreeId, source.TreeId, err)
+		// ctx may have expired, which mustn't stop the clean-up.
+		if _, delErr := storage.SoftDeleteTree(context.Background(), s.registry.AdminStorage, clone.TreeId); delErr != nil {
+			glog.Errorf("%v: failed to delete incomplete clone: %v", clone.TreeId, delErr)
+		}
+		return nil, err
+	}
+	clone = built
+
+	if !req.GetFreeze() {
+		clone, err = storage.UpdateTree(ctx, s.registry.AdminStorage, clone.TreeId, func(tree *trillian.Tree) {
+			tree.TreeState = trillian.TreeState_ACTIVE
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s.recordChange(ctx, "CloneTree", nil /* before */, clone)
+	return redact(proto.Clone(clone).(*trillian.Tree)), nil
+}
+
+// newClone returns the configuration of a clone of source, to be created in
+// storage. The clone is signed by the source's key unless req has a key_spec,
+// so CloneTree only allows the former for frozen clones.
+func (s *Server) newClone(ctx context.Context, source *trillian.Tree, req *trillian.CloneTreeRequest) (*trillian.Tree, error) {
+	clone := proto.Clone(source).(*trillian.Tree)
+	clone.TreeType = trillian.TreeType_PREORDERED_LOG
+	clone.TreeState = trillian.TreeState_ACTIVE
+
+	// Clear generated fields, storage must set those
+	clone.TreeId = 0
+	clone.CreateTime = nil
+	clone.UpdateTime = nil
+	clone.Deleted = false
+	clone.DeleteTime = nil
+	clone.KeyVersion = 0
+	clone.PreviousKeys = nil
+	clone.ScheduledTransition = nil
+	clone.TransitionStatus = nil
+
+	if req.KeySpec == nil {
+		return clone, nil
+	}
+	if s.registry.NewKeyProto == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "key generation is not enabled")
+	}
+	keyProto, err := s.registry.NewKeyProto(ctx, req.KeySpec)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to generate private key: %v", err.Error())
+	}
+	if clone.PrivateKey, err = ptypes.MarshalAny(keyProto); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal private key: %v", err.Error())
+	}
+	signer, err := trees.Signer(ctx, clone)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to create signer for new key: %v", err.Error())
+	}
+	if clone.PublicKey, err = der.ToPublicProto(signer.Public()); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to marshal public key: %v", err.Error())
+	}
+	if clone.PrivateKey, err = s.encryptPrivateKey(ctx, clone.PrivateKey, clone.PublicKey); err != nil {
+		return nil, err
+	}
+	return clone, nil
+}
+
+// buildClone freezes clone, copies the first size leaves of source into it,
+// and checks that the clone's root matches sourceRoot, or is consistent with
+// it if the clone is smaller. The frozen clone is returned.
+func (s *Server) buildClone(ctx context.Context, source, clone *trillian.Tree, hasher hashers.LogHasher, size int64, sourceRoot *types.LogRootV1) (*trillian.Tree, error) {
+	// The clone has no root until copyLog initialises it, so log signers
+	// can't integrate leaves into it before it's frozen.
+	clone, err := storage.UpdateTree(ctx, s.registry.AdminStorage, clone.TreeId, func(tree *trillian.Tree) {
+		tree.TreeState = trillian.TreeState_FROZEN
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.copyLog(ctx, source, clone, hasher, size); err != nil {
+		return nil, err
+	}
+
+	cloneRoot, err := s.latestLogRoot(ctx, clone)
+	if err != nil {
+		return nil, err
+	}
+	if got := int64(cloneRoot.TreeSize); got != size {
+		return nil, status.Errorf(codes.Internal, "clone %v has size %v, want %v", clone.TreeId, got, size)
+	}
+	switch {
+	case size == int64(sourceRoot.TreeSize):
+		if !bytes.Equal(cloneRoot.RootHash, sourceRoot.RootHash) {
+			return nil, status.Errorf(codes.Internal, "clone %v has root hash %x, want %x", clone.TreeId, cloneRoot.RootHash, sourceRoot.RootHash)
+		}
+	case size > 0:
+		// There's no signed source root of this size to compare with, so
+		// check that the source's root extends the clone's.
+		proof, err := s.consistencyProof(ctx, source, hasher, size, int64(sourceRoot.TreeSize))
+		if err != nil {
+			return nil, err
+		}
+		if err := merkle.NewLogVerifier(hasher).VerifyConsistencyProof(size, int64(sourceRoot.TreeSize), cloneRoot.RootHash, sourceRoot.RootHash, proof); err != nil {
+			return nil, status.Errorf(codes.Internal, "clone %v is inconsistent with the source's root: %v", clone.TreeId, err)
+		}
+	}
+	return clone, nil
+}
+
+// consistencyProof returns a proof that the root of tree at size second
+// extends its root at size first, read from the tree's nodes as of its
+// latest root.
+func (s *Server) consistencyProof(ctx context.Context, tree *trillian.Tree, hasher hashers.LogHasher, first, second int64) ([][]byte, error) {
+	tx, err := s.registry.LogStorage.SnapshotForTree(ctx, tree)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Close()
+	slr, err := tx.LatestSignedLogRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var root types.LogRootV1
+	if err := root.UnmarshalBinary(slr.LogRoot); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to parse latest root: %v", err)
+	}
+	fetches, err := merkle.CalcConsistencyProofNodeAddresses(first, second, int64(root.TreeSize), proofMaxBitLen)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]storage.NodeID, 0, len(fetches))
+	for _, fetch := range fetches {
+		ids = append(ids, fetch.NodeID)
+	}
+	nodes, err := tx.GetMerkleNodes(ctx, tx.ReadRevision(), ids)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	if got, want := len(nodes), len(ids); got != want {
+		return nil, status.Errorf(codes.Internal, "read %v proof nodes, want %v", got, want)
+	}
+
+	// Runs of nodes marked for rehashing are combined into a single proof
+	// node, as they are when the log server serves proofs.
+	var proof [][]byte
+	var rehashed []byte
+	rehashing := false
+	for i, node := range nodes {
+		if !node.NodeID.Equivalent(fetches[i].NodeID) {
+			return nil, status.Errorf(codes.Internal, "read proof node %v, want %v", node.NodeID, fetches[i].NodeID)
+		}
+		switch {
+		case fetches[i].Rehash && rehashing:
+			rehashed = hasher.HashChildren(node.Hash, rehashed)
+		case fetches[i].Rehash:
+			rehashed, rehashing = node.Hash, true
+		default:
+			if rehashing {
+				proof, rehashing = append(proof, rehashed), false
+			}
+			proof = append(proof, node.Hash)
+		}
+	}
+	if rehashing {
+		proof = append(proof, rehashed)
+	}
+	return proof, nil
+}
+
+// copyLog initialises clone, and copies the first size leaves of source into
+// it in batches of cloneBatchSize, integrating each batch as it's copied.
+func (s *Server) copyLog(ctx context.Context, source, clone *trillian.Tree, hasher hashers.LogHasher, size int64) error {
+	signer, err := trees.Signer(ctx, clone)
+	if err != nil {
+		return status.Errorf(codes.FailedPrecondition, "failed to create signer for clone: %v", err.Error())
+	}
+	timeSource := util.SystemTimeSource{}
+
+	err = s.registry.LogStorage.ReadWriteTransaction(ctx, clone, func(ctx context.Context, tx storage.LogTreeTX) error {
+		root, err := signer.SignLogRoot(&types.LogRootV1{
+			RootHash:       hasher.EmptyRoot(),
+			TimestampNanos: uint64(timeSource.Now().UnixNano()),
+		})
+		if err != nil {
+			return err
+		}
+		return tx.StoreSignedLogRoot(ctx, *root)
+	})
+	if err != nil && err != storage.ErrTreeNeedsInit {
+		return err
+	}
+
+	// Nothing is queued, so there's no quota to return.
+	sequencer := log.NewSequencer(hasher, timeSource, s.registry.LogStorage, signer, s.registry.MetricFactory, quota.Noop())
+	for start := int64(0); start < size; start += cloneBatchSize {
+		count := size - start
+		if count > cloneBatchSize {
+			count = cloneBatchSize
+		}
+		leaves, err := s.readLeaves(ctx, source, start, count)
+		if err != nil {
+			return err
+		}
+
+		added, err := s.registry.LogStorage.AddSequencedLeaves(ctx, clone, leaves, timeSource.Now())
+		if err != nil {
+			return err
+		}
+		for i, a := range added {
+			if code := codes.Code(a.GetStatus().GetCode()); code != codes.OK {
+				return status.Errorf(codes.Internal, "failed to copy leaf %v: %v (%v)", leaves[i].LeafIndex, code, a.GetStatus().GetMessage())
+			}
+		}
+
+		integrated, err := sequencer.IntegrateBatch(ctx, clone, int(count), 0 /* guardWindow */, 0 /* maxRootDuration */)
+		if err != nil {
+			return err
+		}
+		if integrated != int(count) {
+			return status.Errorf(codes.Internal, "integrated %v leaves from %v, want %v", integrated, start, count)
+		}
+	}
+	return nil
+}
+
+// readLeaves returns copies of the count leaves of tree starting at start,
+// suitable for adding to a clone.
+func (s *Server) readLeaves(ctx context.Context, tree *trillian.Tree, start, count int64) ([]*trillian.LogLeaf, error) {
+	tx, err := s.registry.LogStorage.SnapshotForTree(ctx, tree)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Close()
+	leaves, err := tx.GetLeavesByRange(ctx, start, count)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	if got := int64(len(leaves)); got != count {
+		return nil, status.Errorf(codes.Internal, "read %v leaves from %v, want %v", got, start, count)
+	}
+
+	ret := make([]*trillian.LogLeaf, 0, len(leaves))
+	for i, leaf := range leaves {
+		if want := start + int64(i); leaf.LeafIndex != want {
+			return nil, status.Errorf(codes.Internal, "read leaf %v, want %v", leaf.LeafIndex, want)
+		}
+		ret = append(ret, &trillian.LogLeaf{
+			LeafIndex:        leaf.LeafIndex,
+			MerkleLeafHash:   leaf.MerkleLeafHash,
+			LeafIdentityHash: leaf.LeafIdentityHash,
+			LeafValue:        leaf.LeafValue,
+			ExtraData:        leaf.ExtraData,
+		})
+	}
+	return ret, nil
+}
+
+// latestLogRoot returns the latest root of tree. storage.ErrTreeNeedsInit is
+// returned if tree has no root.
+func (s *Server) latestLogRoot(ctx context.Context, tree *trillian.Tree) (*types.LogRootV1, error) {
+	tx, err := s.registry.LogStorage.SnapshotForTree(ctx, tree)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Close()
+	slr, err := tx.LatestSignedLogRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	var root types.LogRootV1
+	if err := root.UnmarshalBinary(slr.LogRoot); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to parse latest root: %v", err)
+	}
+	return &root, nil
+}
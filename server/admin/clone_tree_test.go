@@ -0,0 +1,359 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/trillian"
+	"github.com/google/trillian/crypto/keys/der"
+	"github.com/google/trillian/crypto/keyspb"
+	"github.com/google/trillian/extension"
+	"github.com/google/trillian/log"
+	"github.com/google/trillian/merkle/rfc6962"
+	"github.com/google/trillian/quota"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/memory"
+	"github.com/google/trillian/storage/testonly"
+	"github.com/google/trillian/trees"
+	"github.com/google/trillian/types"
+	"github.com/google/trillian/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestServer_CloneTree(t *testing.T) {
+	ctx := context.Background()
+	const numLeaves = 5
+
+	ls := memory.NewLogStorage(nil)
+	as := memory.NewAdminStorage(ls)
+	registry := extension.Registry{
+		AdminStorage: as,
+		LogStorage:   ls,
+		NewKeyProto: func(ctx context.Context, spec *keyspb.Specification) (proto.Message, error) {
+			return der.NewProtoFromSpec(spec)
+		},
+	}
+	source, sourceRoot := createLog(ctx, t, registry, numLeaves)
+
+	keySpec := &keyspb.Specification{
+		Params: &keyspb.Specification_EcdsaParams{EcdsaParams: &keyspb.Specification_ECDSA{}},
+	}
+
+	tests := []struct {
+		desc         string
+		req          *trillian.CloneTreeRequest
+		allowedTypes []trillian.TreeType
+		wantSize     int64
+		wantState    trillian.TreeState
+		wantNewKey   bool
+		wantCode     codes.Code
+	}{
+		{
+			desc:      "latestRoot",
+			req:       &trillian.CloneTreeRequest{TreeId: source.TreeId, Freeze: true},
+			wantSize:  numLeaves,
+			wantState: trillian.TreeState_FROZEN,
+		},
+		{
+			desc:      "smallerSize",
+			req:       &trillian.CloneTreeRequest{TreeId: source.TreeId, TreeSize: 3, Freeze: true},
+			wantSize:  3,
+			wantState: trillian.TreeState_FROZEN,
+		},
+		{
+			desc:       "newKey",
+			req:        &trillian.CloneTreeRequest{TreeId: source.TreeId, KeySpec: keySpec},
+			wantSize:   numLeaves,
+			wantState:  trillian.TreeState_ACTIVE,
+			wantNewKey: true,
+		},
+		{
+			desc:       "newKeyFrozen",
+			req:        &trillian.CloneTreeRequest{TreeId: source.TreeId, KeySpec: keySpec, Freeze: true},
+			wantSize:   numLeaves,
+			wantState:  trillian.TreeState_FROZEN,
+			wantNewKey: true,
+		},
+		{
+			desc:       "newKeySmallerSize",
+			req:        &trillian.CloneTreeRequest{TreeId: source.TreeId, TreeSize: 1, KeySpec: keySpec},
+			wantSize:   1,
+			wantState:  trillian.TreeState_ACTIVE,
+			wantNewKey: true,
+		},
+		{
+			desc:     "activeWithSourceKey",
+			req:      &trillian.CloneTreeRequest{TreeId: source.TreeId},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			desc:     "negativeSize",
+			req:      &trillian.CloneTreeRequest{TreeId: source.TreeId, TreeSize: -1, Freeze: true},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			desc:     "sizeTooLarge",
+			req:      &trillian.CloneTreeRequest{TreeId: source.TreeId, TreeSize: numLeaves + 1, Freeze: true},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			desc:         "preorderedLogNotAllowed",
+			req:          &trillian.CloneTreeRequest{TreeId: source.TreeId, Freeze: true},
+			allowedTypes: []trillian.TreeType{trillian.TreeType_LOG},
+			wantCode:     codes.InvalidArgument,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := New(registry, test.allowedTypes)
+			clone, err := s.CloneTree(ctx, test.req)
+			if got := status.Code(err); got != test.wantCode {
+				t.Fatalf("CloneTree() returned err = %v, wantCode = %v", err, test.wantCode)
+			}
+			if err != nil {
+				return
+			}
+
+			if clone.TreeId == source.TreeId {
+				t.Errorf("CloneTree() returned tree %v, want a new tree", clone.TreeId)
+			}
+			if got, want := clone.TreeType, trillian.TreeType_PREORDERED_LOG; got != want {
+				t.Errorf("CloneTree().TreeType = %v, want %v", got, want)
+			}
+			if got := clone.TreeState; got != test.wantState {
+				t.Errorf("CloneTree().TreeState = %v, want %v", got, test.wantState)
+			}
+			if clone.PrivateKey != nil {
+				t.Errorf("CloneTree() returned a private key, want it redacted")
+			}
+			if sameKey := bytes.Equal(clone.PublicKey.GetDer(), source.PublicKey.GetDer()); sameKey == test.wantNewKey {
+				t.Errorf("CloneTree() returned public key %x, want new key = %v", clone.PublicKey.GetDer(), test.wantNewKey)
+			}
+
+			stored, err := storage.GetTree(ctx, as, clone.TreeId)
+			if err != nil {
+				t.Fatalf("GetTree(): %v", err)
+			}
+			root := latestRoot(ctx, t, ls, stored)
+			if got := int64(root.TreeSize); got != test.wantSize {
+				t.Errorf("clone root size = %v, want %v", got, test.wantSize)
+			}
+			if test.wantSize == int64(sourceRoot.TreeSize) && !bytes.Equal(root.RootHash, sourceRoot.RootHash) {
+				t.Errorf("clone root hash = %x, want %x", root.RootHash, sourceRoot.RootHash)
+			}
+			if got, want := leafValues(ctx, t, ls, stored, test.wantSize), leafValues(ctx, t, ls, source, test.wantSize); !reflect.DeepEqual(got, want) {
+				t.Errorf("clone leaves = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestServer_CloneTreeDeletesIncompleteClone(t *testing.T) {
+	ctx := context.Background()
+	ls := memory.NewLogStorage(nil)
+	as := &deleteRecordingAdminStorage{AdminStorage: memory.NewAdminStorage(ls)}
+	registry := extension.Registry{AdminStorage: as, LogStorage: ls}
+	source, sourceRoot := createLog(ctx, t, registry, 3)
+
+	// Replace the source's root with one that has more leaves than the source
+	// holds, so that copying them fails.
+	signer, err := trees.Signer(ctx, source)
+	if err != nil {
+		t.Fatalf("Signer(): %v", err)
+	}
+	if err := ls.ReadWriteTransaction(ctx, source, func(ctx context.Context, tx storage.LogTreeTX) error {
+		root, err := signer.SignLogRoot(&types.LogRootV1{
+			TreeSize:       sourceRoot.TreeSize + 2,
+			RootHash:       sourceRoot.RootHash,
+			TimestampNanos: sourceRoot.TimestampNanos + 1,
+			Revision:       sourceRoot.Revision + 1,
+		})
+		if err != nil {
+			return err
+		}
+		return tx.StoreSignedLogRoot(ctx, *root)
+	}); err != nil {
+		t.Fatalf("StoreSignedLogRoot(): %v", err)
+	}
+
+	s := New(registry, nil /* allowedTreeTypes */)
+	if _, err := s.CloneTree(ctx, &trillian.CloneTreeRequest{TreeId: source.TreeId, Freeze: true}); err == nil {
+		t.Fatal("CloneTree() returned err = nil, want an error")
+	}
+
+	if got := len(as.deleted); got != 1 {
+		t.Fatalf("CloneTree() deleted %v trees, want 1", got)
+	}
+	clone, err := storage.GetTree(ctx, as, as.deleted[0])
+	if err != nil {
+		t.Fatalf("GetTree(): %v", err)
+	}
+	if clone.TreeId == source.TreeId {
+		t.Errorf("CloneTree() deleted the source log")
+	}
+	if got, want := clone.TreeState, trillian.TreeState_FROZEN; got != want {
+		t.Errorf("incomplete clone TreeState = %v, want %v", got, want)
+	}
+}
+
+func TestServer_CloneTreeChecksConsistency(t *testing.T) {
+	ctx := context.Background()
+	ls := memory.NewLogStorage(nil)
+	as := &deleteRecordingAdminStorage{AdminStorage: memory.NewAdminStorage(ls)}
+	registry := extension.Registry{AdminStorage: as, LogStorage: ls}
+	source, sourceRoot := createLog(ctx, t, registry, 5)
+
+	// Replace the source's root with one whose hash doesn't match its leaves,
+	// so that no prefix of them is consistent with it.
+	signer, err := trees.Signer(ctx, source)
+	if err != nil {
+		t.Fatalf("Signer(): %v", err)
+	}
+	if err := ls.ReadWriteTransaction(ctx, source, func(ctx context.Context, tx storage.LogTreeTX) error {
+		root, err := signer.SignLogRoot(&types.LogRootV1{
+			TreeSize:       sourceRoot.TreeSize,
+			RootHash:       bytes.Repeat([]byte{0x01}, len(sourceRoot.RootHash)),
+			TimestampNanos: sourceRoot.TimestampNanos + 1,
+			Revision:       sourceRoot.Revision + 1,
+		})
+		if err != nil {
+			return err
+		}
+		return tx.StoreSignedLogRoot(ctx, *root)
+	}); err != nil {
+		t.Fatalf("StoreSignedLogRoot(): %v", err)
+	}
+
+	s := New(registry, nil /* allowedTreeTypes */)
+	req := &trillian.CloneTreeRequest{TreeId: source.TreeId, TreeSize: 3, Freeze: true}
+	if _, err := s.CloneTree(ctx, req); status.Code(err) != codes.Internal {
+		t.Fatalf("CloneTree() returned err = %v, want code %v", err, codes.Internal)
+	}
+	if got := len(as.deleted); got != 1 {
+		t.Errorf("CloneTree() deleted %v trees, want 1", got)
+	}
+}
+
+// deleteRecordingAdminStorage records the trees soft-deleted through it,
+// since memory storage doesn't support deletion.
+type deleteRecordingAdminStorage struct {
+	storage.AdminStorage
+	deleted []int64
+}
+
+func (s *deleteRecordingAdminStorage) ReadWriteTransaction(ctx context.Context, f storage.AdminTXFunc) error {
+	return s.AdminStorage.ReadWriteTransaction(ctx, func(ctx context.Context, tx storage.AdminTX) error {
+		return f(ctx, &deleteRecordingAdminTX{AdminTX: tx, s: s})
+	})
+}
+
+type deleteRecordingAdminTX struct {
+	storage.AdminTX
+	s *deleteRecordingAdminStorage
+}
+
+func (tx *deleteRecordingAdminTX) SoftDeleteTree(ctx context.Context, treeID int64) (*trillian.Tree, error) {
+	tx.s.deleted = append(tx.s.deleted, treeID)
+	return tx.GetTree(ctx, treeID)
+}
+
+// createLog creates an initialised log with numLeaves integrated leaves, and
+// returns it with its latest root.
+func createLog(ctx context.Context, t *testing.T, registry extension.Registry, numLeaves int) (*trillian.Tree, *types.LogRootV1) {
+	t.Helper()
+	tree, err := storage.CreateTree(ctx, registry.AdminStorage, proto.Clone(testonly.LogTree).(*trillian.Tree))
+	if err != nil {
+		t.Fatalf("CreateTree(): %v", err)
+	}
+	tree = proto.Clone(tree).(*trillian.Tree)
+	signer, err := trees.Signer(ctx, tree)
+	if err != nil {
+		t.Fatalf("Signer(): %v", err)
+	}
+	hasher := rfc6962.DefaultHasher
+	timeSource := util.SystemTimeSource{}
+
+	if err := registry.LogStorage.ReadWriteTransaction(ctx, tree, func(ctx context.Context, tx storage.LogTreeTX) error {
+		root, err := signer.SignLogRoot(&types.LogRootV1{RootHash: hasher.EmptyRoot(), TimestampNanos: uint64(timeSource.Now().UnixNano())})
+		if err != nil {
+			return err
+		}
+		return tx.StoreSignedLogRoot(ctx, *root)
+	}); err != nil && err != storage.ErrTreeNeedsInit {
+		t.Fatalf("StoreSignedLogRoot(): %v", err)
+	}
+
+	leaves := make([]*trillian.LogLeaf, 0, numLeaves)
+	for i := 0; i < numLeaves; i++ {
+		value := []byte(fmt.Sprintf("leaf %d", i))
+		hash, err := hasher.HashLeaf(value)
+		if err != nil {
+			t.Fatalf("HashLeaf(): %v", err)
+		}
+		leaves = append(leaves, &trillian.LogLeaf{LeafValue: value, LeafIdentityHash: hash, MerkleLeafHash: hash})
+	}
+	if _, err := registry.LogStorage.QueueLeaves(ctx, tree, leaves, timeSource.Now()); err != nil {
+		t.Fatalf("QueueLeaves(): %v", err)
+	}
+	sequencer := log.NewSequencer(hasher, timeSource, registry.LogStorage, signer, nil, quota.Noop())
+	if n, err := sequencer.IntegrateBatch(ctx, tree, numLeaves, 0, 0); err != nil || n != numLeaves {
+		t.Fatalf("IntegrateBatch() = (%v, %v), want (%v, nil)", n, err, numLeaves)
+	}
+	return tree, latestRoot(ctx, t, registry.LogStorage, tree)
+}
+
+func latestRoot(ctx context.Context, t *testing.T, ls storage.LogStorage, tree *trillian.Tree) *types.LogRootV1 {
+	t.Helper()
+	tx, err := ls.SnapshotForTree(ctx, tree)
+	if err != nil {
+		t.Fatalf("SnapshotForTree(): %v", err)
+	}
+	defer tx.Close()
+	slr, err := tx.LatestSignedLogRoot(ctx)
+	if err != nil {
+		t.Fatalf("LatestSignedLogRoot(): %v", err)
+	}
+	var root types.LogRootV1
+	if err := root.UnmarshalBinary(slr.LogRoot); err != nil {
+		t.Fatalf("UnmarshalBinary(): %v", err)
+	}
+	return &root
+}
+
+func leafValues(ctx context.Context, t *testing.T, ls storage.LogStorage, tree *trillian.Tree, count int64) [][]byte {
+	t.Helper()
+	tx, err := ls.SnapshotForTree(ctx, tree)
+	if err != nil {
+		t.Fatalf("SnapshotForTree(): %v", err)
+	}
+	defer tx.Close()
+	leaves, err := tx.GetLeavesByRange(ctx, 0, count)
+	if err != nil {
+		t.Fatalf("GetLeavesByRange(): %v", err)
+	}
+	values := make([][]byte, 0, len(leaves))
+	for _, leaf := range leaves {
+		values = append(values, leaf.LeafValue)
+	}
+	return values
+}
@@ -0,0 +1,158 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian/monitoring"
+	"github.com/google/trillian/storage"
+)
+
+var (
+	purgedRows       monitoring.Counter
+	purgedTrees      monitoring.Counter
+	purgeErrors      monitoring.Counter
+	pendingPurges    monitoring.Gauge
+	purgeMetricsOnce sync.Once
+)
+
+// TreeDataPurger purges the data of hard-deleted trees from storage that
+// implements storage.TreePurger, a chunk at a time.
+//
+// Purges are resumable: if a sweep is interrupted, the next one continues
+// where it stopped.
+type TreeDataPurger struct {
+	// purger is the storage.TreePurger interface.
+	purger storage.TreePurger
+
+	// chunkSize is the maximum number of rows deleted per storage call.
+	chunkSize int64
+
+	// minRunInterval defines how frequently sweeps for trees pending purge are performed.
+	// Actual runs happen randomly between [minInterval,2*minInterval).
+	minRunInterval time.Duration
+}
+
+// NewTreeDataPurger returns a new TreeDataPurger.
+func NewTreeDataPurger(purger storage.TreePurger, chunkSize int64, minRunInterval time.Duration, mf monitoring.MetricFactory) *TreeDataPurger {
+	p := &TreeDataPurger{
+		purger:         purger,
+		chunkSize:      chunkSize,
+		minRunInterval: minRunInterval,
+	}
+	purgeMetricsOnce.Do(func() {
+		if mf == nil {
+			mf = monitoring.InertMetricFactory{}
+		}
+		purgedRows = mf.NewCounter("tree_purge_deleted_rows", "Number of rows of hard-deleted trees purged, by table", monitoring.TreeIDLabel, "table")
+		purgedTrees = mf.NewCounter("tree_purge_completed", "Number of hard-deleted trees whose data was completely purged", monitoring.TreeIDLabel)
+		purgeErrors = mf.NewCounter("tree_purge_errors", "Number of failed attempts to purge the data of hard-deleted trees", monitoring.TreeIDLabel)
+		pendingPurges = mf.NewGauge("tree_purge_pending", "Number of hard-deleted trees whose data is yet to be purged")
+	})
+	return p
+}
+
+// Run starts purging trees. It runs until ctx is cancelled.
+func (p *TreeDataPurger) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		count, err := p.RunOnce(ctx)
+		if err != nil {
+			glog.Errorf("TreeDataPurger.Run: %v", err)
+		}
+		if count > 0 {
+			glog.Infof("TreeDataPurger.Run: successfully purged %v trees", count)
+		}
+
+		d := p.minRunInterval + time.Duration(rand.Int63n(p.minRunInterval.Nanoseconds()))
+		timeSleep(d)
+	}
+}
+
+// RunOnce performs a single sweep of the trees pending purge. Returns the
+// number of trees completely purged.
+//
+// It attempts to purge as many trees as possible, regardless of failures. If
+// it encounters any failures the resulting error is non-nil.
+func (p *TreeDataPurger) RunOnce(ctx context.Context) (int, error) {
+	ids, err := p.purger.PendingPurges(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("error listing trees pending purge: %v", err)
+	}
+	pendingPurges.Set(float64(len(ids)))
+
+	count := 0
+	var errs []error
+	for _, id := range ids {
+		if err := p.purgeTree(ctx, id); err != nil {
+			errs = append(errs, fmt.Errorf("error purging tree %v: %v", id, err))
+			purgeErrors.Inc(fmt.Sprint(id))
+			continue
+		}
+		count++
+		purgedTrees.Inc(fmt.Sprint(id))
+		pendingPurges.Add(-1)
+	}
+
+	if len(errs) == 0 {
+		return count, nil
+	}
+
+	buf := &bytes.Buffer{}
+	buf.WriteString("encountered errors purging trees:")
+	for _, err := range errs {
+		buf.WriteString("\n\t")
+		buf.WriteString(err.Error())
+	}
+	return count, errors.New(buf.String())
+}
+
+// purgeTree purges the data of tree treeID, a chunk at a time, until none is
+// left or ctx is done.
+func (p *TreeDataPurger) purgeTree(ctx context.Context, treeID int64) error {
+	var total int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		progress, err := p.purger.PurgeTreeData(ctx, treeID, p.chunkSize)
+		if progress != nil {
+			for table, n := range progress.Deleted {
+				purgedRows.Add(float64(n), fmt.Sprint(treeID), table)
+				total += n
+			}
+		}
+		if err != nil {
+			return err
+		}
+		if progress.Done {
+			glog.Infof("TreeDataPurger: purged %v rows of tree %v", total, treeID)
+			return nil
+		}
+	}
+}
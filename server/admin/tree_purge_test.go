@@ -0,0 +1,131 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/trillian/storage"
+)
+
+// fakeTreePurger is a storage.TreePurger whose trees have rows[treeID] rows
+// of data in a single table.
+type fakeTreePurger struct {
+	rows map[int64]int64
+	// purgeErr, if set, is returned when purging the given tree.
+	purgeErr map[int64]error
+	// maxRows records the maxRows of each PurgeTreeData call.
+	maxRows []int64
+}
+
+func (f *fakeTreePurger) PendingPurges(ctx context.Context) ([]int64, error) {
+	ids := make([]int64, 0, len(f.rows))
+	for id := range f.rows {
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (f *fakeTreePurger) PurgeTreeData(ctx context.Context, treeID int64, maxRows int64) (*storage.PurgeProgress, error) {
+	f.maxRows = append(f.maxRows, maxRows)
+	if err := f.purgeErr[treeID]; err != nil {
+		return nil, err
+	}
+	n := f.rows[treeID]
+	if n > maxRows {
+		n = maxRows
+	}
+	f.rows[treeID] -= n
+	progress := &storage.PurgeProgress{Deleted: map[string]int64{"LeafData": n}}
+	if n < maxRows {
+		delete(f.rows, treeID)
+		progress.Done = true
+	}
+	return progress, nil
+}
+
+func (f *fakeTreePurger) FindOrphanedTreeData(ctx context.Context) ([]storage.TreeDataRows, error) {
+	return nil, nil
+}
+
+func TestTreeDataPurger_RunOnce(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		desc        string
+		rows        map[int64]int64
+		purgeErr    map[int64]error
+		wantCount   int
+		wantErr     string
+		wantRows    map[int64]int64
+		wantMaxRows []int64
+	}{
+		{
+			desc:      "nothingPending",
+			rows:      map[int64]int64{},
+			wantCount: 0,
+			wantRows:  map[int64]int64{},
+		},
+		{
+			desc:        "chunked",
+			rows:        map[int64]int64{1: 25},
+			wantCount:   1,
+			wantRows:    map[int64]int64{},
+			wantMaxRows: []int64{10, 10, 10},
+		},
+		{
+			desc:        "exactChunks",
+			rows:        map[int64]int64{1: 20},
+			wantCount:   1,
+			wantRows:    map[int64]int64{},
+			wantMaxRows: []int64{10, 10, 10},
+		},
+		{
+			desc:        "purgeError",
+			rows:        map[int64]int64{1: 5},
+			purgeErr:    map[int64]error{1: errors.New("purge failed")},
+			wantCount:   0,
+			wantErr:     "purge failed",
+			wantRows:    map[int64]int64{1: 5},
+			wantMaxRows: []int64{10},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			purger := &fakeTreePurger{rows: test.rows, purgeErr: test.purgeErr}
+			p := NewTreeDataPurger(purger, 10 /* chunkSize */, 0 /* minRunInterval */, nil /* mf */)
+
+			count, err := p.RunOnce(ctx)
+			if hasErr := err != nil; hasErr != (test.wantErr != "") {
+				t.Fatalf("RunOnce() returned err = %v, want err containing %q", err, test.wantErr)
+			} else if hasErr && !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("RunOnce() returned err = %v, want err containing %q", err, test.wantErr)
+			}
+			if count != test.wantCount {
+				t.Errorf("RunOnce() returned count = %v, want %v", count, test.wantCount)
+			}
+			if !reflect.DeepEqual(purger.rows, test.wantRows) {
+				t.Errorf("remaining rows = %v, want %v", purger.rows, test.wantRows)
+			}
+			if !reflect.DeepEqual(purger.maxRows, test.wantMaxRows) {
+				t.Errorf("PurgeTreeData() maxRows = %v, want %v", purger.maxRows, test.wantMaxRows)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/audit"
+	"github.com/google/trillian/crypto/keys/pem"
+	"github.com/google/trillian/merkle/rfc6962"
+	"google.golang.org/grpc"
+)
+
+var (
+	auditLogID     = flag.Int64("audit_log_id", 0, "ID of the Trillian log in which changes made through the admin server are recorded. If zero, changes aren't recorded.")
+	auditLogServer = flag.String("audit_log_server", "", "Address of the Trillian log server hosting --audit_log_id (host:port), reached over an unencrypted connection. Defaults to the server's own RPC endpoint, where it hosts logs.")
+	auditLogPubKey = flag.String("audit_log_public_key", "", "Path to the PEM public key of --audit_log_id, used to verify the entries read from it. Required when --audit_log_id is set.")
+)
+
+// NewAuditLogFromFlags returns the audit.Log configured by --audit_log_id,
+// --audit_log_server and --audit_log_public_key, or nil if auditing is
+// disabled.
+// defaultServer is used if --audit_log_server is unset; it may be empty if
+// the binary doesn't host logs itself.
+// The audit log must be an RFC6962_SHA256 log whose roots are signed with a
+// SHA-256 based signature algorithm, as created by default.
+func NewAuditLogFromFlags(defaultServer string) (audit.Log, error) {
+	if *auditLogID == 0 {
+		return nil, nil
+	}
+	addr := *auditLogServer
+	if addr == "" {
+		addr = defaultServer
+	}
+	if addr == "" {
+		return nil, errors.New("--audit_log_server is required when --audit_log_id is set")
+	}
+	if *auditLogPubKey == "" {
+		return nil, errors.New("--audit_log_public_key is required when --audit_log_id is set")
+	}
+	pubKey, err := pem.ReadPublicKeyFile(*auditLogPubKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audit log public key: %v", err)
+	}
+	conn, err := grpc.Dial(addr, grpc.WithInsecure())
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial audit log server %v: %v", addr, err)
+	}
+	return audit.NewTrillianLog(trillian.NewTrillianLogClient(conn), *auditLogID, rfc6962.DefaultHasher, pubKey), nil
+}
@@ -0,0 +1,154 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian/extension"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/trees"
+	"github.com/google/trillian/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CheckpointPath is the HTTP path under which CheckpointHandler serves the
+// checkpoint of each log, as CheckpointPath + <log ID>.
+const CheckpointPath = "/checkpoint/"
+
+// CheckpointHandler serves the latest root of each log as a checkpoint in the
+// signed note format, signed with the log's key. Each root is signed only
+// once; later requests for it are served from a cache. Only checkpoints of
+// logs with Ed25519 keys can be verified by other note verifiers, see
+// crypto.NoteKeyID.
+type CheckpointHandler struct {
+	registry     extension.Registry
+	originPrefix string
+
+	mu sync.Mutex
+	// notes holds the latest checkpoint signed for each log.
+	notes map[int64]cachedCheckpoint
+}
+
+// cachedCheckpoint is a signed checkpoint, along with the root revision and
+// key version it was signed for.
+type cachedCheckpoint struct {
+	revision   uint64
+	keyVersion int64
+	note       []byte
+}
+
+// NewCheckpointHandler returns a CheckpointHandler for the logs in registry.
+// The origin of each checkpoint is originPrefix + "/" + <log ID>.
+func NewCheckpointHandler(registry extension.Registry, originPrefix string) *CheckpointHandler {
+	return &CheckpointHandler{
+		registry:     registry,
+		originPrefix: originPrefix,
+		notes:        make(map[int64]cachedCheckpoint),
+	}
+}
+
+// Origin returns the origin of the checkpoints of log logID.
+func (h *CheckpointHandler) Origin(logID int64) string {
+	return fmt.Sprintf("%s/%d", h.originPrefix, logID)
+}
+
+// ServeHTTP implements http.Handler.
+func (h *CheckpointHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(rw, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	logID, err := strconv.ParseInt(strings.TrimPrefix(req.URL.Path, CheckpointPath), 10, 64)
+	if err != nil {
+		http.Error(rw, fmt.Sprintf("invalid log ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	note, err := h.checkpoint(req.Context(), logID)
+	if err != nil {
+		// Errors may come from storage, so their details aren't served.
+		glog.Warningf("%v: failed to serve checkpoint: %v", logID, err)
+		code := http.StatusInternalServerError
+		if status.Code(err) == codes.NotFound {
+			code = http.StatusNotFound
+		}
+		http.Error(rw, http.StatusText(code), code)
+		return
+	}
+	rw.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	rw.Write(note)
+}
+
+// checkpoint returns the signed checkpoint of the latest root of log logID,
+// signing it if it isn't cached yet.
+func (h *CheckpointHandler) checkpoint(ctx context.Context, logID int64) ([]byte, error) {
+	tree, err := trees.GetTree(ctx, h.registry.AdminStorage, logID, optsLogRead)
+	if err != nil {
+		return nil, err
+	}
+	ctx = trees.NewContext(ctx, tree)
+
+	tx, err := h.registry.LogStorage.SnapshotForTree(ctx, tree)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Close()
+	signedRoot, err := tx.LatestSignedLogRoot(ctx)
+	if err == storage.ErrTreeNeedsInit {
+		return nil, status.Errorf(codes.NotFound, "log %v has not been initialised", logID)
+	} else if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	var logRoot types.LogRootV1
+	if err := logRoot.UnmarshalBinary(signedRoot.LogRoot); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to parse log root: %v", err)
+	}
+
+	h.mu.Lock()
+	cached, ok := h.notes[logID]
+	h.mu.Unlock()
+	if ok && cached.revision == logRoot.Revision && cached.keyVersion == tree.GetKeyVersion() {
+		return cached.note, nil
+	}
+
+	signer, err := trees.Signer(ctx, tree)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "Signer(): %v", err)
+	}
+	note, err := signer.SignCheckpoint(types.NewCheckpoint(h.Origin(logID), &logRoot))
+	if err != nil {
+		return nil, err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	// Don't replace a checkpoint of a newer root signed concurrently.
+	if cached, ok := h.notes[logID]; !ok || cached.revision <= logRoot.Revision {
+		h.notes[logID] = cachedCheckpoint{revision: logRoot.Revision, keyVersion: tree.GetKeyVersion(), note: note}
+	}
+	return note, nil
+}
@@ -0,0 +1,150 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"crypto"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/trillian"
+	"github.com/google/trillian/crypto/keys/der"
+	"github.com/google/trillian/extension"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/types"
+
+	tcrypto "github.com/google/trillian/crypto"
+	stestonly "github.com/google/trillian/storage/testonly"
+)
+
+func TestCheckpointHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logRoot := &types.LogRootV1{TreeSize: 7, RootHash: []byte("root hash"), TimestampNanos: 1000}
+	logRootBytes, err := logRoot.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary(): %v", err)
+	}
+	pubKey, err := der.UnmarshalPublicKey(stestonly.LogTree.PublicKey.Der)
+	if err != nil {
+		t.Fatalf("UnmarshalPublicKey(): %v", err)
+	}
+
+	for _, tc := range []struct {
+		desc       string
+		path       string
+		storeRoot  bool
+		rootErr    error
+		wantStatus int
+	}{
+		{desc: "ok", path: "/checkpoint/6962", storeRoot: true, wantStatus: http.StatusOK},
+		{desc: "badLogID", path: "/checkpoint/llamas", wantStatus: http.StatusBadRequest},
+		{desc: "notInitialised", path: "/checkpoint/6962", rootErr: storage.ErrTreeNeedsInit, wantStatus: http.StatusNotFound},
+		{desc: "storageError", path: "/checkpoint/6962", rootErr: errors.New("LatestSignedLogRoot() error"), wantStatus: http.StatusInternalServerError},
+	} {
+		registry := extension.Registry{
+			AdminStorage: fakeAdminStorage(ctrl, storageParams{treeID: 6962, numSnapshots: 1}),
+		}
+		if tc.storeRoot || tc.rootErr != nil {
+			var signedRoot trillian.SignedLogRoot
+			if tc.storeRoot {
+				signedRoot.LogRoot = logRootBytes
+			}
+			fakeStorage := storage.NewMockLogStorage(ctrl)
+			mockTX := storage.NewMockLogTreeTX(ctrl)
+			fakeStorage.EXPECT().SnapshotForTree(gomock.Any(), gomock.Any()).Return(mockTX, nil)
+			mockTX.EXPECT().LatestSignedLogRoot(gomock.Any()).Return(signedRoot, tc.rootErr)
+			if tc.rootErr == nil {
+				mockTX.EXPECT().Commit().Return(nil)
+			}
+			mockTX.EXPECT().Close().Return(nil)
+			registry.LogStorage = fakeStorage
+		}
+
+		h := NewCheckpointHandler(registry, "example.com/trillian")
+		rw := httptest.NewRecorder()
+		h.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, tc.path, nil))
+		if got, want := rw.Code, tc.wantStatus; got != want {
+			t.Errorf("%v: ServeHTTP(): status %v, want %v (%s)", tc.desc, got, want, rw.Body)
+			continue
+		}
+		if rw.Code != http.StatusOK {
+			if tc.rootErr != nil && strings.Contains(rw.Body.String(), tc.rootErr.Error()) {
+				t.Errorf("%v: ServeHTTP(): body %q contains the storage error", tc.desc, rw.Body)
+			}
+			continue
+		}
+
+		got, err := tcrypto.VerifyCheckpoint(pubKey, crypto.SHA256, h.Origin(6962), rw.Body.Bytes())
+		if err != nil {
+			t.Errorf("%v: VerifyCheckpoint(): %v", tc.desc, err)
+			continue
+		}
+		if want := types.NewCheckpoint("example.com/trillian/6962", logRoot); !reflect.DeepEqual(got, want) {
+			t.Errorf("%v: checkpoint %+v, want %+v", tc.desc, got, want)
+		}
+	}
+}
+
+func TestCheckpointHandlerSignsEachRootOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	roots := []*types.LogRootV1{
+		{TreeSize: 7, RootHash: []byte("root hash"), TimestampNanos: 1000, Revision: 1},
+		{TreeSize: 7, RootHash: []byte("root hash"), TimestampNanos: 1000, Revision: 1},
+		{TreeSize: 8, RootHash: []byte("root hash 2"), TimestampNanos: 2000, Revision: 2},
+	}
+	fakeStorage := storage.NewMockLogStorage(ctrl)
+	for _, root := range roots {
+		rootBytes, err := root.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary(): %v", err)
+		}
+		mockTX := storage.NewMockLogTreeTX(ctrl)
+		fakeStorage.EXPECT().SnapshotForTree(gomock.Any(), gomock.Any()).Return(mockTX, nil)
+		mockTX.EXPECT().LatestSignedLogRoot(gomock.Any()).Return(trillian.SignedLogRoot{LogRoot: rootBytes}, nil)
+		mockTX.EXPECT().Commit().Return(nil)
+		mockTX.EXPECT().Close().Return(nil)
+	}
+	registry := extension.Registry{
+		AdminStorage: fakeAdminStorage(ctrl, storageParams{treeID: 6962, numSnapshots: len(roots)}),
+		LogStorage:   fakeStorage,
+	}
+	h := NewCheckpointHandler(registry, "example.com/trillian")
+
+	var notes []string
+	for range roots {
+		rw := httptest.NewRecorder()
+		h.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/checkpoint/6962", nil))
+		if got, want := rw.Code, http.StatusOK; got != want {
+			t.Fatalf("ServeHTTP(): status %v, want %v (%s)", got, want, rw.Body)
+		}
+		notes = append(notes, rw.Body.String())
+	}
+	// ECDSA signatures are randomized, so identical notes were not re-signed.
+	if notes[0] != notes[1] {
+		t.Errorf("checkpoint of the same root was signed twice: %q and %q", notes[0], notes[1])
+	}
+	if notes[1] == notes[2] {
+		t.Errorf("checkpoint of a new root = %q, want a new note", notes[2])
+	}
+}
@@ -304,12 +304,16 @@ func newRPCInfoForRequestType(req interface{}) (*rpcInfo, error) {
 		info.quota = false   // No quota for admin
 
 	// Admin / readonly
-	case *trillian.GetTreeRequest:
+	case *trillian.GetTreeRequest,
+		*trillian.ListTreeAuditEntriesRequest:
 		info.getTree = false // Read done within RPC handler
 		info.quota = false   // No quota for admin
 
 	// Admin / readwrite
-	case *trillian.DeleteTreeRequest,
+	case *trillian.CloneTreeRequest,
+		*trillian.DeleteTreeRequest,
+		*trillian.RedactLeafRequest,
+		*trillian.RotateTreeKeyRequest,
 		*trillian.UndeleteTreeRequest,
 		*trillian.UpdateTreeRequest:
 		info.getTree = false // Read-modify-write done within RPC handler
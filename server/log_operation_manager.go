@@ -46,6 +46,7 @@ var (
 	signingRuns       monitoring.Counter
 	failedSigningRuns monitoring.Counter
 	entriesAdded      monitoring.Counter
+	expiredDeferrals  monitoring.Counter
 )
 
 func createMetrics(mf monitoring.MetricFactory) {
@@ -58,6 +59,7 @@ func createMetrics(mf monitoring.MetricFactory) {
 	signingRuns = mf.NewCounter("signing_runs", "Number of times a signing run has succeeded", logIDLabel)
 	failedSigningRuns = mf.NewCounter("failed_signing_runs", "Number of times a signing run has failed", logIDLabel)
 	entriesAdded = mf.NewCounter("entries_added", "Number of entries added to the log", logIDLabel)
+	expiredDeferrals = mf.NewCounter("expired_campaign_deferrals", "Number of times the balancer deferred campaigning for mastership for the whole master hold interval, which the log may have spent without a master", logIDLabel)
 }
 
 // LogOperation defines a task that operates on a log. Examples are scheduling, signing,
@@ -100,6 +102,10 @@ type LogOperationInfo struct {
 	// ResignOdds gives the chance of resigning mastership after each
 	// check interval, as the N for 1-in-N.
 	ResignOdds int
+	// Balancer, if set, decides when to resign mastership and whether to
+	// campaign for it, in place of ResignOdds. Campaigning is deferred for at
+	// most MasterHoldInterval.
+	Balancer MastershipBalancer
 	// NumWorkers is the number of worker goroutines to run in parallel.
 	NumWorkers int
 }
@@ -111,6 +117,9 @@ type electionRunner struct {
 	cancel   context.CancelFunc
 	wg       *sync.WaitGroup
 	election util.MasterElection
+	// resignRequests receives requests to resign mastership regardless of
+	// shouldResign, e.g. from operators.
+	resignRequests chan struct{}
 }
 
 type resignation struct {
@@ -147,6 +156,9 @@ func (er *electionRunner) Run(ctx context.Context, pending chan<- resignation) {
 
 	for {
 		glog.V(1).Infof("%d: When I left you, I was but the learner", er.logID)
+		if !er.awaitCampaign(ctx) {
+			return
+		}
 		if err := er.election.WaitForMastership(ctx); err != nil {
 			glog.Errorf("%d: er.election.WaitForMastership() failed: %v", er.logID, err)
 			return
@@ -176,7 +188,13 @@ func (er *electionRunner) Run(ctx context.Context, pending chan<- resignation) {
 				isMaster.Set(0.0, label)
 				break
 			}
-			if er.shouldResign(masterSince) {
+			requested := false
+			select {
+			case <-er.resignRequests:
+				requested = true
+			default:
+			}
+			if requested || er.shouldResign(masterSince) {
 				glog.Infof("%d: queue up resignation of mastership", er.logID)
 				resignations.Inc(label)
 				er.tracker.Set(er.logID, false)
@@ -192,6 +210,34 @@ func (er *electionRunner) Run(ctx context.Context, pending chan<- resignation) {
 	}
 }
 
+// awaitCampaign waits until the balancer, if any, agrees to campaign for
+// mastership, or MasterHoldInterval has passed. Returns false if ctx is done.
+// The latter is counted by expiredDeferrals: it's expected while signers
+// start or stop, but persistently means fewer signers are running than the
+// balancer counts, e.g. with a --num_signers that's too high, leaving logs
+// without a master.
+func (er *electionRunner) awaitCampaign(ctx context.Context) bool {
+	b := er.info.Balancer
+	if b == nil {
+		return true
+	}
+	deadline := er.info.TimeSource.Now().Add(er.info.MasterHoldInterval)
+	for !b.ShouldCampaign(er.logID) {
+		if !er.info.TimeSource.Now().Before(deadline) {
+			glog.Warningf("%d: campaigning for mastership after deferring it for %v", er.logID, er.info.MasterHoldInterval)
+			expiredDeferrals.Inc(strconv.FormatInt(er.logID, 10))
+			break
+		}
+		glog.V(1).Infof("%d: deferring campaign for mastership to balance load", er.logID)
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(er.info.MasterCheckInterval):
+		}
+	}
+	return true
+}
+
 func (er *electionRunner) shouldResign(masterSince time.Time) bool {
 	now := er.info.TimeSource.Now()
 	duration := now.Sub(masterSince)
@@ -199,6 +245,9 @@ func (er *electionRunner) shouldResign(masterSince time.Time) bool {
 		// Always hold onto mastership for a minimum interval to prevent churn.
 		return false
 	}
+	if er.info.Balancer != nil {
+		return er.info.Balancer.ShouldResign(er.logID)
+	}
 	// Roll the bones.
 	odds := er.info.ResignOdds
 	if odds <= 0 {
@@ -214,8 +263,10 @@ type LogOperationManager struct {
 	// logOperation is the task that gets run across active logs in the scheduling loop
 	logOperation LogOperation
 
-	// electionRunner tracks the goroutines that run per-log mastership elections
+	// electionRunner tracks the goroutines that run per-log mastership elections.
+	// It is only modified by masterFor, under runnerMutex.
 	electionRunner      map[int64]*electionRunner
+	runnerMutex         sync.Mutex
 	pendingResignations chan resignation
 	runnerWG            sync.WaitGroup
 	tracker             *util.MasterTracker
@@ -224,6 +275,33 @@ type LogOperationManager struct {
 	// Cache of logID => name; assumed not to change during runtime
 	logNamesMutex sync.Mutex
 	logNames      map[int64]string
+	// statusMutex guards the results of passes, which are kept for status
+	// reporting.
+	statusMutex sync.Mutex
+	lastPass    passStatus
+	logStatus   map[int64]*logPassStatus
+}
+
+// passStatus holds the results of a pass over all the logs.
+type passStatus struct {
+	start     time.Time
+	duration  time.Duration
+	succeeded int
+	failed    int
+	items     int
+}
+
+// logPassStatus holds the results of the passes over a single log.
+type logPassStatus struct {
+	// lastPass is the start time of the most recent pass over the log.
+	lastPass time.Time
+	// items is the number of items processed by the most recent pass.
+	items int
+	// lastIntegration is the start time of the most recent pass that
+	// processed any items.
+	lastIntegration time.Time
+	// err is the error of the most recent pass, if it failed.
+	err error
 }
 
 // fixupElectionInfo ensures operation parameters have required minimum values.
@@ -254,6 +332,7 @@ func NewLogOperationManager(info LogOperationInfo, logOperation LogOperation) *L
 		electionRunner:      make(map[int64]*electionRunner),
 		pendingResignations: make(chan resignation, 100),
 		logNames:            make(map[int64]string),
+		logStatus:           make(map[int64]*logPassStatus),
 	}
 }
 
@@ -335,14 +414,17 @@ func (l *LogOperationManager) masterFor(ctx context.Context, allIDs []int64) ([]
 			cancel()
 			return nil, fmt.Errorf("failed to create election for %d: %v", logID, err)
 		}
+		l.runnerMutex.Lock()
 		l.electionRunner[logID] = &electionRunner{
-			logID:    logID,
-			info:     &l.info,
-			tracker:  l.tracker,
-			cancel:   cancel,
-			wg:       &l.runnerWG,
-			election: election,
+			logID:          logID,
+			info:           &l.info,
+			tracker:        l.tracker,
+			cancel:         cancel,
+			wg:             &l.runnerWG,
+			election:       election,
+			resignRequests: make(chan struct{}, 1),
 		}
+		l.runnerMutex.Unlock()
 		l.runnerWG.Add(1)
 		go l.electionRunner[logID].Run(innerCtx, l.pendingResignations)
 	}
@@ -366,6 +448,42 @@ func (l *LogOperationManager) updateHeldIDs(ctx context.Context, logIDs, allIDs
 	}
 }
 
+// fencedContext returns a ctx with the fencing token of the mastership of
+// logID, if its election provides one, so that storage rejects the writes of
+// a pass that outlives the mastership.
+func (l *LogOperationManager) fencedContext(ctx context.Context, logID int64) context.Context {
+	er := l.electionRunner[logID]
+	if er == nil {
+		return ctx
+	}
+	fe, ok := er.election.(util.FencedElection)
+	if !ok {
+		return ctx
+	}
+	return storage.WithFencingToken(ctx, fe.Epoch())
+}
+
+// updateBalancer passes the current load of the logs to the balancer. Failures
+// are logged, and leave the balancer with the load of the previous pass.
+func (l *LogOperationManager) updateBalancer(ctx context.Context, allIDs, heldIDs []int64) {
+	tx, err := l.info.Registry.LogStorage.Snapshot(ctx)
+	if err != nil {
+		glog.Warningf("failed to get tx for counting unsequenced leaves: %v", err)
+		return
+	}
+	defer tx.Close()
+	queued, err := tx.GetUnsequencedCounts(ctx)
+	if err != nil {
+		glog.Warningf("failed to count unsequenced leaves: %v", err)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		glog.Warningf("failed to commit counting unsequenced leaves: %v", err)
+		return
+	}
+	l.info.Balancer.Update(ctx, allIDs, queued, heldIDs)
+}
+
 func (l *LogOperationManager) getLogsAndExecutePass(ctx context.Context) error {
 	allIDs, err := l.getLogIDs(ctx)
 	if err != nil {
@@ -376,6 +494,9 @@ func (l *LogOperationManager) getLogsAndExecutePass(ctx context.Context) error {
 		return fmt.Errorf("failed to determine log IDs we're master for: %v", err)
 	}
 	l.updateHeldIDs(ctx, logIDs, allIDs)
+	if l.info.Balancer != nil {
+		l.updateBalancer(ctx, allIDs, logIDs)
+	}
 
 	numWorkers := l.info.NumWorkers
 	if numWorkers == 0 {
@@ -410,7 +531,8 @@ func (l *LogOperationManager) getLogsAndExecutePass(ctx context.Context) error {
 
 				label := strconv.FormatInt(logID, 10)
 				start := l.info.TimeSource.Now()
-				count, err := l.logOperation.ExecutePass(ctx, logID, &l.info)
+				count, err := l.logOperation.ExecutePass(l.fencedContext(ctx, logID), logID, &l.info)
+				l.recordLogPass(logID, start, count, err)
 				if err != nil {
 					glog.Errorf("ExecutePass(%v) failed: %v", logID, err)
 					failedSigningRuns.Inc(label)
@@ -441,10 +563,65 @@ func (l *LogOperationManager) getLogsAndExecutePass(ctx context.Context) error {
 	wg.Wait()
 	d := util.SecondsSince(l.info.TimeSource, startBatch)
 	glog.Infof("Group run completed in %.2f seconds: %v succeeded, %v failed, %v items processed", d, successCount, len(logIDs)-successCount, itemCount)
+	l.statusMutex.Lock()
+	l.lastPass = passStatus{
+		start:     startBatch,
+		duration:  l.info.TimeSource.Now().Sub(startBatch),
+		succeeded: successCount,
+		failed:    len(logIDs) - successCount,
+		items:     itemCount,
+	}
+	l.statusMutex.Unlock()
 
 	return nil
 }
 
+// recordLogPass records the results of a pass over logID, which started at
+// start.
+func (l *LogOperationManager) recordLogPass(logID int64, start time.Time, items int, err error) {
+	l.statusMutex.Lock()
+	defer l.statusMutex.Unlock()
+	status := l.logStatus[logID]
+	if status == nil {
+		status = &logPassStatus{}
+		l.logStatus[logID] = status
+	}
+	status.lastPass = start
+	status.items = items
+	status.err = err
+	if err == nil && items > 0 {
+		status.lastIntegration = start
+	}
+}
+
+// heldIDs returns the IDs of the logs this instance acted as master for in
+// the most recent pass.
+func (l *LogOperationManager) heldIDs() []int64 {
+	l.heldMutex.Lock()
+	defer l.heldMutex.Unlock()
+	ids := make([]int64, len(l.lastHeld))
+	copy(ids, l.lastHeld)
+	return ids
+}
+
+// requestResignation asks the election runner of logID to resign mastership
+// at its next mastership check. Returns an error if this instance isn't
+// running an election for logID.
+func (l *LogOperationManager) requestResignation(logID int64) error {
+	l.runnerMutex.Lock()
+	er := l.electionRunner[logID]
+	l.runnerMutex.Unlock()
+	if er == nil || er.resignRequests == nil {
+		return fmt.Errorf("no mastership election for log %v", logID)
+	}
+	select {
+	case er.resignRequests <- struct{}{}:
+	default:
+		// A resignation is already pending.
+	}
+	return nil
+}
+
 // OperationSingle performs a single pass of the manager.
 func (l *LogOperationManager) OperationSingle(ctx context.Context) {
 	if err := l.getLogsAndExecutePass(ctx); err != nil {
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"math"
 	"reflect"
+	"strconv"
 	"sync"
 	"testing"
 	"time"
@@ -261,6 +262,32 @@ func TestShouldResign(t *testing.T) {
 	}
 }
 
+// neverCampaignBalancer is a MastershipBalancer that always defers campaigns.
+type neverCampaignBalancer struct{}
+
+func (neverCampaignBalancer) Update(context.Context, []int64, storage.CountByLogID, []int64) {}
+func (neverCampaignBalancer) ShouldCampaign(int64) bool                                      { return false }
+func (neverCampaignBalancer) ShouldResign(int64) bool                                        { return false }
+
+func TestAwaitCampaignExpires(t *testing.T) {
+	once.Do(func() { createMetrics(nil) })
+	const logID = 6962
+	// With no hold interval, the deferral expires immediately.
+	info := LogOperationInfo{
+		Balancer:   neverCampaignBalancer{},
+		TimeSource: util.NewFakeTimeSource(time.Now()),
+	}
+	er := electionRunner{logID: logID, info: &info}
+
+	before := expiredDeferrals.Value(strconv.Itoa(logID))
+	if !er.awaitCampaign(context.Background()) {
+		t.Fatal("awaitCampaign() = false, want true")
+	}
+	if got, want := expiredDeferrals.Value(strconv.Itoa(logID))-before, 1.0; got != want {
+		t.Errorf("expired_campaign_deferrals increased by %v, want %v", got, want)
+	}
+}
+
 func TestMasterFor(t *testing.T) {
 	ctx := context.Background()
 	firstIDs := []int64{1, 2, 3, 4}
@@ -309,6 +336,29 @@ func TestMasterFor(t *testing.T) {
 	}
 }
 
+func TestFencedContext(t *testing.T) {
+	ctx := context.Background()
+	lom := NewLogOperationManager(LogOperationInfo{TimeSource: util.SystemTimeSource{}}, nil)
+	lom.electionRunner[1] = &electionRunner{logID: 1, election: &testElection{isMaster: true}}
+	lom.electionRunner[2] = &electionRunner{logID: 2, election: &fencedTestElection{testElection: testElection{isMaster: true}, epoch: 7}}
+
+	var tests = []struct {
+		logID     int64
+		wantToken int64
+		wantOK    bool
+	}{
+		{logID: 1},
+		{logID: 2, wantToken: 7, wantOK: true},
+		{logID: 3},
+	}
+	for _, test := range tests {
+		token, ok := storage.FencingTokenFromContext(lom.fencedContext(ctx, test.logID))
+		if token != test.wantToken || ok != test.wantOK {
+			t.Errorf("fencedContext(%v) has token %v,%v; want %v,%v", test.logID, token, ok, test.wantToken, test.wantOK)
+		}
+	}
+}
+
 type masterForEvenFactory struct{}
 
 func (m masterForEvenFactory) NewElection(ctx context.Context, treeID int64) (util.MasterElection, error) {
@@ -355,6 +405,15 @@ func (te *testElection) Close(ctx context.Context) error {
 	return te.closeErr
 }
 
+type fencedTestElection struct {
+	testElection
+	epoch int64
+}
+
+func (fe *fencedTestElection) Epoch() int64 {
+	return fe.epoch
+}
+
 type failureFactory struct{}
 
 func (ff failureFactory) NewElection(ctx context.Context, treeID int64) (util.MasterElection, error) {
@@ -16,7 +16,9 @@ package server
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
+	"strings"
 
 	"github.com/golang/glog"
 	"github.com/google/trillian"
@@ -138,6 +140,16 @@ func (t *TrillianLogRPCServer) QueueLeaves(ctx context.Context, req *trillian.Qu
 	if err := hashLeaves(req.Leaves, hasher); err != nil {
 		return nil, err
 	}
+	// The sequencer records the same attribute on its spans, so that the
+	// integration of queued leaves can be traced.
+	span.AddAttributes(trace.Int64Attribute("log_id", logID))
+	if span.IsRecordingEvents() {
+		hashes := make([]string, 0, len(req.Leaves))
+		for _, leaf := range req.Leaves {
+			hashes = append(hashes, hex.EncodeToString(leaf.LeafIdentityHash))
+		}
+		span.AddAttributes(trace.StringAttribute("leaf_identity_hashes", strings.Join(hashes, ",")))
+	}
 
 	ret, err := t.registry.LogStorage.QueueLeaves(ctx, tree, req.Leaves, t.timeSource.Now())
 	if err != nil {
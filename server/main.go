@@ -27,6 +27,7 @@ import (
 	"github.com/google/trillian/monitoring"
 	"github.com/google/trillian/server/admin"
 	"github.com/google/trillian/server/interceptor"
+	"github.com/google/trillian/storage"
 	"github.com/google/trillian/util"
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -48,6 +49,10 @@ const (
 	// hard-deleting them.
 	// Actual runs happen randomly between [minInterval,2*minInterval).
 	DefaultTreeDeleteMinInterval = 4 * time.Hour
+
+	// DefaultTreePurgeChunkSize is the suggested maximum number of rows deleted
+	// per transaction when purging the data of hard-deleted trees.
+	DefaultTreePurgeChunkSize = 1000
 )
 
 // Main encapsulates the data and logic to start a Trillian server (Log or Map).
@@ -70,6 +75,9 @@ type Main struct {
 	RegisterHandlerFn func(context.Context, *runtime.ServeMux, string, []grpc.DialOption) error
 	// RegisterServerFn is called to register RPC servers.
 	RegisterServerFn func(*grpc.Server, extension.Registry) error
+	// HTTPHandlers are additional handlers served by the HTTP server, keyed by
+	// the pattern they are registered under.
+	HTTPHandlers map[string]http.Handler
 
 	// IsHealthy will be called whenever "/healthz" is called on the mux.
 	// A nil return value from this function will result in a 200-OK response
@@ -86,6 +94,10 @@ type Main struct {
 	TreeGCEnabled         bool
 	TreeDeleteThreshold   time.Duration
 	TreeDeleteMinInterval time.Duration
+	// TreePurgeChunkSize is the maximum number of rows deleted per transaction
+	// when purging the data of hard-deleted trees, if the AdminStorage
+	// implements storage.TreePurger.
+	TreePurgeChunkSize int64
 
 	// These will be added to the GRPC server options.
 	ExtraOptions []grpc.ServerOption
@@ -139,6 +151,9 @@ func (m *Main) Run(ctx context.Context) error {
 		http.Handle("/", gatewayMux)
 		http.Handle("/metrics", promhttp.Handler())
 		http.HandleFunc("/healthz", m.healthz)
+		for pattern, handler := range m.HTTPHandlers {
+			http.Handle(pattern, handler)
+		}
 
 		go func() {
 			glog.Infof("HTTP server starting on %v", endpoint)
@@ -174,6 +189,22 @@ func (m *Main) Run(ctx context.Context) error {
 				m.Registry.MetricFactory)
 			gc.Run(ctx)
 		}()
+
+		if purger, ok := m.Registry.AdminStorage.(storage.TreePurger); ok {
+			chunkSize := m.TreePurgeChunkSize
+			if chunkSize <= 0 {
+				chunkSize = DefaultTreePurgeChunkSize
+			}
+			go func() {
+				glog.Info("Tree data purger started")
+				p := admin.NewTreeDataPurger(
+					purger,
+					chunkSize,
+					m.TreeDeleteMinInterval,
+					m.Registry.MetricFactory)
+				p.Run(ctx)
+			}()
+		}
 	}
 
 	if err := srv.Serve(lis); err != nil {
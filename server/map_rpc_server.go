@@ -69,7 +69,7 @@ func (t *TrillianMapServer) IsHealthy() error {
 func (t *TrillianMapServer) GetLeaves(ctx context.Context, req *trillian.GetMapLeavesRequest) (*trillian.GetMapLeavesResponse, error) {
 	ctx, span := spanFor(ctx, "GetLeaves")
 	defer span.End()
-	return t.getLeavesByRevision(ctx, req.MapId, req.Index, mostRecentRevision)
+	return t.getLeavesByRevision(ctx, req.MapId, req.Index, mostRecentRevision, req.ProofFormat)
 }
 
 // GetLeavesByRevision implements the GetLeavesByRevision RPC method.
@@ -79,10 +79,17 @@ func (t *TrillianMapServer) GetLeavesByRevision(ctx context.Context, req *trilli
 	if req.Revision < 0 {
 		return nil, fmt.Errorf("map revision %d must be >= 0", req.Revision)
 	}
-	return t.getLeavesByRevision(ctx, req.MapId, req.Index, req.Revision)
+	return t.getLeavesByRevision(ctx, req.MapId, req.Index, req.Revision, req.ProofFormat)
 }
 
-func (t *TrillianMapServer) getLeavesByRevision(ctx context.Context, mapID int64, indices [][]byte, revision int64) (*trillian.GetMapLeavesResponse, error) {
+func (t *TrillianMapServer) getLeavesByRevision(ctx context.Context, mapID int64, indices [][]byte, revision int64, format trillian.MapProofFormat) (*trillian.GetMapLeavesResponse, error) {
+	switch format {
+	case trillian.MapProofFormat_MAP_PROOF_FORMAT_FULL,
+		trillian.MapProofFormat_MAP_PROOF_FORMAT_COMPRESSED,
+		trillian.MapProofFormat_MAP_PROOF_FORMAT_MULTI:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported proof format: %v", format)
+	}
 	tree, hasher, err := t.getTreeAndHasher(ctx, mapID, optsMapRead)
 	if err != nil {
 		return nil, fmt.Errorf("could not get map %v: %v", mapID, err)
@@ -148,19 +155,32 @@ func (t *TrillianMapServer) getLeavesByRevision(ctx context.Context, mapID int64
 			}
 		}
 
-		// Fetch the proof regardless of whether the leaf exists.
-		proof, err := smtReader.InclusionProof(ctx, int64(mapRoot.Revision), index)
-		if err != nil {
-			return nil, fmt.Errorf("could not get inclusion proof for leaf %x: %v", index, err)
+		inclusion := &trillian.MapLeafInclusion{Leaf: leaf}
+		if format != trillian.MapProofFormat_MAP_PROOF_FORMAT_MULTI {
+			// Fetch the proof regardless of whether the leaf exists.
+			proof, err := smtReader.InclusionProof(ctx, int64(mapRoot.Revision), index)
+			if err != nil {
+				return nil, fmt.Errorf("could not get inclusion proof for leaf %x: %v", index, err)
+			}
+			inclusion.Inclusion = proof
+			if format == trillian.MapProofFormat_MAP_PROOF_FORMAT_COMPRESSED {
+				inclusion.InclusionBitmap, inclusion.Inclusion = merkle.CompressMapInclusionProof(proof)
+			}
 		}
-
-		inclusions = append(inclusions, &trillian.MapLeafInclusion{
-			Leaf:      leaf,
-			Inclusion: proof,
-		})
+		inclusions = append(inclusions, inclusion)
 	}
 	glog.V(1).Infof("%v: wanted %v leaves, found %v", mapID, len(indices), found)
 
+	var multiInclusion *trillian.MapMultiInclusionProof
+	if format == trillian.MapProofFormat_MAP_PROOF_FORMAT_MULTI {
+		proof, err := smtReader.MultiInclusionProof(ctx, int64(mapRoot.Revision), indices)
+		if err != nil {
+			return nil, fmt.Errorf("could not get multi inclusion proof: %v", err)
+		}
+		multiInclusion = &trillian.MapMultiInclusionProof{}
+		multiInclusion.Bitmap, multiInclusion.Hashes = merkle.CompressMapInclusionProof(proof)
+	}
+
 	if err := tx.Commit(); err != nil {
 		return nil, fmt.Errorf("could not commit db transaction: %v", err)
 	}
@@ -168,6 +188,7 @@ func (t *TrillianMapServer) getLeavesByRevision(ctx context.Context, mapID int64
 	return &trillian.GetMapLeavesResponse{
 		MapLeafInclusion: inclusions,
 		MapRoot:          root,
+		MultiInclusion:   multiInclusion,
 	}, nil
 }
 
@@ -0,0 +1,256 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/golang/glog"
+	"github.com/google/trillian/monitoring"
+	"github.com/google/trillian/storage"
+)
+
+var (
+	balancerMetricsOnce sync.Once
+	logLoad             monitoring.Gauge
+	heldLoad            monitoring.Gauge
+	fairShareLoad       monitoring.Gauge
+	deferredCampaigns   monitoring.Counter
+)
+
+func createBalancerMetrics(mf monitoring.MetricFactory) {
+	if mf == nil {
+		mf = monitoring.InertMetricFactory{}
+	}
+	logLoad = mf.NewGauge("balancer_log_load", "Load of each log, as seen by the mastership balancer", logIDLabel)
+	heldLoad = mf.NewGauge("balancer_held_load", "Total load of the logs this instance is master for")
+	fairShareLoad = mf.NewGauge("balancer_fair_share_load", "Share of the total load of all logs that this instance aims to be master for")
+	deferredCampaigns = mf.NewCounter("balancer_deferred_campaigns", "Number of times campaigning for mastership was deferred to balance load", logIDLabel)
+}
+
+// MastershipBalancer is a strategy for spreading mastership of logs across
+// signers. It is used by LogOperationManager in place of random resignations.
+type MastershipBalancer interface {
+	// Update is called at the start of each pass with the IDs of all active
+	// logs, their numbers of unsequenced leaves, and the IDs of the logs this
+	// instance is master for.
+	Update(ctx context.Context, allIDs []int64, queued storage.CountByLogID, held []int64)
+	// ShouldCampaign returns whether to campaign for mastership of logID now.
+	ShouldCampaign(logID int64) bool
+	// ShouldResign returns whether to resign mastership of logID, once it has
+	// been held for the minimum interval.
+	ShouldResign(logID int64) bool
+}
+
+// SignerCounter returns the number of signers currently sharing the logs.
+type SignerCounter func(ctx context.Context) (int, error)
+
+// StaticSignerCounter returns a SignerCounter that always returns n. It's up
+// to operators to keep n equal to the number of running signers: if fewer are
+// running, logs may be left without a master for up to the master hold
+// interval at a time, which is counted by the expired_campaign_deferrals
+// metric.
+func StaticSignerCounter(n int) SignerCounter {
+	return func(context.Context) (int, error) {
+		return n, nil
+	}
+}
+
+// EtcdSignerCounter returns a SignerCounter that counts the signers announced
+// under etcdService by AnnounceSelf. Announcements expire with their etcd
+// lease, so signers that stop are no longer counted.
+func EtcdSignerCounter(client *clientv3.Client, etcdService string) SignerCounter {
+	return func(ctx context.Context) (int, error) {
+		resp, err := client.Get(ctx, etcdService+"/", clientv3.WithPrefix(), clientv3.WithCountOnly())
+		if err != nil {
+			return 0, err
+		}
+		return int(resp.Count), nil
+	}
+}
+
+// LoadBalancer is a MastershipBalancer that spreads the load of logs evenly
+// across the signers counted by a SignerCounter.
+//
+// The load of a log is 1 plus its number of unsequenced leaves, so that idle
+// logs are spread by count. Each signer aims to be master for its fair share
+// of the total load, i.e. the total divided by the number of signers. It
+// resigns mastership of logs while its load exceeds its share by more than the
+// tolerance, as long as doing so doesn't take it below its share; and doesn't
+// campaign for logs that would take its load over its share, unless it holds
+// no logs.
+//
+// The balancer fails closed: if the signers can't be counted, it stops
+// balancing until they can, so that it campaigns for all logs and resigns
+// none of them.
+type LoadBalancer struct {
+	counter   SignerCounter
+	tolerance float64
+
+	mu sync.Mutex
+	// numSigners is the number of signers counted by the last Update, or 0
+	// if they couldn't be counted.
+	numSigners int
+	// loads holds the load of each active log.
+	loads map[int64]int64
+	// total is the sum of loads.
+	total int64
+	// held holds the logs this instance is master for, or is expected to be
+	// master for shortly.
+	held map[int64]bool
+	// heldLoad is the sum of the loads of held logs.
+	heldLoad int64
+}
+
+// NewLoadBalancer returns a LoadBalancer for the signers counted by counter,
+// which tolerates loads up to tolerance (as a fraction) over the fair share.
+func NewLoadBalancer(counter SignerCounter, tolerance float64, mf monitoring.MetricFactory) *LoadBalancer {
+	balancerMetricsOnce.Do(func() {
+		createBalancerMetrics(mf)
+	})
+	return &LoadBalancer{
+		counter:   counter,
+		tolerance: tolerance,
+		loads:     make(map[int64]int64),
+		held:      make(map[int64]bool),
+	}
+}
+
+// Update counts the signers, and records the current load of all logs and the
+// logs this instance is master for.
+func (b *LoadBalancer) Update(ctx context.Context, allIDs []int64, queued storage.CountByLogID, held []int64) {
+	numSigners, err := b.counter(ctx)
+	if err == nil && numSigners < 1 {
+		err = errors.New("no signers found")
+	}
+	if err != nil {
+		glog.Warningf("Failed to count signers, suspending mastership balancing: %v", err)
+		numSigners = 0
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.numSigners = numSigners
+	b.loads = make(map[int64]int64, len(allIDs))
+	b.total = 0
+	for _, id := range allIDs {
+		load := 1 + queued[id]
+		b.loads[id] = load
+		b.total += load
+		logLoad.Set(float64(load), strconv.FormatInt(id, 10))
+	}
+	b.held = make(map[int64]bool, len(held))
+	b.heldLoad = 0
+	for _, id := range held {
+		b.held[id] = true
+		b.heldLoad += b.loads[id]
+	}
+	heldLoad.Set(float64(b.heldLoad))
+	fairShareLoad.Set(b.fairShare())
+}
+
+// fairShare returns the load this instance should be master for, which is
+// the total load if the signers couldn't be counted. Must be called with mu
+// held.
+func (b *LoadBalancer) fairShare() float64 {
+	if b.numSigners < 1 {
+		return float64(b.total)
+	}
+	return float64(b.total) / float64(b.numSigners)
+}
+
+// load returns the load of logID, which is 1 for logs not yet seen by Update.
+// Must be called with mu held.
+func (b *LoadBalancer) load(logID int64) int64 {
+	if load, ok := b.loads[logID]; ok {
+		return load
+	}
+	return 1
+}
+
+// ShouldCampaign returns true if mastership of logID wouldn't take the load
+// of this instance over its fair share, if it holds no logs, or if the signers
+// couldn't be counted. If so, logID is counted as held until the next Update.
+func (b *LoadBalancer) ShouldCampaign(logID int64) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.held[logID] {
+		return true
+	}
+	load := b.load(logID)
+	if b.numSigners > 0 && b.heldLoad > 0 && float64(b.heldLoad+load) > b.fairShare()*(1+b.tolerance) {
+		deferredCampaigns.Inc(strconv.FormatInt(logID, 10))
+		return false
+	}
+	b.held[logID] = true
+	b.heldLoad += load
+	return true
+}
+
+// ShouldResign returns true if the load of this instance exceeds its fair
+// share by more than the tolerance, and would still be at least its fair share
+// without logID. If so, logID is no longer counted as held. It returns false
+// if the signers couldn't be counted.
+func (b *LoadBalancer) ShouldResign(logID int64) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.numSigners < 1 {
+		return false
+	}
+	share := b.fairShare()
+	load := b.load(logID)
+	if float64(b.heldLoad) <= share*(1+b.tolerance) || float64(b.heldLoad-load) < share {
+		return false
+	}
+	if b.held[logID] {
+		delete(b.held, logID)
+		b.heldLoad -= load
+	}
+	return true
+}
+
+// ServeHTTP writes the current assignment of logs as seen by the balancer, as
+// plain text.
+func (b *LoadBalancer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ids := make([]int64, 0, len(b.loads))
+	for id := range b.loads {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	signers := strconv.Itoa(b.numSigners)
+	if b.numSigners < 1 {
+		signers = "unknown, balancing suspended"
+	}
+	fmt.Fprintf(w, "signers: %s\ntotal load: %d\nfair share: %.1f\nheld load: %d\n\n", signers, b.total, b.fairShare(), b.heldLoad)
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "log\tload\tmaster")
+	for _, id := range ids {
+		fmt.Fprintf(tw, "%d\t%d\t%v\n", id, b.loads[id], b.held[id])
+	}
+	tw.Flush()
+}
@@ -0,0 +1,122 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/trillian/storage"
+)
+
+func TestLoadBalancer(t *testing.T) {
+	ctx := context.Background()
+	allIDs := []int64{1, 2, 3, 4}
+	// Loads are 1 + queued: 10, 1, 5 and 4, for a total of 20.
+	queued := storage.CountByLogID{1: 9, 3: 4, 4: 3}
+
+	var tests = []struct {
+		desc         string
+		counter      SignerCounter
+		held         []int64
+		wantCampaign map[int64]bool
+		wantResign   map[int64]bool
+	}{
+		{
+			desc:    "holdsNothing",
+			counter: StaticSignerCounter(2),
+			// Logs campaigned for count towards the held load.
+			wantCampaign: map[int64]bool{1: true, 2: true, 3: false, 4: false},
+			wantResign:   map[int64]bool{1: false, 2: false, 3: false, 4: false},
+		},
+		{
+			desc:         "holdsFairShare",
+			counter:      StaticSignerCounter(2),
+			held:         []int64{1},
+			wantCampaign: map[int64]bool{1: true, 2: true, 4: false},
+			wantResign:   map[int64]bool{1: false},
+		},
+		{
+			desc:    "holdsEverything",
+			counter: StaticSignerCounter(2),
+			held:    allIDs,
+			// Resigning log 1 leaves a load of 10, so others aren't resigned.
+			wantResign: map[int64]bool{1: true, 2: false, 3: false, 4: false},
+		},
+		{
+			desc:         "singleSigner",
+			counter:      StaticSignerCounter(1),
+			held:         []int64{1},
+			wantCampaign: map[int64]bool{2: true, 3: true, 4: true},
+			wantResign:   map[int64]bool{1: false, 2: false, 3: false, 4: false},
+		},
+		{
+			desc: "countFailed",
+			counter: func(context.Context) (int, error) {
+				return 0, errors.New("count failed")
+			},
+			held: []int64{1, 2},
+			// Balancing is suspended: all logs are campaigned for and kept.
+			wantCampaign: map[int64]bool{3: true, 4: true},
+			wantResign:   map[int64]bool{1: false, 2: false, 3: false, 4: false},
+		},
+		{
+			desc:       "noSigners",
+			counter:    StaticSignerCounter(0),
+			held:       allIDs,
+			wantResign: map[int64]bool{1: false, 2: false, 3: false, 4: false},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			b := NewLoadBalancer(test.counter, 0.1, nil)
+			b.Update(ctx, allIDs, queued, test.held)
+			for _, id := range allIDs {
+				if want, ok := test.wantCampaign[id]; ok {
+					if got := b.ShouldCampaign(id); got != want {
+						t.Errorf("ShouldCampaign(%v) = %v, want %v", id, got, want)
+					}
+				}
+			}
+
+			b.Update(ctx, allIDs, queued, test.held)
+			for _, id := range allIDs {
+				if want, ok := test.wantResign[id]; ok {
+					if got := b.ShouldResign(id); got != want {
+						t.Errorf("ShouldResign(%v) = %v, want %v", id, got, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestLoadBalancer_ServeHTTP(t *testing.T) {
+	b := NewLoadBalancer(StaticSignerCounter(2), 0.1, nil)
+	b.Update(context.Background(), []int64{1, 2}, storage.CountByLogID{2: 5}, []int64{2})
+
+	w := httptest.NewRecorder()
+	b.ServeHTTP(w, httptest.NewRequest("GET", "/assignment", nil))
+	body := w.Body.String()
+	for _, want := range []string{"total load: 7", "fair share: 3.5", "held load: 6", "1    1     false", "2    6     true"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("ServeHTTP() wrote %q, want it to contain %q", body, want)
+		}
+	}
+}
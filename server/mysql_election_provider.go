@@ -0,0 +1,35 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian/util"
+	"github.com/google/trillian/util/mysql"
+)
+
+// NewMySQLElectionFactory returns an ElectionFactory whose elections are
+// leases held in the MySQL database of the storage provider. The storage
+// provider must have been created with --storage_system=mysql.
+func NewMySQLElectionFactory(instanceID string, leaseDuration time.Duration) (util.ElectionFactory, error) {
+	if mySQLstorageInstance == nil {
+		return nil, errors.New("MySQL election requires --storage_system=mysql")
+	}
+	glog.Info("Using MySQL master election")
+	return mysql.NewElectionFactory(instanceID, mySQLstorageInstance.db, leaseDuration), nil
+}
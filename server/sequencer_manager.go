@@ -26,7 +26,9 @@ import (
 	"github.com/google/trillian/extension"
 	"github.com/google/trillian/log"
 	"github.com/google/trillian/merkle/hashers"
+	"github.com/google/trillian/storage"
 	"github.com/google/trillian/trees"
+	"go.opencensus.io/trace"
 
 	tcrypto "github.com/google/trillian/crypto"
 )
@@ -35,19 +37,27 @@ import (
 type SequencerManager struct {
 	guardWindow  time.Duration
 	registry     extension.Registry
-	signers      map[int64]*tcrypto.Signer
+	signers      map[int64]cachedSigner
 	signersMutex sync.Mutex
 }
 
+// cachedSigner is a signer for a tree, and the version of the tree's key that
+// it was created for.
+type cachedSigner struct {
+	keyVersion int64
+	signer     *tcrypto.Signer
+}
+
 var seqOpts = trees.NewGetOpts(trees.SequenceLog, trillian.TreeType_LOG, trillian.TreeType_PREORDERED_LOG)
 
 // NewSequencerManager creates a new SequencerManager instance based on the provided KeyManager instance
 // and guard window.
 func NewSequencerManager(registry extension.Registry, gw time.Duration) *SequencerManager {
+	transitionMetricsOnce.Do(func() { createTransitionMetrics(registry.MetricFactory) })
 	return &SequencerManager{
 		guardWindow: gw,
 		registry:    registry,
-		signers:     make(map[int64]*tcrypto.Signer),
+		signers:     make(map[int64]cachedSigner),
 	}
 }
 
@@ -58,8 +68,20 @@ func (s *SequencerManager) Name() string {
 
 // ExecutePass performs sequencing for the specified Log.
 func (s *SequencerManager) ExecutePass(ctx context.Context, logID int64, info *LogOperationInfo) (int, error) {
-	// TODO(Martin2112): Honor the sequencing enabled in log parameters, needs an API change
-	// so deferring it
+	ctx, span := spanFor(ctx, "SequencerManager.ExecutePass")
+	defer span.End()
+	span.AddAttributes(trace.Int64Attribute("log_id", logID))
+
+	if cs, ok := s.registry.AdminStorage.(storage.TreeControlStorage); ok {
+		control, err := cs.GetTreeControl(ctx, logID)
+		if err != nil {
+			return 0, fmt.Errorf("error retrieving controls of log %v: %v", logID, err)
+		}
+		if !control.SequencingEnabled || !control.SigningEnabled {
+			glog.V(1).Infof("%v: sequencing paused", logID)
+			return 0, nil
+		}
+	}
 
 	tree, err := trees.GetTree(ctx, s.registry.AdminStorage, logID, seqOpts)
 	if err != nil {
@@ -88,17 +110,19 @@ func (s *SequencerManager) ExecutePass(ctx context.Context, logID int64, info *L
 	if err != nil {
 		return 0, fmt.Errorf("failed to integrate batch for %v: %v", logID, err)
 	}
+	s.applyScheduledTransition(ctx, tree, info.TimeSource.Now())
 	return leaves, nil
 }
 
 // getSigner returns a signer for the given tree.
-// Signers are cached, so only one will be created per tree.
+// Signers are cached, so only one will be created per tree and key version:
+// the cached signer is replaced once the tree's key is rotated.
 func (s *SequencerManager) getSigner(ctx context.Context, tree *trillian.Tree) (*tcrypto.Signer, error) {
 	s.signersMutex.Lock()
 	defer s.signersMutex.Unlock()
 
-	if signer, ok := s.signers[tree.GetTreeId()]; ok {
-		return signer, nil
+	if cached, ok := s.signers[tree.GetTreeId()]; ok && cached.keyVersion == tree.GetKeyVersion() {
+		return cached.signer, nil
 	}
 
 	signer, err := trees.Signer(ctx, tree)
@@ -106,6 +130,6 @@ func (s *SequencerManager) getSigner(ctx context.Context, tree *trillian.Tree) (
 		return nil, err
 	}
 
-	s.signers[tree.GetTreeId()] = signer
+	s.signers[tree.GetTreeId()] = cachedSigner{keyVersion: tree.GetKeyVersion(), signer: signer}
 	return signer, nil
 }
@@ -15,6 +15,7 @@
 package server
 
 import (
+	"bytes"
 	"context"
 	"crypto"
 	"errors"
@@ -133,6 +134,28 @@ func TestSequencerManagerSingleLogNoLeaves(t *testing.T) {
 	sm.ExecutePass(ctx, logID, createTestInfo(registry))
 }
 
+func TestSequencerManagerPausedLog(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	_, as, logID := newMemoryLog(ctx, t)
+	if err := as.(storage.TreeControlStorage).UpdateTreeControl(ctx, logID, &storage.TreeControl{SigningEnabled: true}); err != nil {
+		t.Fatalf("UpdateTreeControl(): %v", err)
+	}
+
+	registry := extension.Registry{
+		AdminStorage: as,
+		// Log storage isn't expected to be used for paused logs.
+		LogStorage:   storage.NewMockLogStorage(mockCtrl),
+		QuotaManager: quota.Noop(),
+	}
+	sm := NewSequencerManager(registry, zeroDuration)
+	if n, err := sm.ExecutePass(ctx, logID, createTestInfo(registry)); n != 0 || err != nil {
+		t.Errorf("ExecutePass() = (%v, %v), want (0, nil)", n, err)
+	}
+}
+
 func TestSequencerManagerCachesSigners(t *testing.T) {
 	ctx := context.Background()
 	mockCtrl := gomock.NewController(t)
@@ -188,6 +211,38 @@ func TestSequencerManagerCachesSigners(t *testing.T) {
 	}
 }
 
+func TestSequencerManagerReplacesSignerOnKeyRotation(t *testing.T) {
+	ctx := context.Background()
+	var keyProto ptypes.DynamicAny
+	if err := ptypes.UnmarshalAny(stestonly.LogTree.PrivateKey, &keyProto); err != nil {
+		t.Fatalf("Failed to unmarshal stestonly.LogTree.PrivateKey: %v", err)
+	}
+	keys.RegisterHandler(fakeKeyProtoHandler(keyProto.Message, fixedGoSigner, nil))
+	defer keys.UnregisterHandler(keyProto.Message)
+
+	sm := NewSequencerManager(extension.Registry{}, zeroDuration)
+	tree := proto.Clone(stestonly.LogTree).(*trillian.Tree)
+	first, err := sm.getSigner(ctx, tree)
+	if err != nil {
+		t.Fatalf("getSigner(): %v", err)
+	}
+	if again, err := sm.getSigner(ctx, tree); err != nil || again != first {
+		t.Errorf("getSigner() = (%p, %v), want cached signer %p", again, err, first)
+	}
+
+	tree.KeyVersion = 1
+	rotated, err := sm.getSigner(ctx, tree)
+	if err != nil {
+		t.Fatalf("getSigner() after rotation: %v", err)
+	}
+	if rotated == first {
+		t.Error("getSigner() after rotation returned the signer of the previous key version")
+	}
+	if got, want := rotated.KeyHint, types.SerializeKeyVersionHint(tree.TreeId, 1); !bytes.Equal(got, want) {
+		t.Errorf("getSigner() after rotation: KeyHint = %x, want %x", got, want)
+	}
+}
+
 // Test that sequencing is skipped if no signer is available.
 func TestSequencerManagerSingleLogNoSigner(t *testing.T) {
 	ctx := context.Background()
@@ -0,0 +1,302 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SignerStatusHandler serves the state of a LogOperationManager over HTTP.
+//
+// GET /status shows the logs this instance is master for, the results of the
+// most recent passes, and the queue depth and controls of each log.
+type SignerStatusHandler struct {
+	lom *LogOperationManager
+	mux *http.ServeMux
+}
+
+// NewSignerStatusHandler returns a SignerStatusHandler for lom.
+func NewSignerStatusHandler(lom *LogOperationManager) *SignerStatusHandler {
+	h := &SignerStatusHandler{lom: lom, mux: http.NewServeMux()}
+	h.mux.HandleFunc("/status", h.serveStatus)
+	return h
+}
+
+// ServeHTTP implements http.Handler.
+func (h *SignerStatusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// SignerControlHandler lets operators control the processing of individual
+// logs by a LogOperationManager over HTTP.
+//
+// POST /pause?log_id=N disables sequencing of log N, and POST /resume?log_id=N
+// re-enables it, through the log's storage.TreeControl. These affect all
+// signers, and require AdminStorage that implements
+// storage.TreeControlStorage.
+//
+// POST /resign?log_id=N makes this instance resign mastership of log N at its
+// next mastership check. It re-joins the election afterwards, so other
+// instances may or may not take over.
+//
+// Requests must carry the handler's token as "Authorization: Bearer <token>",
+// and parameters may not be sent as a form body. A browser therefore can't be
+// made to send requests from another site. The handler should still only be
+// served on endpoints reachable by operators.
+type SignerControlHandler struct {
+	lom   *LogOperationManager
+	token string
+	mux   *http.ServeMux
+}
+
+// NewSignerControlHandler returns a SignerControlHandler for lom, which
+// accepts requests carrying token. If token is empty, all requests are
+// refused.
+func NewSignerControlHandler(lom *LogOperationManager, token string) *SignerControlHandler {
+	h := &SignerControlHandler{lom: lom, token: token, mux: http.NewServeMux()}
+	h.mux.HandleFunc("/pause", h.post(h.pause))
+	h.mux.HandleFunc("/resume", h.post(h.resume))
+	h.mux.HandleFunc("/resign", h.post(h.resign))
+	return h
+}
+
+// ServeHTTP implements http.Handler.
+func (h *SignerControlHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// authorized returns true if r carries the handler's token.
+func (h *SignerControlHandler) authorized(r *http.Request) bool {
+	const prefix = "Bearer "
+	auth := r.Header.Get("Authorization")
+	if h.token == "" || !strings.HasPrefix(auth, prefix) {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(auth[len(prefix):]), []byte(h.token)) == 1
+}
+
+// isForm returns true if r has a body that browsers may send in a cross-site
+// form submission.
+func isForm(r *http.Request) bool {
+	ct := r.Header.Get("Content-Type")
+	if ct == "" {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(ct)
+	if err != nil {
+		return true
+	}
+	switch mediaType {
+	case "application/x-www-form-urlencoded", "multipart/form-data", "text/plain":
+		return true
+	}
+	return false
+}
+
+// post returns a handler that parses the log_id parameter of authorized POST
+// requests, and passes it to f. Errors returned by f are reported with the
+// HTTP status corresponding to their gRPC code.
+func (h *SignerControlHandler) post(f func(ctx context.Context, logID int64) (string, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if !h.authorized(r) {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if isForm(r) {
+			http.Error(w, "form requests not supported, pass log_id in the URL", http.StatusUnsupportedMediaType)
+			return
+		}
+		logID, err := strconv.ParseInt(r.URL.Query().Get("log_id"), 10, 64)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid log_id: %v", err), http.StatusBadRequest)
+			return
+		}
+		msg, err := f(r.Context(), logID)
+		if err != nil {
+			http.Error(w, err.Error(), httpStatus(err))
+			return
+		}
+		glog.Infof("%v: %s", logID, msg)
+		fmt.Fprintf(w, "log %d: %s\n", logID, msg)
+	}
+}
+
+func httpStatus(err error) int {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.FailedPrecondition:
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// controlStorage returns the TreeControlStorage of lom, if its AdminStorage
+// is one.
+func controlStorage(lom *LogOperationManager) (storage.TreeControlStorage, error) {
+	cs, ok := lom.info.Registry.AdminStorage.(storage.TreeControlStorage)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "storage doesn't support tree controls")
+	}
+	return cs, nil
+}
+
+// setSequencing enables or disables sequencing of the log, leaving its other
+// controls as they are.
+func (h *SignerControlHandler) setSequencing(ctx context.Context, logID int64, enabled bool) error {
+	cs, err := controlStorage(h.lom)
+	if err != nil {
+		return err
+	}
+	control, err := cs.GetTreeControl(ctx, logID)
+	if err != nil {
+		return err
+	}
+	control.SequencingEnabled = enabled
+	return cs.UpdateTreeControl(ctx, logID, control)
+}
+
+func (h *SignerControlHandler) pause(ctx context.Context, logID int64) (string, error) {
+	if err := h.setSequencing(ctx, logID, false); err != nil {
+		return "", err
+	}
+	return "sequencing paused", nil
+}
+
+func (h *SignerControlHandler) resume(ctx context.Context, logID int64) (string, error) {
+	if err := h.setSequencing(ctx, logID, true); err != nil {
+		return "", err
+	}
+	return "sequencing resumed", nil
+}
+
+func (h *SignerControlHandler) resign(ctx context.Context, logID int64) (string, error) {
+	if err := h.lom.requestResignation(logID); err != nil {
+		return "", status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return "resignation requested", nil
+}
+
+func (h *SignerStatusHandler) serveStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	lom := h.lom
+
+	held := make(map[int64]bool)
+	for _, id := range lom.heldIDs() {
+		held[id] = true
+	}
+
+	// Queue depths and controls are best effort: failures are reported on
+	// the page instead.
+	var queued storage.CountByLogID
+	queuedErr := func() error {
+		tx, err := lom.info.Registry.LogStorage.Snapshot(ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Close()
+		if queued, err = tx.GetUnsequencedCounts(ctx); err != nil {
+			return err
+		}
+		return tx.Commit()
+	}()
+	cs, _ := controlStorage(lom)
+
+	lom.statusMutex.Lock()
+	lastPass := lom.lastPass
+	logStatus := make(map[int64]logPassStatus, len(lom.logStatus))
+	for id, s := range lom.logStatus {
+		logStatus[id] = *s
+	}
+	lom.statusMutex.Unlock()
+
+	ids := make([]int64, 0, len(logStatus))
+	for id := range logStatus {
+		ids = append(ids, id)
+	}
+	for id := range held {
+		if _, ok := logStatus[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintf(w, "%s\n", lom.heldInfo(ctx, lom.heldIDs()))
+	if lastPass.start.IsZero() {
+		fmt.Fprintf(w, "last pass: none\n")
+	} else {
+		fmt.Fprintf(w, "last pass: started %s, took %v, %d succeeded, %d failed, %d items processed\n",
+			formatTime(lastPass.start), lastPass.duration, lastPass.succeeded, lastPass.failed, lastPass.items)
+	}
+	if queuedErr != nil {
+		fmt.Fprintf(w, "failed to count unsequenced leaves: %v\n", queuedErr)
+	}
+	fmt.Fprintln(w)
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "log\tmaster\tqueued\tsequencing\tsigning\tlast pass\titems\tlast integration\terror")
+	for _, id := range ids {
+		s := logStatus[id]
+		sequencing, signing := "?", "?"
+		if cs != nil {
+			if control, err := cs.GetTreeControl(ctx, id); err == nil {
+				sequencing, signing = enabledString(control.SequencingEnabled), enabledString(control.SigningEnabled)
+			}
+		}
+		errStr := ""
+		if s.err != nil {
+			errStr = s.err.Error()
+		}
+		fmt.Fprintf(tw, "%d\t%v\t%d\t%s\t%s\t%s\t%d\t%s\t%s\n",
+			id, held[id], queued[id], sequencing, signing, formatTime(s.lastPass), s.items, formatTime(s.lastIntegration), errStr)
+	}
+	tw.Flush()
+}
+
+func enabledString(b bool) string {
+	if b {
+		return "enabled"
+	}
+	return "paused"
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
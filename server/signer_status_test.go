@@ -0,0 +1,180 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/trillian/crypto/keys"
+	"github.com/google/trillian/crypto/keys/der"
+	"github.com/google/trillian/crypto/keyspb"
+	"github.com/google/trillian/extension"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/memory"
+
+	stestonly "github.com/google/trillian/storage/testonly"
+)
+
+// newMemoryLog returns memory storage holding a single log, and the log's ID.
+func newMemoryLog(ctx context.Context, t *testing.T) (storage.LogStorage, storage.AdminStorage, int64) {
+	t.Helper()
+	// Storage checks the key when creating trees.
+	keys.RegisterHandler(&keyspb.PrivateKey{}, func(ctx context.Context, pb proto.Message) (crypto.Signer, error) {
+		return der.FromProto(pb.(*keyspb.PrivateKey))
+	})
+	defer keys.UnregisterHandler(&keyspb.PrivateKey{})
+
+	ls := memory.NewLogStorage(nil)
+	as := memory.NewAdminStorage(ls)
+	tree, err := storage.CreateTree(ctx, as, stestonly.LogTree)
+	if err != nil {
+		t.Fatalf("CreateTree(): %v", err)
+	}
+	return ls, as, tree.TreeId
+}
+
+func TestSignerControlHandler(t *testing.T) {
+	ctx := context.Background()
+	ls, as, logID := newMemoryLog(ctx, t)
+	cs := as.(storage.TreeControlStorage)
+	lom := NewLogOperationManager(LogOperationInfo{
+		Registry:   extension.Registry{AdminStorage: as, LogStorage: ls},
+		TimeSource: fakeTimeSource,
+	}, nil)
+	h := NewSignerControlHandler(lom, "token")
+
+	for _, test := range []struct {
+		desc           string
+		method, path   string
+		auth           string
+		contentType    string
+		wantCode       int
+		wantSequencing bool
+	}{
+		{desc: "pauseGet", method: "GET", path: fmt.Sprintf("/pause?log_id=%d", logID), wantCode: http.StatusMethodNotAllowed, wantSequencing: true},
+		{desc: "pauseNoToken", method: "POST", path: fmt.Sprintf("/pause?log_id=%d", logID), auth: "-", wantCode: http.StatusUnauthorized, wantSequencing: true},
+		{desc: "pauseWrongToken", method: "POST", path: fmt.Sprintf("/pause?log_id=%d", logID), auth: "Bearer other", wantCode: http.StatusUnauthorized, wantSequencing: true},
+		{desc: "pauseForm", method: "POST", path: fmt.Sprintf("/pause?log_id=%d", logID), contentType: "application/x-www-form-urlencoded", wantCode: http.StatusUnsupportedMediaType, wantSequencing: true},
+		{desc: "pauseBadID", method: "POST", path: "/pause?log_id=abc", wantCode: http.StatusBadRequest, wantSequencing: true},
+		{desc: "pauseUnknownLog", method: "POST", path: fmt.Sprintf("/pause?log_id=%d", logID+1), wantCode: http.StatusNotFound, wantSequencing: true},
+		{desc: "pause", method: "POST", path: fmt.Sprintf("/pause?log_id=%d", logID), wantCode: http.StatusOK, wantSequencing: false},
+		{desc: "resume", method: "POST", path: fmt.Sprintf("/resume?log_id=%d", logID), contentType: "application/json", wantCode: http.StatusOK, wantSequencing: true},
+		// Resignation needs a running election for the log.
+		{desc: "resignNotMaster", method: "POST", path: fmt.Sprintf("/resign?log_id=%d", logID), wantCode: http.StatusConflict, wantSequencing: true},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			r := httptest.NewRequest(test.method, test.path, nil)
+			switch test.auth {
+			case "":
+				r.Header.Set("Authorization", "Bearer token")
+			case "-":
+			default:
+				r.Header.Set("Authorization", test.auth)
+			}
+			if test.contentType != "" {
+				r.Header.Set("Content-Type", test.contentType)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if got, want := w.Code, test.wantCode; got != want {
+				t.Errorf("%s %s: got status %v, want %v (body: %q)", test.method, test.path, got, want, w.Body.String())
+			}
+			control, err := cs.GetTreeControl(ctx, logID)
+			if err != nil {
+				t.Fatalf("GetTreeControl(): %v", err)
+			}
+			if got, want := control.SequencingEnabled, test.wantSequencing; got != want {
+				t.Errorf("SequencingEnabled = %v, want %v", got, want)
+			}
+			if !control.SigningEnabled {
+				t.Error("SigningEnabled = false, want true")
+			}
+		})
+	}
+
+	// Resuming sequencing leaves signing as it was.
+	if err := cs.UpdateTreeControl(ctx, logID, &storage.TreeControl{}); err != nil {
+		t.Fatalf("UpdateTreeControl(): %v", err)
+	}
+	r := httptest.NewRequest("POST", fmt.Sprintf("/resume?log_id=%d", logID), nil)
+	r.Header.Set("Authorization", "Bearer token")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if got, want := w.Code, http.StatusOK; got != want {
+		t.Fatalf("POST /resume: got status %v, want %v (body: %q)", got, want, w.Body.String())
+	}
+	control, err := cs.GetTreeControl(ctx, logID)
+	if err != nil {
+		t.Fatalf("GetTreeControl(): %v", err)
+	}
+	if want := (storage.TreeControl{SequencingEnabled: true}); *control != want {
+		t.Errorf("TreeControl after resume = %+v, want %+v", *control, want)
+	}
+
+	// Without a token, all requests are refused.
+	h = NewSignerControlHandler(lom, "")
+	r = httptest.NewRequest("POST", fmt.Sprintf("/pause?log_id=%d", logID), nil)
+	r.Header.Set("Authorization", "Bearer ")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if got, want := w.Code, http.StatusUnauthorized; got != want {
+		t.Errorf("POST /pause with no token configured: got status %v, want %v", got, want)
+	}
+}
+
+func TestSignerStatusHandler_Status(t *testing.T) {
+	ctx := context.Background()
+	ls, as, logID := newMemoryLog(ctx, t)
+	lom := NewLogOperationManager(LogOperationInfo{
+		Registry:   extension.Registry{AdminStorage: as, LogStorage: ls},
+		TimeSource: fakeTimeSource,
+	}, nil)
+	h := NewSignerStatusHandler(lom)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/status", nil))
+	if got, want := w.Body.String(), "last pass: none"; !strings.Contains(got, want) {
+		t.Errorf("status before any pass = %q, want it to contain %q", got, want)
+	}
+
+	lom.heldMutex.Lock()
+	lom.lastHeld = []int64{logID}
+	lom.heldMutex.Unlock()
+	lom.recordLogPass(logID, fakeTime, 3, nil)
+	lom.recordLogPass(logID+1, fakeTime, 0, errors.New("pass failed"))
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/status", nil))
+	body := w.Body.String()
+	for _, want := range []string{
+		"master for: " + stestonly.LogTree.DisplayName,
+		fmt.Sprintf("%d  true    0       enabled", logID),
+		"2016-06-28T13:40:12Z  3      2016-06-28T13:40:12Z",
+		fmt.Sprintf("%d  false   0       ?", logID+1),
+		"pass failed",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("status = %q, want it to contain %q", body, want)
+		}
+	}
+}
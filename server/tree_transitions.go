@@ -0,0 +1,172 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/google/trillian"
+	"github.com/google/trillian/monitoring"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/types"
+)
+
+const phaseLabel = "phase"
+
+var (
+	transitionMetricsOnce sync.Once
+	transitionPhases      monitoring.Counter
+	failedTransitions     monitoring.Counter
+)
+
+func createTransitionMetrics(mf monitoring.MetricFactory) {
+	if mf == nil {
+		mf = monitoring.InertMetricFactory{}
+	}
+	transitionPhases = mf.NewCounter("scheduled_transitions", "Number of scheduled tree state transitions that entered each phase", logIDLabel, phaseLabel)
+	failedTransitions = mf.NewCounter("failed_scheduled_transitions", "Number of times advancing a scheduled tree state transition has failed", logIDLabel)
+}
+
+// applyScheduledTransition advances the scheduled_transition of tree, if it
+// has one whose start_time has passed. Trees scheduled to be FROZEN are first
+// moved to DRAINING, and frozen on a later pass once drained (see isDrained).
+// Failures are logged and counted, but otherwise ignored: the transition is
+// retried on the next pass.
+func (s *SequencerManager) applyScheduledTransition(ctx context.Context, tree *trillian.Tree, now time.Time) {
+	if err := s.advanceTransition(ctx, tree, now); err != nil {
+		failedTransitions.Inc(strconv.FormatInt(tree.TreeId, 10))
+		glog.Warningf("%v: failed to apply scheduled transition: %v", tree.TreeId, err)
+	}
+}
+
+func (s *SequencerManager) advanceTransition(ctx context.Context, tree *trillian.Tree, now time.Time) error {
+	st := tree.ScheduledTransition
+	if st == nil {
+		return nil
+	}
+	start, err := ptypes.Timestamp(st.StartTime)
+	if err != nil {
+		return fmt.Errorf("malformed start_time: %v", err)
+	}
+	if now.Before(start) {
+		return nil
+	}
+
+	switch {
+	case tree.TreeState == trillian.TreeState_ACTIVE && st.TargetState == trillian.TreeState_FROZEN:
+		return s.updateTransition(ctx, tree, now, trillian.TreeState_DRAINING, trillian.TreeTransitionStatus_DRAINING)
+	case tree.TreeState == trillian.TreeState_DRAINING && st.TargetState == trillian.TreeState_FROZEN:
+		drained, err := s.isDrained(ctx, tree)
+		if err != nil || !drained {
+			return err
+		}
+		return s.updateTransition(ctx, tree, now, trillian.TreeState_FROZEN, trillian.TreeTransitionStatus_COMPLETED)
+	default:
+		// Scheduled to be DRAINING, which may already be the case.
+		return s.updateTransition(ctx, tree, now, st.TargetState, trillian.TreeTransitionStatus_COMPLETED)
+	}
+}
+
+// isDrained returns true if tree has no unsequenced leaves, and its latest
+// signed root covers all its sequenced leaves.
+func (s *SequencerManager) isDrained(ctx context.Context, tree *trillian.Tree) (bool, error) {
+	tx, err := s.registry.LogStorage.Snapshot(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Close()
+	counts, err := tx.GetUnsequencedCounts(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to count unsequenced leaves: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	if counts[tree.TreeId] > 0 {
+		return false, nil
+	}
+
+	treeTX, err := s.registry.LogStorage.SnapshotForTree(ctx, tree)
+	if err != nil {
+		return false, err
+	}
+	defer treeTX.Close()
+	slr, err := treeTX.LatestSignedLogRoot(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get latest root: %v", err)
+	}
+	var root types.LogRootV1
+	if err := root.UnmarshalBinary(slr.LogRoot); err != nil {
+		return false, fmt.Errorf("failed to parse latest root: %v", err)
+	}
+	sequenced, err := treeTX.GetSequencedLeafCount(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to count sequenced leaves: %v", err)
+	}
+	if err := treeTX.Commit(); err != nil {
+		return false, err
+	}
+	return uint64(sequenced) == root.TreeSize, nil
+}
+
+// updateTransition moves tree to state, and its transition to phase.
+// The scheduled_transition is cleared once the transition is complete.
+// Nothing is changed if the tree's state or scheduled_transition were
+// modified since tree was read, eg, by a concurrent UpdateTree.
+func (s *SequencerManager) updateTransition(ctx context.Context, tree *trillian.Tree, now time.Time, state trillian.TreeState, phase trillian.TreeTransitionStatus_Phase) error {
+	nowPB, err := ptypes.TimestampProto(now)
+	if err != nil {
+		return err
+	}
+	target := tree.ScheduledTransition.GetTargetState()
+	updated := false
+	err = s.registry.AdminStorage.ReadWriteTransaction(ctx, func(ctx context.Context, tx storage.AdminTX) error {
+		stored, err := tx.GetTree(ctx, tree.TreeId)
+		if err != nil {
+			return err
+		}
+		if stored.TreeState != tree.TreeState || !proto.Equal(stored.ScheduledTransition, tree.ScheduledTransition) {
+			return nil
+		}
+		_, err = tx.UpdateTree(ctx, tree.TreeId, func(t *trillian.Tree) {
+			t.TreeState = state
+			t.TransitionStatus = &trillian.TreeTransitionStatus{
+				Phase:       phase,
+				TargetState: target,
+				UpdateTime:  nowPB,
+			}
+			if phase == trillian.TreeTransitionStatus_COMPLETED {
+				t.ScheduledTransition = nil
+			}
+		})
+		updated = err == nil
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update tree: %v", err)
+	}
+	if updated {
+		transitionPhases.Inc(strconv.FormatInt(tree.TreeId, 10), phase.String())
+		glog.Infof("%v: scheduled transition to %v entered phase %v (tree_state=%v)", tree.TreeId, target, phase, state)
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/google/trillian"
+	"github.com/google/trillian/crypto/keys"
+	"github.com/google/trillian/crypto/keys/der"
+	"github.com/google/trillian/crypto/keyspb"
+	"github.com/google/trillian/extension"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/memory"
+	"github.com/google/trillian/types"
+
+	stestonly "github.com/google/trillian/storage/testonly"
+)
+
+func TestScheduledTransitions(t *testing.T) {
+	ctx := context.Background()
+	start := fakeTime
+
+	// Other tests unregister the handler for stestonly.LogTree's key, so
+	// register it here (storage checks the key when creating trees).
+	keys.RegisterHandler(&keyspb.PrivateKey{}, func(ctx context.Context, pb proto.Message) (crypto.Signer, error) {
+		return der.FromProto(pb.(*keyspb.PrivateKey))
+	})
+	defer keys.UnregisterHandler(&keyspb.PrivateKey{})
+
+	startPB, err := ptypes.TimestampProto(start)
+	if err != nil {
+		t.Fatalf("TimestampProto(): %v", err)
+	}
+
+	for _, test := range []struct {
+		desc   string
+		target trillian.TreeState
+		// steps are applied in order, each followed by a transition pass at
+		// its time.
+		steps []transitionStep
+	}{
+		{
+			desc:   "draining",
+			target: trillian.TreeState_DRAINING,
+			steps: []transitionStep{
+				{desc: "beforeStart", now: start.Add(-time.Second), wantState: trillian.TreeState_ACTIVE},
+				{
+					desc:      "afterStart",
+					now:       start,
+					wantState: trillian.TreeState_DRAINING,
+					wantPhase: trillian.TreeTransitionStatus_COMPLETED,
+				},
+			},
+		},
+		{
+			desc:   "frozen",
+			target: trillian.TreeState_FROZEN,
+			steps: []transitionStep{
+				{desc: "beforeStart", now: start.Add(-time.Second), queue: true, wantState: trillian.TreeState_ACTIVE},
+				{
+					desc:      "afterStart",
+					now:       start,
+					wantState: trillian.TreeState_DRAINING,
+					wantPhase: trillian.TreeTransitionStatus_DRAINING,
+				},
+				{
+					desc:      "queueNotEmpty",
+					now:       start.Add(time.Second),
+					wantState: trillian.TreeState_DRAINING,
+					wantPhase: trillian.TreeTransitionStatus_DRAINING,
+				},
+				{
+					desc:      "rootNotSigned",
+					now:       start.Add(2 * time.Second),
+					sequence:  true,
+					wantState: trillian.TreeState_DRAINING,
+					wantPhase: trillian.TreeTransitionStatus_DRAINING,
+				},
+				{
+					desc:      "drained",
+					now:       start.Add(3 * time.Second),
+					signRoot:  true,
+					wantState: trillian.TreeState_FROZEN,
+					wantPhase: trillian.TreeTransitionStatus_COMPLETED,
+				},
+			},
+		},
+	} {
+		ls := memory.NewLogStorage(nil)
+		as := memory.NewAdminStorage(ls)
+		sm := NewSequencerManager(extension.Registry{AdminStorage: as, LogStorage: ls}, zeroDuration)
+
+		tree := proto.Clone(stestonly.LogTree).(*trillian.Tree)
+		tree.ScheduledTransition = &trillian.ScheduledTransition{TargetState: test.target, StartTime: startPB}
+		tree, err = storage.CreateTree(ctx, as, tree)
+		if err != nil {
+			t.Fatalf("%v: CreateTree(): %v", test.desc, err)
+		}
+		if err := storeRoot(ctx, ls, tree, 0 /* size */, 1 /* timestamp */); err != nil {
+			t.Fatalf("%v: storeRoot(): %v", test.desc, err)
+		}
+
+		leaf := &trillian.LogLeaf{LeafValue: []byte("llama")}
+		hash := sha256.Sum256(leaf.LeafValue)
+		leaf.LeafIdentityHash = hash[:]
+		leaf.MerkleLeafHash = hash[:]
+
+		for i, step := range test.steps {
+			if step.queue {
+				if _, err := ls.QueueLeaves(ctx, tree, []*trillian.LogLeaf{leaf}, step.now); err != nil {
+					t.Fatalf("%v/%v: QueueLeaves(): %v", test.desc, step.desc, err)
+				}
+			}
+			if step.sequence {
+				if err := ls.ReadWriteTransaction(ctx, tree, func(ctx context.Context, tx storage.LogTreeTX) error {
+					return tx.UpdateSequencedLeaves(ctx, []*trillian.LogLeaf{leaf})
+				}); err != nil {
+					t.Fatalf("%v/%v: UpdateSequencedLeaves(): %v", test.desc, step.desc, err)
+				}
+			}
+			if step.signRoot {
+				if err := storeRoot(ctx, ls, tree, 1 /* size */, uint64(2+i) /* timestamp */); err != nil {
+					t.Fatalf("%v/%v: storeRoot(): %v", test.desc, step.desc, err)
+				}
+			}
+
+			current, err := storage.GetTree(ctx, as, tree.TreeId)
+			if err != nil {
+				t.Fatalf("%v/%v: GetTree(): %v", test.desc, step.desc, err)
+			}
+			sm.applyScheduledTransition(ctx, proto.Clone(current).(*trillian.Tree), step.now)
+
+			got, err := storage.GetTree(ctx, as, tree.TreeId)
+			if err != nil {
+				t.Fatalf("%v/%v: GetTree(): %v", test.desc, step.desc, err)
+			}
+			if got.TreeState != step.wantState {
+				t.Errorf("%v/%v: tree_state = %v, want %v", test.desc, step.desc, got.TreeState, step.wantState)
+			}
+			if got, want := got.TransitionStatus.GetPhase(), step.wantPhase; got != want {
+				t.Errorf("%v/%v: transition_status.phase = %v, want %v", test.desc, step.desc, got, want)
+			}
+			completed := step.wantPhase == trillian.TreeTransitionStatus_COMPLETED
+			if gotScheduled := got.ScheduledTransition != nil; gotScheduled == completed {
+				t.Errorf("%v/%v: scheduled_transition = %v, want set = %v", test.desc, step.desc, got.ScheduledTransition, !completed)
+			}
+		}
+	}
+}
+
+type transitionStep struct {
+	desc string
+	now  time.Time
+	// queue, sequence and signRoot respectively queue a leaf, sequence it and
+	// sign a root that includes it, before the transition pass.
+	queue, sequence, signRoot bool
+	wantState                 trillian.TreeState
+	wantPhase                 trillian.TreeTransitionStatus_Phase
+}
+
+// storeRoot stores an (unsigned) root of the given size for tree.
+func storeRoot(ctx context.Context, ls storage.LogStorage, tree *trillian.Tree, size, timestamp uint64) error {
+	logRoot, err := (&types.LogRootV1{TreeSize: size, TimestampNanos: timestamp, RootHash: []byte{}}).MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return ls.ReadWriteTransaction(ctx, tree, func(ctx context.Context, tx storage.LogTreeTX) error {
+		return tx.StoreSignedLogRoot(ctx, trillian.SignedLogRoot{LogRoot: logRoot})
+	})
+}
@@ -19,32 +19,41 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/golang/glog"
 	"github.com/google/trillian/cmd"
 	"github.com/google/trillian/extension"
 	"github.com/google/trillian/log"
+	"github.com/google/trillian/monitoring/opencensus"
 	"github.com/google/trillian/monitoring/prometheus"
 	"github.com/google/trillian/server"
 	"github.com/google/trillian/storage"
 	"github.com/google/trillian/util"
 	"github.com/google/trillian/util/etcd"
+	"github.com/google/trillian/util/mysql"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	// Register key ProtoHandlers
 	_ "github.com/google/trillian/crypto/keys/der/proto"
+	_ "github.com/google/trillian/crypto/keys/envelope/proto"
 	_ "github.com/google/trillian/crypto/keys/pem/proto"
 	_ "github.com/google/trillian/crypto/keys/pkcs11/proto"
+	_ "github.com/google/trillian/crypto/keys/remote/proto"
 	// Load hashers
 	_ "github.com/google/trillian/merkle/objhasher"
+	_ "github.com/google/trillian/merkle/protohasher"
 	_ "github.com/google/trillian/merkle/rfc6962"
 )
 
 var (
 	httpEndpoint             = flag.String("http_endpoint", "localhost:8091", "Endpoint for HTTP (host:port, empty means disabled)")
+	controlEndpoint          = flag.String("control_endpoint", "", "Endpoint for HTTP requests that pause, resume or resign logs (host:port, empty means disabled). Should only be reachable by operators")
+	controlTokenFile         = flag.String("control_token_file", "", "File holding the bearer token that requests to --control_endpoint must carry. Required if --control_endpoint is set")
 	tlsCertFile              = flag.String("tls_cert_file", "", "Path to the TLS server certificate. If unset, the server will use unsecured connections.")
 	tlsKeyFile               = flag.String("tls_key_file", "", "Path to the TLS server key. If unset, the server will use unsecured connections.")
 	sequencerIntervalFlag    = flag.Duration("sequencer_interval", time.Second*10, "Time between each sequencing pass through all logs")
@@ -52,6 +61,8 @@ var (
 	numSeqFlag               = flag.Int("num_sequencers", 10, "Number of sequencer workers to run in parallel")
 	sequencerGuardWindowFlag = flag.Duration("sequencer_guard_window", 0, "If set, the time elapsed before submitted leaves are eligible for sequencing")
 	forceMaster              = flag.Bool("force_master", false, "If true, assume master for all logs")
+	electionSystem           = flag.String("election_system", "etcd", "Master election system to use, one of: etcd, mysql. Ignored if --force_master is set. The fencing tokens of different systems can't be compared, so before switching system, stop all signers and reset the tokens stored for the logs (for MySQL storage: UPDATE TreeControl SET MasterEpoch = 0), or writes by the new masters fail as stale")
+	mysqlLeaseDuration       = flag.Duration("mysql_lease_duration", mysql.DefaultLeaseDuration, "Duration of mastership leases. Only effective for --election_system=mysql")
 	etcdHTTPService          = flag.String("etcd_http_service", "trillian-logsigner-http", "Service name to announce our HTTP endpoint under")
 	lockDir                  = flag.String("lock_file_path", "/test/multimaster", "etcd lock file directory path")
 	healthzTimeout           = flag.Duration("healthz_timeout", time.Second*5, "Timeout used during healthz checks")
@@ -64,6 +75,16 @@ var (
 	masterCheckInterval = flag.Duration("master_check_interval", 5*time.Second, "Interval between checking mastership still held")
 	masterHoldInterval  = flag.Duration("master_hold_interval", 60*time.Second, "Minimum interval to hold mastership for")
 	resignOdds          = flag.Int("resign_odds", 10, "Chance of resigning mastership after each check, the N in 1-in-N")
+	balanceMastership   = flag.Bool("balance_mastership", false, "If true, mastership is balanced by the logs' load rather than by --resign_odds, across the signers announced in etcd under --etcd_http_service. Requires --etcd_servers and --http_endpoint on all signers")
+	numSigners          = flag.Int("num_signers", 0, "Fixed number of signers sharing the logs. If set, mastership is balanced as for --balance_mastership, but across this many signers. All of them must be running: while any is down, logs it would be master for may go without a master for up to --master_hold_interval at a time, as counted by the expired_campaign_deferrals metric. Prefer --balance_mastership, which counts the running signers")
+	balanceTolerance    = flag.Float64("balance_tolerance", 0.1, "Fraction of the fair share of load a signer may exceed before resigning mastership. Only effective if --balance_mastership or --num_signers is set")
+
+	tracing          = flag.Bool("tracing", false, "If true opencensus tracing will be enabled. See https://opencensus.io/.")
+	tracingExporter  = flag.String("tracing_exporter", opencensus.StackdriverExporter, "Exporter for traces, one of: stackdriver, jaeger, file")
+	tracingProjectID = flag.String("tracing_project_id", "", "project ID to pass to Stackdriver client. Can be empty for GCP, consult docs for other platforms.")
+	tracingEndpoint  = flag.String("tracing_endpoint", "localhost:6831", "Jaeger agent (host:port), or collector base URL (e.g. http://localhost:14268), to export traces to. Only effective for --tracing_exporter=jaeger")
+	tracingFile      = flag.String("tracing_file", "-", "File to append traces to, or - for stdout. Only effective for --tracing_exporter=file")
+	tracingPercent   = flag.Int("tracing_percent", 0, "Percent of sequencing passes to be traced. Zero is a special case to use the DefaultSampler")
 
 	configFile = flag.String("config", "", "Config file containing flags, file contents can be overridden by command line flags")
 )
@@ -82,6 +103,21 @@ func main() {
 
 	mf := prometheus.MetricFactory{}
 
+	if *tracing {
+		flush, err := opencensus.EnableTracing(opencensus.TraceOptions{
+			Exporter:    *tracingExporter,
+			ProjectID:   *tracingProjectID,
+			ServiceName: "trillian_log_signer",
+			Endpoint:    *tracingEndpoint,
+			Path:        *tracingFile,
+			Percent:     *tracingPercent,
+		})
+		if err != nil {
+			glog.Exitf("Failed to initialize opencensus tracing: %v", err)
+		}
+		defer flush()
+	}
+
 	sp, err := server.NewStorageProviderFromFlags(mf)
 	if err != nil {
 		glog.Exitf("Failed to get storage provider: %v", err)
@@ -104,10 +140,17 @@ func main() {
 	case *forceMaster:
 		glog.Warning("**** Acting as master for all logs ****")
 		electionFactory = util.NoopElectionFactory{InstanceID: instanceID}
+	case *electionSystem == "mysql":
+		electionFactory, err = server.NewMySQLElectionFactory(instanceID, *mysqlLeaseDuration)
+		if err != nil {
+			glog.Exitf("Failed to create MySQL election factory: %v", err)
+		}
+	case *electionSystem != "etcd":
+		glog.Exitf("Unknown --election_system %q", *electionSystem)
 	case client != nil:
 		electionFactory = etcd.NewElectionFactory(instanceID, client, *lockDir)
 	default:
-		glog.Exit("Either --force_master or --etcd_servers must be supplied")
+		glog.Exit("Either --force_master, --election_system=mysql or --etcd_servers must be supplied")
 	}
 
 	qm, err := server.NewQuotaManagerFromFlags()
@@ -115,6 +158,18 @@ func main() {
 		glog.Exitf("Error creating quota manager: %v", err)
 	}
 
+	var balancer *server.LoadBalancer
+	switch {
+	case *numSigners > 0:
+		glog.Warningf("Balancing mastership across a fixed %d signers, which must all be running", *numSigners)
+		balancer = server.NewLoadBalancer(server.StaticSignerCounter(*numSigners), *balanceTolerance, mf)
+	case *balanceMastership:
+		if client == nil || *httpEndpoint == "" {
+			glog.Exit("--balance_mastership needs --etcd_servers and --http_endpoint to count the signers, or use --num_signers")
+		}
+		balancer = server.NewLoadBalancer(server.EtcdSignerCounter(client, *etcdHTTPService), *balanceTolerance, mf)
+	}
+
 	registry := extension.Registry{
 		AdminStorage:    sp.AdminStorage(),
 		LogStorage:      sp.LogStorage(),
@@ -123,6 +178,26 @@ func main() {
 		MetricFactory:   mf,
 	}
 
+	// Set up the sequencing loop, which controls both sequencing and signing.
+	// TODO(Martin2112): Should respect read only mode
+	log.QuotaIncreaseFactor = *quotaIncreaseFactor
+	sequencerManager := server.NewSequencerManager(registry, *sequencerGuardWindowFlag)
+	info := server.LogOperationInfo{
+		Registry:            registry,
+		BatchSize:           *batchSizeFlag,
+		NumWorkers:          *numSeqFlag,
+		RunInterval:         *sequencerIntervalFlag,
+		TimeSource:          util.SystemTimeSource{},
+		PreElectionPause:    *preElectionPause,
+		MasterCheckInterval: *masterCheckInterval,
+		MasterHoldInterval:  *masterHoldInterval,
+		ResignOdds:          *resignOdds,
+	}
+	if balancer != nil {
+		info.Balancer = balancer
+	}
+	sequencerTask := server.NewLogOperationManager(info, sequencerManager)
+
 	// Start HTTP server (optional)
 	if *httpEndpoint != "" {
 		// Announce our endpoint to etcd if so configured.
@@ -132,28 +207,35 @@ func main() {
 		glog.Infof("Creating HTTP server starting on %v", *httpEndpoint)
 		http.Handle("/metrics", promhttp.Handler())
 		http.HandleFunc("/healthz", healthzFunc(sp.AdminStorage(), *healthzTimeout))
+		if balancer != nil {
+			http.Handle("/assignment", balancer)
+		}
+		http.Handle("/signer/", http.StripPrefix("/signer", server.NewSignerStatusHandler(sequencerTask)))
 		if err := util.StartHTTPServer(*httpEndpoint, *tlsCertFile, *tlsKeyFile); err != nil {
 			glog.Exitf("Failed to start HTTP server on %v: %v", *httpEndpoint, err)
 		}
 	}
 
-	// Start the sequencing loop, which will run until we terminate the process. This controls
-	// both sequencing and signing.
-	// TODO(Martin2112): Should respect read only mode and the flags in tree control etc
-	log.QuotaIncreaseFactor = *quotaIncreaseFactor
-	sequencerManager := server.NewSequencerManager(registry, *sequencerGuardWindowFlag)
-	info := server.LogOperationInfo{
-		Registry:            registry,
-		BatchSize:           *batchSizeFlag,
-		NumWorkers:          *numSeqFlag,
-		RunInterval:         *sequencerIntervalFlag,
-		TimeSource:          util.SystemTimeSource{},
-		PreElectionPause:    *preElectionPause,
-		MasterCheckInterval: *masterCheckInterval,
-		MasterHoldInterval:  *masterHoldInterval,
-		ResignOdds:          *resignOdds,
+	// Start control HTTP server (optional). It's kept apart from the HTTP
+	// server above, which is announced and may be widely reachable.
+	if *controlEndpoint != "" {
+		tokenFile, err := ioutil.ReadFile(*controlTokenFile)
+		if err != nil {
+			glog.Exitf("Failed to read --control_token_file: %v", err)
+		}
+		token := strings.TrimSpace(string(tokenFile))
+		if token == "" {
+			glog.Exit("Empty --control_token_file, please provide a token for --control_endpoint")
+		}
+		glog.Infof("Creating control HTTP server starting on %v", *controlEndpoint)
+		mux := http.NewServeMux()
+		mux.Handle("/signer/", http.StripPrefix("/signer", server.NewSignerControlHandler(sequencerTask, token)))
+		if err := util.StartHTTPServerWithHandler(*controlEndpoint, *tlsCertFile, *tlsKeyFile, mux); err != nil {
+			glog.Exitf("Failed to start control HTTP server on %v: %v", *controlEndpoint, err)
+		}
 	}
-	sequencerTask := server.NewLogOperationManager(info, sequencerManager)
+
+	// Start the sequencing loop, which will run until we terminate the process.
 	sequencerTask.OperationLoop(ctx)
 
 	// Give things a few seconds to tidy up
@@ -24,6 +24,7 @@ import (
 	"github.com/google/trillian"
 	"github.com/google/trillian/cmd"
 	"github.com/google/trillian/crypto/keys/der"
+	envelopeproto "github.com/google/trillian/crypto/keys/envelope/proto"
 	"github.com/google/trillian/crypto/keyspb"
 	"github.com/google/trillian/extension"
 	"github.com/google/trillian/monitoring/opencensus"
@@ -41,6 +42,7 @@ import (
 	_ "github.com/google/trillian/crypto/keys/der/proto"
 	_ "github.com/google/trillian/crypto/keys/pem/proto"
 	_ "github.com/google/trillian/crypto/keys/pkcs11/proto"
+	_ "github.com/google/trillian/crypto/keys/remote/proto"
 	// Load hashers
 	_ "github.com/google/trillian/merkle/coniks"
 	_ "github.com/google/trillian/merkle/maphasher"
@@ -58,9 +60,13 @@ var (
 	treeGCEnabled            = flag.Bool("tree_gc", true, "If true, tree garbage collection (hard-deletion) is periodically performed")
 	treeDeleteThreshold      = flag.Duration("tree_delete_threshold", server.DefaultTreeDeleteThreshold, "Minimum period a tree has to remain deleted before being hard-deleted")
 	treeDeleteMinRunInterval = flag.Duration("tree_delete_min_run_interval", server.DefaultTreeDeleteMinInterval, "Minimum interval between tree garbage collection sweeps. Actual runs happen randomly between [minInterval,2*minInterval).")
+	treePurgeChunkSize       = flag.Int64("tree_purge_chunk_size", server.DefaultTreePurgeChunkSize, "Maximum number of rows deleted per transaction when purging the data of hard-deleted trees")
 
-	tracing          = flag.Bool("tracing", false, "If true opencensus Stackdriver tracing will be enabled. See https://opencensus.io/.")
+	tracing          = flag.Bool("tracing", false, "If true opencensus tracing will be enabled. See https://opencensus.io/.")
+	tracingExporter  = flag.String("tracing_exporter", opencensus.StackdriverExporter, "Exporter for traces, one of: stackdriver, jaeger, file")
 	tracingProjectID = flag.String("tracing_project_id", "", "project ID to pass to Stackdriver client. Can be empty for GCP, consult docs for other platforms.")
+	tracingEndpoint  = flag.String("tracing_endpoint", "localhost:6831", "Jaeger agent (host:port), or collector base URL (e.g. http://localhost:14268), to export traces to. Only effective for --tracing_exporter=jaeger")
+	tracingFile      = flag.String("tracing_file", "-", "File to append traces to, or - for stdout. Only effective for --tracing_exporter=file")
 	tracingPercent   = flag.Int("tracing_percent", 0, "Percent of requests to be traced. Zero is a special case to use the DefaultSampler")
 
 	configFile = flag.String("config", "", "Config file containing flags, file contents can be overridden by command line flags")
@@ -79,9 +85,21 @@ func main() {
 	mf := prometheus.MetricFactory{}
 
 	if *tracing {
-		opts, err := opencensus.EnableRPCServerTracing(*tracingProjectID, *tracingPercent)
+		flush, err := opencensus.EnableTracing(opencensus.TraceOptions{
+			Exporter:    *tracingExporter,
+			ProjectID:   *tracingProjectID,
+			ServiceName: "trillian_map_server",
+			Endpoint:    *tracingEndpoint,
+			Path:        *tracingFile,
+			Percent:     *tracingPercent,
+		})
 		if err != nil {
-			glog.Exitf("Failed to initialize stackdriver / opencensus tracing: %v", err)
+			glog.Exitf("Failed to initialize opencensus tracing: %v", err)
+		}
+		defer flush()
+		opts, err := opencensus.RPCServerOptions()
+		if err != nil {
+			glog.Exitf("Failed to initialize opencensus RPC tracing: %v", err)
 		}
 		// Enable the server request counter tracing etc.
 		options = append(options, opts...)
@@ -102,6 +120,16 @@ func main() {
 		glog.Exitf("Error creating quota manager: %v", err)
 	}
 
+	kek, err := envelopeproto.Provider()
+	if err != nil {
+		glog.Exitf("Failed to load key encryption keys: %v", err)
+	}
+
+	auditLog, err := server.NewAuditLogFromFlags("" /* defaultServer */)
+	if err != nil {
+		glog.Exitf("Failed to create audit log: %v", err)
+	}
+
 	registry := extension.Registry{
 		AdminStorage:  sp.AdminStorage(),
 		MapStorage:    sp.MapStorage(),
@@ -110,6 +138,8 @@ func main() {
 		NewKeyProto: func(ctx context.Context, spec *keyspb.Specification) (proto.Message, error) {
 			return der.NewProtoFromSpec(spec)
 		},
+		KEKProvider: kek,
+		AuditLog:    auditLog,
 	}
 
 	m := server.Main{
@@ -151,6 +181,7 @@ func main() {
 		TreeGCEnabled:         *treeGCEnabled,
 		TreeDeleteThreshold:   *treeDeleteThreshold,
 		TreeDeleteMinInterval: *treeDeleteMinRunInterval,
+		TreePurgeChunkSize:    *treePurgeChunkSize,
 	}
 
 	ctx := context.Background()
@@ -52,6 +52,21 @@ func ListTrees(ctx context.Context, admin AdminStorage, includeDeleted bool) ([]
 	return resp, err
 }
 
+// ListTreesPage reads the trees selected by opts from storage using a
+// snapshot transaction.
+// It's a convenience wrapper around RunInAdminSnapshot and AdminReader's ListTreesPage.
+// See RunInAdminSnapshot if you need to perform more than one action per transaction.
+func ListTreesPage(ctx context.Context, admin AdminStorage, opts ListTreesOptions) ([]*trillian.Tree, error) {
+	ctx, span := spanFor(ctx, "ListTreesPage")
+	defer span.End()
+	var resp []*trillian.Tree
+	err := RunInAdminSnapshot(ctx, admin, func(tx ReadOnlyAdminTX) (err error) {
+		resp, err = tx.ListTreesPage(ctx, opts)
+		return
+	})
+	return resp, err
+}
+
 // CreateTree creates a tree in storage.
 // It's a convenience wrapper around ReadWriteTransaction and AdminWriter's CreateTree.
 // See ReadWriteTransaction if you need to perform more than one action per transaction.
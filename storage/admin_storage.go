@@ -74,6 +74,15 @@ type AdminStorage interface {
 	CheckDatabaseAccessible(ctx context.Context) error
 }
 
+// TreeFeatureChecker is implemented by AdminStorage implementations that can't
+// store all fields of trees, so that trees using them can be rejected before
+// any work is done on them.
+type TreeFeatureChecker interface {
+	// CheckTreeFeatures returns an Unimplemented error if tree uses a feature
+	// that isn't supported by the storage.
+	CheckTreeFeatures(tree *trillian.Tree) error
+}
+
 // AdminReader provides a read-only interface for tree data.
 type AdminReader interface {
 	// GetTree returns the tree corresponding to treeID or an error.
@@ -88,6 +97,64 @@ type AdminReader interface {
 	// Note that there's no authorization restriction on the trees returned,
 	// so it should be used with caution in production code.
 	ListTrees(ctx context.Context, includeDeleted bool) ([]*trillian.Tree, error)
+
+	// ListTreesPage returns the trees in storage that match opts, in order
+	// of tree ID.
+	// As with ListTrees, there's no authorization restriction on the trees
+	// returned.
+	ListTreesPage(ctx context.Context, opts ListTreesOptions) ([]*trillian.Tree, error)
+}
+
+// ListTreesOptions selects the trees returned by AdminReader.ListTreesPage.
+// The zero value selects all non-deleted trees.
+type ListTreesOptions struct {
+	// IncludeDeleted selects soft deleted trees as well.
+	IncludeDeleted bool
+	// AfterTreeID, if positive, selects only trees with greater IDs.
+	AfterTreeID int64
+	// Limit, if positive, is the maximum number of trees returned.
+	Limit int
+	// TreeTypes, if not empty, selects only trees of these types.
+	TreeTypes []trillian.TreeType
+	// TreeStates, if not empty, selects only trees in these states.
+	TreeStates []trillian.TreeState
+	// Labels selects only trees that have all of these labels, with the
+	// same values.
+	Labels map[string]string
+}
+
+// Matches returns true if tree is selected by all of opts, except Limit.
+func (opts *ListTreesOptions) Matches(tree *trillian.Tree) bool {
+	if tree.Deleted && !opts.IncludeDeleted {
+		return false
+	}
+	if tree.TreeId <= opts.AfterTreeID {
+		return false
+	}
+	if len(opts.TreeTypes) > 0 {
+		found := false
+		for _, tt := range opts.TreeTypes {
+			found = found || tt == tree.TreeType
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(opts.TreeStates) > 0 {
+		found := false
+		for _, ts := range opts.TreeStates {
+			found = found || ts == tree.TreeState
+		}
+		if !found {
+			return false
+		}
+	}
+	for k, v := range opts.Labels {
+		if got, ok := tree.Labels[k]; !ok || got != v {
+			return false
+		}
+	}
+	return true
 }
 
 // AdminWriter provides a write-only interface for tree data.
@@ -119,6 +186,8 @@ type AdminWriter interface {
 	// The tree must exist and currently be soft deleted, as per SoftDeletedTree, otherwise an error
 	// is returned.
 	// Hard deleted trees cannot be recovered.
+	// Implementations of TreePurger may leave the tree's data to be purged
+	// after the tree is deleted.
 	HardDeleteTree(ctx context.Context, treeID int64) error
 
 	// UndeleteTree undeletes a soft-deleted tree.
@@ -0,0 +1,87 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/google/trillian"
+)
+
+func TestListTreesOptionsMatches(t *testing.T) {
+	tree := &trillian.Tree{
+		TreeId:    10,
+		TreeType:  trillian.TreeType_LOG,
+		TreeState: trillian.TreeState_ACTIVE,
+		Labels:    map[string]string{"tenant": "llamas", "env": "prod"},
+	}
+	deletedTree := &trillian.Tree{TreeId: 10, Deleted: true}
+
+	for _, test := range []struct {
+		desc string
+		opts ListTreesOptions
+		tree *trillian.Tree
+		want bool
+	}{
+		{desc: "zero", tree: tree, want: true},
+		{desc: "deleted", tree: deletedTree},
+		{desc: "includeDeleted", opts: ListTreesOptions{IncludeDeleted: true}, tree: deletedTree, want: true},
+		{desc: "beforeAfterTreeID", opts: ListTreesOptions{AfterTreeID: 9}, tree: tree, want: true},
+		{desc: "atAfterTreeID", opts: ListTreesOptions{AfterTreeID: 10}, tree: tree},
+		{desc: "limitIgnored", opts: ListTreesOptions{Limit: 1}, tree: tree, want: true},
+		{
+			desc: "treeTypes",
+			opts: ListTreesOptions{TreeTypes: []trillian.TreeType{trillian.TreeType_MAP, trillian.TreeType_LOG}},
+			tree: tree,
+			want: true,
+		},
+		{
+			desc: "otherTreeType",
+			opts: ListTreesOptions{TreeTypes: []trillian.TreeType{trillian.TreeType_MAP}},
+			tree: tree,
+		},
+		{
+			desc: "treeStates",
+			opts: ListTreesOptions{TreeStates: []trillian.TreeState{trillian.TreeState_ACTIVE}},
+			tree: tree,
+			want: true,
+		},
+		{
+			desc: "otherTreeState",
+			opts: ListTreesOptions{TreeStates: []trillian.TreeState{trillian.TreeState_FROZEN, trillian.TreeState_DRAINING}},
+			tree: tree,
+		},
+		{
+			desc: "labels",
+			opts: ListTreesOptions{Labels: map[string]string{"tenant": "llamas", "env": "prod"}},
+			tree: tree,
+			want: true,
+		},
+		{
+			desc: "otherLabelValue",
+			opts: ListTreesOptions{Labels: map[string]string{"tenant": "alpacas"}},
+			tree: tree,
+		},
+		{
+			desc: "missingLabel",
+			opts: ListTreesOptions{Labels: map[string]string{"tenant": "llamas", "region": "eu"}},
+			tree: tree,
+		},
+	} {
+		if got := test.opts.Matches(test.tree); got != test.want {
+			t.Errorf("%v: Matches() = %v, want %v", test.desc, got, test.want)
+		}
+	}
+}
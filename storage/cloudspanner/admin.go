@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -57,13 +58,18 @@ var (
 		trillian.HashStrategy_TEST_MAP_HASHER:       spannerpb.HashStrategy_TEST_MAP_HASHER,
 		trillian.HashStrategy_OBJECT_RFC6962_SHA256: spannerpb.HashStrategy_OBJECT_RFC6962_SHA256,
 		trillian.HashStrategy_CONIKS_SHA512_256:     spannerpb.HashStrategy_CONIKS_SHA512_256,
+		trillian.HashStrategy_RFC6962_SHA512_256:    spannerpb.HashStrategy_RFC6962_SHA512_256,
+		trillian.HashStrategy_RFC6962_SHA3_256:      spannerpb.HashStrategy_RFC6962_SHA3_256,
+		trillian.HashStrategy_RFC6962_BLAKE2B_256:   spannerpb.HashStrategy_RFC6962_BLAKE2B_256,
+		trillian.HashStrategy_PROTO_RFC6962_SHA256:  spannerpb.HashStrategy_PROTO_RFC6962_SHA256,
 	}
 	hashAlgMap = map[sigpb.DigitallySigned_HashAlgorithm]spannerpb.HashAlgorithm{
 		sigpb.DigitallySigned_SHA256: spannerpb.HashAlgorithm_SHA256,
 	}
 	signatureAlgMap = map[sigpb.DigitallySigned_SignatureAlgorithm]spannerpb.SignatureAlgorithm{
-		sigpb.DigitallySigned_RSA:   spannerpb.SignatureAlgorithm_RSA,
-		sigpb.DigitallySigned_ECDSA: spannerpb.SignatureAlgorithm_ECDSA,
+		sigpb.DigitallySigned_RSA:     spannerpb.SignatureAlgorithm_RSA,
+		sigpb.DigitallySigned_ECDSA:   spannerpb.SignatureAlgorithm_ECDSA,
+		sigpb.DigitallySigned_ED25519: spannerpb.SignatureAlgorithm_ED25519,
 	}
 
 	treeStateReverseMap    = reverseTreeStateMap(treeStateMap)
@@ -161,6 +167,26 @@ func (s *adminStorage) CheckDatabaseAccessible(ctx context.Context) error {
 	return checkDatabaseAccessible(ctx, s.client)
 }
 
+// CheckTreeFeatures implements storage.TreeFeatureChecker.
+func (s *adminStorage) CheckTreeFeatures(tree *trillian.Tree) error {
+	return checkTreeFeatures(tree)
+}
+
+// checkTreeFeatures returns an error if tree uses multi-signatures, key
+// rotation or scheduled transitions, which TreeInfo has no room for.
+func checkTreeFeatures(tree *trillian.Tree) error {
+	if tree.MultiSig != nil {
+		return status.Error(codes.Unimplemented, "multi_sig not supported by cloudspanner storage")
+	}
+	if tree.KeyVersion != 0 {
+		return status.Error(codes.Unimplemented, "key rotation not supported by cloudspanner storage")
+	}
+	if tree.ScheduledTransition != nil {
+		return status.Error(codes.Unimplemented, "scheduled_transition not supported by cloudspanner storage")
+	}
+	return nil
+}
+
 func (s *adminStorage) Snapshot(ctx context.Context) (storage.ReadOnlyAdminTX, error) {
 	tx := s.client.ReadOnlyTransaction()
 	return &adminTX{client: s.client, tx: tx}, nil
@@ -313,6 +339,26 @@ func (t *adminTX) ListTrees(ctx context.Context, includeDeleted bool) ([]*trilli
 	return trees, err
 }
 
+func (t *adminTX) ListTreesPage(ctx context.Context, opts storage.ListTreesOptions) ([]*trillian.Tree, error) {
+	// TreeRoots is only indexed by Deleted, so the other filters are applied
+	// to the trees read.
+	trees, err := t.ListTrees(ctx, opts.IncludeDeleted)
+	if err != nil {
+		return nil, err
+	}
+	matched := []*trillian.Tree{}
+	for _, tree := range trees {
+		if opts.Matches(tree) {
+			matched = append(matched, tree)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].TreeId < matched[j].TreeId })
+	if opts.Limit > 0 && len(matched) > opts.Limit {
+		matched = matched[:opts.Limit]
+	}
+	return matched, nil
+}
+
 func (t *adminTX) readTrees(ctx context.Context, includeDeleted, idOnly bool, f func(*spanner.Row) error) error {
 	var stmt spanner.Statement
 	if idOnly {
@@ -334,6 +380,9 @@ func (t *adminTX) CreateTree(ctx context.Context, tree *trillian.Tree) (*trillia
 	if err := storage.ValidateTreeForCreation(ctx, tree); err != nil {
 		return nil, err
 	}
+	if err := checkTreeFeatures(tree); err != nil {
+		return nil, err
+	}
 
 	id, err := storage.NewTreeID()
 	if err != nil {
@@ -423,6 +472,7 @@ func newTreeInfo(tree *trillian.Tree, treeID int64, now time.Time) (*spannerpb.T
 		PrivateKey:            tree.GetPrivateKey(),
 		PublicKeyDer:          tree.GetPublicKey().GetDer(),
 		MaxRootDurationMillis: int64(maxRootDuration / time.Millisecond),
+		Labels:                tree.Labels,
 	}
 
 	switch tree.TreeType {
@@ -498,6 +548,9 @@ func (t *adminTX) UpdateTree(ctx context.Context, treeID int64, updateFunc func(
 	if !proto.Equal(beforeTree.StorageSettings, tree.StorageSettings) {
 		return nil, status.New(codes.InvalidArgument, "readonly field changed: storage_settings").Err()
 	}
+	if err := checkTreeFeatures(tree); err != nil {
+		return nil, err
+	}
 
 	ts, ok := treeStateMap[tree.TreeState]
 	if !ok {
@@ -517,6 +570,7 @@ func (t *adminTX) UpdateTree(ctx context.Context, treeID int64, updateFunc func(
 	info.UpdateTimeNanos = now.UnixNano()
 	info.MaxRootDurationMillis = int64(maxRootDuration / time.Millisecond)
 	info.PrivateKey = tree.PrivateKey
+	info.Labels = tree.Labels
 
 	if err := t.updateTreeInfo(ctx, info); err != nil {
 		return nil, err
@@ -599,16 +653,14 @@ func (t *adminTX) HardDeleteTree(ctx context.Context, treeID int64) error {
 	}
 
 	// Due to cloud spanner sizing recommendations, we don't interleave our tables
-	// which means no ON DELETE CASCADE goodies for us, so we have to
-	// transactionally delete related data from all tables.
+	// which means no ON DELETE CASCADE goodies for us. Deleting related data
+	// from all tables may be too much for one transaction, so it's purged
+	// later, in chunks (see PurgeTreeData).
 	return stx.BufferWrite([]*spanner.Mutation{
 		spanner.Delete("TreeRoots", spanner.Key{info.TreeId}),
-		spanner.Delete("TreeHeads", spanner.Key{info.TreeId}.AsPrefix()),
-		spanner.Delete("SubtreeData", spanner.Key{info.TreeId}.AsPrefix()),
-		spanner.Delete("LeafData", spanner.Key{info.TreeId}.AsPrefix()),
-		spanner.Delete("SequencedLeafData", spanner.Key{info.TreeId}.AsPrefix()),
-		spanner.Delete("Unsequenced", spanner.Key{info.TreeId}.AsPrefix()),
-		spanner.Delete("MapLeafData", spanner.Key{info.TreeId}.AsPrefix()),
+		spanner.Insert("TreePurges",
+			[]string{"TreeID", "HardDeleteTimeMillis"},
+			[]interface{}{info.TreeId, TimeNow().UnixNano() / int64(time.Millisecond)}),
 	})
 }
 
@@ -648,6 +700,7 @@ func toTrillianTree(info *spannerpb.TreeInfo) (*trillian.Tree, error) {
 		PrivateKey:      info.PrivateKey,
 		PublicKey:       &keyspb.PublicKey{Der: info.PublicKeyDer},
 		MaxRootDuration: ptypes.DurationProto(time.Duration(info.MaxRootDurationMillis) * time.Millisecond),
+		Labels:          info.Labels,
 	}
 
 	ts, ok := treeStateReverseMap[info.TreeState]
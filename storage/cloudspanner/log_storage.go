@@ -140,6 +140,8 @@ func newLogCache(tree *trillian.Tree) (cache.SubtreeCache, error) {
 }
 
 func (ls *logStorage) begin(ctx context.Context, tree *trillian.Tree, readonly bool, stx spanRead) (*logTX, error) {
+	ctx, span := spanFor(ctx, "LogStorage.begin")
+	defer span.End()
 	tx, err := ls.ts.begin(ctx, tree, newLogCache, stx)
 	if err != nil {
 		return nil, err
@@ -162,6 +164,8 @@ func (ls *logStorage) BeginForTree(ctx context.Context, treeID int64) (storage.L
 }
 
 func (ls *logStorage) ReadWriteTransaction(ctx context.Context, tree *trillian.Tree, f storage.LogTXFunc) error {
+	ctx, span := spanFor(ctx, "LogStorage.ReadWriteTransaction")
+	defer span.End()
 	_, err := ls.ts.client.ReadWriteTransaction(ctx, func(ctx context.Context, stx *spanner.ReadWriteTransaction) error {
 		tx, err := ls.begin(ctx, tree, false /* readonly */, stx)
 		if err != nil {
@@ -180,6 +184,8 @@ func (ls *logStorage) SnapshotForTree(ctx context.Context, tree *trillian.Tree)
 }
 
 func (ls *logStorage) QueueLeaves(ctx context.Context, tree *trillian.Tree, leaves []*trillian.LogLeaf, qTimestamp time.Time) ([]*trillian.QueuedLogLeaf, error) {
+	ctx, span := spanFor(ctx, "LogStorage.QueueLeaves")
+	defer span.End()
 	_, treeConfig, err := ls.ts.getTreeAndConfig(ctx, tree)
 	if err != nil {
 		return nil, err
@@ -341,10 +347,17 @@ func (tx *logTX) LatestSignedLogRoot(ctx context.Context) (trillian.SignedLogRoo
 		return trillian.SignedLogRoot{}, err
 	}
 
+	// Roots stored before KeyHint was stored were signed by the tree's
+	// original key.
+	keyHint := currentSTH.KeyHint
+	if len(keyHint) == 0 {
+		keyHint = types.SerializeKeyHint(tx.treeID)
+	}
+
 	// We already read the latest root as part of starting the transaction (in
 	// order to calculate the writeRevision), so we just return that data here:
 	return trillian.SignedLogRoot{
-		KeyHint:          types.SerializeKeyHint(tx.treeID),
+		KeyHint:          keyHint,
 		LogRoot:          logRoot,
 		LogRootSignature: currentSTH.Signature,
 		// TODO(gbelvin): Remove deprecated fields
@@ -359,6 +372,8 @@ func (tx *logTX) LatestSignedLogRoot(ctx context.Context) (trillian.SignedLogRoo
 // This method will return an error if the caller attempts to store more than
 // one root per log for a given tree size.
 func (tx *logTX) StoreSignedLogRoot(ctx context.Context, root trillian.SignedLogRoot) error {
+	ctx, span := spanFor(ctx, "LogTX.StoreSignedLogRoot")
+	defer span.End()
 	writeRev, err := tx.writeRev(ctx)
 	if err == storage.ErrTreeNeedsInit {
 		writeRev = 0
@@ -371,6 +386,9 @@ func (tx *logTX) StoreSignedLogRoot(ctx context.Context, root trillian.SignedLog
 		glog.Warningf("Failed to parse log root: %x %v", root.LogRoot, err)
 		return err
 	}
+	if len(root.Signatures) != 0 {
+		return status.Error(codes.Unimplemented, "cloudspanner storage does not support root co-signatures")
+	}
 
 	m := spanner.Insert(
 		"TreeHeads",
@@ -382,6 +400,7 @@ func (tx *logTX) StoreSignedLogRoot(ctx context.Context, root trillian.SignedLog
 			"RootSignature",
 			"TreeRevision",
 			"TreeMetadata",
+			"KeyHint",
 		},
 		[]interface{}{
 			int64(tx.treeID),
@@ -391,6 +410,7 @@ func (tx *logTX) StoreSignedLogRoot(ctx context.Context, root trillian.SignedLog
 			root.LogRootSignature,
 			writeRev,
 			logRoot.Metadata,
+			root.KeyHint,
 		})
 
 	stx, ok := tx.stx.(*spanner.ReadWriteTransaction)
@@ -449,6 +469,8 @@ func (tx *logTX) AddSequencedLeaves(ctx context.Context, leaves []*trillian.LogL
 //
 // TODO(al): cutoff is currently ignored.
 func (tx *logTX) DequeueLeaves(ctx context.Context, limit int, cutoff time.Time) ([]*trillian.LogLeaf, error) {
+	ctx, span := spanFor(ctx, "LogTX.DequeueLeaves")
+	defer span.End()
 	if limit <= 0 {
 		return nil, fmt.Errorf("limit should be > 0, got %d", limit)
 	}
@@ -523,6 +545,8 @@ func (tx *logTX) DequeueLeaves(ctx context.Context, limit int, cutoff time.Time)
 // UpdateSequencedLeaves stores the sequence numbers assigned to the leaves,
 // and integrates them into the tree.
 func (tx *logTX) UpdateSequencedLeaves(ctx context.Context, leaves []*trillian.LogLeaf) error {
+	ctx, span := spanFor(ctx, "LogTX.UpdateSequencedLeaves")
+	defer span.End()
 	stx, ok := tx.stx.(*spanner.ReadWriteTransaction)
 	if !ok {
 		return ErrWrongTXType
@@ -564,6 +588,34 @@ func (tx *logTX) UpdateSequencedLeaves(ctx context.Context, leaves []*trillian.L
 	return nil
 }
 
+// UpdateLeafData replaces the value and extra data of the leaf at leafIndex.
+func (tx *logTX) UpdateLeafData(ctx context.Context, leafIndex int64, leafValue, extraData []byte) error {
+	stx, ok := tx.stx.(*spanner.ReadWriteTransaction)
+	if !ok {
+		return ErrWrongTXType
+	}
+
+	row, err := stx.ReadRow(ctx, seqDataTbl, spanner.Key{tx.treeID, leafIndex}, []string{colLeafIdentityHash})
+	switch {
+	case spanner.ErrCode(err) == codes.NotFound:
+		return status.Errorf(codes.NotFound, "leaf %d not found", leafIndex)
+	case err != nil:
+		return err
+	}
+	var identityHash []byte
+	if err := row.Columns(&identityHash); err != nil {
+		return err
+	}
+
+	m := spanner.Update(leafDataTbl,
+		[]string{colTreeID, colLeafIdentityHash, colLeafValue, colExtraData},
+		[]interface{}{tx.treeID, identityHash, leafValue, extraData})
+	if err := stx.BufferWrite([]*spanner.Mutation{m}); err != nil {
+		return fmt.Errorf("bufferwrite(): %v", err)
+	}
+	return nil
+}
+
 // GetSequencedLeafCount returns the number of leaves integrated into the tree
 // at the time the transaction was started.
 func (tx *logTX) GetSequencedLeafCount(ctx context.Context) (int64, error) {
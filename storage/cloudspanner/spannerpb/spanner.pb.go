@@ -94,6 +94,10 @@ const (
 	HashStrategy_TEST_MAP_HASHER       HashStrategy = 2
 	HashStrategy_OBJECT_RFC6962_SHA256 HashStrategy = 3
 	HashStrategy_CONIKS_SHA512_256     HashStrategy = 4
+	HashStrategy_RFC6962_SHA512_256    HashStrategy = 5
+	HashStrategy_RFC6962_SHA3_256      HashStrategy = 6
+	HashStrategy_RFC6962_BLAKE2B_256   HashStrategy = 7
+	HashStrategy_PROTO_RFC6962_SHA256  HashStrategy = 8
 )
 
 var HashStrategy_name = map[int32]string{
@@ -102,6 +106,10 @@ var HashStrategy_name = map[int32]string{
 	2: "TEST_MAP_HASHER",
 	3: "OBJECT_RFC6962_SHA256",
 	4: "CONIKS_SHA512_256",
+	5: "RFC6962_SHA512_256",
+	6: "RFC6962_SHA3_256",
+	7: "RFC6962_BLAKE2B_256",
+	8: "PROTO_RFC6962_SHA256",
 }
 var HashStrategy_value = map[string]int32{
 	"UNKNOWN_HASH_STRATEGY": 0,
@@ -109,6 +117,10 @@ var HashStrategy_value = map[string]int32{
 	"TEST_MAP_HASHER":       2,
 	"OBJECT_RFC6962_SHA256": 3,
 	"CONIKS_SHA512_256":     4,
+	"RFC6962_SHA512_256":    5,
+	"RFC6962_SHA3_256":      6,
+	"RFC6962_BLAKE2B_256":   7,
+	"PROTO_RFC6962_SHA256":  8,
 }
 
 func (x HashStrategy) String() string {
@@ -156,17 +168,21 @@ const (
 	SignatureAlgorithm_RSA SignatureAlgorithm = 1
 	// ECDSA signature scheme.
 	SignatureAlgorithm_ECDSA SignatureAlgorithm = 3
+	// Ed25519 signature scheme.
+	SignatureAlgorithm_ED25519 SignatureAlgorithm = 7
 )
 
 var SignatureAlgorithm_name = map[int32]string{
 	0: "ANONYMOUS",
 	1: "RSA",
 	3: "ECDSA",
+	7: "ED25519",
 }
 var SignatureAlgorithm_value = map[string]int32{
 	"ANONYMOUS": 0,
 	"RSA":       1,
 	"ECDSA":     3,
+	"ED25519":   7,
 }
 
 func (x SignatureAlgorithm) String() string {
@@ -267,6 +283,8 @@ type TreeInfo struct {
 	Deleted bool `protobuf:"varint,18,opt,name=deleted" json:"deleted,omitempty"`
 	// Time of tree deletion, if any.
 	DeleteTimeNanos int64 `protobuf:"varint,19,opt,name=delete_time_nanos,json=deleteTimeNanos" json:"delete_time_nanos,omitempty"`
+	// Key/value labels of the tree.
+	Labels map[string]string `protobuf:"bytes,20,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 }
 
 func (m *TreeInfo) Reset()                    { *m = TreeInfo{} }
@@ -421,6 +439,13 @@ func (m *TreeInfo) GetDeleteTimeNanos() int64 {
 	return 0
 }
 
+func (m *TreeInfo) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*TreeInfo) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _TreeInfo_OneofMarshaler, _TreeInfo_OneofUnmarshaler, _TreeInfo_OneofSizer, []interface{}{
@@ -513,6 +538,10 @@ type TreeHead struct {
 	// tree_revision identifies the revision at which the TreeHead was created.
 	TreeRevision int64  `protobuf:"varint,6,opt,name=tree_revision,json=treeRevision" json:"tree_revision,omitempty"`
 	Metadata     []byte `protobuf:"bytes,9,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// key_hint identifies the version of the tree's key that signed this
+	// TreeHead. It is empty for TreeHeads signed by the tree's original key
+	// before key_hint was stored.
+	KeyHint []byte `protobuf:"bytes,11,opt,name=key_hint,json=keyHint,proto3" json:"key_hint,omitempty"`
 }
 
 func (m *TreeHead) Reset()                    { *m = TreeHead{} }
@@ -569,6 +598,13 @@ func (m *TreeHead) GetMetadata() []byte {
 	return nil
 }
 
+func (m *TreeHead) GetKeyHint() []byte {
+	if m != nil {
+		return m.KeyHint
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*LogStorageConfig)(nil), "spannerpb.LogStorageConfig")
 	proto.RegisterType((*MapStorageConfig)(nil), "spannerpb.MapStorageConfig")
@@ -584,65 +620,73 @@ func init() {
 func init() { proto.RegisterFile("spanner.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 950 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x55, 0xed, 0x6e, 0x1a, 0x47,
-	0x14, 0xf5, 0x1a, 0x0c, 0xcb, 0x35, 0xd8, 0xe3, 0x71, 0xdc, 0xac, 0x93, 0x56, 0x42, 0x6e, 0x2b,
-	0x51, 0x54, 0x41, 0xeb, 0xc8, 0x8e, 0xa2, 0x54, 0xaa, 0xd6, 0x18, 0x07, 0x9b, 0xb0, 0x44, 0xb3,
-	0xeb, 0x56, 0xc9, 0x9f, 0xd5, 0xc0, 0x8e, 0x61, 0xe5, 0xfd, 0xea, 0xee, 0x6c, 0x14, 0xf2, 0xa3,
-	0x4f, 0xd0, 0x17, 0xed, 0x5b, 0x54, 0x33, 0xb3, 0x60, 0x8c, 0xd5, 0x7f, 0x33, 0xe7, 0x9e, 0x73,
-	0xaf, 0xe7, 0xfa, 0x9c, 0x05, 0x1a, 0x59, 0x42, 0xa3, 0x88, 0xa5, 0x9d, 0x24, 0x8d, 0x79, 0x8c,
-	0x6b, 0xc5, 0x35, 0x99, 0xbc, 0x38, 0x9e, 0xc5, 0xf1, 0x2c, 0x60, 0x5d, 0x59, 0x98, 0xe4, 0x77,
-	0x5d, 0x1a, 0x2d, 0x14, 0xeb, 0x24, 0x00, 0xf4, 0x3e, 0x9e, 0xd9, 0x3c, 0x4e, 0xe9, 0x8c, 0xf5,
-	0xe2, 0xe8, 0xce, 0x9f, 0xe1, 0x36, 0x1c, 0x44, 0x79, 0xe8, 0xe6, 0x51, 0xc6, 0xfe, 0x72, 0x27,
-	0xf9, 0xf4, 0x9e, 0xf1, 0xcc, 0xd0, 0x9a, 0x5a, 0xab, 0x44, 0xf6, 0xa3, 0x3c, 0xbc, 0x15, 0xf8,
-	0x85, 0x82, 0xf1, 0xcf, 0x80, 0x05, 0x37, 0x64, 0xe9, 0x7d, 0xc0, 0x56, 0xe4, 0x6d, 0x49, 0x46,
-	0x51, 0x1e, 0x8e, 0x64, 0xa1, 0x60, 0x9f, 0x60, 0x40, 0x23, 0x9a, 0x3c, 0x9a, 0x76, 0xf2, 0x4f,
-	0x15, 0x74, 0x27, 0x65, 0xec, 0x3a, 0xba, 0x8b, 0xf1, 0x73, 0xa8, 0xf2, 0x94, 0x31, 0xd7, 0xf7,
-	0x8a, 0x81, 0x15, 0x71, 0xbd, 0xf6, 0xf0, 0x11, 0x54, 0xee, 0xd9, 0x42, 0xe0, 0xaa, 0xf7, 0xce,
-	0x3d, 0x5b, 0x5c, 0x7b, 0x18, 0x43, 0x39, 0xa2, 0x21, 0x33, 0x4a, 0x4d, 0xad, 0x55, 0x23, 0xf2,
-	0x8c, 0x9b, 0xb0, 0xeb, 0xb1, 0x6c, 0x9a, 0xfa, 0x09, 0xf7, 0xe3, 0xc8, 0x28, 0xcb, 0xd2, 0x3a,
-	0x84, 0x7f, 0x81, 0x9a, 0x9c, 0xc2, 0x17, 0x09, 0x33, 0x76, 0x9a, 0x5a, 0x6b, 0xef, 0xf4, 0xb0,
-	0xb3, 0x5a, 0x57, 0x47, 0xfc, 0x35, 0xce, 0x22, 0x61, 0x44, 0xe7, 0xc5, 0x09, 0xbf, 0x02, 0x90,
-	0x8a, 0x8c, 0x53, 0xce, 0x0c, 0x5d, 0x4a, 0x9e, 0x6d, 0x48, 0x6c, 0x51, 0x23, 0xb2, 0xb3, 0x3c,
-	0xe2, 0xdf, 0xa0, 0x31, 0xa7, 0xd9, 0xdc, 0xcd, 0x78, 0x4a, 0x39, 0x9b, 0x2d, 0x8c, 0x9a, 0xd4,
-	0x3d, 0x5f, 0xd3, 0x0d, 0x68, 0x36, 0xb7, 0x8b, 0x32, 0xa9, 0xcf, 0xd7, 0x6e, 0xf8, 0x77, 0xd8,
-	0x93, 0x6a, 0x1a, 0xcc, 0xe2, 0xd4, 0xe7, 0xf3, 0xd0, 0x00, 0x29, 0x37, 0x36, 0xe4, 0xe6, 0xb2,
-	0x4e, 0xe4, 0xb4, 0xd5, 0x15, 0x5b, 0x70, 0x98, 0xf9, 0xb3, 0x88, 0xf2, 0x3c, 0x65, 0x6b, 0x5d,
-	0x76, 0x65, 0x97, 0xef, 0xd6, 0xba, 0xd8, 0x4b, 0xd6, 0x43, 0x2b, 0x9c, 0x3d, 0xc1, 0x84, 0x2d,
-	0xa6, 0x29, 0xa3, 0x9c, 0xb9, 0xdc, 0x0f, 0x99, 0x1b, 0xd1, 0x28, 0xce, 0x8c, 0x86, 0xb2, 0x85,
-	0x2a, 0x38, 0x7e, 0xc8, 0x2c, 0x01, 0x0b, 0x6e, 0x9e, 0x78, 0x1b, 0xdc, 0x3d, 0xc5, 0x55, 0x85,
-	0x07, 0xee, 0x19, 0xec, 0x26, 0xa9, 0xff, 0x59, 0x90, 0xef, 0xd9, 0xc2, 0xd8, 0x6f, 0x6a, 0xad,
-	0xdd, 0xd3, 0x67, 0x1d, 0xe5, 0xd9, 0xce, 0xd2, 0xb3, 0x1d, 0x33, 0x5a, 0x10, 0x28, 0x88, 0x43,
-	0xb6, 0xc0, 0x3f, 0xc0, 0x5e, 0x92, 0x4f, 0x02, 0x7f, 0x2a, 0x54, 0xae, 0xc7, 0x52, 0x03, 0x35,
-	0xb5, 0x56, 0x9d, 0xd4, 0x15, 0x3a, 0x64, 0x8b, 0x4b, 0x96, 0xe2, 0x21, 0xe0, 0x20, 0x9e, 0xb9,
-	0x99, 0xb2, 0x9c, 0x3b, 0x95, 0x9e, 0x33, 0x2a, 0x72, 0xc6, 0xcb, 0xb5, 0x1d, 0x6c, 0x86, 0x60,
-	0xb0, 0x45, 0x50, 0xb0, 0x19, 0x8c, 0x21, 0xe0, 0x90, 0x26, 0x9b, 0xcd, 0xaa, 0x4f, 0x9a, 0x6d,
-	0x7a, 0x5c, 0x34, 0x0b, 0x37, 0x30, 0xfc, 0x1a, 0x8c, 0x90, 0x7e, 0x71, 0xd3, 0x38, 0xe6, 0xae,
-	0x97, 0xa7, 0x54, 0x38, 0xd3, 0x0d, 0xfd, 0x20, 0xf0, 0x33, 0xe3, 0x40, 0x6e, 0xea, 0x28, 0xa4,
-	0x5f, 0x48, 0x1c, 0xf3, 0xcb, 0xa2, 0x3a, 0x92, 0x45, 0x6c, 0x40, 0xd5, 0x63, 0x01, 0xe3, 0xcc,
-	0x33, 0x70, 0x53, 0x6b, 0xe9, 0x64, 0x79, 0x15, 0x5b, 0x57, 0xc7, 0xf5, 0xad, 0x1f, 0xaa, 0xad,
-	0xab, 0xc2, 0x6a, 0xeb, 0x17, 0x08, 0xf6, 0x1e, 0xbf, 0xe3, 0xa6, 0xac, 0xd7, 0x51, 0xe3, 0xe4,
-	0x5f, 0x4d, 0xc5, 0x71, 0xc0, 0xa8, 0xf7, 0xff, 0x71, 0x3c, 0x06, 0x9d, 0x67, 0xc5, 0x00, 0x15,
-	0xc8, 0x2a, 0xcf, 0xd4, 0xbf, 0xf3, 0x65, 0x11, 0xae, 0xcc, 0xff, 0xaa, 0x72, 0x59, 0x52, 0x39,
-	0xb2, 0xfd, 0xaf, 0x4c, 0x14, 0xe5, 0x83, 0x85, 0x53, 0x65, 0x32, 0xeb, 0x44, 0x17, 0x80, 0x30,
-	0x32, 0xfe, 0x16, 0x6a, 0x2b, 0xdb, 0x49, 0xb3, 0xd7, 0xc9, 0x03, 0x80, 0xbf, 0x87, 0x86, 0xec,
-	0x9b, 0xb2, 0xcf, 0x7e, 0x26, 0x82, 0x5d, 0x91, 0xbd, 0xeb, 0x02, 0x24, 0x05, 0x86, 0x5f, 0x80,
-	0x1e, 0x32, 0x4e, 0x3d, 0xca, 0xa9, 0x4c, 0x5b, 0x9d, 0xac, 0xee, 0x37, 0x65, 0x7d, 0x07, 0x55,
-	0x6e, 0xca, 0xba, 0x8e, 0x6a, 0x37, 0x65, 0xbd, 0x8a, 0xf4, 0xf6, 0x5b, 0xa8, 0xad, 0x82, 0x8b,
-	0xbf, 0x01, 0x7c, 0x6b, 0x0d, 0xad, 0xf1, 0x9f, 0x96, 0xeb, 0x90, 0x7e, 0xdf, 0xb5, 0x1d, 0xd3,
-	0xe9, 0xa3, 0x2d, 0x0c, 0x50, 0x31, 0x7b, 0xce, 0xf5, 0x1f, 0x7d, 0xa4, 0x89, 0xf3, 0x15, 0x19,
-	0x7f, 0xea, 0x5b, 0x68, 0xbb, 0xfd, 0x93, 0xda, 0x93, 0xfc, 0x3c, 0xec, 0x42, 0xb5, 0xd0, 0xa2,
-	0x2d, 0x5c, 0x85, 0xd2, 0xfb, 0xf1, 0x3b, 0xa4, 0x89, 0xc3, 0xc8, 0xfc, 0x80, 0xb6, 0xdb, 0x7f,
-	0x43, 0x7d, 0x3d, 0xe8, 0xf8, 0x18, 0x8e, 0x96, 0xa3, 0x06, 0xa6, 0x3d, 0x70, 0x6d, 0x87, 0x98,
-	0x4e, 0xff, 0xdd, 0x47, 0xb4, 0x85, 0xeb, 0xa0, 0x93, 0xab, 0x9e, 0x7b, 0xfe, 0xe6, 0xfc, 0x14,
-	0x69, 0xf8, 0x10, 0xf6, 0x9d, 0xbe, 0xed, 0xb8, 0x23, 0xf3, 0x83, 0x64, 0xf6, 0x09, 0xda, 0x16,
-	0xea, 0xf1, 0xc5, 0x4d, 0xbf, 0xe7, 0xb8, 0xe4, 0xaa, 0x27, 0x88, 0xae, 0x3d, 0x30, 0x4f, 0xcf,
-	0xce, 0x51, 0x09, 0x1f, 0xc1, 0x41, 0x6f, 0x6c, 0x5d, 0x0f, 0x6d, 0x01, 0x9d, 0xfd, 0x7a, 0xea,
-	0x0a, 0xb8, 0xdc, 0xfe, 0x11, 0x1a, 0x8f, 0xbe, 0x14, 0x58, 0x87, 0xb2, 0x35, 0xb6, 0x8a, 0xd7,
-	0x15, 0xea, 0x72, 0xfb, 0x35, 0xe0, 0xa7, 0x9f, 0x02, 0xdc, 0x80, 0x9a, 0x69, 0x8d, 0xad, 0x8f,
-	0xa3, 0xf1, 0xad, 0xad, 0x5e, 0x47, 0x6c, 0x13, 0x69, 0xb8, 0x06, 0x3b, 0xfd, 0xde, 0xa5, 0x6d,
-	0xa2, 0xd2, 0xc5, 0xdb, 0x4f, 0x6f, 0x66, 0x3e, 0x9f, 0xe7, 0x93, 0xce, 0x34, 0x0e, 0xbb, 0xc5,
-	0x8f, 0x0d, 0x4f, 0x85, 0x5d, 0x69, 0xd4, 0x2d, 0x6c, 0xd6, 0x9d, 0x06, 0x71, 0xee, 0x15, 0x21,
-	0xe9, 0xae, 0xc2, 0x32, 0xa9, 0xc8, 0x84, 0xbf, 0xfa, 0x2f, 0x00, 0x00, 0xff, 0xff, 0x4d, 0xcf,
-	0xfb, 0xcd, 0xbf, 0x06, 0x00, 0x00,
+	// 1074 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x55, 0xdf, 0x6f, 0xda, 0x56,
+	0x14, 0x8e, 0x03, 0x01, 0x73, 0x80, 0xf4, 0xf6, 0x26, 0x59, 0xdd, 0x76, 0xd3, 0x50, 0xb6, 0x49,
+	0x0c, 0x4d, 0xb0, 0x52, 0xa5, 0x5d, 0xd6, 0x49, 0x93, 0x21, 0x6e, 0x49, 0x08, 0x50, 0x5d, 0xbb,
+	0x9b, 0xda, 0x17, 0xeb, 0x82, 0x6f, 0xc1, 0xc2, 0x3f, 0x98, 0x7d, 0x5d, 0xd5, 0x7d, 0xda, 0xc3,
+	0xfe, 0xcf, 0xfd, 0x2b, 0xd3, 0xbd, 0x36, 0xc4, 0xa5, 0xda, 0xdb, 0x39, 0xdf, 0xf9, 0xce, 0xb9,
+	0xf8, 0xcb, 0xf9, 0x4e, 0xa0, 0x19, 0x6f, 0x68, 0x10, 0xb0, 0xa8, 0xbb, 0x89, 0x42, 0x1e, 0xe2,
+	0x5a, 0x9e, 0x6e, 0xe6, 0x8f, 0x1e, 0x2e, 0xc3, 0x70, 0xe9, 0xb1, 0x9e, 0x2c, 0xcc, 0x93, 0xf7,
+	0x3d, 0x1a, 0xa4, 0x19, 0xeb, 0xdc, 0x03, 0x74, 0x1b, 0x2e, 0x4d, 0x1e, 0x46, 0x74, 0xc9, 0x86,
+	0x61, 0xf0, 0xde, 0x5d, 0xe2, 0x0e, 0xdc, 0x0f, 0x12, 0xdf, 0x4e, 0x82, 0x98, 0xfd, 0x65, 0xcf,
+	0x93, 0xc5, 0x9a, 0xf1, 0x58, 0x53, 0x5a, 0x4a, 0xbb, 0x44, 0xee, 0x05, 0x89, 0xff, 0x46, 0xe0,
+	0x83, 0x0c, 0xc6, 0x3f, 0x01, 0x16, 0x5c, 0x9f, 0x45, 0x6b, 0x8f, 0xed, 0xc8, 0x87, 0x92, 0x8c,
+	0x82, 0xc4, 0x9f, 0xc8, 0x42, 0xce, 0x3e, 0xc7, 0x80, 0x26, 0x74, 0xf3, 0xd9, 0x6b, 0xe7, 0x7f,
+	0xab, 0xa0, 0x5a, 0x11, 0x63, 0xd7, 0xc1, 0xfb, 0x10, 0x3f, 0x80, 0x2a, 0x8f, 0x18, 0xb3, 0x5d,
+	0x27, 0x7f, 0xb0, 0x22, 0xd2, 0x6b, 0x07, 0x9f, 0x41, 0x65, 0xcd, 0x52, 0x81, 0x67, 0xb3, 0x8f,
+	0xd6, 0x2c, 0xbd, 0x76, 0x30, 0x86, 0x72, 0x40, 0x7d, 0xa6, 0x95, 0x5a, 0x4a, 0xbb, 0x46, 0x64,
+	0x8c, 0x5b, 0x50, 0x77, 0x58, 0xbc, 0x88, 0xdc, 0x0d, 0x77, 0xc3, 0x40, 0x2b, 0xcb, 0x52, 0x11,
+	0xc2, 0x3f, 0x43, 0x4d, 0xbe, 0xc2, 0xd3, 0x0d, 0xd3, 0x8e, 0x5a, 0x4a, 0xfb, 0xb8, 0x7f, 0xd2,
+	0xdd, 0xc9, 0xd5, 0x15, 0xbf, 0xc6, 0x4a, 0x37, 0x8c, 0xa8, 0x3c, 0x8f, 0xf0, 0x53, 0x00, 0xd9,
+	0x11, 0x73, 0xca, 0x99, 0xa6, 0xca, 0x96, 0xd3, 0xbd, 0x16, 0x53, 0xd4, 0x48, 0x8d, 0x6f, 0x43,
+	0xfc, 0x1b, 0x34, 0x57, 0x34, 0x5e, 0xd9, 0x31, 0x8f, 0x28, 0x67, 0xcb, 0x54, 0xab, 0xc9, 0xbe,
+	0x07, 0x85, 0xbe, 0x11, 0x8d, 0x57, 0x66, 0x5e, 0x26, 0x8d, 0x55, 0x21, 0xc3, 0xbf, 0xc3, 0xb1,
+	0xec, 0xa6, 0xde, 0x32, 0x8c, 0x5c, 0xbe, 0xf2, 0x35, 0x90, 0xed, 0xda, 0x5e, 0xbb, 0xbe, 0xad,
+	0x93, 0xe6, 0xaa, 0x98, 0xe2, 0x29, 0x9c, 0xc4, 0xee, 0x32, 0xa0, 0x3c, 0x89, 0x58, 0x61, 0x4a,
+	0x5d, 0x4e, 0xf9, 0xa6, 0x30, 0xc5, 0xdc, 0xb2, 0xee, 0x46, 0xe1, 0xf8, 0x0b, 0x4c, 0xac, 0xc5,
+	0x22, 0x62, 0x94, 0x33, 0x9b, 0xbb, 0x3e, 0xb3, 0x03, 0x1a, 0x84, 0xb1, 0xd6, 0xcc, 0xd6, 0x22,
+	0x2b, 0x58, 0xae, 0xcf, 0xa6, 0x02, 0x16, 0xdc, 0x64, 0xe3, 0xec, 0x71, 0x8f, 0x33, 0x6e, 0x56,
+	0xb8, 0xe3, 0x5e, 0x40, 0x7d, 0x13, 0xb9, 0x1f, 0x04, 0x79, 0xcd, 0x52, 0xed, 0x5e, 0x4b, 0x69,
+	0xd7, 0xfb, 0xa7, 0xdd, 0x6c, 0x67, 0xbb, 0xdb, 0x9d, 0xed, 0xea, 0x41, 0x4a, 0x20, 0x27, 0x8e,
+	0x59, 0x8a, 0xbf, 0x87, 0xe3, 0x4d, 0x32, 0xf7, 0xdc, 0x85, 0xe8, 0xb2, 0x1d, 0x16, 0x69, 0xa8,
+	0xa5, 0xb4, 0x1b, 0xa4, 0x91, 0xa1, 0x63, 0x96, 0x5e, 0xb1, 0x08, 0x8f, 0x01, 0x7b, 0xe1, 0xd2,
+	0x8e, 0xb3, 0x95, 0xb3, 0x17, 0x72, 0xe7, 0xb4, 0x8a, 0x7c, 0xe3, 0x71, 0x41, 0x83, 0x7d, 0x13,
+	0x8c, 0x0e, 0x08, 0xf2, 0xf6, 0x30, 0x31, 0xcc, 0xa7, 0x9b, 0xfd, 0x61, 0xd5, 0x2f, 0x86, 0xed,
+	0xef, 0xb8, 0x18, 0xe6, 0xef, 0x61, 0xf8, 0x39, 0x68, 0x3e, 0xfd, 0x68, 0x47, 0x61, 0xc8, 0x6d,
+	0x27, 0x89, 0xa8, 0xd8, 0x4c, 0xdb, 0x77, 0x3d, 0xcf, 0x8d, 0xb5, 0xfb, 0x52, 0xa9, 0x33, 0x9f,
+	0x7e, 0x24, 0x61, 0xc8, 0xaf, 0xf2, 0xea, 0x44, 0x16, 0xb1, 0x06, 0x55, 0x87, 0x79, 0x8c, 0x33,
+	0x47, 0xc3, 0x2d, 0xa5, 0xad, 0x92, 0x6d, 0x2a, 0x54, 0xcf, 0xc2, 0xa2, 0xea, 0x27, 0x99, 0xea,
+	0x59, 0xe1, 0x4e, 0xf5, 0xe7, 0x50, 0xf1, 0xe8, 0x9c, 0x79, 0xb1, 0x76, 0xda, 0x2a, 0xb5, 0xeb,
+	0xfd, 0x6f, 0xf7, 0xb6, 0x59, 0xd8, 0xb1, 0x7b, 0x2b, 0x19, 0x46, 0xc0, 0xa3, 0x94, 0xe4, 0xf4,
+	0x47, 0x97, 0x50, 0x2f, 0xc0, 0x18, 0x41, 0x49, 0xfc, 0xd5, 0x14, 0xe9, 0x32, 0x11, 0xe2, 0x53,
+	0x38, 0xfa, 0x40, 0xbd, 0x84, 0x49, 0xa7, 0xd6, 0x48, 0x96, 0xfc, 0x7a, 0xf8, 0x8b, 0x32, 0x40,
+	0x70, 0xfc, 0xb9, 0x76, 0x37, 0x65, 0xb5, 0x81, 0x9a, 0xe7, 0xff, 0x1c, 0x66, 0x27, 0x60, 0xc4,
+	0xa8, 0xf3, 0xff, 0x27, 0xe0, 0x21, 0xa8, 0x3c, 0xce, 0x3f, 0x2a, 0x3b, 0x02, 0x55, 0x1e, 0x67,
+	0x1f, 0xf3, 0x38, 0x37, 0x74, 0xec, 0x7e, 0xca, 0x6e, 0x41, 0x29, 0xf3, 0xae, 0xe9, 0x7e, 0x62,
+	0xa2, 0x28, 0x45, 0x16, 0xee, 0x90, 0xd7, 0xa0, 0x41, 0x54, 0x01, 0x08, 0xf3, 0xe0, 0xaf, 0xa1,
+	0xb6, 0x5b, 0x75, 0x69, 0xb0, 0x06, 0xb9, 0x03, 0xf0, 0x77, 0xd0, 0x94, 0x73, 0x23, 0xf6, 0xc1,
+	0x8d, 0xc5, 0x31, 0xa9, 0xc8, 0xd9, 0x0d, 0x01, 0x92, 0x1c, 0xc3, 0x8f, 0x40, 0xf5, 0x19, 0xa7,
+	0x0e, 0xe5, 0x54, 0x3a, 0xbc, 0x41, 0x76, 0xb9, 0xf8, 0xcd, 0x62, 0x3b, 0x57, 0x6e, 0xc0, 0xa5,
+	0xf1, 0x1a, 0xa4, 0xba, 0x66, 0xe9, 0xc8, 0x0d, 0xf8, 0x4d, 0x59, 0x3d, 0x42, 0x95, 0x9b, 0xb2,
+	0xaa, 0xa2, 0xda, 0x4d, 0x59, 0xad, 0x22, 0xb5, 0xf3, 0x02, 0x6a, 0xbb, 0x3b, 0x82, 0xbf, 0x02,
+	0xfc, 0x66, 0x3a, 0x9e, 0xce, 0xfe, 0x9c, 0xda, 0x16, 0x31, 0x0c, 0xdb, 0xb4, 0x74, 0xcb, 0x40,
+	0x07, 0x18, 0xa0, 0xa2, 0x0f, 0xad, 0xeb, 0x3f, 0x0c, 0xa4, 0x88, 0xf8, 0x25, 0x99, 0xbd, 0x33,
+	0xa6, 0xe8, 0xb0, 0xf3, 0x63, 0x26, 0xa1, 0xbc, 0x56, 0x75, 0xa8, 0xe6, 0xbd, 0xe8, 0x00, 0x57,
+	0xa1, 0x74, 0x3b, 0x7b, 0x85, 0x14, 0x11, 0x4c, 0xf4, 0xd7, 0xe8, 0xb0, 0xf3, 0xaf, 0x02, 0x8d,
+	0xe2, 0xe1, 0xc1, 0x0f, 0xe1, 0x6c, 0xfb, 0xd6, 0x48, 0x37, 0x47, 0xb6, 0x69, 0x11, 0xdd, 0x32,
+	0x5e, 0xbd, 0x45, 0x07, 0xb8, 0x01, 0x2a, 0x79, 0x39, 0xb4, 0x9f, 0x5d, 0x3e, 0xeb, 0x23, 0x05,
+	0x9f, 0xc0, 0x3d, 0xcb, 0x30, 0x2d, 0x7b, 0xa2, 0xbf, 0x96, 0x4c, 0x83, 0xa0, 0x43, 0xd1, 0x3d,
+	0x1b, 0xdc, 0x18, 0x43, 0xcb, 0x26, 0x2f, 0x87, 0x82, 0x68, 0x9b, 0x23, 0xbd, 0x7f, 0xf1, 0x0c,
+	0x95, 0xf0, 0x19, 0xdc, 0x1f, 0xce, 0xa6, 0xd7, 0x63, 0x53, 0x40, 0x17, 0x4f, 0xfa, 0xb6, 0x80,
+	0xcb, 0xe2, 0xdb, 0x0a, 0xd4, 0x2d, 0x7e, 0x84, 0x4f, 0x01, 0x15, 0xf0, 0xa7, 0x12, 0xad, 0xe0,
+	0x07, 0x70, 0xb2, 0x45, 0x07, 0xb7, 0xfa, 0xd8, 0xe8, 0x0f, 0x64, 0xa1, 0x8a, 0x35, 0x38, 0x7d,
+	0x4d, 0x66, 0xd6, 0x6c, 0xff, 0x5d, 0xb5, 0xf3, 0x03, 0x34, 0x3f, 0x3b, 0x8d, 0x58, 0x85, 0xf2,
+	0x74, 0x36, 0xcd, 0xf5, 0xcb, 0x69, 0xe5, 0xce, 0x15, 0xe0, 0x2f, 0x6f, 0x1f, 0x6e, 0x42, 0x4d,
+	0x9f, 0xce, 0xa6, 0x6f, 0x27, 0xb3, 0x37, 0x66, 0xa6, 0x1f, 0x31, 0x75, 0xa4, 0xe0, 0x1a, 0x1c,
+	0x19, 0xc3, 0x2b, 0x53, 0x47, 0x25, 0x21, 0xb0, 0x71, 0xd5, 0xbf, 0xb8, 0x78, 0x72, 0x89, 0xaa,
+	0x83, 0x17, 0xef, 0x2e, 0x97, 0x2e, 0x5f, 0x25, 0xf3, 0xee, 0x22, 0xf4, 0x7b, 0xf9, 0xbf, 0x5a,
+	0x1e, 0x09, 0xb3, 0xd2, 0xa0, 0x97, 0x2f, 0x7c, 0x6f, 0xe1, 0x85, 0x89, 0x93, 0x5b, 0xac, 0xb7,
+	0xb3, 0xda, 0xbc, 0x22, 0xef, 0xdb, 0xd3, 0xff, 0x06, 0x00, 0x49, 0xb1, 0x26, 0x09, 0xbd, 0x07,
+	0x00, 0x00,
 }
@@ -0,0 +1,163 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudspanner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"cloud.google.com/go/spanner"
+	"github.com/google/trillian/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// purgeTable describes a table holding tree data, which is purged after the
+// tree is hard-deleted.
+type purgeTable struct {
+	name string
+	// keyColumns are the columns of the table's primary key.
+	keyColumns []string
+	// bytesColumns are the keyColumns of type BYTES. The others are INT64.
+	bytesColumns map[string]bool
+}
+
+// purgeTables are the tables holding tree data, in the order they're purged.
+var purgeTables = []purgeTable{
+	{name: "TreeHeads", keyColumns: []string{"TreeID", "TreeRevision"}},
+	{name: "SubtreeData", keyColumns: []string{"TreeID", "SubtreeID", "Revision"}, bytesColumns: map[string]bool{"SubtreeID": true}},
+	{name: "SequencedLeafData", keyColumns: []string{"TreeID", "SequenceNumber"}},
+	{name: "LeafData", keyColumns: []string{"TreeID", "LeafIdentityHash"}, bytesColumns: map[string]bool{"LeafIdentityHash": true}},
+	{name: "Unsequenced", keyColumns: []string{"TreeID", "Bucket", "QueueTimestampNanos", "MerkleLeafHash"}, bytesColumns: map[string]bool{"MerkleLeafHash": true}},
+	{name: "MapLeafData", keyColumns: []string{"TreeID", "LeafIndex", "MapRevision"}, bytesColumns: map[string]bool{"LeafIndex": true}},
+}
+
+// PendingPurges implements storage.TreePurger.
+func (s *adminStorage) PendingPurges(ctx context.Context) ([]int64, error) {
+	var ids []int64
+	rows := s.client.Single().Query(ctx, spanner.NewStatement("SELECT TreeID FROM TreePurges ORDER BY TreeID"))
+	err := rows.Do(func(r *spanner.Row) error {
+		var id int64
+		if err := r.Columns(&id); err != nil {
+			return err
+		}
+		ids = append(ids, id)
+		return nil
+	})
+	return ids, err
+}
+
+// PurgeTreeData implements storage.TreePurger.
+// Each chunk is the keys of up to the remaining of maxRows rows of a table,
+// read and then deleted in a single commit. maxRows should be small enough
+// for the deletes to stay within Spanner's limit of mutations per commit.
+func (s *adminStorage) PurgeTreeData(ctx context.Context, treeID int64, maxRows int64) (*storage.PurgeProgress, error) {
+	if maxRows <= 0 {
+		return nil, fmt.Errorf("invalid maxRows %d, want > 0", maxRows)
+	}
+	if _, err := s.client.Single().ReadRow(ctx, "TreePurges", spanner.Key{treeID}, []string{"TreeID"}); spanner.ErrCode(err) == codes.NotFound {
+		return nil, status.Errorf(codes.NotFound, "tree %v is not pending purge", treeID)
+	} else if err != nil {
+		return nil, err
+	}
+
+	progress := &storage.PurgeProgress{Deleted: make(map[string]int64)}
+	remaining := maxRows
+	for _, table := range purgeTables {
+		n, err := s.purgeChunk(ctx, table, treeID, remaining)
+		if err != nil {
+			return progress, fmt.Errorf("failed to purge %s: %v", table.name, err)
+		}
+		if n > 0 {
+			progress.Deleted[table.name] = n
+		}
+		if remaining -= n; remaining <= 0 {
+			// The table may have more rows.
+			return progress, nil
+		}
+	}
+
+	if _, err := s.client.Apply(ctx, []*spanner.Mutation{spanner.Delete("TreePurges", spanner.Key{treeID})}); err != nil {
+		return progress, err
+	}
+	progress.Done = true
+	return progress, nil
+}
+
+// purgeChunk deletes up to limit rows of treeID from table, and returns the
+// number of rows deleted.
+func (s *adminStorage) purgeChunk(ctx context.Context, table purgeTable, treeID, limit int64) (int64, error) {
+	stmt := spanner.NewStatement(fmt.Sprintf(
+		"SELECT %s FROM %s WHERE TreeID = @tree_id LIMIT @limit",
+		strings.Join(table.keyColumns, ", "), table.name))
+	stmt.Params["tree_id"] = treeID
+	stmt.Params["limit"] = limit
+
+	var deletes []*spanner.Mutation
+	rows := s.client.Single().Query(ctx, stmt)
+	err := rows.Do(func(r *spanner.Row) error {
+		key := make(spanner.Key, 0, len(table.keyColumns))
+		for i, col := range table.keyColumns {
+			if table.bytesColumns[col] {
+				var b []byte
+				if err := r.Column(i, &b); err != nil {
+					return err
+				}
+				key = append(key, b)
+				continue
+			}
+			var n int64
+			if err := r.Column(i, &n); err != nil {
+				return err
+			}
+			key = append(key, n)
+		}
+		deletes = append(deletes, spanner.Delete(table.name, key))
+		return nil
+	})
+	if err != nil || len(deletes) == 0 {
+		return 0, err
+	}
+	if _, err := s.client.Apply(ctx, deletes); err != nil {
+		return 0, err
+	}
+	return int64(len(deletes)), nil
+}
+
+// FindOrphanedTreeData implements storage.TreePurger.
+func (s *adminStorage) FindOrphanedTreeData(ctx context.Context) ([]storage.TreeDataRows, error) {
+	var orphans []storage.TreeDataRows
+	for _, table := range purgeTables {
+		stmt := spanner.NewStatement(fmt.Sprintf(
+			"SELECT TreeID, COUNT(*) FROM %s"+
+				" WHERE TreeID NOT IN (SELECT TreeID FROM TreeRoots)"+
+				" AND TreeID NOT IN (SELECT TreeID FROM TreePurges)"+
+				" GROUP BY TreeID ORDER BY TreeID", table.name))
+		rows := s.client.Single().Query(ctx, stmt)
+		err := rows.Do(func(r *spanner.Row) error {
+			o := storage.TreeDataRows{Table: table.name}
+			if err := r.Columns(&o.TreeID, &o.Rows); err != nil {
+				return err
+			}
+			orphans = append(orphans, o)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to check %s: %v", table.name, err)
+		}
+	}
+	return orphans, nil
+}
@@ -30,10 +30,13 @@ import (
 	"github.com/google/trillian/storage/cache"
 	"github.com/google/trillian/storage/cloudspanner/spannerpb"
 	"github.com/google/trillian/storage/storagepb"
+	"go.opencensus.io/trace"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+const traceSpanRoot = "github.com/google/trillian/storage/cloudspanner"
+
 var (
 	// ErrNotFound is returned when a read/lookup fails because there was no such
 	// item.
@@ -96,7 +99,7 @@ type spanRead interface {
 // latestSTH reads and returns the newest STH.
 func (t *treeStorage) latestSTH(ctx context.Context, stx spanRead, treeID int64) (*spannerpb.TreeHead, error) {
 	query := spanner.NewStatement(
-		"SELECT t.TreeID, t.TimestampNanos, t.TreeSize, t.RootHash, t.RootSignature, t.TreeRevision, t.TreeMetadata FROM TreeHeads t" +
+		"SELECT t.TreeID, t.TimestampNanos, t.TreeSize, t.RootHash, t.RootSignature, t.TreeRevision, t.TreeMetadata, t.KeyHint FROM TreeHeads t" +
 			"   WHERE t.TreeID = @tree_id" +
 			"   ORDER BY t.TreeRevision DESC " +
 			"   LIMIT 1")
@@ -107,7 +110,7 @@ func (t *treeStorage) latestSTH(ctx context.Context, stx spanRead, treeID int64)
 	defer rows.Stop()
 	err := rows.Do(func(r *spanner.Row) error {
 		tth := &spannerpb.TreeHead{}
-		if err := r.Columns(&tth.TreeId, &tth.TsNanos, &tth.TreeSize, &tth.RootHash, &tth.Signature, &tth.TreeRevision, &tth.Metadata); err != nil {
+		if err := r.Columns(&tth.TreeId, &tth.TsNanos, &tth.TreeSize, &tth.RootHash, &tth.Signature, &tth.TreeRevision, &tth.Metadata, &tth.KeyHint); err != nil {
 			return err
 		}
 
@@ -445,6 +448,8 @@ func (t *treeTX) GetMerkleNodes(ctx context.Context, rev int64, ids []storage.No
 // SetMerkleNodes stores the provided merkle nodes at the writeRevision of the
 // transaction.
 func (t *treeTX) SetMerkleNodes(ctx context.Context, nodes []storage.Node) error {
+	ctx, span := spanFor(ctx, "TreeTX.SetMerkleNodes")
+	defer span.End()
 	t.mu.RLock()
 	defer t.mu.RUnlock()
 	if t.stx == nil {
@@ -511,3 +516,7 @@ func (t *snapshotTX) Close() error {
 
 	return nil
 }
+
+func spanFor(ctx context.Context, name string) (context.Context, *trace.Span) {
+	return trace.StartSpan(ctx, fmt.Sprintf("%s.%s", traceSpanRoot, name))
+}
@@ -0,0 +1,51 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrStaleFencingToken is returned by log storage read-write transactions
+// whose fencing token is lower than one already used to write to the tree,
+// i.e. whose caller is no longer master for it.
+var ErrStaleFencingToken = status.Error(codes.FailedPrecondition, "stale fencing token")
+
+type fencingTokenKey struct{}
+
+// WithFencingToken returns a ctx with the given fencing token, which should
+// identify the caller's current mastership of the tree it writes to, and
+// increase every time mastership changes hands.
+//
+// Log storage implementations that support fencing check the token at the
+// start of LogStorage.ReadWriteTransaction and AddSequencedLeaves: the
+// transaction fails with ErrStaleFencingToken if a higher token has already
+// been used for the tree, and otherwise records the token, so that writes by
+// previous masters fail from then on. Transactions without a token are not
+// checked. Tokens from different election systems aren't comparable, so the
+// recorded tokens must be reset when switching between them.
+func WithFencingToken(ctx context.Context, token int64) context.Context {
+	return context.WithValue(ctx, fencingTokenKey{}, token)
+}
+
+// FencingTokenFromContext returns the fencing token within ctx if present,
+// together with an indication of whether a token was present.
+func FencingTokenFromContext(ctx context.Context) (int64, bool) {
+	token, ok := ctx.Value(fencingTokenKey{}).(int64)
+	return token, ok
+}
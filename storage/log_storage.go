@@ -95,6 +95,14 @@ type LogTreeTX interface {
 	// UpdateSequencedLeaves associates the leaves with the sequence numbers
 	// assigned to them.
 	UpdateSequencedLeaves(ctx context.Context, leaves []*trillian.LogLeaf) error
+
+	// UpdateLeafData replaces the value and extra data of the sequenced leaf at
+	// leafIndex, e.g. with a redacted form of them. The Merkle leaf hash and the
+	// nodes of the tree are not changed, so it is up to the caller to ensure
+	// that the new value still hashes to the same leaf hash.
+	// Implementations that store the data of duplicate leaves only once will
+	// update all the leaves with the same leaf identity hash.
+	UpdateLeafData(ctx context.Context, leafIndex int64, leafValue, extraData []byte) error
 }
 
 // ReadOnlyLogStorage represents a narrowed read-only view into a LogStorage.
@@ -17,6 +17,7 @@ package memory
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -24,6 +25,8 @@ import (
 	"github.com/golang/protobuf/ptypes"
 	"github.com/google/trillian"
 	"github.com/google/trillian/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // NewAdminStorage returns a storage.AdminStorage implementation backed by
@@ -54,6 +57,34 @@ func (s *memoryAdminStorage) CheckDatabaseAccessible(ctx context.Context) error
 	return nil
 }
 
+// GetTreeControl implements storage.TreeControlStorage.
+func (s *memoryAdminStorage) GetTreeControl(ctx context.Context, treeID int64) (*storage.TreeControl, error) {
+	tree := s.ms.getTree(treeID)
+	if tree == nil {
+		return nil, status.Errorf(codes.NotFound, "tree %v not found", treeID)
+	}
+	tree.RLock()
+	defer tree.RUnlock()
+	if tree.control == nil {
+		return &storage.TreeControl{SigningEnabled: true, SequencingEnabled: true}, nil
+	}
+	control := *tree.control
+	return &control, nil
+}
+
+// UpdateTreeControl implements storage.TreeControlStorage.
+func (s *memoryAdminStorage) UpdateTreeControl(ctx context.Context, treeID int64, control *storage.TreeControl) error {
+	tree := s.ms.getTree(treeID)
+	if tree == nil {
+		return status.Errorf(codes.NotFound, "tree %v not found", treeID)
+	}
+	tree.Lock()
+	defer tree.Unlock()
+	c := *control
+	tree.control = &c
+	return nil
+}
+
 type adminTX struct {
 	ms *memoryTreeStorage
 	// mu guards reads/writes on closed, which happen only on
@@ -136,6 +167,23 @@ func (t *adminTX) ListTrees(ctx context.Context, includeDeleted bool) ([]*trilli
 	return ret, nil
 }
 
+func (t *adminTX) ListTreesPage(ctx context.Context, opts storage.ListTreesOptions) ([]*trillian.Tree, error) {
+	t.ms.mu.RLock()
+	defer t.ms.mu.RUnlock()
+
+	var ret []*trillian.Tree
+	for _, v := range t.ms.trees {
+		if opts.Matches(v.meta) {
+			ret = append(ret, v.meta)
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].TreeId < ret[j].TreeId })
+	if opts.Limit > 0 && len(ret) > opts.Limit {
+		ret = ret[:opts.Limit]
+	}
+	return ret, nil
+}
+
 func (t *adminTX) CreateTree(ctx context.Context, tr *trillian.Tree) (*trillian.Tree, error) {
 	if err := storage.ValidateTreeForCreation(ctx, tr); err != nil {
 		return nil, err
@@ -23,6 +23,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/google/btree"
 	"github.com/google/trillian"
 	"github.com/google/trillian/merkle/hashers"
@@ -133,6 +134,8 @@ func (t *readOnlyLogTX) GetActiveLogIDs(ctx context.Context) ([]int64, error) {
 }
 
 func (m *memoryLogStorage) beginInternal(ctx context.Context, tree *trillian.Tree, readonly bool) (storage.LogTreeTX, error) {
+	ctx, span := spanFor(ctx, "LogStorage.begin")
+	defer span.End()
 	once.Do(func() {
 		createMetrics(m.metricFactory)
 	})
@@ -146,6 +149,13 @@ func (m *memoryLogStorage) beginInternal(ctx context.Context, tree *trillian.Tre
 	if err != nil {
 		return nil, err
 	}
+	if token, ok := storage.FencingTokenFromContext(ctx); ok && !readonly {
+		if token < ttx.tree.masterEpoch {
+			ttx.Rollback()
+			return nil, storage.ErrStaleFencingToken
+		}
+		ttx.tree.masterEpoch = token
+	}
 
 	ltx := &logTreeTX{
 		treeTX: ttx,
@@ -171,6 +181,8 @@ func (m *memoryLogStorage) beginInternal(ctx context.Context, tree *trillian.Tre
 }
 
 func (m *memoryLogStorage) ReadWriteTransaction(ctx context.Context, tree *trillian.Tree, f storage.LogTXFunc) error {
+	ctx, span := spanFor(ctx, "LogStorage.ReadWriteTransaction")
+	defer span.End()
 	tx, err := m.beginInternal(ctx, tree, false /* readonly */)
 	if err != nil && err != storage.ErrTreeNeedsInit {
 		return err
@@ -183,7 +195,21 @@ func (m *memoryLogStorage) ReadWriteTransaction(ctx context.Context, tree *trill
 }
 
 func (m *memoryLogStorage) AddSequencedLeaves(ctx context.Context, tree *trillian.Tree, leaves []*trillian.LogLeaf, timestamp time.Time) ([]*trillian.QueuedLogLeaf, error) {
-	return nil, status.Errorf(codes.Unimplemented, "AddSequencedLeaves is not implemented")
+	ctx, span := spanFor(ctx, "LogStorage.AddSequencedLeaves")
+	defer span.End()
+	tx, err := m.beginInternal(ctx, tree, false /* readonly */)
+	if err != nil && err != storage.ErrTreeNeedsInit {
+		return nil, err
+	}
+	defer tx.Close()
+	ret, err := tx.AddSequencedLeaves(ctx, leaves, timestamp)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return ret, nil
 }
 
 func (m *memoryLogStorage) SnapshotForTree(ctx context.Context, tree *trillian.Tree) (storage.ReadOnlyLogTreeTX, error) {
@@ -195,6 +221,8 @@ func (m *memoryLogStorage) SnapshotForTree(ctx context.Context, tree *trillian.T
 }
 
 func (m *memoryLogStorage) QueueLeaves(ctx context.Context, tree *trillian.Tree, leaves []*trillian.LogLeaf, queueTimestamp time.Time) ([]*trillian.QueuedLogLeaf, error) {
+	ctx, span := spanFor(ctx, "LogStorage.QueueLeaves")
+	defer span.End()
 	tx, err := m.beginInternal(ctx, tree, false /* readonly */)
 	if err != nil {
 		return nil, err
@@ -238,6 +266,8 @@ func (t *logTreeTX) WriteRevision() int64 {
 }
 
 func (t *logTreeTX) DequeueLeaves(ctx context.Context, limit int, cutoffTime time.Time) ([]*trillian.LogLeaf, error) {
+	ctx, span := spanFor(ctx, "LogTX.DequeueLeaves")
+	defer span.End()
 	leaves := make([]*trillian.LogLeaf, 0, limit)
 
 	q := t.tx.Get(unseqKey(t.treeID)).(*kv).v.(*list.List)
@@ -270,13 +300,35 @@ func (t *logTreeTX) QueueLeaves(ctx context.Context, leaves []*trillian.LogLeaf,
 }
 
 func (t *logTreeTX) AddSequencedLeaves(ctx context.Context, leaves []*trillian.LogLeaf, timestamp time.Time) ([]*trillian.QueuedLogLeaf, error) {
-	return nil, status.Errorf(codes.Unimplemented, "AddSequencedLeaves is not implemented")
+	// Don't accept batches if any of the leaves are invalid.
+	for i, leaf := range leaves {
+		if got, want := len(leaf.LeafIdentityHash), t.hashSizeBytes; got != want {
+			return nil, status.Errorf(codes.FailedPrecondition, "leaves[%d] has incorrect hash size %d, want %d", i, got, want)
+		}
+	}
+	ok := status.New(codes.OK, "OK").Proto()
+	ret := make([]*trillian.QueuedLogLeaf, len(leaves))
+	for i, leaf := range leaves {
+		ret[i] = &trillian.QueuedLogLeaf{Status: ok}
+		k := seqLeafKey(t.treeID, leaf.LeafIndex)
+		if t.tx.Has(k) {
+			ret[i].Status = status.New(codes.FailedPrecondition, "conflicting LeafIndex").Proto()
+			continue
+		}
+		k.(*kv).v = leaf
+		t.tx.ReplaceOrInsert(k)
+		// update merkle-to-seq mapping:
+		m := t.tx.Get(hashToSeqKey(t.treeID)).(*kv).v.(map[string][]int64)
+		m[string(leaf.MerkleLeafHash)] = append(m[string(leaf.MerkleLeafHash)], leaf.LeafIndex)
+	}
+	return ret, nil
 }
 
 func (t *logTreeTX) GetSequencedLeafCount(ctx context.Context) (int64, error) {
 	var sequencedLeafCount int64
 
-	t.tx.DescendRange(seqLeafKey(t.treeID, math.MaxInt64), seqLeafKey(t.treeID, 0), func(i btree.Item) bool {
+	// The lower bound is exclusive, so use -1 to include the leaf at index 0.
+	t.tx.DescendRange(seqLeafKey(t.treeID, math.MaxInt64), seqLeafKey(t.treeID, -1), func(i btree.Item) bool {
 		sequencedLeafCount = i.(*kv).v.(*trillian.LogLeaf).LeafIndex + 1
 		return false
 	})
@@ -339,6 +391,8 @@ func (t *logTreeTX) fetchLatestRoot(ctx context.Context) (trillian.SignedLogRoot
 }
 
 func (t *logTreeTX) StoreSignedLogRoot(ctx context.Context, slr trillian.SignedLogRoot) error {
+	ctx, span := spanFor(ctx, "LogTX.StoreSignedLogRoot")
+	defer span.End()
 	var root types.LogRootV1
 	if err := root.UnmarshalBinary(slr.LogRoot); err != nil {
 		return err
@@ -355,6 +409,8 @@ func (t *logTreeTX) StoreSignedLogRoot(ctx context.Context, slr trillian.SignedL
 }
 
 func (t *logTreeTX) UpdateSequencedLeaves(ctx context.Context, leaves []*trillian.LogLeaf) error {
+	ctx, span := spanFor(ctx, "LogTX.UpdateSequencedLeaves")
+	defer span.End()
 	countByMerkleHash := make(map[string]int)
 	for _, leaf := range leaves {
 		// This should fail on insert but catch it early
@@ -398,6 +454,20 @@ func (t *logTreeTX) UpdateSequencedLeaves(ctx context.Context, leaves []*trillia
 	return nil
 }
 
+func (t *logTreeTX) UpdateLeafData(ctx context.Context, leafIndex int64, leafValue, extraData []byte) error {
+	k := t.tx.Get(seqLeafKey(t.treeID, leafIndex))
+	if k == nil {
+		return status.Errorf(codes.NotFound, "leaf %d not found", leafIndex)
+	}
+	// Copy the leaf, so that the update isn't visible to other transactions
+	// holding a reference to it.
+	leaf := proto.Clone(k.(*kv).v.(*trillian.LogLeaf)).(*trillian.LogLeaf)
+	leaf.LeafValue = leafValue
+	leaf.ExtraData = extraData
+	t.tx.ReplaceOrInsert(&kv{k: k.(*kv).k, v: leaf})
+	return nil
+}
+
 func (t *logTreeTX) getActiveLogIDs(ctx context.Context) ([]int64, error) {
 	var ret []int64
 	for k := range t.ts.trees {
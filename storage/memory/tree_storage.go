@@ -28,8 +28,11 @@ import (
 	"github.com/google/trillian/storage"
 	"github.com/google/trillian/storage/cache"
 	"github.com/google/trillian/storage/storagepb"
+	"go.opencensus.io/trace"
 )
 
+const traceSpanRoot = "github.com/google/trillian/storage/memory"
+
 const degree = 8
 
 // unseqKey formats a key for use in a tree's BTree store.
@@ -59,6 +62,10 @@ type tree struct {
 	// currentSTH is the timestamp of the current STH.
 	currentSTH uint64
 	meta       *trillian.Tree
+	// masterEpoch is the highest fencing token used to write to the tree.
+	masterEpoch int64
+	// control is the tree's TreeControl, or nil if it was never updated.
+	control *storage.TreeControl
 }
 
 func (t *tree) Lock() {
@@ -239,6 +246,8 @@ func (t *treeTX) GetMerkleNodes(ctx context.Context, treeRevision int64, nodeIDs
 }
 
 func (t *treeTX) SetMerkleNodes(ctx context.Context, nodes []storage.Node) error {
+	ctx, span := spanFor(ctx, "TreeTX.SetMerkleNodes")
+	defer span.End()
 	for _, n := range nodes {
 		err := t.subtreeCache.SetNodeHash(n.NodeID, n.Hash,
 			func(nID storage.NodeID) (*storagepb.SubtreeProto, error) {
@@ -289,3 +298,7 @@ func (t *treeTX) Close() error {
 func (t *treeTX) IsOpen() bool {
 	return !t.closed
 }
+
+func spanFor(ctx context.Context, name string) (context.Context, *trace.Span) {
+	return trace.StartSpan(ctx, fmt.Sprintf("%s.%s", traceSpanRoot, name))
+}
@@ -195,6 +195,19 @@ func (mr *MockAdminTXMockRecorder) ListTrees(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrees", reflect.TypeOf((*MockAdminTX)(nil).ListTrees), arg0, arg1)
 }
 
+// ListTreesPage mocks base method
+func (m *MockAdminTX) ListTreesPage(arg0 context.Context, arg1 ListTreesOptions) ([]*trillian.Tree, error) {
+	ret := m.ctrl.Call(m, "ListTreesPage", arg0, arg1)
+	ret0, _ := ret[0].([]*trillian.Tree)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTreesPage indicates an expected call of ListTreesPage
+func (mr *MockAdminTXMockRecorder) ListTreesPage(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTreesPage", reflect.TypeOf((*MockAdminTX)(nil).ListTreesPage), arg0, arg1)
+}
+
 // Rollback mocks base method
 func (m *MockAdminTX) Rollback() error {
 	ret := m.ctrl.Call(m, "Rollback")
@@ -569,6 +582,18 @@ func (mr *MockLogTreeTXMockRecorder) StoreSignedLogRoot(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreSignedLogRoot", reflect.TypeOf((*MockLogTreeTX)(nil).StoreSignedLogRoot), arg0, arg1)
 }
 
+// UpdateLeafData mocks base method
+func (m *MockLogTreeTX) UpdateLeafData(arg0 context.Context, arg1 int64, arg2, arg3 []byte) error {
+	ret := m.ctrl.Call(m, "UpdateLeafData", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLeafData indicates an expected call of UpdateLeafData
+func (mr *MockLogTreeTXMockRecorder) UpdateLeafData(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLeafData", reflect.TypeOf((*MockLogTreeTX)(nil).UpdateLeafData), arg0, arg1, arg2, arg3)
+}
+
 // UpdateSequencedLeaves mocks base method
 func (m *MockLogTreeTX) UpdateSequencedLeaves(arg0 context.Context, arg1 []*trillian.LogLeaf) error {
 	ret := m.ctrl.Call(m, "UpdateSequencedLeaves", arg0, arg1)
@@ -947,6 +972,19 @@ func (mr *MockReadOnlyAdminTXMockRecorder) ListTrees(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrees", reflect.TypeOf((*MockReadOnlyAdminTX)(nil).ListTrees), arg0, arg1)
 }
 
+// ListTreesPage mocks base method
+func (m *MockReadOnlyAdminTX) ListTreesPage(arg0 context.Context, arg1 ListTreesOptions) ([]*trillian.Tree, error) {
+	ret := m.ctrl.Call(m, "ListTreesPage", arg0, arg1)
+	ret0, _ := ret[0].([]*trillian.Tree)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTreesPage indicates an expected call of ListTreesPage
+func (mr *MockReadOnlyAdminTXMockRecorder) ListTreesPage(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTreesPage", reflect.TypeOf((*MockReadOnlyAdminTX)(nil).ListTreesPage), arg0, arg1)
+}
+
 // Rollback mocks base method
 func (m *MockReadOnlyAdminTX) Rollback() error {
 	ret := m.ctrl.Call(m, "Rollback")
@@ -18,6 +18,8 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -36,7 +38,8 @@ import (
 const (
 	defaultSequenceIntervalSeconds = 60
 
-	nonDeletedWhere = " WHERE (Deleted IS NULL OR Deleted = 'false')"
+	nonDeletedCondition = "(Deleted IS NULL OR Deleted = 'false')"
+	nonDeletedWhere     = " WHERE " + nonDeletedCondition
 
 	selectTreeIDs           = "SELECT TreeId FROM Trees"
 	selectNonDeletedTreeIDs = selectTreeIDs + nonDeletedWhere
@@ -57,14 +60,48 @@ const (
 			PublicKey,
 			MaxRootDurationMillis,
 			Deleted,
-			DeleteTimeMillis
+			DeleteTimeMillis,
+			KeyVersion,
+			MultiSigConfig,
+			ScheduledTransition,
+			TransitionStatus
 		FROM Trees`
 	selectNonDeletedTrees = selectTrees + nonDeletedWhere
 	selectTreeByID        = selectTrees + " WHERE TreeId = ?"
 
 	updateTreeSQL = `UPDATE Trees
-		SET TreeState = ?, TreeType = ?, DisplayName = ?, Description = ?, UpdateTimeMillis = ?, MaxRootDurationMillis = ?, PrivateKey = ?
+		SET TreeState = ?, TreeType = ?, DisplayName = ?, Description = ?, UpdateTimeMillis = ?, MaxRootDurationMillis = ?, PrivateKey = ?, PublicKey = ?, KeyVersion = ?,
+			MultiSigConfig = ?, ScheduledTransition = ?, TransitionStatus = ?
 		WHERE TreeId = ?`
+
+	selectTreeKeys = `
+		SELECT
+			TreeId,
+			KeyVersion,
+			PublicKey,
+			RetireTimeMillis,
+			Rotation,
+			RotationSignature
+		FROM TreeKeys`
+	selectTreeKeysByID = selectTreeKeys + " WHERE TreeId = ? ORDER BY KeyVersion"
+	selectAllTreeKeys  = selectTreeKeys + " ORDER BY TreeId, KeyVersion"
+
+	selectTreeLabels        = "SELECT TreeId, LabelKey, LabelValue FROM TreeLabels"
+	selectTreeLabelsByID    = selectTreeLabels + " WHERE TreeId = ?"
+	insertTreeLabelSQL      = "INSERT INTO TreeLabels(TreeId, LabelKey, LabelValue) VALUES(?, ?, ?)"
+	deleteTreeLabelsByIDSQL = "DELETE FROM TreeLabels WHERE TreeId = ?"
+
+	// hasLabelCondition selects trees with a label, in ListTreesPage queries.
+	hasLabelCondition = "EXISTS (SELECT 1 FROM TreeLabels l WHERE l.TreeId = Trees.TreeId AND l.LabelKey = ? AND l.LabelValue = ?)"
+
+	insertTreeKeySQL = `INSERT INTO TreeKeys(
+			TreeId,
+			KeyVersion,
+			PublicKey,
+			RetireTimeMillis,
+			Rotation,
+			RotationSignature)
+		VALUES(?, ?, ?, ?, ?, ?)`
 )
 
 // NewAdminStorage returns a MySQL storage.AdminStorage implementation backed by DB.
@@ -169,6 +206,18 @@ func (t *adminTX) GetTree(ctx context.Context, treeID int64) (*trillian.Tree, er
 	case err != nil:
 		return nil, fmt.Errorf("error reading tree %v: %v", treeID, err)
 	}
+
+	treeKeys, err := t.readTreeKeys(ctx, selectTreeKeysByID, treeID)
+	if err != nil {
+		return nil, fmt.Errorf("error reading keys of tree %v: %v", treeID, err)
+	}
+	tree.PreviousKeys = treeKeys[treeID]
+
+	treeLabels, err := t.readTreeLabels(ctx, selectTreeLabelsByID, treeID)
+	if err != nil {
+		return nil, fmt.Errorf("error reading labels of tree %v: %v", treeID, err)
+	}
+	tree.Labels = treeLabels[treeID]
 	return tree, nil
 }
 
@@ -185,7 +234,7 @@ func readTree(row row) (*trillian.Tree, error) {
 	var treeState, treeType, hashStrategy, hashAlgorithm, signatureAlgorithm string
 	var createMillis, updateMillis, maxRootDurationMillis int64
 	var displayName, description sql.NullString
-	var privateKey, publicKey []byte
+	var privateKey, publicKey, multiSig, transition, transitionStatus []byte
 	var deleted sql.NullBool
 	var deleteMillis sql.NullInt64
 	err := row.Scan(
@@ -204,6 +253,10 @@ func readTree(row row) (*trillian.Tree, error) {
 		&maxRootDurationMillis,
 		&deleted,
 		&deleteMillis,
+		&tree.KeyVersion,
+		&multiSig,
+		&transition,
+		&transitionStatus,
 	)
 	if err != nil {
 		return nil, err
@@ -267,6 +320,24 @@ func readTree(row row) (*trillian.Tree, error) {
 		return nil, fmt.Errorf("could not unmarshal PrivateKey: %v", err)
 	}
 	tree.PublicKey = &keyspb.PublicKey{Der: publicKey}
+	if len(multiSig) > 0 {
+		tree.MultiSig = &trillian.MultiSigConfig{}
+		if err := proto.Unmarshal(multiSig, tree.MultiSig); err != nil {
+			return nil, fmt.Errorf("could not unmarshal MultiSigConfig: %v", err)
+		}
+	}
+	if len(transition) > 0 {
+		tree.ScheduledTransition = &trillian.ScheduledTransition{}
+		if err := proto.Unmarshal(transition, tree.ScheduledTransition); err != nil {
+			return nil, fmt.Errorf("could not unmarshal ScheduledTransition: %v", err)
+		}
+	}
+	if len(transitionStatus) > 0 {
+		tree.TransitionStatus = &trillian.TreeTransitionStatus{}
+		if err := proto.Unmarshal(transitionStatus, tree.TransitionStatus); err != nil {
+			return nil, fmt.Errorf("could not unmarshal TransitionStatus: %v", err)
+		}
+	}
 
 	tree.Deleted = deleted.Valid && deleted.Bool
 	if tree.Deleted && deleteMillis.Valid {
@@ -279,6 +350,74 @@ func readTree(row row) (*trillian.Tree, error) {
 	return tree, nil
 }
 
+// readTreeKeys runs a query of selectTreeKeys, and returns the keys it reads
+// grouped by tree ID.
+func (t *adminTX) readTreeKeys(ctx context.Context, query string, args ...interface{}) (map[int64][]*trillian.TreeKey, error) {
+	rows, err := t.tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	treeKeys := make(map[int64][]*trillian.TreeKey)
+	for rows.Next() {
+		var treeID, retireMillis int64
+		key := &trillian.TreeKey{PublicKey: &keyspb.PublicKey{}}
+		if err := rows.Scan(
+			&treeID,
+			&key.KeyVersion,
+			&key.PublicKey.Der,
+			&retireMillis,
+			&key.Rotation,
+			&key.RotationSignature,
+		); err != nil {
+			return nil, err
+		}
+		if key.RetireTime, err = ptypes.TimestampProto(fromMillisSinceEpoch(retireMillis)); err != nil {
+			return nil, fmt.Errorf("failed to parse retire time: %v", err)
+		}
+		treeKeys[treeID] = append(treeKeys[treeID], key)
+	}
+	return treeKeys, rows.Err()
+}
+
+// readTreeLabels runs a query of selectTreeLabels, and returns the labels it
+// reads grouped by tree ID.
+func (t *adminTX) readTreeLabels(ctx context.Context, query string, args ...interface{}) (map[int64]map[string]string, error) {
+	rows, err := t.tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	treeLabels := make(map[int64]map[string]string)
+	for rows.Next() {
+		var treeID int64
+		var key, value string
+		if err := rows.Scan(&treeID, &key, &value); err != nil {
+			return nil, err
+		}
+		if treeLabels[treeID] == nil {
+			treeLabels[treeID] = make(map[string]string)
+		}
+		treeLabels[treeID][key] = value
+	}
+	return treeLabels, rows.Err()
+}
+
+// writeTreeLabels replaces the labels of the tree with labels.
+func (t *adminTX) writeTreeLabels(ctx context.Context, treeID int64, labels map[string]string) error {
+	if _, err := t.tx.ExecContext(ctx, deleteTreeLabelsByIDSQL, treeID); err != nil {
+		return err
+	}
+	for k, v := range labels {
+		if _, err := t.tx.ExecContext(ctx, insertTreeLabelSQL, treeID, k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // setNullStringIfValid assigns src to dest if src is Valid.
 func setNullStringIfValid(src sql.NullString, dest *string) {
 	if src.Valid {
@@ -343,9 +482,105 @@ func (t *adminTX) ListTrees(ctx context.Context, includeDeleted bool) ([]*trilli
 		}
 		trees = append(trees, tree)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	// The rows must be closed before the transaction can run another query.
+	rows.Close()
+
+	treeKeys, err := t.readTreeKeys(ctx, selectAllTreeKeys)
+	if err != nil {
+		return nil, fmt.Errorf("error reading tree keys: %v", err)
+	}
+	treeLabels, err := t.readTreeLabels(ctx, selectTreeLabels)
+	if err != nil {
+		return nil, fmt.Errorf("error reading tree labels: %v", err)
+	}
+	for _, tree := range trees {
+		tree.PreviousKeys = treeKeys[tree.TreeId]
+		tree.Labels = treeLabels[tree.TreeId]
+	}
 	return trees, nil
 }
 
+func (t *adminTX) ListTreesPage(ctx context.Context, opts storage.ListTreesOptions) ([]*trillian.Tree, error) {
+	conditions := []string{"TreeId > ?"}
+	args := []interface{}{opts.AfterTreeID}
+	if !opts.IncludeDeleted {
+		conditions = append(conditions, nonDeletedCondition)
+	}
+	if len(opts.TreeTypes) > 0 {
+		conditions = append(conditions, "TreeType IN ("+placeholders(len(opts.TreeTypes))+")")
+		for _, tt := range opts.TreeTypes {
+			args = append(args, tt.String())
+		}
+	}
+	if len(opts.TreeStates) > 0 {
+		conditions = append(conditions, "TreeState IN ("+placeholders(len(opts.TreeStates))+")")
+		for _, ts := range opts.TreeStates {
+			args = append(args, ts.String())
+		}
+	}
+	labelKeys := make([]string, 0, len(opts.Labels))
+	for k := range opts.Labels {
+		labelKeys = append(labelKeys, k)
+	}
+	sort.Strings(labelKeys)
+	for _, k := range labelKeys {
+		conditions = append(conditions, hasLabelCondition)
+		args = append(args, k, opts.Labels[k])
+	}
+	query := selectTrees + " WHERE " + strings.Join(conditions, " AND ") + " ORDER BY TreeId"
+	if opts.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, opts.Limit)
+	}
+
+	rows, err := t.tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	trees := []*trillian.Tree{}
+	treeIDs := []interface{}{}
+	for rows.Next() {
+		tree, err := readTree(rows)
+		if err != nil {
+			return nil, err
+		}
+		trees = append(trees, tree)
+		treeIDs = append(treeIDs, tree.TreeId)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	// The rows must be closed before the transaction can run another query.
+	rows.Close()
+	if len(trees) == 0 {
+		return trees, nil
+	}
+
+	byIDs := " WHERE TreeId IN (" + placeholders(len(treeIDs)) + ")"
+	treeKeys, err := t.readTreeKeys(ctx, selectTreeKeys+byIDs+" ORDER BY TreeId, KeyVersion", treeIDs...)
+	if err != nil {
+		return nil, fmt.Errorf("error reading tree keys: %v", err)
+	}
+	treeLabels, err := t.readTreeLabels(ctx, selectTreeLabels+byIDs, treeIDs...)
+	if err != nil {
+		return nil, fmt.Errorf("error reading tree labels: %v", err)
+	}
+	for _, tree := range trees {
+		tree.PreviousKeys = treeKeys[tree.TreeId]
+		tree.Labels = treeLabels[tree.TreeId]
+	}
+	return trees, nil
+}
+
+// placeholders returns a comma-separated list of n query placeholders.
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?,", n), ",")
+}
+
 func (t *adminTX) CreateTree(ctx context.Context, tree *trillian.Tree) (*trillian.Tree, error) {
 	if err := storage.ValidateTreeForCreation(ctx, tree); err != nil {
 		return nil, err
@@ -393,8 +628,11 @@ func (t *adminTX) CreateTree(ctx context.Context, tree *trillian.Tree) (*trillia
 			UpdateTimeMillis,
 			PrivateKey,
 			PublicKey,
-			MaxRootDurationMillis)
-		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+			MaxRootDurationMillis,
+			MultiSigConfig,
+			ScheduledTransition,
+			TransitionStatus)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
 	if err != nil {
 		return nil, err
 	}
@@ -404,6 +642,16 @@ func (t *adminTX) CreateTree(ctx context.Context, tree *trillian.Tree) (*trillia
 	if err != nil {
 		return nil, fmt.Errorf("could not marshal PrivateKey: %v", err)
 	}
+	var multiSig []byte
+	if newTree.MultiSig != nil {
+		if multiSig, err = proto.Marshal(newTree.MultiSig); err != nil {
+			return nil, fmt.Errorf("could not marshal MultiSigConfig: %v", err)
+		}
+	}
+	transition, transitionStatus, err := marshalTransition(&newTree)
+	if err != nil {
+		return nil, err
+	}
 
 	_, err = insertTreeStmt.ExecContext(
 		ctx,
@@ -420,6 +668,9 @@ func (t *adminTX) CreateTree(ctx context.Context, tree *trillian.Tree) (*trillia
 		privateKey,
 		newTree.PublicKey.GetDer(),
 		rootDuration/time.Millisecond,
+		multiSig,
+		transition,
+		transitionStatus,
 	)
 	if err != nil {
 		return nil, err
@@ -434,6 +685,10 @@ func (t *adminTX) CreateTree(ctx context.Context, tree *trillian.Tree) (*trillia
 		return nil, fmt.Errorf("enum truncated: %v", err)
 	}
 
+	if err := t.writeTreeLabels(ctx, newTree.TreeId, newTree.Labels); err != nil {
+		return nil, err
+	}
+
 	// TODO(codingllama): There's a strong disconnect between trillian.Tree and TreeControl. Are we OK with that?
 	insertControlStmt, err := t.tx.PrepareContext(
 		ctx,
@@ -461,6 +716,24 @@ func (t *adminTX) CreateTree(ctx context.Context, tree *trillian.Tree) (*trillia
 	return &newTree, nil
 }
 
+// marshalTransition returns the serialized ScheduledTransition and
+// TransitionStatus of tree, or nil for unset fields.
+func marshalTransition(tree *trillian.Tree) ([]byte, []byte, error) {
+	var transition, transitionStatus []byte
+	var err error
+	if tree.ScheduledTransition != nil {
+		if transition, err = proto.Marshal(tree.ScheduledTransition); err != nil {
+			return nil, nil, fmt.Errorf("could not marshal ScheduledTransition: %v", err)
+		}
+	}
+	if tree.TransitionStatus != nil {
+		if transitionStatus, err = proto.Marshal(tree.TransitionStatus); err != nil {
+			return nil, nil, fmt.Errorf("could not marshal TransitionStatus: %v", err)
+		}
+	}
+	return transition, transitionStatus, nil
+}
+
 func (t *adminTX) UpdateTree(ctx context.Context, treeID int64, updateFunc func(*trillian.Tree)) (*trillian.Tree, error) {
 	tree, err := t.GetTree(ctx, treeID)
 	if err != nil {
@@ -495,6 +768,17 @@ func (t *adminTX) UpdateTree(ctx context.Context, treeID int64, updateFunc func(
 	if err != nil {
 		return nil, fmt.Errorf("could not marshal PrivateKey: %v", err)
 	}
+	// The signers' private keys may have changed.
+	var multiSig []byte
+	if tree.MultiSig != nil {
+		if multiSig, err = proto.Marshal(tree.MultiSig); err != nil {
+			return nil, fmt.Errorf("could not marshal MultiSigConfig: %v", err)
+		}
+	}
+	transition, transitionStatus, err := marshalTransition(tree)
+	if err != nil {
+		return nil, err
+	}
 
 	stmt, err := t.tx.PrepareContext(ctx, updateTreeSQL)
 	if err != nil {
@@ -511,10 +795,46 @@ func (t *adminTX) UpdateTree(ctx context.Context, treeID int64, updateFunc func(
 		nowMillis,
 		rootDuration/time.Millisecond,
 		privateKey,
+		tree.PublicKey.GetDer(),
+		tree.KeyVersion,
+		multiSig,
+		transition,
+		transitionStatus,
 		tree.TreeId); err != nil {
 		return nil, err
 	}
 
+	if !labelsEqual(beforeUpdate.Labels, tree.Labels) {
+		if err := t.writeTreeLabels(ctx, tree.TreeId, tree.Labels); err != nil {
+			return nil, err
+		}
+	}
+
+	// Store the keys replaced by key rotation, which ValidateTreeForUpdate
+	// has checked are appended to PreviousKeys.
+	for _, key := range tree.PreviousKeys[len(beforeUpdate.PreviousKeys):] {
+		retireTime, err := ptypes.Timestamp(key.RetireTime)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse RetireTime: %v", err)
+		}
+		retireMillis := toMillisSinceEpoch(retireTime)
+		if _, err := t.tx.ExecContext(
+			ctx,
+			insertTreeKeySQL,
+			tree.TreeId,
+			key.KeyVersion,
+			key.PublicKey.GetDer(),
+			retireMillis,
+			key.Rotation,
+			key.RotationSignature); err != nil {
+			return nil, err
+		}
+		// Return the retire time as stored.
+		if key.RetireTime, err = ptypes.TimestampProto(fromMillisSinceEpoch(retireMillis)); err != nil {
+			return nil, fmt.Errorf("failed to build retire time: %v", err)
+		}
+	}
+
 	return tree, nil
 }
 
@@ -546,6 +866,11 @@ func (t *adminTX) HardDeleteTree(ctx context.Context, treeID int64) error {
 		return err
 	}
 
+	// The tree's data is purged later, in chunks (see PurgeTreeData).
+	if _, err := t.tx.ExecContext(ctx, insertTreePurgeSQL, treeID, toMillisSinceEpoch(time.Now())); err != nil {
+		return err
+	}
+
 	// TreeControl didn't have "ON DELETE CASCADE" on previous versions, so let's hit it explicitly
 	if _, err := t.tx.ExecContext(ctx, "DELETE FROM TreeControl WHERE TreeId = ?", treeID); err != nil {
 		return err
@@ -572,6 +897,19 @@ func validateDeleted(ctx context.Context, tx *sql.Tx, treeID int64, wantDeleted
 	return nil
 }
 
+// labelsEqual returns true if a and b hold the same labels.
+func labelsEqual(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if w, ok := b[k]; !ok || w != v {
+			return false
+		}
+	}
+	return true
+}
+
 func toMillisSinceEpoch(t time.Time) int64 {
 	return t.UnixNano() / 1000000
 }
@@ -211,11 +211,15 @@ func TestAdminTX_HardDeleteTree(t *testing.T) {
 
 	// Unlike the HardDelete tests on AdminStorageTester, here we have the chance to poke inside the
 	// database and check that the rows are gone, so let's do just that.
-	// If there's no record on Trees, then there can be no record in any of the dependent tables.
+	// The tree's data isn't deleted along with it, but is pending purge.
 	var name string
 	if err := DB.QueryRowContext(ctx, "SELECT DisplayName FROM Trees WHERE TreeId = ?", tree.TreeId).Scan(&name); err != sql.ErrNoRows {
 		t.Errorf("QueryRowContext() returned err = %v, want = %v", err, sql.ErrNoRows)
 	}
+	var id int64
+	if err := DB.QueryRowContext(ctx, selectTreePurgeSQL, tree.TreeId).Scan(&id); err != nil {
+		t.Errorf("QueryRowContext(TreePurges) returned err = %v, want = nil", err)
+	}
 }
 
 func TestCheckDatabaseAccessible_Fails(t *testing.T) {
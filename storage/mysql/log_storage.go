@@ -43,6 +43,10 @@ const (
 
 	insertLeafDataSQL      = "INSERT INTO LeafData(TreeId,LeafIdentityHash,LeafValue,ExtraData,QueueTimestampNanos) VALUES" + valuesPlaceholder5
 	insertSequencedLeafSQL = "INSERT INTO SequencedLeafData(TreeId,LeafIdentityHash,MerkleLeafHash,SequenceNumber,IntegrateTimestampNanos) VALUES"
+	updateLeafDataSQL      = `UPDATE LeafData l, SequencedLeafData s
+			SET l.LeafValue = ?, l.ExtraData = ?
+			WHERE l.TreeId = s.TreeId AND l.LeafIdentityHash = s.LeafIdentityHash
+			AND s.TreeId = ? AND s.SequenceNumber = ?`
 
 	selectNonDeletedTreeIDByTypeAndStateSQL = `
 		SELECT TreeId FROM Trees
@@ -51,10 +55,18 @@ const (
 		  AND (Deleted IS NULL OR Deleted = 'false')`
 
 	selectSequencedLeafCountSQL   = "SELECT COUNT(*) FROM SequencedLeafData WHERE TreeId=?"
+	selectSequencedLeafExistsSQL  = "SELECT 1 FROM SequencedLeafData WHERE TreeId=? AND SequenceNumber=?"
 	selectUnsequencedLeafCountSQL = "SELECT TreeId, COUNT(1) FROM Unsequenced GROUP BY TreeId"
-	selectLatestSignedLogRootSQL  = `SELECT TreeHeadTimestamp,TreeSize,RootHash,TreeRevision,RootSignature
+	selectLatestSignedLogRootSQL  = `SELECT TreeHeadTimestamp,TreeSize,RootHash,TreeRevision,RootSignature,KeyHint
 			FROM TreeHead WHERE TreeId=?
 			ORDER BY TreeHeadTimestamp DESC LIMIT 1`
+	selectTreeHeadSignaturesSQL = `SELECT SignerIndex,Signature
+			FROM TreeHeadSignature WHERE TreeId=? AND TreeHeadTimestamp=?
+			ORDER BY SignerIndex`
+	insertTreeHeadSignatureSQL = `INSERT INTO TreeHeadSignature(TreeId,TreeHeadTimestamp,SignerIndex,Signature)
+			VALUES(?,?,?,?)`
+	selectMasterEpochSQL = "SELECT MasterEpoch FROM TreeControl WHERE TreeId=? FOR UPDATE"
+	updateMasterEpochSQL = "UPDATE TreeControl SET MasterEpoch=? WHERE TreeId=?"
 
 	selectLeavesByRangeSQL = `SELECT s.MerkleLeafHash,l.LeafIdentityHash,l.LeafValue,s.SequenceNumber,l.ExtraData,l.QueueTimestampNanos,s.IntegrateTimestampNanos
 			FROM LeafData l,SequencedLeafData s
@@ -223,7 +235,9 @@ func (t *readOnlyLogTX) GetActiveLogIDs(ctx context.Context) ([]int64, error) {
 	return ids, rows.Err()
 }
 
-func (m *mySQLLogStorage) beginInternal(ctx context.Context, tree *trillian.Tree) (storage.LogTreeTX, error) {
+func (m *mySQLLogStorage) beginInternal(ctx context.Context, tree *trillian.Tree, readonly bool) (storage.LogTreeTX, error) {
+	ctx, span := spanFor(ctx, "LogStorage.begin")
+	defer span.End()
 	once.Do(func() {
 		createMetrics(m.metricFactory)
 	})
@@ -237,6 +251,14 @@ func (m *mySQLLogStorage) beginInternal(ctx context.Context, tree *trillian.Tree
 	if err != nil && err != storage.ErrTreeNeedsInit {
 		return nil, err
 	}
+	if token, ok := storage.FencingTokenFromContext(ctx); ok && !readonly {
+		// This must be the first statement of the transaction, so that its
+		// snapshot includes the writes of any master it waited for.
+		if err := ttx.checkFencingToken(ctx, token); err != nil {
+			ttx.Rollback()
+			return nil, err
+		}
+	}
 
 	ltx := &logTreeTX{
 		treeTX: ttx,
@@ -260,7 +282,9 @@ func (m *mySQLLogStorage) beginInternal(ctx context.Context, tree *trillian.Tree
 }
 
 func (m *mySQLLogStorage) ReadWriteTransaction(ctx context.Context, tree *trillian.Tree, f storage.LogTXFunc) error {
-	tx, err := m.beginInternal(ctx, tree)
+	ctx, span := spanFor(ctx, "LogStorage.ReadWriteTransaction")
+	defer span.End()
+	tx, err := m.beginInternal(ctx, tree, false /* readonly */)
 	if err != nil && err != storage.ErrTreeNeedsInit {
 		return err
 	}
@@ -271,8 +295,32 @@ func (m *mySQLLogStorage) ReadWriteTransaction(ctx context.Context, tree *trilli
 	return tx.Commit()
 }
 
+// checkFencingToken fails with storage.ErrStaleFencingToken if a fencing
+// token higher than token has been used to write to the tree, and otherwise
+// records token. The tree's TreeControl row stays locked until the end of the
+// transaction, so transactions of a new master wait for those of the previous
+// one to end, and the previous master's later transactions fail.
+func (t *treeTX) checkFencingToken(ctx context.Context, token int64) error {
+	var epoch int64
+	if err := t.tx.QueryRowContext(ctx, selectMasterEpochSQL, t.treeID).Scan(&epoch); err != nil {
+		return fmt.Errorf("failed to read fencing token: %v", err)
+	}
+	switch {
+	case token < epoch:
+		glog.Warningf("%v: rejecting transaction with fencing token %v, already fenced at %v", t.treeID, token, epoch)
+		return storage.ErrStaleFencingToken
+	case token > epoch:
+		if _, err := t.tx.ExecContext(ctx, updateMasterEpochSQL, token, t.treeID); err != nil {
+			return fmt.Errorf("failed to record fencing token: %v", err)
+		}
+	}
+	return nil
+}
+
 func (m *mySQLLogStorage) AddSequencedLeaves(ctx context.Context, tree *trillian.Tree, leaves []*trillian.LogLeaf, timestamp time.Time) ([]*trillian.QueuedLogLeaf, error) {
-	tx, err := m.beginInternal(ctx, tree)
+	ctx, span := spanFor(ctx, "LogStorage.AddSequencedLeaves")
+	defer span.End()
+	tx, err := m.beginInternal(ctx, tree, false /* readonly */)
 	if err != nil {
 		return nil, err
 	}
@@ -287,7 +335,7 @@ func (m *mySQLLogStorage) AddSequencedLeaves(ctx context.Context, tree *trillian
 }
 
 func (m *mySQLLogStorage) SnapshotForTree(ctx context.Context, tree *trillian.Tree) (storage.ReadOnlyLogTreeTX, error) {
-	tx, err := m.beginInternal(ctx, tree)
+	tx, err := m.beginInternal(ctx, tree, true /* readonly */)
 	if err != nil && err != storage.ErrTreeNeedsInit {
 		return nil, err
 	}
@@ -295,7 +343,9 @@ func (m *mySQLLogStorage) SnapshotForTree(ctx context.Context, tree *trillian.Tr
 }
 
 func (m *mySQLLogStorage) QueueLeaves(ctx context.Context, tree *trillian.Tree, leaves []*trillian.LogLeaf, queueTimestamp time.Time) ([]*trillian.QueuedLogLeaf, error) {
-	tx, err := m.beginInternal(ctx, tree)
+	ctx, span := spanFor(ctx, "LogStorage.QueueLeaves")
+	defer span.End()
+	tx, err := m.beginInternal(ctx, tree, false /* readonly */)
 	if err != nil {
 		return nil, err
 	}
@@ -338,6 +388,8 @@ func (t *logTreeTX) WriteRevision() int64 {
 }
 
 func (t *logTreeTX) DequeueLeaves(ctx context.Context, limit int, cutoffTime time.Time) ([]*trillian.LogLeaf, error) {
+	ctx, span := spanFor(ctx, "LogTX.DequeueLeaves")
+	defer span.End()
 	start := time.Now()
 	stx, err := t.tx.PrepareContext(ctx, selectQueuedLeavesSQL)
 	if err != nil {
@@ -598,6 +650,22 @@ func (t *logTreeTX) AddSequencedLeaves(ctx context.Context, leaves []*trillian.L
 	return res, nil
 }
 
+func (t *logTreeTX) UpdateLeafData(ctx context.Context, leafIndex int64, leafValue, extraData []byte) error {
+	var exists int
+	if err := t.tx.QueryRowContext(ctx, selectSequencedLeafExistsSQL, t.treeID, leafIndex).Scan(&exists); err == sql.ErrNoRows {
+		return status.Errorf(codes.NotFound, "leaf %d not found", leafIndex)
+	} else if err != nil {
+		return err
+	}
+
+	// The affected row count can't be checked, as it's zero if the data doesn't change.
+	if _, err := t.tx.ExecContext(ctx, updateLeafDataSQL, leafValue, extraData, t.treeID, leafIndex); err != nil {
+		glog.Warningf("Failed to update data of leaf %d: %s", leafIndex, err)
+		return err
+	}
+	return nil
+}
+
 func (t *logTreeTX) GetSequencedLeafCount(ctx context.Context) (int64, error) {
 	var sequencedLeafCount int64
 
@@ -755,15 +823,20 @@ func (t *logTreeTX) LatestSignedLogRoot(ctx context.Context) (trillian.SignedLog
 // fetchLatestRoot reads the latest SignedLogRoot from the DB and returns it.
 func (t *logTreeTX) fetchLatestRoot(ctx context.Context) (trillian.SignedLogRoot, error) {
 	var timestamp, treeSize, treeRevision int64
-	var rootHash, rootSignatureBytes []byte
+	var rootHash, rootSignatureBytes, keyHint []byte
 	if err := t.tx.QueryRowContext(
 		ctx, selectLatestSignedLogRootSQL, t.treeID).Scan(
-		&timestamp, &treeSize, &rootHash, &treeRevision, &rootSignatureBytes,
+		&timestamp, &treeSize, &rootHash, &treeRevision, &rootSignatureBytes, &keyHint,
 	); err == sql.ErrNoRows {
 		// It's possible there are no roots for this tree yet
 		return trillian.SignedLogRoot{}, storage.ErrTreeNeedsInit
 	}
 
+	signatures, err := t.fetchRootSignatures(ctx, timestamp)
+	if err != nil {
+		return trillian.SignedLogRoot{}, err
+	}
+
 	// Put logRoot back together. Fortunately LogRoot has a deterministic serialization.
 	logRoot, err := (&types.LogRootV1{
 		RootHash:       rootHash,
@@ -775,10 +848,17 @@ func (t *logTreeTX) fetchLatestRoot(ctx context.Context) (trillian.SignedLogRoot
 		return trillian.SignedLogRoot{}, err
 	}
 
+	// Roots stored before the KeyHint column was added were all signed by the
+	// tree's original key.
+	if keyHint == nil {
+		keyHint = types.SerializeKeyHint(t.treeID)
+	}
+
 	return trillian.SignedLogRoot{
-		KeyHint:          types.SerializeKeyHint(t.treeID),
+		KeyHint:          keyHint,
 		LogRoot:          logRoot,
 		LogRootSignature: rootSignatureBytes,
+		Signatures:       signatures,
 		// TODO(gbelvin): Remove deprecated fields
 		TimestampNanos: timestamp,
 		RootHash:       rootHash,
@@ -788,6 +868,8 @@ func (t *logTreeTX) fetchLatestRoot(ctx context.Context) (trillian.SignedLogRoot
 }
 
 func (t *logTreeTX) StoreSignedLogRoot(ctx context.Context, root trillian.SignedLogRoot) error {
+	ctx, span := spanFor(ctx, "LogTX.StoreSignedLogRoot")
+	defer span.End()
 	var logRoot types.LogRootV1
 	if err := logRoot.UnmarshalBinary(root.LogRoot); err != nil {
 		glog.Warningf("Failed to parse log root: %x %v", root.LogRoot, err)
@@ -806,12 +888,51 @@ func (t *logTreeTX) StoreSignedLogRoot(ctx context.Context, root trillian.Signed
 		logRoot.TreeSize,
 		logRoot.RootHash,
 		logRoot.Revision,
-		root.LogRootSignature)
+		root.LogRootSignature,
+		root.KeyHint)
 	if err != nil {
 		glog.Warningf("Failed to store signed root: %s", err)
 	}
+	if err := checkResultOkAndRowCountIs(res, err, 1); err != nil {
+		return err
+	}
 
-	return checkResultOkAndRowCountIs(res, err, 1)
+	for _, sig := range root.Signatures {
+		res, err := t.tx.ExecContext(
+			ctx,
+			insertTreeHeadSignatureSQL,
+			t.treeID,
+			logRoot.TimestampNanos,
+			sig.SignerIndex,
+			sig.Signature)
+		if err != nil {
+			glog.Warningf("Failed to store root signature of signer %d: %s", sig.SignerIndex, err)
+		}
+		if err := checkResultOkAndRowCountIs(res, err, 1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fetchRootSignatures reads the co-signatures of the root with the given
+// timestamp. Roots of trees without a multi-signature configuration have none.
+func (t *logTreeTX) fetchRootSignatures(ctx context.Context, timestamp int64) ([]*trillian.RootSignature, error) {
+	rows, err := t.tx.QueryContext(ctx, selectTreeHeadSignaturesSQL, t.treeID, timestamp)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var signatures []*trillian.RootSignature
+	for rows.Next() {
+		sig := &trillian.RootSignature{}
+		if err := rows.Scan(&sig.SignerIndex, &sig.Signature); err != nil {
+			return nil, err
+		}
+		signatures = append(signatures, sig)
+	}
+	return signatures, rows.Err()
 }
 
 func (t *logTreeTX) getLeavesByHashInternal(ctx context.Context, leafHashes [][]byte, tmpl *sql.Stmt, desc string) ([]*trillian.LogLeaf, error) {
@@ -33,6 +33,8 @@ import (
 	"github.com/google/trillian/storage/testonly"
 	"github.com/google/trillian/types"
 	"github.com/kylelemons/godebug/pretty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	tcrypto "github.com/google/trillian/crypto"
 	ttestonly "github.com/google/trillian/testonly"
@@ -40,7 +42,7 @@ import (
 	_ "github.com/go-sql-driver/mysql"
 )
 
-var allTables = []string{"Unsequenced", "TreeHead", "SequencedLeafData", "LeafData", "Subtree", "TreeControl", "Trees", "MapLeaf", "MapHead"}
+var allTables = []string{"Unsequenced", "TreeHeadSignature", "TreeHead", "SequencedLeafData", "LeafData", "Subtree", "TreeControl", "TreeKeys", "TreeLabels", "TreePurges", "MasterLease", "Trees", "MapLeaf", "MapHead"}
 
 // Must be 32 bytes to match sha256 length if it was a real hash
 var dummyHash = []byte("hashxxxxhashxxxxhashxxxxhashxxxx")
@@ -262,6 +264,43 @@ func TestReadWriteTransaction(t *testing.T) {
 	}
 }
 
+func TestReadWriteTransaction_FencingToken(t *testing.T) {
+	cleanTestDB(DB)
+	tree := createTreeOrPanic(DB, testonly.LogTree)
+	createFakeSignedLogRoot(DB, tree, 0)
+
+	ctx := context.Background()
+	s := NewLogStorage(DB, nil)
+	noop := func(ctx context.Context, tx storage.LogTreeTX) error { return nil }
+
+	// Steps run in order, against the same tree.
+	tests := []struct {
+		desc    string
+		ctx     context.Context
+		wantErr error
+	}{
+		{desc: "noToken", ctx: ctx},
+		{desc: "firstToken", ctx: storage.WithFencingToken(ctx, 2)},
+		{desc: "sameToken", ctx: storage.WithFencingToken(ctx, 2)},
+		{desc: "staleToken", ctx: storage.WithFencingToken(ctx, 1), wantErr: storage.ErrStaleFencingToken},
+		{desc: "newerToken", ctx: storage.WithFencingToken(ctx, 3)},
+		{desc: "supersededToken", ctx: storage.WithFencingToken(ctx, 2), wantErr: storage.ErrStaleFencingToken},
+		{desc: "noTokenAfterFencing", ctx: ctx},
+	}
+	for _, test := range tests {
+		if err := s.ReadWriteTransaction(test.ctx, tree, noop); err != test.wantErr {
+			t.Errorf("%v: ReadWriteTransaction() = %v, want %v", test.desc, err, test.wantErr)
+		}
+	}
+
+	// Stale tokens don't prevent reads.
+	tx, err := s.SnapshotForTree(storage.WithFencingToken(ctx, 1), tree)
+	if err != nil {
+		t.Fatalf("SnapshotForTree() with stale token: %v", err)
+	}
+	tx.Close()
+}
+
 func TestQueueDuplicateLeaf(t *testing.T) {
 	cleanTestDB(DB)
 	tree := createTreeOrPanic(DB, testonly.LogTree)
@@ -872,6 +911,39 @@ func TestGetLeavesByIndex(t *testing.T) {
 	})
 }
 
+func TestUpdateLeafData(t *testing.T) {
+	ctx := context.Background()
+
+	cleanTestDB(DB)
+	tree := createTreeOrPanic(DB, testonly.LogTree)
+	s := NewLogStorage(DB, nil)
+
+	createFakeLeaf(ctx, DB, tree.TreeId, dummyRawHash, dummyHash, []byte("some data"), someExtraData, sequenceNumber, t)
+
+	redacted := []byte("redacted data")
+	runLogTX(s, tree, t, func(ctx context.Context, tx storage.LogTreeTX) error {
+		if err := tx.UpdateLeafData(ctx, sequenceNumber, redacted, nil); err != nil {
+			t.Fatalf("UpdateLeafData(): %v", err)
+		}
+		if err := tx.UpdateLeafData(ctx, sequenceNumber+1, redacted, nil); status.Code(err) != codes.NotFound {
+			t.Errorf("UpdateLeafData(missing leaf): %v, want NotFound", err)
+		}
+		return nil
+	})
+	runLogTX(s, tree, t, func(ctx context.Context, tx storage.LogTreeTX) error {
+		leaves, err := tx.GetLeavesByIndex(ctx, []int64{sequenceNumber})
+		if err != nil {
+			t.Fatalf("Unexpected error getting leaf by index: %v", err)
+		}
+		if len(leaves) != 1 {
+			t.Fatalf("Got %d leaves but expected one", len(leaves))
+		}
+		// The Merkle leaf hash is unchanged.
+		checkLeafContents(leaves[0], sequenceNumber, dummyRawHash, dummyHash, redacted, nil, t)
+		return nil
+	})
+}
+
 // GetLeavesByRange tests. -----------------------------------------------------
 
 type getLeavesByRangeTest struct {
@@ -1021,6 +1093,34 @@ func TestLatestSignedLogRoot(t *testing.T) {
 	}
 }
 
+func TestLatestSignedLogRootKeyHint(t *testing.T) {
+	cleanTestDB(DB)
+	tree := createTreeOrPanic(DB, testonly.LogTree)
+	s := NewLogStorage(DB, nil)
+
+	// The root is signed by a rotated key, so its KeyHint holds a key version.
+	signer := tcrypto.NewSigner(tree.TreeId, ttestonly.NewSignerWithFixedSig(nil, []byte("notempty")), crypto.SHA256)
+	signer.KeyHint = types.SerializeKeyVersionHint(tree.TreeId, 2)
+	root, err := signer.SignLogRoot(&types.LogRootV1{TimestampNanos: 98765, TreeSize: 16, Revision: 5, RootHash: []byte(dummyHash)})
+	if err != nil {
+		t.Fatalf("SignLogRoot(): %v", err)
+	}
+
+	runLogTX(s, tree, t, func(ctx context.Context, tx storage.LogTreeTX) error {
+		return tx.StoreSignedLogRoot(ctx, *root)
+	})
+	runLogTX(s, tree, t, func(ctx context.Context, tx storage.LogTreeTX) error {
+		root2, err := tx.LatestSignedLogRoot(ctx)
+		if err != nil {
+			t.Fatalf("Failed to read back new log root: %v", err)
+		}
+		if got, want := root2.KeyHint, root.KeyHint; !bytes.Equal(got, want) {
+			t.Errorf("LatestSignedLogRoot().KeyHint = %x, want %x", got, want)
+		}
+		return nil
+	})
+}
+
 func TestDuplicateSignedLogRoot(t *testing.T) {
 	cleanTestDB(DB)
 	tree := createTreeOrPanic(DB, testonly.LogTree)
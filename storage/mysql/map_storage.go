@@ -131,6 +131,8 @@ func (m *mySQLMapStorage) SnapshotForTree(ctx context.Context, tree *trillian.Tr
 }
 
 func (m *mySQLMapStorage) ReadWriteTransaction(ctx context.Context, tree *trillian.Tree, f storage.MapTXFunc) error {
+	ctx, span := spanFor(ctx, "MapStorage.ReadWriteTransaction")
+	defer span.End()
 	tx, err := m.begin(ctx, tree)
 	if tx != nil {
 		defer tx.Close()
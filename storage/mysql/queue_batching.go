@@ -93,6 +93,8 @@ func queueArgs(treeID int64, identityHash []byte, queueTimestamp time.Time) []in
 }
 
 func (t *logTreeTX) UpdateSequencedLeaves(ctx context.Context, leaves []*trillian.LogLeaf) error {
+	ctx, span := spanFor(ctx, "LogTX.UpdateSequencedLeaves")
+	defer span.End()
 	querySuffix := []string{}
 	args := []interface{}{}
 	for _, leaf := range leaves {
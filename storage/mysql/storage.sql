@@ -1,4 +1,6 @@
 # MySQL / MariaDB version of the tree schema
+# Databases created by an earlier version of this file can be brought up to
+# date with upgrade_storage.sql.
 
 -- ---------------------------------------------
 -- Tree stuff here
@@ -10,9 +12,9 @@ CREATE TABLE IF NOT EXISTS Trees(
   TreeId                BIGINT NOT NULL,
   TreeState             ENUM('ACTIVE', 'FROZEN', 'DRAINING') NOT NULL,
   TreeType              ENUM('LOG', 'MAP', 'PREORDERED_LOG') NOT NULL,
-  HashStrategy          ENUM('RFC6962_SHA256', 'TEST_MAP_HASHER', 'OBJECT_RFC6962_SHA256', 'CONIKS_SHA512_256') NOT NULL,
+  HashStrategy          ENUM('RFC6962_SHA256', 'TEST_MAP_HASHER', 'OBJECT_RFC6962_SHA256', 'CONIKS_SHA512_256', 'RFC6962_SHA512_256', 'RFC6962_SHA3_256', 'RFC6962_BLAKE2B_256', 'PROTO_RFC6962_SHA256') NOT NULL,
   HashAlgorithm         ENUM('SHA256') NOT NULL,
-  SignatureAlgorithm    ENUM('ECDSA', 'RSA') NOT NULL,
+  SignatureAlgorithm    ENUM('ECDSA', 'RSA', 'ED25519') NOT NULL,
   DisplayName           VARCHAR(20),
   Description           VARCHAR(200),
   CreateTimeMillis      BIGINT NOT NULL,
@@ -20,8 +22,13 @@ CREATE TABLE IF NOT EXISTS Trees(
   MaxRootDurationMillis BIGINT NOT NULL,
   PrivateKey            MEDIUMBLOB NOT NULL,
   PublicKey             MEDIUMBLOB NOT NULL,
+  KeyVersion            BIGINT NOT NULL DEFAULT 0,
+  MultiSigConfig        MEDIUMBLOB,
   Deleted               BOOLEAN,
   DeleteTimeMillis      BIGINT,
+  -- Serialized trillian.ScheduledTransition and trillian.TreeTransitionStatus.
+  ScheduledTransition   MEDIUMBLOB,
+  TransitionStatus      MEDIUMBLOB,
   PRIMARY KEY(TreeId)
 );
 
@@ -32,17 +39,66 @@ CREATE TABLE IF NOT EXISTS TreeControl(
   SigningEnabled          BOOLEAN NOT NULL,
   SequencingEnabled       BOOLEAN NOT NULL,
   SequenceIntervalSeconds INTEGER NOT NULL,
+  -- The highest fencing token used to write to the tree, see
+  -- storage.WithFencingToken. Tokens only increase within one election
+  -- system, so when log signers are switched to another, this must be reset
+  -- to 0 while all of them are stopped.
+  MasterEpoch             BIGINT NOT NULL DEFAULT 0,
   PRIMARY KEY(TreeId),
   FOREIGN KEY(TreeId) REFERENCES Trees(TreeId) ON DELETE CASCADE
 );
 
+-- This table contains the keys of a tree that were replaced by key rotation.
+-- The current key of the tree is kept in the Trees table.
+CREATE TABLE IF NOT EXISTS TreeKeys(
+  TreeId                BIGINT NOT NULL,
+  KeyVersion            BIGINT NOT NULL,
+  PublicKey             MEDIUMBLOB NOT NULL,
+  RetireTimeMillis      BIGINT NOT NULL,
+  -- Rotation statement signed by this key, naming the key that replaced it.
+  Rotation              MEDIUMBLOB NOT NULL,
+  RotationSignature     BLOB NOT NULL,
+  PRIMARY KEY(TreeId, KeyVersion),
+  FOREIGN KEY(TreeId) REFERENCES Trees(TreeId) ON DELETE CASCADE
+);
+
+-- This table contains the key/value labels of trees.
+CREATE TABLE IF NOT EXISTS TreeLabels(
+  TreeId                BIGINT NOT NULL,
+  LabelKey              VARCHAR(63) NOT NULL,
+  LabelValue            VARCHAR(63) NOT NULL,
+  PRIMARY KEY(TreeId, LabelKey),
+  INDEX TreeLabelsByKeyValue(LabelKey, LabelValue),
+  FOREIGN KEY(TreeId) REFERENCES Trees(TreeId) ON DELETE CASCADE
+);
+
+-- This table lists hard-deleted trees whose data is yet to be purged.
+-- Deleting a tree from Trees only cascades to its configuration. Its data, in
+-- the tables below, is deleted in chunks by a background process, as it may be
+-- too large to delete in a single transaction.
+CREATE TABLE IF NOT EXISTS TreePurges(
+  TreeId                BIGINT NOT NULL,
+  HardDeleteTimeMillis  BIGINT NOT NULL,
+  PRIMARY KEY(TreeId)
+);
+
+-- This table holds the mastership leases of the MySQL-based master election
+-- (util/mysql), one row per tree. Epoch is incremented each time the lease
+-- changes hands, and serves as a fencing token.
+CREATE TABLE IF NOT EXISTS MasterLease(
+  TreeId                BIGINT NOT NULL,
+  InstanceId            VARCHAR(255) NOT NULL,
+  Epoch                 BIGINT NOT NULL,
+  ExpiryMillis          BIGINT NOT NULL,
+  PRIMARY KEY(TreeId)
+);
+
 CREATE TABLE IF NOT EXISTS Subtree(
   TreeId               BIGINT NOT NULL,
   SubtreeId            VARBINARY(255) NOT NULL,
   Nodes                MEDIUMBLOB NOT NULL,
   SubtreeRevision      INTEGER NOT NULL,  -- negated because DESC indexes aren't supported :/
-  PRIMARY KEY(TreeId, SubtreeId, SubtreeRevision),
-  FOREIGN KEY(TreeId) REFERENCES Trees(TreeId) ON DELETE CASCADE
+  PRIMARY KEY(TreeId, SubtreeId, SubtreeRevision)
 );
 
 -- The TreeRevisionIdx is used to enforce that there is only one STH at any
@@ -54,13 +110,27 @@ CREATE TABLE IF NOT EXISTS TreeHead(
   RootHash             VARBINARY(255) NOT NULL,
   RootSignature        VARBINARY(1024) NOT NULL,
   TreeRevision         BIGINT,
-  PRIMARY KEY(TreeId, TreeHeadTimestamp),
-  FOREIGN KEY(TreeId) REFERENCES Trees(TreeId) ON DELETE CASCADE
+  -- The KeyHint of the root, identifying the version of the tree's key that
+  -- signed it. NULL for roots stored before this column was added, which were
+  -- all signed by the original key.
+  KeyHint              VARBINARY(255),
+  PRIMARY KEY(TreeId, TreeHeadTimestamp)
 );
 
 CREATE UNIQUE INDEX TreeHeadRevisionIdx
   ON TreeHead(TreeId, TreeRevision);
 
+-- This table contains the co-signatures of log roots of trees that have a
+-- multi-signature configuration, one row per signer.
+CREATE TABLE IF NOT EXISTS TreeHeadSignature(
+  TreeId               BIGINT NOT NULL,
+  TreeHeadTimestamp    BIGINT NOT NULL,
+  SignerIndex          INTEGER NOT NULL,
+  Signature            VARBINARY(1024) NOT NULL,
+  PRIMARY KEY(TreeId, TreeHeadTimestamp, SignerIndex),
+  FOREIGN KEY(TreeId, TreeHeadTimestamp) REFERENCES TreeHead(TreeId, TreeHeadTimestamp) ON DELETE CASCADE
+);
+
 -- ---------------------------------------------
 -- Log specific stuff here
 -- ---------------------------------------------
@@ -85,8 +155,7 @@ CREATE TABLE IF NOT EXISTS LeafData(
   ExtraData            LONGBLOB,
   -- The timestamp from when this leaf data was first queued for inclusion.
   QueueTimestampNanos  BIGINT NOT NULL,
-  PRIMARY KEY(TreeId, LeafIdentityHash),
-  FOREIGN KEY(TreeId) REFERENCES Trees(TreeId) ON DELETE CASCADE
+  PRIMARY KEY(TreeId, LeafIdentityHash)
 );
 
 -- When a leaf is sequenced a row is added to this table. If logs allow duplicates then
@@ -107,7 +176,6 @@ CREATE TABLE IF NOT EXISTS SequencedLeafData(
   MerkleLeafHash       VARBINARY(255) NOT NULL,
   IntegrateTimestampNanos BIGINT NOT NULL,
   PRIMARY KEY(TreeId, SequenceNumber),
-  FOREIGN KEY(TreeId) REFERENCES Trees(TreeId) ON DELETE CASCADE,
   FOREIGN KEY(TreeId, LeafIdentityHash) REFERENCES LeafData(TreeId, LeafIdentityHash) ON DELETE CASCADE
 );
 
@@ -143,8 +211,7 @@ CREATE TABLE IF NOT EXISTS MapLeaf(
   -- st. more recent revisions come first.
   MapRevision           BIGINT NOT NULL,
   LeafValue             LONGBLOB NOT NULL,
-  PRIMARY KEY(TreeId, KeyHash, MapRevision),
-  FOREIGN KEY(TreeId) REFERENCES Trees(TreeId) ON DELETE CASCADE
+  PRIMARY KEY(TreeId, KeyHash, MapRevision)
 );
 
 
@@ -155,8 +222,7 @@ CREATE TABLE IF NOT EXISTS MapHead(
   MapRevision          BIGINT,
   RootSignature        VARBINARY(1024) NOT NULL,
   MapperData           MEDIUMBLOB,
-  PRIMARY KEY(TreeId, MapHeadTimestamp),
-  FOREIGN KEY(TreeId) REFERENCES Trees(TreeId) ON DELETE CASCADE
+  PRIMARY KEY(TreeId, MapHeadTimestamp)
 );
 
 CREATE UNIQUE INDEX MapHeadRevisionIdx
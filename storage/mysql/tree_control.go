@@ -0,0 +1,56 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/trillian/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	selectTreeControlSQL = "SELECT SigningEnabled, SequencingEnabled FROM TreeControl WHERE TreeId = ?"
+	updateTreeControlSQL = "UPDATE TreeControl SET SigningEnabled = ?, SequencingEnabled = ? WHERE TreeId = ?"
+)
+
+// GetTreeControl implements storage.TreeControlStorage.
+func (s *mysqlAdminStorage) GetTreeControl(ctx context.Context, treeID int64) (*storage.TreeControl, error) {
+	var control storage.TreeControl
+	switch err := s.db.QueryRowContext(ctx, selectTreeControlSQL, treeID).Scan(&control.SigningEnabled, &control.SequencingEnabled); {
+	case err == sql.ErrNoRows:
+		return nil, status.Errorf(codes.NotFound, "tree %v not found", treeID)
+	case err != nil:
+		return nil, err
+	}
+	return &control, nil
+}
+
+// UpdateTreeControl implements storage.TreeControlStorage.
+func (s *mysqlAdminStorage) UpdateTreeControl(ctx context.Context, treeID int64, control *storage.TreeControl) error {
+	res, err := s.db.ExecContext(ctx, updateTreeControlSQL, control.SigningEnabled, control.SequencingEnabled, treeID)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil || n > 0 {
+		return err
+	}
+	// Rows updated to their current values aren't counted as affected, so
+	// check whether the tree exists.
+	_, err = s.GetTreeControl(ctx, treeID)
+	return err
+}
@@ -0,0 +1,143 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/trillian/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	insertTreePurgeSQL = "INSERT INTO TreePurges(TreeId, HardDeleteTimeMillis) VALUES(?, ?)"
+	selectTreePurgeSQL = "SELECT TreeId FROM TreePurges WHERE TreeId = ?"
+	deleteTreePurgeSQL = "DELETE FROM TreePurges WHERE TreeId = ?"
+)
+
+var (
+	// purgeTables are the tables holding tree data that isn't deleted along
+	// with the tree, in the order they're purged. Rows that other tables
+	// reference are purged last, so that deletes don't cascade.
+	purgeTables = []string{
+		"TreeHeadSignature",
+		"TreeHead",
+		"Subtree",
+		"SequencedLeafData",
+		"LeafData",
+		"Unsequenced",
+		"MapLeaf",
+		"MapHead",
+		"MasterLease",
+	}
+
+	// orphanTables are the tables checked by FindOrphanedTreeData. The tables
+	// of tree configuration are included, even though their rows are deleted
+	// along with the tree.
+	orphanTables = append([]string{"TreeControl", "TreeKeys", "TreeLabels"}, purgeTables...)
+)
+
+// PendingPurges implements storage.TreePurger.
+func (s *mysqlAdminStorage) PendingPurges(ctx context.Context) ([]int64, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT TreeId FROM TreePurges ORDER BY TreeId")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// PurgeTreeData implements storage.TreePurger.
+// Each chunk is a single-statement DELETE, limited to the rows remaining of
+// maxRows, which commits independently of the others.
+func (s *mysqlAdminStorage) PurgeTreeData(ctx context.Context, treeID int64, maxRows int64) (*storage.PurgeProgress, error) {
+	if maxRows <= 0 {
+		return nil, fmt.Errorf("invalid maxRows %d, want > 0", maxRows)
+	}
+	var id int64
+	switch err := s.db.QueryRowContext(ctx, selectTreePurgeSQL, treeID).Scan(&id); {
+	case err == sql.ErrNoRows:
+		return nil, status.Errorf(codes.NotFound, "tree %v is not pending purge", treeID)
+	case err != nil:
+		return nil, err
+	}
+
+	progress := &storage.PurgeProgress{Deleted: make(map[string]int64)}
+	remaining := maxRows
+	for _, table := range purgeTables {
+		res, err := s.db.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE TreeId = ? LIMIT ?", table), treeID, remaining)
+		if err != nil {
+			return progress, fmt.Errorf("failed to purge %s: %v", table, err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return progress, err
+		}
+		if n > 0 {
+			progress.Deleted[table] = n
+		}
+		if remaining -= n; remaining <= 0 {
+			// The table may have more rows.
+			return progress, nil
+		}
+	}
+
+	if _, err := s.db.ExecContext(ctx, deleteTreePurgeSQL, treeID); err != nil {
+		return progress, err
+	}
+	progress.Done = true
+	return progress, nil
+}
+
+// FindOrphanedTreeData implements storage.TreePurger.
+func (s *mysqlAdminStorage) FindOrphanedTreeData(ctx context.Context) ([]storage.TreeDataRows, error) {
+	var orphans []storage.TreeDataRows
+	for _, table := range orphanTables {
+		query := fmt.Sprintf(
+			`SELECT TreeId, COUNT(*) FROM %s
+			WHERE TreeId NOT IN (SELECT TreeId FROM Trees)
+			AND TreeId NOT IN (SELECT TreeId FROM TreePurges)
+			GROUP BY TreeId ORDER BY TreeId`, table)
+		rows, err := s.db.QueryContext(ctx, query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check %s: %v", table, err)
+		}
+		for rows.Next() {
+			r := storage.TreeDataRows{Table: table}
+			if err := rows.Scan(&r.TreeID, &r.Rows); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			orphans = append(orphans, r)
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return orphans, nil
+}
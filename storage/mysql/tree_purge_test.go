@@ -0,0 +1,118 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/testonly"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPurgeTreeData(t *testing.T) {
+	cleanTestDB(DB)
+	ctx := context.Background()
+	s := NewAdminStorage(DB)
+	purger := s.(storage.TreePurger)
+
+	tree := createTreeOrPanic(DB, testonly.LogTree)
+	createFakeSignedLogRoot(DB, tree, 0)
+	const numLeaves = 5
+	for i := 0; i < numLeaves; i++ {
+		data := []byte(fmt.Sprintf("leaf %d", i))
+		hash := sha256.Sum256(data)
+		createFakeLeaf(ctx, DB, tree.TreeId, hash[:], hash[:], data, nil, int64(i), t)
+	}
+
+	if _, err := purger.PurgeTreeData(ctx, tree.TreeId, 1); status.Code(err) != codes.NotFound {
+		t.Errorf("PurgeTreeData() of live tree returned err = %v, want code %v", err, codes.NotFound)
+	}
+
+	if _, err := storage.SoftDeleteTree(ctx, s, tree.TreeId); err != nil {
+		t.Fatalf("SoftDeleteTree(): %v", err)
+	}
+	if err := storage.HardDeleteTree(ctx, s, tree.TreeId); err != nil {
+		t.Fatalf("HardDeleteTree(): %v", err)
+	}
+
+	pending, err := purger.PendingPurges(ctx)
+	if err != nil {
+		t.Fatalf("PendingPurges(): %v", err)
+	}
+	if want := []int64{tree.TreeId}; !reflect.DeepEqual(pending, want) {
+		t.Errorf("PendingPurges() = %v, want %v", pending, want)
+	}
+
+	// Purge in chunks of 2 rows: 1 TreeHead, 5 SequencedLeafData and 5
+	// LeafData rows take 6 calls, plus one to find that nothing is left.
+	deleted := make(map[string]int64)
+	calls := 0
+	for done := false; !done; calls++ {
+		progress, err := purger.PurgeTreeData(ctx, tree.TreeId, 2)
+		if err != nil {
+			t.Fatalf("PurgeTreeData(): %v", err)
+		}
+		for table, n := range progress.Deleted {
+			deleted[table] += n
+		}
+		done = progress.Done
+		if calls > 10 {
+			t.Fatalf("PurgeTreeData() not done after %v calls", calls)
+		}
+	}
+	if want := map[string]int64{"TreeHead": 1, "SequencedLeafData": numLeaves, "LeafData": numLeaves}; !reflect.DeepEqual(deleted, want) {
+		t.Errorf("PurgeTreeData() deleted %v, want %v", deleted, want)
+	}
+	if want := 7; calls != want {
+		t.Errorf("PurgeTreeData() done after %v calls, want %v", calls, want)
+	}
+
+	if pending, err := purger.PendingPurges(ctx); err != nil || len(pending) != 0 {
+		t.Errorf("PendingPurges() = (%v, %v), want (nil, nil)", pending, err)
+	}
+	if orphans, err := purger.FindOrphanedTreeData(ctx); err != nil || len(orphans) != 0 {
+		t.Errorf("FindOrphanedTreeData() = (%v, %v), want (nil, nil)", orphans, err)
+	}
+}
+
+func TestFindOrphanedTreeData(t *testing.T) {
+	cleanTestDB(DB)
+	ctx := context.Background()
+	purger := NewAdminStorage(DB).(storage.TreePurger)
+
+	tree := createTreeOrPanic(DB, testonly.LogTree)
+	hash := sha256.Sum256([]byte("leaf"))
+	createFakeLeaf(ctx, DB, tree.TreeId, hash[:], hash[:], []byte("leaf"), nil, 0, t)
+	const orphanID = 12345
+	createFakeLeaf(ctx, DB, orphanID, hash[:], hash[:], []byte("leaf"), nil, 0, t)
+
+	orphans, err := purger.FindOrphanedTreeData(ctx)
+	if err != nil {
+		t.Fatalf("FindOrphanedTreeData(): %v", err)
+	}
+	want := []storage.TreeDataRows{
+		{TreeID: orphanID, Table: "SequencedLeafData", Rows: 1},
+		{TreeID: orphanID, Table: "LeafData", Rows: 1},
+	}
+	if !reflect.DeepEqual(orphans, want) {
+		t.Errorf("FindOrphanedTreeData() = %+v, want %+v", orphans, want)
+	}
+}
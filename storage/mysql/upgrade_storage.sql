@@ -0,0 +1,138 @@
+# Upgrades a MySQL / MariaDB database created by an earlier version of
+# storage.sql to the current schema. Run it once, before starting servers of
+# the new version, e.g.:
+#
+#   mysql -D <database> < storage/mysql/upgrade_storage.sql
+#
+# Databases created by the current storage.sql don't need upgrading. Changes
+# are listed in the order they were made.
+
+-- Ed25519 signatures.
+ALTER TABLE Trees
+  MODIFY SignatureAlgorithm ENUM('ECDSA', 'RSA', 'ED25519') NOT NULL;
+
+-- Key rotation. Existing roots keep a NULL KeyHint, as they were all signed by
+-- the original key.
+ALTER TABLE Trees
+  ADD COLUMN KeyVersion BIGINT NOT NULL DEFAULT 0 AFTER PublicKey;
+
+ALTER TABLE TreeHead
+  ADD COLUMN KeyHint VARBINARY(255);
+
+CREATE TABLE IF NOT EXISTS TreeKeys(
+  TreeId                BIGINT NOT NULL,
+  KeyVersion            BIGINT NOT NULL,
+  PublicKey             MEDIUMBLOB NOT NULL,
+  RetireTimeMillis      BIGINT NOT NULL,
+  Rotation              MEDIUMBLOB NOT NULL,
+  RotationSignature     BLOB NOT NULL,
+  PRIMARY KEY(TreeId, KeyVersion),
+  FOREIGN KEY(TreeId) REFERENCES Trees(TreeId) ON DELETE CASCADE
+);
+
+-- Multi-signature trees.
+ALTER TABLE Trees
+  ADD COLUMN MultiSigConfig MEDIUMBLOB AFTER KeyVersion;
+
+CREATE TABLE IF NOT EXISTS TreeHeadSignature(
+  TreeId               BIGINT NOT NULL,
+  TreeHeadTimestamp    BIGINT NOT NULL,
+  SignerIndex          INTEGER NOT NULL,
+  Signature            VARBINARY(1024) NOT NULL,
+  PRIMARY KEY(TreeId, TreeHeadTimestamp, SignerIndex),
+  FOREIGN KEY(TreeId, TreeHeadTimestamp) REFERENCES TreeHead(TreeId, TreeHeadTimestamp) ON DELETE CASCADE
+);
+
+-- SHA-512/256, SHA3-256 and BLAKE2b-256 hash strategies.
+ALTER TABLE Trees
+  MODIFY HashStrategy ENUM('RFC6962_SHA256', 'TEST_MAP_HASHER', 'OBJECT_RFC6962_SHA256', 'CONIKS_SHA512_256', 'RFC6962_SHA512_256', 'RFC6962_SHA3_256', 'RFC6962_BLAKE2B_256') NOT NULL;
+
+-- Protobuf leaf hash strategy.
+ALTER TABLE Trees
+  MODIFY HashStrategy ENUM('RFC6962_SHA256', 'TEST_MAP_HASHER', 'OBJECT_RFC6962_SHA256', 'CONIKS_SHA512_256', 'RFC6962_SHA512_256', 'RFC6962_SHA3_256', 'RFC6962_BLAKE2B_256', 'PROTO_RFC6962_SHA256') NOT NULL;
+
+-- Tree labels.
+CREATE TABLE IF NOT EXISTS TreeLabels(
+  TreeId                BIGINT NOT NULL,
+  LabelKey              VARCHAR(63) NOT NULL,
+  LabelValue            VARCHAR(63) NOT NULL,
+  PRIMARY KEY(TreeId, LabelKey),
+  INDEX TreeLabelsByKeyValue(LabelKey, LabelValue),
+  FOREIGN KEY(TreeId) REFERENCES Trees(TreeId) ON DELETE CASCADE
+);
+
+-- Scheduled tree state transitions.
+ALTER TABLE Trees
+  ADD COLUMN ScheduledTransition MEDIUMBLOB AFTER DeleteTimeMillis,
+  ADD COLUMN TransitionStatus MEDIUMBLOB AFTER ScheduledTransition;
+
+-- Purging of tree data. Tree data is no longer deleted along with the tree,
+-- but in chunks by the purger (see TreePurges), so the tables holding it no
+-- longer reference Trees. The foreign keys were created without names, so each
+-- is looked up by the table it references, and skipped if already dropped.
+CREATE TABLE IF NOT EXISTS TreePurges(
+  TreeId                BIGINT NOT NULL,
+  HardDeleteTimeMillis  BIGINT NOT NULL,
+  PRIMARY KEY(TreeId)
+);
+
+SET @fk = NULL;
+SELECT CONSTRAINT_NAME INTO @fk FROM information_schema.REFERENTIAL_CONSTRAINTS
+  WHERE CONSTRAINT_SCHEMA = DATABASE() AND TABLE_NAME = 'Subtree' AND REFERENCED_TABLE_NAME = 'Trees';
+SET @stmt = IF(@fk IS NULL, 'DO 0', CONCAT('ALTER TABLE Subtree DROP FOREIGN KEY ', @fk));
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @fk = NULL;
+SELECT CONSTRAINT_NAME INTO @fk FROM information_schema.REFERENTIAL_CONSTRAINTS
+  WHERE CONSTRAINT_SCHEMA = DATABASE() AND TABLE_NAME = 'TreeHead' AND REFERENCED_TABLE_NAME = 'Trees';
+SET @stmt = IF(@fk IS NULL, 'DO 0', CONCAT('ALTER TABLE TreeHead DROP FOREIGN KEY ', @fk));
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @fk = NULL;
+SELECT CONSTRAINT_NAME INTO @fk FROM information_schema.REFERENTIAL_CONSTRAINTS
+  WHERE CONSTRAINT_SCHEMA = DATABASE() AND TABLE_NAME = 'LeafData' AND REFERENCED_TABLE_NAME = 'Trees';
+SET @stmt = IF(@fk IS NULL, 'DO 0', CONCAT('ALTER TABLE LeafData DROP FOREIGN KEY ', @fk));
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @fk = NULL;
+SELECT CONSTRAINT_NAME INTO @fk FROM information_schema.REFERENTIAL_CONSTRAINTS
+  WHERE CONSTRAINT_SCHEMA = DATABASE() AND TABLE_NAME = 'SequencedLeafData' AND REFERENCED_TABLE_NAME = 'Trees';
+SET @stmt = IF(@fk IS NULL, 'DO 0', CONCAT('ALTER TABLE SequencedLeafData DROP FOREIGN KEY ', @fk));
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @fk = NULL;
+SELECT CONSTRAINT_NAME INTO @fk FROM information_schema.REFERENTIAL_CONSTRAINTS
+  WHERE CONSTRAINT_SCHEMA = DATABASE() AND TABLE_NAME = 'MapLeaf' AND REFERENCED_TABLE_NAME = 'Trees';
+SET @stmt = IF(@fk IS NULL, 'DO 0', CONCAT('ALTER TABLE MapLeaf DROP FOREIGN KEY ', @fk));
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @fk = NULL;
+SELECT CONSTRAINT_NAME INTO @fk FROM information_schema.REFERENTIAL_CONSTRAINTS
+  WHERE CONSTRAINT_SCHEMA = DATABASE() AND TABLE_NAME = 'MapHead' AND REFERENCED_TABLE_NAME = 'Trees';
+SET @stmt = IF(@fk IS NULL, 'DO 0', CONCAT('ALTER TABLE MapHead DROP FOREIGN KEY ', @fk));
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+-- MySQL master election.
+CREATE TABLE IF NOT EXISTS MasterLease(
+  TreeId                BIGINT NOT NULL,
+  InstanceId            VARCHAR(255) NOT NULL,
+  Epoch                 BIGINT NOT NULL,
+  ExpiryMillis          BIGINT NOT NULL,
+  PRIMARY KEY(TreeId)
+);
+
+-- Fencing tokens.
+ALTER TABLE TreeControl
+  ADD COLUMN MasterEpoch BIGINT NOT NULL DEFAULT 0;
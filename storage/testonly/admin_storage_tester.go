@@ -27,6 +27,7 @@ import (
 	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/any"
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/google/trillian"
 	"github.com/google/trillian/crypto/keys"
 	"github.com/google/trillian/crypto/keys/pem"
@@ -173,6 +174,7 @@ func (tester *AdminStorageTester) RunAllTests(t *testing.T) {
 	t.Run("TestCreateTree", tester.TestCreateTree)
 	t.Run("TestUpdateTree", tester.TestUpdateTree)
 	t.Run("TestListTrees", tester.TestListTrees)
+	t.Run("TestListTreesPage", tester.TestListTreesPage)
 	t.Run("TestSoftDeleteTree", tester.TestSoftDeleteTree)
 	t.Run("TestSoftDeleteTreeErrors", tester.TestSoftDeleteTreeErrors)
 	t.Run("TestHardDeleteTree", tester.TestHardDeleteTree)
@@ -332,6 +334,51 @@ func (tester *AdminStorageTester) TestUpdateTree(t *testing.T) {
 		})
 	}
 
+	keyRotatedTree := *LogTree
+	keyRotatedTree.KeyVersion = 1
+	keyRotatedTree.PreviousKeys = []*trillian.TreeKey{{
+		KeyVersion:        0,
+		PublicKey:         LogTree.PublicKey,
+		RetireTime:        &timestamp.Timestamp{Seconds: 1500000000},
+		Rotation:          []byte("rotation"),
+		RotationSignature: []byte("signature"),
+	}}
+	keyRotatedTree.PrivateKey = mustMarshalAny(&keyspb.PrivateKey{
+		Der: ktestonly.MustMarshalPrivatePEMToDER(testonly.DemoPrivateKey, testonly.DemoPrivateKeyPass),
+	})
+	keyRotatedTree.PublicKey = &keyspb.PublicKey{
+		Der: ktestonly.MustMarshalPublicPEMToDER(testonly.DemoPublicKey),
+	}
+	keyRotatedFunc := func(tree *trillian.Tree) {
+		tree.KeyVersion = keyRotatedTree.KeyVersion
+		tree.PreviousKeys = []*trillian.TreeKey{proto.Clone(keyRotatedTree.PreviousKeys[0]).(*trillian.TreeKey)}
+		tree.PrivateKey = keyRotatedTree.PrivateKey
+		tree.PublicKey = keyRotatedTree.PublicKey
+	}
+
+	transitionTree := *LogTree
+	transitionTree.TreeState = trillian.TreeState_DRAINING
+	transitionTree.ScheduledTransition = &trillian.ScheduledTransition{
+		TargetState: trillian.TreeState_FROZEN,
+		StartTime:   &timestamp.Timestamp{Seconds: 1500000000},
+	}
+	transitionTree.TransitionStatus = &trillian.TreeTransitionStatus{
+		Phase:       trillian.TreeTransitionStatus_DRAINING,
+		TargetState: trillian.TreeState_FROZEN,
+		UpdateTime:  &timestamp.Timestamp{Seconds: 1500000001},
+	}
+	transitionFunc := func(tree *trillian.Tree) {
+		tree.TreeState = transitionTree.TreeState
+		tree.ScheduledTransition = transitionTree.ScheduledTransition
+		tree.TransitionStatus = transitionTree.TransitionStatus
+	}
+
+	publicKeyChangedFunc := func(tree *trillian.Tree) {
+		keyRotatedFunc(tree)
+		tree.KeyVersion = 0
+		tree.PreviousKeys = nil
+	}
+
 	// Test for an unknown tree outside the loop: it makes the test logic simpler
 	if _, err := storage.UpdateTree(ctx, s, -1, func(tree *trillian.Tree) {}); err == nil {
 		t.Error("UpdateTree() for treeID -1 returned nil err")
@@ -385,6 +432,24 @@ func (tester *AdminStorageTester) TestUpdateTree(t *testing.T) {
 			updateFunc: privateKeyChangedAndKeyMaterialDifferentFunc,
 			wantErr:    true,
 		},
+		{
+			desc:       "scheduledTransition",
+			create:     &referenceLog,
+			updateFunc: transitionFunc,
+			want:       &transitionTree,
+		},
+		{
+			desc:       "keyRotated",
+			create:     &referenceLog,
+			updateFunc: keyRotatedFunc,
+			want:       &keyRotatedTree,
+		},
+		{
+			desc:       "publicKeyChangedWithoutRotation",
+			create:     &referenceLog,
+			updateFunc: publicKeyChangedFunc,
+			wantErr:    true,
+		},
 	}
 	for _, test := range tests {
 		createdTree, err := storage.CreateTree(ctx, s, test.create)
@@ -474,6 +539,105 @@ func (tester *AdminStorageTester) TestListTrees(t *testing.T) {
 	run("multipleTreesDeleted", true /* includeDeleted */, []*trillian.Tree{activeLog, frozenLog, deletedLog, activeMap})
 }
 
+// TestListTreesPage tests filtering and pagination of AdminReader's ListTreesPage.
+func (tester *AdminStorageTester) TestListTreesPage(t *testing.T) {
+	ctx := context.Background()
+	s := tester.NewAdminStorage()
+
+	labelled := func(tree *trillian.Tree, labels map[string]string) *trillian.Tree {
+		tree = proto.Clone(tree).(*trillian.Tree)
+		tree.Labels = labels
+		return tree
+	}
+	llamaLog := makeTreeOrFail(ctx, s, spec{Tree: labelled(LogTree, map[string]string{"tenant": "llamas", "env": "prod"})}, t.Fatalf)
+	alpacaLog := makeTreeOrFail(ctx, s, spec{Tree: labelled(LogTree, map[string]string{"tenant": "alpacas", "env": "prod"})}, t.Fatalf)
+	frozenLog := makeTreeOrFail(ctx, s, spec{Tree: LogTree, Frozen: true}, t.Fatalf)
+	deletedLog := makeTreeOrFail(ctx, s, spec{Tree: labelled(LogTree, map[string]string{"tenant": "llamas"}), Deleted: true}, t.Fatalf)
+	llamaMap := makeTreeOrFail(ctx, s, spec{Tree: labelled(MapTree, map[string]string{"tenant": "llamas"})}, t.Fatalf)
+
+	ids := func(trees ...*trillian.Tree) []int64 {
+		var ids []int64
+		for _, tree := range trees {
+			ids = append(ids, tree.TreeId)
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		return ids
+	}
+	allIDs := ids(llamaLog, alpacaLog, frozenLog, deletedLog, llamaMap)
+
+	tests := []struct {
+		desc    string
+		opts    storage.ListTreesOptions
+		wantIDs []int64
+	}{
+		{desc: "nonDeleted", wantIDs: ids(llamaLog, alpacaLog, frozenLog, llamaMap)},
+		{desc: "includeDeleted", opts: storage.ListTreesOptions{IncludeDeleted: true}, wantIDs: allIDs},
+		{desc: "limit", opts: storage.ListTreesOptions{IncludeDeleted: true, Limit: 2}, wantIDs: allIDs[:2]},
+		{
+			desc:    "afterTreeID",
+			opts:    storage.ListTreesOptions{IncludeDeleted: true, AfterTreeID: allIDs[1], Limit: 2},
+			wantIDs: allIDs[2:4],
+		},
+		{
+			desc:    "treeTypes",
+			opts:    storage.ListTreesOptions{TreeTypes: []trillian.TreeType{trillian.TreeType_MAP}},
+			wantIDs: ids(llamaMap),
+		},
+		{
+			desc:    "treeStates",
+			opts:    storage.ListTreesOptions{TreeStates: []trillian.TreeState{trillian.TreeState_FROZEN}},
+			wantIDs: ids(frozenLog),
+		},
+		{
+			desc:    "label",
+			opts:    storage.ListTreesOptions{Labels: map[string]string{"tenant": "llamas"}},
+			wantIDs: ids(llamaLog, llamaMap),
+		},
+		{
+			desc:    "labelDeleted",
+			opts:    storage.ListTreesOptions{IncludeDeleted: true, Labels: map[string]string{"tenant": "llamas"}},
+			wantIDs: ids(llamaLog, deletedLog, llamaMap),
+		},
+		{
+			desc:    "multipleLabels",
+			opts:    storage.ListTreesOptions{Labels: map[string]string{"tenant": "llamas", "env": "prod"}},
+			wantIDs: ids(llamaLog),
+		},
+		{
+			desc: "allFilters",
+			opts: storage.ListTreesOptions{
+				TreeTypes:  []trillian.TreeType{trillian.TreeType_LOG},
+				TreeStates: []trillian.TreeState{trillian.TreeState_ACTIVE},
+				Labels:     map[string]string{"env": "prod"},
+			},
+			wantIDs: ids(llamaLog, alpacaLog),
+		},
+		{desc: "noMatches", opts: storage.ListTreesOptions{Labels: map[string]string{"tenant": "vicunas"}}},
+	}
+
+	byID := make(map[int64]*trillian.Tree)
+	for _, tree := range []*trillian.Tree{llamaLog, alpacaLog, frozenLog, deletedLog, llamaMap} {
+		byID[tree.TreeId] = tree
+	}
+	for _, test := range tests {
+		trees, err := storage.ListTreesPage(ctx, s, test.opts)
+		if err != nil {
+			t.Errorf("%v: ListTreesPage() returned err = %v", test.desc, err)
+			continue
+		}
+		var gotIDs []int64
+		for _, tree := range trees {
+			gotIDs = append(gotIDs, tree.TreeId)
+			if want := byID[tree.TreeId]; !proto.Equal(tree, want) {
+				t.Errorf("%v: post-ListTreesPage() diff (-got +want):\n%v", test.desc, pretty.Compare(tree, want))
+			}
+		}
+		if diff := pretty.Compare(gotIDs, test.wantIDs); diff != "" {
+			t.Errorf("%v: post-ListTreesPage() IDs diff (-got +want):\n%v", test.desc, diff)
+		}
+	}
+}
+
 func runListTreeIDsTest(ctx context.Context, tx storage.ReadOnlyAdminTX, includeDeleted bool, wantTrees []*trillian.Tree) error {
 	got, err := tx.ListTreeIDs(ctx, includeDeleted)
 	if err != nil {
@@ -0,0 +1,38 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import "context"
+
+// TreeControl holds the parameters of a tree that can be changed at runtime,
+// e.g. by operators, without changing the tree itself.
+type TreeControl struct {
+	// SigningEnabled is false if no new roots are to be signed for the tree.
+	SigningEnabled bool
+	// SequencingEnabled is false if queued leaves are not to be sequenced.
+	SequencingEnabled bool
+}
+
+// TreeControlStorage is implemented by AdminStorage implementations that
+// store TreeControls. New trees have all operations enabled.
+type TreeControlStorage interface {
+	// GetTreeControl returns the TreeControl of a tree. Returns a NotFound
+	// error if the tree doesn't exist.
+	GetTreeControl(ctx context.Context, treeID int64) (*TreeControl, error)
+
+	// UpdateTreeControl replaces the TreeControl of a tree. Returns a NotFound
+	// error if the tree doesn't exist.
+	UpdateTreeControl(ctx context.Context, treeID int64, control *TreeControl) error
+}
@@ -0,0 +1,58 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import "context"
+
+// TreePurger is implemented by AdminStorage implementations that delete the
+// data of hard-deleted trees in the background.
+//
+// For these implementations, HardDeleteTree removes the tree itself, and
+// records that its data (leaves, subtrees, tree heads, etc) is pending purge.
+// The data is then deleted in chunks by calls to PurgeTreeData, so that no
+// single transaction has to delete all of it.
+type TreePurger interface {
+	// PendingPurges returns the IDs of the hard-deleted trees whose data is
+	// yet to be purged.
+	PendingPurges(ctx context.Context) ([]int64, error)
+
+	// PurgeTreeData deletes up to maxRows rows of the data of a tree pending
+	// purge. Each chunk of rows is deleted in its own transaction, so purges
+	// may be interrupted at any point, and are resumed by the next call.
+	// Once all of the tree's data is deleted, the tree is no longer pending
+	// purge, and Done is set in the returned progress.
+	PurgeTreeData(ctx context.Context, treeID int64, maxRows int64) (*PurgeProgress, error)
+
+	// FindOrphanedTreeData returns the rows of tree data that belong to trees
+	// that neither exist nor are pending purge, grouped by tree and table.
+	// It reads all tree data, so is intended for occasional verification
+	// only.
+	FindOrphanedTreeData(ctx context.Context) ([]TreeDataRows, error)
+}
+
+// PurgeProgress describes the rows deleted by a call to PurgeTreeData.
+type PurgeProgress struct {
+	// Deleted is the number of rows deleted, by table name.
+	Deleted map[string]int64
+	// Done is true if the tree's data has been completely purged.
+	Done bool
+}
+
+// TreeDataRows counts the rows of a table that belong to a tree.
+type TreeDataRows struct {
+	TreeID int64
+	Table  string
+	Rows   int64
+}
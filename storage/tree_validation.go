@@ -17,20 +17,26 @@ package storage
 import (
 	"bytes"
 	"context"
+	"fmt"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
 	"github.com/google/trillian"
 	"github.com/google/trillian/crypto/keys"
 	"github.com/google/trillian/crypto/keys/der"
+	"github.com/google/trillian/crypto/keyspb"
 	"github.com/google/trillian/crypto/sigpb"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+
+	tcrypto "github.com/google/trillian/crypto"
 )
 
 const (
 	maxDisplayNameLength = 20
 	maxDescriptionLength = 200
+	maxLabelLength       = 63
 )
 
 // ValidateTreeForCreation returns nil if tree is valid for insertion, error
@@ -59,8 +65,15 @@ func ValidateTreeForCreation(ctx context.Context, tree *trillian.Tree) error {
 		return status.Errorf(codes.InvalidArgument, "invalid deleted: %v", tree.Deleted)
 	case tree.DeleteTime != nil:
 		return status.Errorf(codes.InvalidArgument, "invalid delete_time: %+v (must be nil)", tree.DeleteTime)
+	case tree.KeyVersion != 0:
+		return status.Errorf(codes.InvalidArgument, "invalid key_version: %v (must be 0)", tree.KeyVersion)
+	case len(tree.PreviousKeys) != 0:
+		return status.Error(codes.InvalidArgument, "invalid previous_keys (must be empty)")
 	}
 
+	if err := validateMultiSig(ctx, tree); err != nil {
+		return err
+	}
 	return validateMutableTreeFields(ctx, tree)
 }
 
@@ -110,16 +123,98 @@ func ValidateTreeForUpdate(ctx context.Context, storedTree, newTree *trillian.Tr
 		return status.Error(codes.InvalidArgument, "readonly field changed: create_time")
 	case !proto.Equal(storedTree.UpdateTime, newTree.UpdateTime):
 		return status.Error(codes.InvalidArgument, "readonly field changed: update_time")
-	case !proto.Equal(storedTree.PublicKey, newTree.PublicKey):
-		return status.Error(codes.InvalidArgument, "readonly field changed: public_key")
 	case storedTree.Deleted != newTree.Deleted:
 		return status.Error(codes.InvalidArgument, "readonly field changed: deleted")
 	case !proto.Equal(storedTree.DeleteTime, newTree.DeleteTime):
 		return status.Error(codes.InvalidArgument, "readonly field changed: delete_time")
+	case !multiSigEqualButPrivateKeys(storedTree.MultiSig, newTree.MultiSig):
+		return status.Error(codes.InvalidArgument, "readonly field changed: multi_sig")
+	}
+
+	// The public key may only change through key rotation.
+	if storedTree.KeyVersion != newTree.KeyVersion {
+		if err := validateKeyRotation(storedTree, newTree); err != nil {
+			return err
+		}
+	} else {
+		switch {
+		case !proto.Equal(storedTree.PublicKey, newTree.PublicKey):
+			return status.Error(codes.InvalidArgument, "readonly field changed: public_key")
+		case !previousKeysEqual(storedTree.PreviousKeys, newTree.PreviousKeys):
+			return status.Error(codes.InvalidArgument, "readonly field changed: previous_keys")
+		}
+	}
+	// Check that changed multi_sig private keys still match their public keys.
+	if !proto.Equal(storedTree.MultiSig, newTree.MultiSig) {
+		if err := validateMultiSig(ctx, newTree); err != nil {
+			return err
+		}
 	}
 	return validateMutableTreeFields(ctx, newTree)
 }
 
+// multiSigEqualButPrivateKeys returns true if a and b are equal, except maybe
+// for the private keys of their signers. Those may change, e.g. to encrypt
+// them, as long as they match the same public keys.
+func multiSigEqualButPrivateKeys(a, b *trillian.MultiSigConfig) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if a.Threshold != b.Threshold || len(a.Signers) != len(b.Signers) {
+		return false
+	}
+	for i := range a.Signers {
+		if !proto.Equal(a.Signers[i].PublicKey, b.Signers[i].PublicKey) {
+			return false
+		}
+	}
+	return true
+}
+
+// validateKeyRotation returns nil iff newTree is oldTree with its key rotated,
+// i.e. its key_version incremented and the old public key appended to
+// previous_keys, along with a rotation statement.
+func validateKeyRotation(oldTree, newTree *trillian.Tree) error {
+	const prefix = "invalid key rotation"
+
+	if got, want := newTree.KeyVersion, oldTree.KeyVersion+1; got != want {
+		return status.Errorf(codes.InvalidArgument, "%s: key_version=%v, want %v", prefix, got, want)
+	}
+	if got, want := len(newTree.PreviousKeys), len(oldTree.PreviousKeys)+1; got != want {
+		return status.Errorf(codes.InvalidArgument, "%s: %v previous_keys, want %v", prefix, got, want)
+	}
+	if !previousKeysEqual(oldTree.PreviousKeys, newTree.PreviousKeys[:len(oldTree.PreviousKeys)]) {
+		return status.Errorf(codes.InvalidArgument, "%s: previous_keys changed", prefix)
+	}
+
+	replaced := newTree.PreviousKeys[len(oldTree.PreviousKeys)]
+	switch {
+	case replaced.KeyVersion != oldTree.KeyVersion:
+		return status.Errorf(codes.InvalidArgument, "%s: replaced key_version=%v, want %v", prefix, replaced.KeyVersion, oldTree.KeyVersion)
+	case !proto.Equal(replaced.PublicKey, oldTree.PublicKey):
+		return status.Errorf(codes.InvalidArgument, "%s: replaced public_key doesn't match", prefix)
+	case proto.Equal(newTree.PublicKey, oldTree.PublicKey):
+		return status.Errorf(codes.InvalidArgument, "%s: public_key unchanged", prefix)
+	case replaced.RetireTime == nil:
+		return status.Errorf(codes.InvalidArgument, "%s: retire_time is required", prefix)
+	case len(replaced.Rotation) == 0 || len(replaced.RotationSignature) == 0:
+		return status.Errorf(codes.InvalidArgument, "%s: signed rotation statement is required", prefix)
+	}
+	return nil
+}
+
+func previousKeysEqual(a, b []*trillian.TreeKey) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !proto.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
 func validateMutableTreeFields(ctx context.Context, tree *trillian.Tree) error {
 	switch {
 	case tree.TreeState == trillian.TreeState_UNKNOWN_TREE_STATE:
@@ -135,6 +230,21 @@ func validateMutableTreeFields(ctx context.Context, tree *trillian.Tree) error {
 		return status.Errorf(codes.InvalidArgument, "max_root_duration negative: %v", tree.MaxRootDuration)
 	}
 
+	for k, v := range tree.Labels {
+		switch {
+		case k == "":
+			return status.Error(codes.InvalidArgument, "empty label key")
+		case len(k) > maxLabelLength:
+			return status.Errorf(codes.InvalidArgument, "label key too big, max length is %v: %v", maxLabelLength, k)
+		case len(v) > maxLabelLength:
+			return status.Errorf(codes.InvalidArgument, "label value too big, max length is %v: %v", maxLabelLength, v)
+		}
+	}
+
+	if err := validateScheduledTransition(tree); err != nil {
+		return err
+	}
+
 	// Implementations may vary, so let's assume storage_settings is mutable.
 	// Other than checking that it's a valid Any there isn't much to do at this layer, though.
 	if tree.StorageSettings != nil {
@@ -144,23 +254,91 @@ func validateMutableTreeFields(ctx context.Context, tree *trillian.Tree) error {
 		}
 	}
 
+	return validateKeyPair(ctx, "", tree.PrivateKey, tree.PublicKey)
+}
+
+// validateKeyPair checks that privateKey can be obtained and matches
+// publicKey. The field names in errors are qualified by prefix.
+func validateKeyPair(ctx context.Context, prefix string, privateKey *any.Any, publicKey *keyspb.PublicKey) error {
 	var privateKeyProto ptypes.DynamicAny
-	if err := ptypes.UnmarshalAny(tree.PrivateKey, &privateKeyProto); err != nil {
-		return status.Errorf(codes.InvalidArgument, "invalid private_key: %v", err)
+	if err := ptypes.UnmarshalAny(privateKey, &privateKeyProto); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid %sprivate_key: %v", prefix, err)
 	}
 
-	// Check that the private key can be obtained and matches the public key.
-	privateKey, err := keys.NewSigner(ctx, privateKeyProto.Message)
+	signer, err := keys.NewSigner(keys.WithPublicKey(ctx, publicKey.GetDer()), privateKeyProto.Message)
 	if err != nil {
-		return status.Errorf(codes.InvalidArgument, "invalid private_key: %v", err)
+		return status.Errorf(codes.InvalidArgument, "invalid %sprivate_key: %v", prefix, err)
 	}
-	publicKeyDER, err := der.MarshalPublicKey(privateKey.Public())
+	publicKeyDER, err := der.MarshalPublicKey(signer.Public())
 	if err != nil {
-		return status.Errorf(codes.InvalidArgument, "invalid private_key: %v", err)
+		return status.Errorf(codes.InvalidArgument, "invalid %sprivate_key: %v", prefix, err)
 	}
-	if !bytes.Equal(publicKeyDER, tree.PublicKey.GetDer()) {
-		return status.Errorf(codes.InvalidArgument, "private_key and public_key are not a matching pair")
+	if !bytes.Equal(publicKeyDER, publicKey.GetDer()) {
+		return status.Errorf(codes.InvalidArgument, "%sprivate_key and %spublic_key are not a matching pair", prefix, prefix)
 	}
 
 	return nil
 }
+
+// validateMultiSig returns nil if tree has no multi_sig configuration, or a
+// valid one.
+func validateMultiSig(ctx context.Context, tree *trillian.Tree) error {
+	ms := tree.MultiSig
+	if ms == nil {
+		return nil
+	}
+	if tree.TreeType != trillian.TreeType_LOG && tree.TreeType != trillian.TreeType_PREORDERED_LOG {
+		return status.Errorf(codes.InvalidArgument, "multi_sig not supported for tree_type: %s", tree.TreeType)
+	}
+	if ms.Threshold < 1 || int(ms.Threshold) > len(ms.Signers) {
+		return status.Errorf(codes.InvalidArgument, "invalid multi_sig.threshold: %v (must be between 1 and %v)", ms.Threshold, len(ms.Signers))
+	}
+	for i, signer := range ms.Signers {
+		prefix := fmt.Sprintf("multi_sig.signers[%d].", i)
+		switch {
+		case signer.PrivateKey == nil:
+			return status.Errorf(codes.InvalidArgument, "a %sprivate_key is required", prefix)
+		case signer.PublicKey == nil:
+			return status.Errorf(codes.InvalidArgument, "a %spublic_key is required", prefix)
+		}
+		if err := validateKeyPair(ctx, prefix, signer.PrivateKey, signer.PublicKey); err != nil {
+			return err
+		}
+		// Co-signatures are verified with the tree's signature_algorithm.
+		publicKey, err := der.FromPublicProto(signer.PublicKey)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid %spublic_key: %v", prefix, err)
+		}
+		if got := tcrypto.SignatureAlgorithm(publicKey); got != tree.SignatureAlgorithm {
+			return status.Errorf(codes.InvalidArgument, "multi_sig.signers[%d] has signature algorithm %s, want the tree's signature_algorithm %s", i, got, tree.SignatureAlgorithm)
+		}
+	}
+	return nil
+}
+
+// validateScheduledTransition returns nil if tree has no
+// scheduled_transition, or a valid one.
+func validateScheduledTransition(tree *trillian.Tree) error {
+	st := tree.ScheduledTransition
+	if st == nil {
+		return nil
+	}
+	if tree.TreeType != trillian.TreeType_LOG && tree.TreeType != trillian.TreeType_PREORDERED_LOG {
+		return status.Errorf(codes.InvalidArgument, "scheduled_transition not supported for tree_type: %s", tree.TreeType)
+	}
+	if tree.TreeState == trillian.TreeState_FROZEN {
+		return status.Errorf(codes.InvalidArgument, "scheduled_transition not supported for tree_state: %s", tree.TreeState)
+	}
+	switch st.TargetState {
+	case trillian.TreeState_DRAINING, trillian.TreeState_FROZEN:
+	default:
+		return status.Errorf(codes.InvalidArgument, "invalid scheduled_transition.target_state: %s", st.TargetState)
+	}
+	if st.StartTime == nil {
+		return status.Error(codes.InvalidArgument, "a scheduled_transition.start_time is required")
+	}
+	if _, err := ptypes.Timestamp(st.StartTime); err != nil {
+		return status.Errorf(codes.InvalidArgument, "scheduled_transition.start_time malformed: %v", err)
+	}
+	return nil
+}
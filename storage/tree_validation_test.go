@@ -16,13 +16,17 @@ package storage
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/any"
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/google/trillian"
+	"github.com/google/trillian/crypto/keys/der"
 	"github.com/google/trillian/crypto/keyspb"
 	"github.com/google/trillian/crypto/sigpb"
 	"github.com/google/trillian/testonly"
@@ -88,6 +92,43 @@ func TestValidateTreeForCreation(t *testing.T) {
 		A Very Long Description That Clearly Won't Fit, Also Mentions Llamas, For Some Reason Has Only Capitalized Words And Keeps Repeating Itself.
 		`
 
+	validLabels := newTree()
+	validLabels.Labels = map[string]string{"tenant": "llamas", "empty": ""}
+
+	emptyLabelKey := newTree()
+	emptyLabelKey.Labels = map[string]string{"": "llamas"}
+
+	longLabelKey := newTree()
+	longLabelKey.Labels = map[string]string{strings.Repeat("k", maxLabelLength+1): "llamas"}
+
+	longLabelValue := newTree()
+	longLabelValue.Labels = map[string]string{"tenant": strings.Repeat("v", maxLabelLength+1)}
+
+	scheduledTransition := newTree()
+	scheduledTransition.ScheduledTransition = &trillian.ScheduledTransition{
+		TargetState: trillian.TreeState_FROZEN,
+		StartTime:   ptypes.TimestampNow(),
+	}
+
+	scheduledTransitionMapTree := newTree()
+	scheduledTransitionMapTree.TreeType = trillian.TreeType_MAP
+	scheduledTransitionMapTree.ScheduledTransition = proto.Clone(scheduledTransition.ScheduledTransition).(*trillian.ScheduledTransition)
+
+	scheduledTransitionToActive := newTree()
+	scheduledTransitionToActive.ScheduledTransition = &trillian.ScheduledTransition{
+		TargetState: trillian.TreeState_ACTIVE,
+		StartTime:   ptypes.TimestampNow(),
+	}
+
+	scheduledTransitionNoStartTime := newTree()
+	scheduledTransitionNoStartTime.ScheduledTransition = &trillian.ScheduledTransition{TargetState: trillian.TreeState_DRAINING}
+
+	scheduledTransitionInvalidStartTime := newTree()
+	scheduledTransitionInvalidStartTime.ScheduledTransition = &trillian.ScheduledTransition{
+		TargetState: trillian.TreeState_DRAINING,
+		StartTime:   &timestamp.Timestamp{Nanos: -1},
+	}
+
 	unsupportedPrivateKey := newTree()
 	unsupportedPrivateKey.PrivateKey.TypeUrl = "urn://unknown-type"
 
@@ -126,6 +167,34 @@ func TestValidateTreeForCreation(t *testing.T) {
 	deleteTimeTree := newTree()
 	deleteTimeTree.DeleteTime = ptypes.TimestampNow()
 
+	rotatedKeyTree := newTree()
+	rotateKey(rotatedKeyTree)
+
+	multiSigTree := newTree()
+	addMultiSig(multiSigTree, 2)
+
+	multiSigMapTree := newTree()
+	multiSigMapTree.TreeType = trillian.TreeType_MAP
+	addMultiSig(multiSigMapTree, 1)
+
+	multiSigZeroThreshold := newTree()
+	addMultiSig(multiSigZeroThreshold, 0)
+
+	multiSigThresholdTooHigh := newTree()
+	addMultiSig(multiSigThresholdTooHigh, 3)
+
+	multiSigNilPrivateKey := newTree()
+	addMultiSig(multiSigNilPrivateKey, 1)
+	multiSigNilPrivateKey.MultiSig.Signers[1].PrivateKey = nil
+
+	multiSigMismatchedKey := newTree()
+	addMultiSig(multiSigMismatchedKey, 1)
+	multiSigMismatchedKey.MultiSig.Signers[1].PublicKey = multiSigMismatchedKey.PublicKey
+
+	multiSigMismatchedAlgorithm := newTree()
+	addMultiSig(multiSigMismatchedAlgorithm, 1)
+	multiSigMismatchedAlgorithm.MultiSig.Signers[1] = newEd25519Signer()
+
 	tests := []struct {
 		desc    string
 		tree    *trillian.Tree
@@ -184,6 +253,49 @@ func TestValidateTreeForCreation(t *testing.T) {
 			tree:    invalidDescription,
 			wantErr: true,
 		},
+		{
+			desc: "validLabels",
+			tree: validLabels,
+		},
+		{
+			desc:    "emptyLabelKey",
+			tree:    emptyLabelKey,
+			wantErr: true,
+		},
+		{
+			desc:    "longLabelKey",
+			tree:    longLabelKey,
+			wantErr: true,
+		},
+		{
+			desc:    "longLabelValue",
+			tree:    longLabelValue,
+			wantErr: true,
+		},
+		{
+			desc: "scheduledTransition",
+			tree: scheduledTransition,
+		},
+		{
+			desc:    "scheduledTransitionMapTree",
+			tree:    scheduledTransitionMapTree,
+			wantErr: true,
+		},
+		{
+			desc:    "scheduledTransitionToActive",
+			tree:    scheduledTransitionToActive,
+			wantErr: true,
+		},
+		{
+			desc:    "scheduledTransitionNoStartTime",
+			tree:    scheduledTransitionNoStartTime,
+			wantErr: true,
+		},
+		{
+			desc:    "scheduledTransitionInvalidStartTime",
+			tree:    scheduledTransitionInvalidStartTime,
+			wantErr: true,
+		},
 		{
 			desc:    "unsupportedPrivateKey",
 			tree:    unsupportedPrivateKey,
@@ -238,6 +350,45 @@ func TestValidateTreeForCreation(t *testing.T) {
 			tree:    deleteTimeTree,
 			wantErr: true,
 		},
+		{
+			desc:    "rotatedKeyTree",
+			tree:    rotatedKeyTree,
+			wantErr: true,
+		},
+		{
+			desc: "multiSigTree",
+			tree: multiSigTree,
+		},
+		{
+			desc:    "multiSigMapTree",
+			tree:    multiSigMapTree,
+			wantErr: true,
+		},
+		{
+			desc:    "multiSigZeroThreshold",
+			tree:    multiSigZeroThreshold,
+			wantErr: true,
+		},
+		{
+			desc:    "multiSigThresholdTooHigh",
+			tree:    multiSigThresholdTooHigh,
+			wantErr: true,
+		},
+		{
+			desc:    "multiSigNilPrivateKey",
+			tree:    multiSigNilPrivateKey,
+			wantErr: true,
+		},
+		{
+			desc:    "multiSigMismatchedKey",
+			tree:    multiSigMismatchedKey,
+			wantErr: true,
+		},
+		{
+			desc:    "multiSigMismatchedAlgorithm",
+			tree:    multiSigMismatchedAlgorithm,
+			wantErr: true,
+		},
 	}
 	for _, test := range tests {
 		err := ValidateTreeForCreation(ctx, test.tree)
@@ -257,6 +408,7 @@ func TestValidateTreeForUpdate(t *testing.T) {
 		desc      string
 		treeState trillian.TreeState
 		treeType  trillian.TreeType
+		multiSig  bool
 		updatefn  func(*trillian.Tree)
 		wantErr   bool
 	}{
@@ -432,6 +584,116 @@ func TestValidateTreeForUpdate(t *testing.T) {
 			updatefn: func(tree *trillian.Tree) { tree.DeleteTime = ptypes.TimestampNow() },
 			wantErr:  true,
 		},
+		{
+			desc: "PublicKey",
+			updatefn: func(tree *trillian.Tree) {
+				rotateKey(tree)
+				tree.KeyVersion--
+				tree.PreviousKeys = nil
+			},
+			wantErr: true,
+		},
+		{
+			desc: "PreviousKeys",
+			updatefn: func(tree *trillian.Tree) {
+				tree.PreviousKeys = []*trillian.TreeKey{{PublicKey: tree.PublicKey}}
+			},
+			wantErr: true,
+		},
+		{
+			desc:     "MultiSig",
+			updatefn: func(tree *trillian.Tree) { addMultiSig(tree, 1) },
+			wantErr:  true,
+		},
+		{
+			desc:     "MultiSigThreshold",
+			multiSig: true,
+			updatefn: func(tree *trillian.Tree) {
+				tree.MultiSig = proto.Clone(tree.MultiSig).(*trillian.MultiSigConfig)
+				tree.MultiSig.Threshold = 2
+			},
+			wantErr: true,
+		},
+		{
+			desc:     "MultiSigPublicKey",
+			multiSig: true,
+			updatefn: func(tree *trillian.Tree) {
+				tree.MultiSig = proto.Clone(tree.MultiSig).(*trillian.MultiSigConfig)
+				tree.MultiSig.Signers[1].PublicKey = tree.PublicKey
+			},
+			wantErr: true,
+		},
+		{
+			desc:     "MultiSigPrivateKeySameKeyMaterial",
+			multiSig: true,
+			updatefn: func(tree *trillian.Tree) {
+				tree.MultiSig = proto.Clone(tree.MultiSig).(*trillian.MultiSigConfig)
+				tree.MultiSig.Signers[1].PrivateKey = proto.Clone(tree.MultiSig.Signers[1].PrivateKey).(*any.Any)
+			},
+		},
+		{
+			desc:     "MultiSigPrivateKeyDifferentKeyMaterial",
+			multiSig: true,
+			updatefn: func(tree *trillian.Tree) {
+				tree.MultiSig = proto.Clone(tree.MultiSig).(*trillian.MultiSigConfig)
+				tree.MultiSig.Signers[1].PrivateKey = tree.PrivateKey
+			},
+			wantErr: true,
+		},
+		// Key rotation
+		{
+			desc:     "KeyRotation",
+			updatefn: rotateKey,
+		},
+		{
+			desc: "KeyRotationSkippingVersion",
+			updatefn: func(tree *trillian.Tree) {
+				rotateKey(tree)
+				tree.KeyVersion++
+			},
+			wantErr: true,
+		},
+		{
+			desc: "KeyRotationWithoutPreviousKey",
+			updatefn: func(tree *trillian.Tree) {
+				rotateKey(tree)
+				tree.PreviousKeys = nil
+			},
+			wantErr: true,
+		},
+		{
+			desc: "KeyRotationWithWrongPreviousKey",
+			updatefn: func(tree *trillian.Tree) {
+				rotateKey(tree)
+				tree.PreviousKeys[0].PublicKey = tree.PublicKey
+			},
+			wantErr: true,
+		},
+		{
+			desc: "KeyRotationWithoutStatement",
+			updatefn: func(tree *trillian.Tree) {
+				rotateKey(tree)
+				tree.PreviousKeys[0].Rotation = nil
+			},
+			wantErr: true,
+		},
+		{
+			desc: "KeyRotationWithoutRetireTime",
+			updatefn: func(tree *trillian.Tree) {
+				rotateKey(tree)
+				tree.PreviousKeys[0].RetireTime = nil
+			},
+			wantErr: true,
+		},
+		{
+			desc: "KeyRotationWithMismatchedPrivateKey",
+			updatefn: func(tree *trillian.Tree) {
+				privateKey := tree.PrivateKey
+				rotateKey(tree)
+				tree.PrivateKey = privateKey
+			},
+			wantErr: true,
+		},
 	}
 	for _, test := range tests {
 		tree := newTree()
@@ -441,6 +703,9 @@ func TestValidateTreeForUpdate(t *testing.T) {
 		if test.treeState != trillian.TreeState_UNKNOWN_TREE_STATE {
 			tree.TreeState = test.treeState
 		}
+		if test.multiSig {
+			addMultiSig(tree, 1)
+		}
 
 		baseTree := *tree
 		test.updatefn(tree)
@@ -455,6 +720,75 @@ func TestValidateTreeForUpdate(t *testing.T) {
 	}
 }
 
+// rotateKey replaces the key of tree the same way as key rotation does,
+// although the rotation statement is not a real one.
+func rotateKey(tree *trillian.Tree) {
+	privateKey, err := ptypes.MarshalAny(&keyspb.PrivateKey{
+		Der: ktestonly.MustMarshalPrivatePEMToDER(testonly.DemoPrivateKey, testonly.DemoPrivateKeyPass),
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	tree.PreviousKeys = append(tree.PreviousKeys, &trillian.TreeKey{
+		KeyVersion:        tree.KeyVersion,
+		PublicKey:         tree.PublicKey,
+		RetireTime:        ptypes.TimestampNow(),
+		Rotation:          []byte("rotation"),
+		RotationSignature: []byte("signature"),
+	})
+	tree.KeyVersion++
+	tree.PrivateKey = privateKey
+	tree.PublicKey = &keyspb.PublicKey{
+		Der: ktestonly.MustMarshalPublicPEMToDER(testonly.DemoPublicKey),
+	}
+}
+
+// addMultiSig configures tree to be co-signed by two signers, the first of
+// which uses the tree's own key.
+func addMultiSig(tree *trillian.Tree, threshold int32) {
+	privateKey, err := ptypes.MarshalAny(&keyspb.PrivateKey{
+		Der: ktestonly.MustMarshalPrivatePEMToDER(testonly.DemoPrivateKey, testonly.DemoPrivateKeyPass),
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	tree.MultiSig = &trillian.MultiSigConfig{
+		Threshold: threshold,
+		Signers: []*trillian.TreeSigner{
+			{PrivateKey: tree.PrivateKey, PublicKey: tree.PublicKey},
+			{
+				PrivateKey: privateKey,
+				PublicKey:  &keyspb.PublicKey{Der: ktestonly.MustMarshalPublicPEMToDER(testonly.DemoPublicKey)},
+			},
+		},
+	}
+}
+
+// newEd25519Signer returns a co-signer with a new Ed25519 key.
+func newEd25519Signer() *trillian.TreeSigner {
+	keyProto, err := der.NewProtoFromSpec(&keyspb.Specification{
+		Params: &keyspb.Specification_Ed25519Params{Ed25519Params: &keyspb.Specification_Ed25519{}},
+	})
+	if err != nil {
+		panic(err)
+	}
+	signer, err := der.FromProto(keyProto)
+	if err != nil {
+		panic(err)
+	}
+	publicKey, err := der.ToPublicProto(signer.Public())
+	if err != nil {
+		panic(err)
+	}
+	privateKey, err := ptypes.MarshalAny(keyProto)
+	if err != nil {
+		panic(err)
+	}
+	return &trillian.TreeSigner{PrivateKey: privateKey, PublicKey: publicKey}
+}
+
 // newTree returns a valid log tree for tests.
 func newTree() *trillian.Tree {
 	privateKey, err := ptypes.MarshalAny(&keyspb.PEMKeyFile{
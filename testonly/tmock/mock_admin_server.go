@@ -34,6 +34,19 @@ func (m *MockTrillianAdminServer) EXPECT() *MockTrillianAdminServerMockRecorder
 	return m.recorder
 }
 
+// CloneTree mocks base method
+func (m *MockTrillianAdminServer) CloneTree(arg0 context.Context, arg1 *trillian.CloneTreeRequest) (*trillian.Tree, error) {
+	ret := m.ctrl.Call(m, "CloneTree", arg0, arg1)
+	ret0, _ := ret[0].(*trillian.Tree)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloneTree indicates an expected call of CloneTree
+func (mr *MockTrillianAdminServerMockRecorder) CloneTree(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloneTree", reflect.TypeOf((*MockTrillianAdminServer)(nil).CloneTree), arg0, arg1)
+}
+
 // CreateTree mocks base method
 func (m *MockTrillianAdminServer) CreateTree(arg0 context.Context, arg1 *trillian.CreateTreeRequest) (*trillian.Tree, error) {
 	ret := m.ctrl.Call(m, "CreateTree", arg0, arg1)
@@ -73,6 +86,19 @@ func (mr *MockTrillianAdminServerMockRecorder) GetTree(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTree", reflect.TypeOf((*MockTrillianAdminServer)(nil).GetTree), arg0, arg1)
 }
 
+// ListTreeAuditEntries mocks base method
+func (m *MockTrillianAdminServer) ListTreeAuditEntries(arg0 context.Context, arg1 *trillian.ListTreeAuditEntriesRequest) (*trillian.ListTreeAuditEntriesResponse, error) {
+	ret := m.ctrl.Call(m, "ListTreeAuditEntries", arg0, arg1)
+	ret0, _ := ret[0].(*trillian.ListTreeAuditEntriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTreeAuditEntries indicates an expected call of ListTreeAuditEntries
+func (mr *MockTrillianAdminServerMockRecorder) ListTreeAuditEntries(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTreeAuditEntries", reflect.TypeOf((*MockTrillianAdminServer)(nil).ListTreeAuditEntries), arg0, arg1)
+}
+
 // ListTrees mocks base method
 func (m *MockTrillianAdminServer) ListTrees(arg0 context.Context, arg1 *trillian.ListTreesRequest) (*trillian.ListTreesResponse, error) {
 	ret := m.ctrl.Call(m, "ListTrees", arg0, arg1)
@@ -86,6 +112,32 @@ func (mr *MockTrillianAdminServerMockRecorder) ListTrees(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrees", reflect.TypeOf((*MockTrillianAdminServer)(nil).ListTrees), arg0, arg1)
 }
 
+// RedactLeaf mocks base method
+func (m *MockTrillianAdminServer) RedactLeaf(arg0 context.Context, arg1 *trillian.RedactLeafRequest) (*trillian.LogLeaf, error) {
+	ret := m.ctrl.Call(m, "RedactLeaf", arg0, arg1)
+	ret0, _ := ret[0].(*trillian.LogLeaf)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RedactLeaf indicates an expected call of RedactLeaf
+func (mr *MockTrillianAdminServerMockRecorder) RedactLeaf(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedactLeaf", reflect.TypeOf((*MockTrillianAdminServer)(nil).RedactLeaf), arg0, arg1)
+}
+
+// RotateTreeKey mocks base method
+func (m *MockTrillianAdminServer) RotateTreeKey(arg0 context.Context, arg1 *trillian.RotateTreeKeyRequest) (*trillian.Tree, error) {
+	ret := m.ctrl.Call(m, "RotateTreeKey", arg0, arg1)
+	ret0, _ := ret[0].(*trillian.Tree)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateTreeKey indicates an expected call of RotateTreeKey
+func (mr *MockTrillianAdminServerMockRecorder) RotateTreeKey(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateTreeKey", reflect.TypeOf((*MockTrillianAdminServer)(nil).RotateTreeKey), arg0, arg1)
+}
+
 // UndeleteTree mocks base method
 func (m *MockTrillianAdminServer) UndeleteTree(arg0 context.Context, arg1 *trillian.UndeleteTreeRequest) (*trillian.Tree, error) {
 	ret := m.ctrl.Call(m, "UndeleteTree", arg0, arg1)
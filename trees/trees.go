@@ -22,10 +22,13 @@ import (
 	"fmt"
 
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
 	"github.com/google/trillian"
 	"github.com/google/trillian/crypto/keys"
+	"github.com/google/trillian/crypto/keyspb"
 	"github.com/google/trillian/crypto/sigpb"
 	"github.com/google/trillian/storage"
+	"github.com/google/trillian/types"
 	"go.opencensus.io/trace"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -194,6 +197,8 @@ func Hash(tree *trillian.Tree) (crypto.Hash, error) {
 }
 
 // Signer returns a Trillian crypto.Signer configured by the tree.
+// Its KeyHint identifies the tree's current key version. If the tree has a
+// multi-signature configuration, the signer also has the tree's co-signers.
 func Signer(ctx context.Context, tree *trillian.Tree) (*tcrypto.Signer, error) {
 	if tree.SignatureAlgorithm == sigpb.DigitallySigned_ANONYMOUS {
 		return nil, fmt.Errorf("signature algorithm not supported: %s", tree.SignatureAlgorithm)
@@ -204,12 +209,37 @@ func Signer(ctx context.Context, tree *trillian.Tree) (*tcrypto.Signer, error) {
 		return nil, err
 	}
 
+	signer, err := newSigner(ctx, tree, tree.PrivateKey, tree.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	s := tcrypto.NewSigner(tree.GetTreeId(), signer, hash)
+	s.KeyHint = types.SerializeKeyVersionHint(tree.GetTreeId(), tree.GetKeyVersion())
+
+	if ms := tree.GetMultiSig(); ms != nil {
+		for i, ts := range ms.Signers {
+			coSigner, err := newSigner(ctx, tree, ts.PrivateKey, ts.PublicKey)
+			if err != nil {
+				return nil, fmt.Errorf("multi_sig signer %v: %v", i, err)
+			}
+			s.CoSigners = append(s.CoSigners, coSigner)
+		}
+		s.CoSignThreshold = int(ms.Threshold)
+	}
+	return s, nil
+}
+
+// newSigner returns the crypto.Signer identified by privateKey, which must
+// produce signatures of the tree's signature algorithm. publicKey, if known,
+// is needed to decrypt an encrypted privateKey.
+func newSigner(ctx context.Context, tree *trillian.Tree, privateKey *any.Any, publicKey *keyspb.PublicKey) (crypto.Signer, error) {
 	var keyProto ptypes.DynamicAny
-	if err := ptypes.UnmarshalAny(tree.PrivateKey, &keyProto); err != nil {
+	if err := ptypes.UnmarshalAny(privateKey, &keyProto); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal tree.PrivateKey: %v", err)
 	}
 
-	signer, err := keys.NewSigner(ctx, keyProto.Message)
+	signer, err := keys.NewSigner(keys.WithPublicKey(ctx, publicKey.GetDer()), keyProto.Message)
 	if err != nil {
 		return nil, err
 	}
@@ -217,8 +247,7 @@ func Signer(ctx context.Context, tree *trillian.Tree) (*tcrypto.Signer, error) {
 	if tcrypto.SignatureAlgorithm(signer.Public()) != tree.SignatureAlgorithm {
 		return nil, fmt.Errorf("%s signature not supported by signer of type %T", tree.SignatureAlgorithm, signer)
 	}
-
-	return tcrypto.NewSigner(tree.GetTreeId(), signer, hash), nil
+	return signer, nil
 }
 
 func spanFor(ctx context.Context, name string) (context.Context, *trace.Span) {
@@ -33,6 +33,7 @@ import (
 	"github.com/google/trillian/crypto/sigpb"
 	"github.com/google/trillian/storage"
 	"github.com/google/trillian/storage/testonly"
+	"github.com/google/trillian/types"
 	"github.com/kylelemons/godebug/pretty"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -372,6 +373,8 @@ func TestSigner(t *testing.T) {
 	tests := []struct {
 		desc         string
 		sigAlgo      sigpb.DigitallySigned_SignatureAlgorithm
+		keyVersion   int64
+		coSignThresh int32
 		signer       crypto.Signer
 		newSignerErr error
 		wantErr      bool
@@ -391,6 +394,18 @@ func TestSigner(t *testing.T) {
 			sigAlgo: sigpb.DigitallySigned_RSA,
 			signer:  rsaKey,
 		},
+		{
+			desc:       "rotatedKey",
+			sigAlgo:    sigpb.DigitallySigned_ECDSA,
+			keyVersion: 3,
+			signer:     ecdsaKey,
+		},
+		{
+			desc:         "multiSig",
+			sigAlgo:      sigpb.DigitallySigned_ECDSA,
+			coSignThresh: 1,
+			signer:       ecdsaKey,
+		},
 		{
 			desc:    "keyMismatch1",
 			sigAlgo: sigpb.DigitallySigned_ECDSA,
@@ -418,6 +433,16 @@ func TestSigner(t *testing.T) {
 			tree.HashAlgorithm = sigpb.DigitallySigned_SHA256
 			tree.HashStrategy = trillian.HashStrategy_RFC6962_SHA256
 			tree.SignatureAlgorithm = test.sigAlgo
+			tree.KeyVersion = test.keyVersion
+			if test.coSignThresh > 0 {
+				tree.MultiSig = &trillian.MultiSigConfig{
+					Threshold: test.coSignThresh,
+					Signers: []*trillian.TreeSigner{
+						{PrivateKey: tree.PrivateKey},
+						{PrivateKey: tree.PrivateKey},
+					},
+				}
+			}
 
 			var wantKeyProto ptypes.DynamicAny
 			if err := ptypes.UnmarshalAny(tree.PrivateKey, &wantKeyProto); err != nil {
@@ -440,6 +465,11 @@ func TestSigner(t *testing.T) {
 			}
 
 			want := tcrypto.NewSigner(0, test.signer, crypto.SHA256)
+			want.KeyHint = types.SerializeKeyVersionHint(tree.TreeId, test.keyVersion)
+			if test.coSignThresh > 0 {
+				want.CoSigners = []crypto.Signer{test.signer, test.signer}
+				want.CoSignThreshold = int(test.coSignThresh)
+			}
 			if diff := pretty.Compare(signer, want); diff != "" {
 				t.Fatalf("post-Signer(_, %s) diff:\n%v", test.sigAlgo, diff)
 			}
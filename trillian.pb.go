@@ -84,6 +84,20 @@ const (
 	HashStrategy_OBJECT_RFC6962_SHA256 HashStrategy = 3
 	// The CONIKS sparse tree hasher with SHA512_256 as the hash algorithm.
 	HashStrategy_CONIKS_SHA512_256 HashStrategy = 4
+	// Append-only log strategy with the structure of RFC6962_SHA256, but with
+	// SHA-512/256 as the hash function.
+	HashStrategy_RFC6962_SHA512_256 HashStrategy = 5
+	// Append-only log strategy with the structure of RFC6962_SHA256, but with
+	// SHA3-256 as the hash function.
+	HashStrategy_RFC6962_SHA3_256 HashStrategy = 6
+	// Append-only log strategy with the structure of RFC6962_SHA256, but with
+	// BLAKE2b-256 as the hash function.
+	HashStrategy_RFC6962_BLAKE2B_256 HashStrategy = 7
+	// Append-only log strategy where leaves are protobuf messages, hashed field
+	// by field so that individual fields can be proven while others are
+	// redacted. See the merkle/protohasher package. All other properties are
+	// equal to RFC6962_SHA256.
+	HashStrategy_PROTO_RFC6962_SHA256 HashStrategy = 8
 )
 
 var HashStrategy_name = map[int32]string{
@@ -92,6 +106,10 @@ var HashStrategy_name = map[int32]string{
 	2: "TEST_MAP_HASHER",
 	3: "OBJECT_RFC6962_SHA256",
 	4: "CONIKS_SHA512_256",
+	5: "RFC6962_SHA512_256",
+	6: "RFC6962_SHA3_256",
+	7: "RFC6962_BLAKE2B_256",
+	8: "PROTO_RFC6962_SHA256",
 }
 var HashStrategy_value = map[string]int32{
 	"UNKNOWN_HASH_STRATEGY": 0,
@@ -99,6 +117,10 @@ var HashStrategy_value = map[string]int32{
 	"TEST_MAP_HASHER":       2,
 	"OBJECT_RFC6962_SHA256": 3,
 	"CONIKS_SHA512_256":     4,
+	"RFC6962_SHA512_256":    5,
+	"RFC6962_SHA3_256":      6,
+	"RFC6962_BLAKE2B_256":   7,
+	"PROTO_RFC6962_SHA256":  8,
 }
 
 func (x HashStrategy) String() string {
@@ -185,6 +207,43 @@ func (x TreeType) String() string {
 }
 func (TreeType) EnumDescriptor() ([]byte, []int) { return fileDescriptor3, []int{4} }
 
+type TreeTransitionStatus_Phase int32
+
+const (
+	TreeTransitionStatus_UNKNOWN_PHASE TreeTransitionStatus_Phase = 0
+	// The transition's start_time hasn't been reached yet.
+	TreeTransitionStatus_PENDING TreeTransitionStatus_Phase = 1
+	// The tree has been moved to DRAINING, and will be frozen once drained.
+	TreeTransitionStatus_DRAINING TreeTransitionStatus_Phase = 2
+	// The tree has reached the target state.
+	TreeTransitionStatus_COMPLETED TreeTransitionStatus_Phase = 3
+	// The transition was cancelled before completing, by clearing
+	// scheduled_transition.
+	TreeTransitionStatus_CANCELLED TreeTransitionStatus_Phase = 4
+)
+
+var TreeTransitionStatus_Phase_name = map[int32]string{
+	0: "UNKNOWN_PHASE",
+	1: "PENDING",
+	2: "DRAINING",
+	3: "COMPLETED",
+	4: "CANCELLED",
+}
+var TreeTransitionStatus_Phase_value = map[string]int32{
+	"UNKNOWN_PHASE": 0,
+	"PENDING":       1,
+	"DRAINING":      2,
+	"COMPLETED":     3,
+	"CANCELLED":     4,
+}
+
+func (x TreeTransitionStatus_Phase) String() string {
+	return proto.EnumName(TreeTransitionStatus_Phase_name, int32(x))
+}
+func (TreeTransitionStatus_Phase) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor3, []int{5, 0}
+}
+
 // Represents a tree, which may be either a verifiable log or map.
 // Readonly attributes are assigned at tree creation, after which they may not
 // be modified.
@@ -251,6 +310,34 @@ type Tree struct {
 	// Time of tree deletion, if any.
 	// Readonly.
 	DeleteTime *google_protobuf1.Timestamp `protobuf:"bytes,20,opt,name=delete_time,json=deleteTime" json:"delete_time,omitempty"`
+	// Version of the key identified by private_key and public_key.
+	// Starts at zero and is incremented each time the key is rotated.
+	// Readonly (assigned by RotateTreeKey, which is not supported by Cloud
+	// Spanner storage).
+	KeyVersion int64 `protobuf:"varint,21,opt,name=key_version,json=keyVersion" json:"key_version,omitempty"`
+	// Keys that were used by the tree before being replaced by key rotation,
+	// oldest first.
+	// Readonly (assigned by RotateTreeKey).
+	PreviousKeys []*TreeKey `protobuf:"bytes,22,rep,name=previous_keys,json=previousKeys" json:"previous_keys,omitempty"`
+	// Additional signers of tree heads. If set, each root also carries
+	// signatures from at least multi_sig.threshold of these signers, so that
+	// compromise of a single key isn't enough to forge a root.
+	// Only supported by log trees, and not by Cloud Spanner storage.
+	// Readonly, although storage allows the signers' private keys to be replaced
+	// by equivalent ones, e.g. when they're encrypted.
+	MultiSig *MultiSigConfig `protobuf:"bytes,23,opt,name=multi_sig,json=multiSig" json:"multi_sig,omitempty"`
+	// Key/value labels of the tree, e.g. naming the tenant that owns it.
+	// Trees may be filtered by label in ListTrees.
+	// Keys must be 1 to 63 characters long, and values at most 63.
+	Labels map[string]string `protobuf:"bytes,24,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// A change of tree_state to be made at a future time, by the log signer.
+	// Cleared once the change is complete.
+	// Only supported by log trees, and not by Cloud Spanner storage.
+	ScheduledTransition *ScheduledTransition `protobuf:"bytes,25,opt,name=scheduled_transition,json=scheduledTransition" json:"scheduled_transition,omitempty"`
+	// Progress of the latest scheduled_transition.
+	// Readonly (assigned when scheduled_transition is set, and by the log
+	// signer as the transition progresses).
+	TransitionStatus *TreeTransitionStatus `protobuf:"bytes,26,opt,name=transition_status,json=transitionStatus" json:"transition_status,omitempty"`
 }
 
 func (m *Tree) Reset()                    { *m = Tree{} }
@@ -370,6 +457,228 @@ func (m *Tree) GetDeleteTime() *google_protobuf1.Timestamp {
 	return nil
 }
 
+func (m *Tree) GetKeyVersion() int64 {
+	if m != nil {
+		return m.KeyVersion
+	}
+	return 0
+}
+
+func (m *Tree) GetPreviousKeys() []*TreeKey {
+	if m != nil {
+		return m.PreviousKeys
+	}
+	return nil
+}
+
+func (m *Tree) GetMultiSig() *MultiSigConfig {
+	if m != nil {
+		return m.MultiSig
+	}
+	return nil
+}
+
+func (m *Tree) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *Tree) GetScheduledTransition() *ScheduledTransition {
+	if m != nil {
+		return m.ScheduledTransition
+	}
+	return nil
+}
+
+func (m *Tree) GetTransitionStatus() *TreeTransitionStatus {
+	if m != nil {
+		return m.TransitionStatus
+	}
+	return nil
+}
+
+// Configuration of the additional signers of a multi-signature tree.
+type MultiSigConfig struct {
+	// Minimum number of signers whose signatures a root must carry.
+	// Must be between 1 and the number of signers.
+	Threshold int32 `protobuf:"varint,1,opt,name=threshold" json:"threshold,omitempty"`
+	// The signers. Roots identify each signature by the signer's index in this
+	// list.
+	Signers []*TreeSigner `protobuf:"bytes,2,rep,name=signers" json:"signers,omitempty"`
+}
+
+func (m *MultiSigConfig) Reset()                    { *m = MultiSigConfig{} }
+func (m *MultiSigConfig) String() string            { return proto.CompactTextString(m) }
+func (*MultiSigConfig) ProtoMessage()               {}
+func (*MultiSigConfig) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{1} }
+
+func (m *MultiSigConfig) GetThreshold() int32 {
+	if m != nil {
+		return m.Threshold
+	}
+	return 0
+}
+
+func (m *MultiSigConfig) GetSigners() []*TreeSigner {
+	if m != nil {
+		return m.Signers
+	}
+	return nil
+}
+
+// A signer of a multi-signature tree.
+type TreeSigner struct {
+	// Identifies the signer's private key, in the same way as
+	// Tree.private_key.
+	// Private keys are write-only: they're never returned by RPCs.
+	PrivateKey *google_protobuf2.Any `protobuf:"bytes,1,opt,name=private_key,json=privateKey" json:"private_key,omitempty"`
+	// The public key that verifies the signer's signatures.
+	PublicKey *keyspb.PublicKey `protobuf:"bytes,2,opt,name=public_key,json=publicKey" json:"public_key,omitempty"`
+}
+
+func (m *TreeSigner) Reset()                    { *m = TreeSigner{} }
+func (m *TreeSigner) String() string            { return proto.CompactTextString(m) }
+func (*TreeSigner) ProtoMessage()               {}
+func (*TreeSigner) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{2} }
+
+func (m *TreeSigner) GetPrivateKey() *google_protobuf2.Any {
+	if m != nil {
+		return m.PrivateKey
+	}
+	return nil
+}
+
+func (m *TreeSigner) GetPublicKey() *keyspb.PublicKey {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+// A signing key that was replaced by key rotation.
+type TreeKey struct {
+	// Version of the key.
+	KeyVersion int64 `protobuf:"varint,1,opt,name=key_version,json=keyVersion" json:"key_version,omitempty"`
+	// The public key, which verifies roots signed before retire_time.
+	PublicKey *keyspb.PublicKey `protobuf:"bytes,2,opt,name=public_key,json=publicKey" json:"public_key,omitempty"`
+	// End of the transition period. Roots signed by this key are not valid if
+	// their timestamp is after retire_time.
+	RetireTime *google_protobuf1.Timestamp `protobuf:"bytes,3,opt,name=retire_time,json=retireTime" json:"retire_time,omitempty"`
+	// TLS-serialized KeyRotation statement, which identifies the key that
+	// replaced this one.
+	Rotation []byte `protobuf:"bytes,4,opt,name=rotation,proto3" json:"rotation,omitempty"`
+	// Signature over rotation by this key.
+	RotationSignature []byte `protobuf:"bytes,5,opt,name=rotation_signature,json=rotationSignature,proto3" json:"rotation_signature,omitempty"`
+}
+
+func (m *TreeKey) Reset()                    { *m = TreeKey{} }
+func (m *TreeKey) String() string            { return proto.CompactTextString(m) }
+func (*TreeKey) ProtoMessage()               {}
+func (*TreeKey) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{3} }
+
+func (m *TreeKey) GetKeyVersion() int64 {
+	if m != nil {
+		return m.KeyVersion
+	}
+	return 0
+}
+
+func (m *TreeKey) GetPublicKey() *keyspb.PublicKey {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+func (m *TreeKey) GetRetireTime() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.RetireTime
+	}
+	return nil
+}
+
+func (m *TreeKey) GetRotation() []byte {
+	if m != nil {
+		return m.Rotation
+	}
+	return nil
+}
+
+func (m *TreeKey) GetRotationSignature() []byte {
+	if m != nil {
+		return m.RotationSignature
+	}
+	return nil
+}
+
+// A change of a tree's state to be made at a future time.
+type ScheduledTransition struct {
+	// State to move the tree to: DRAINING or FROZEN.
+	// Trees scheduled to be FROZEN are first moved to DRAINING, and frozen once
+	// drained: when their queue of unsequenced leaves is empty and their latest
+	// signed root covers all their leaves.
+	TargetState TreeState `protobuf:"varint,1,opt,name=target_state,json=targetState,enum=trillian.TreeState" json:"target_state,omitempty"`
+	// Time at which the transition starts.
+	StartTime *google_protobuf1.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime" json:"start_time,omitempty"`
+}
+
+func (m *ScheduledTransition) Reset()                    { *m = ScheduledTransition{} }
+func (m *ScheduledTransition) String() string            { return proto.CompactTextString(m) }
+func (*ScheduledTransition) ProtoMessage()               {}
+func (*ScheduledTransition) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{4} }
+
+func (m *ScheduledTransition) GetTargetState() TreeState {
+	if m != nil {
+		return m.TargetState
+	}
+	return TreeState_UNKNOWN_TREE_STATE
+}
+
+func (m *ScheduledTransition) GetStartTime() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.StartTime
+	}
+	return nil
+}
+
+// Progress of a scheduled tree state transition.
+type TreeTransitionStatus struct {
+	// Current phase of the transition.
+	Phase TreeTransitionStatus_Phase `protobuf:"varint,1,opt,name=phase,enum=trillian.TreeTransitionStatus_Phase" json:"phase,omitempty"`
+	// State the transition moves the tree to.
+	TargetState TreeState `protobuf:"varint,2,opt,name=target_state,json=targetState,enum=trillian.TreeState" json:"target_state,omitempty"`
+	// Time at which the transition entered its current phase.
+	UpdateTime *google_protobuf1.Timestamp `protobuf:"bytes,3,opt,name=update_time,json=updateTime" json:"update_time,omitempty"`
+}
+
+func (m *TreeTransitionStatus) Reset()                    { *m = TreeTransitionStatus{} }
+func (m *TreeTransitionStatus) String() string            { return proto.CompactTextString(m) }
+func (*TreeTransitionStatus) ProtoMessage()               {}
+func (*TreeTransitionStatus) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{5} }
+
+func (m *TreeTransitionStatus) GetPhase() TreeTransitionStatus_Phase {
+	if m != nil {
+		return m.Phase
+	}
+	return TreeTransitionStatus_UNKNOWN_PHASE
+}
+
+func (m *TreeTransitionStatus) GetTargetState() TreeState {
+	if m != nil {
+		return m.TargetState
+	}
+	return TreeState_UNKNOWN_TREE_STATE
+}
+
+func (m *TreeTransitionStatus) GetUpdateTime() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.UpdateTime
+	}
+	return nil
+}
+
 type SignedEntryTimestamp struct {
 	TimestampNanos int64                  `protobuf:"varint,1,opt,name=timestamp_nanos,json=timestampNanos" json:"timestamp_nanos,omitempty"`
 	LogId          int64                  `protobuf:"varint,2,opt,name=log_id,json=logId" json:"log_id,omitempty"`
@@ -379,7 +688,7 @@ type SignedEntryTimestamp struct {
 func (m *SignedEntryTimestamp) Reset()                    { *m = SignedEntryTimestamp{} }
 func (m *SignedEntryTimestamp) String() string            { return proto.CompactTextString(m) }
 func (*SignedEntryTimestamp) ProtoMessage()               {}
-func (*SignedEntryTimestamp) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{1} }
+func (*SignedEntryTimestamp) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{6} }
 
 func (m *SignedEntryTimestamp) GetTimestampNanos() int64 {
 	if m != nil {
@@ -441,12 +750,16 @@ type SignedLogRoot struct {
 	LogRoot []byte `protobuf:"bytes,8,opt,name=log_root,json=logRoot,proto3" json:"log_root,omitempty"`
 	// log_root_signature is the raw signature over log_root.
 	LogRootSignature []byte `protobuf:"bytes,9,opt,name=log_root_signature,json=logRootSignature,proto3" json:"log_root_signature,omitempty"`
+	// Signatures over log_root by the signers of a multi-signature tree (see
+	// Tree.multi_sig), in addition to log_root_signature. Clients should check
+	// that enough of them are valid with VerifySignedLogRootThreshold.
+	Signatures []*RootSignature `protobuf:"bytes,10,rep,name=signatures" json:"signatures,omitempty"`
 }
 
 func (m *SignedLogRoot) Reset()                    { *m = SignedLogRoot{} }
 func (m *SignedLogRoot) String() string            { return proto.CompactTextString(m) }
 func (*SignedLogRoot) ProtoMessage()               {}
-func (*SignedLogRoot) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{2} }
+func (*SignedLogRoot) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{7} }
 
 func (m *SignedLogRoot) GetTimestampNanos() int64 {
 	if m != nil {
@@ -497,6 +810,40 @@ func (m *SignedLogRoot) GetLogRootSignature() []byte {
 	return nil
 }
 
+func (m *SignedLogRoot) GetSignatures() []*RootSignature {
+	if m != nil {
+		return m.Signatures
+	}
+	return nil
+}
+
+// A signature over a root by one of the signers of a multi-signature tree.
+type RootSignature struct {
+	// Index of the signer in MultiSigConfig.signers.
+	SignerIndex int32 `protobuf:"varint,1,opt,name=signer_index,json=signerIndex" json:"signer_index,omitempty"`
+	// The raw signature over the root.
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (m *RootSignature) Reset()                    { *m = RootSignature{} }
+func (m *RootSignature) String() string            { return proto.CompactTextString(m) }
+func (*RootSignature) ProtoMessage()               {}
+func (*RootSignature) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{8} }
+
+func (m *RootSignature) GetSignerIndex() int32 {
+	if m != nil {
+		return m.SignerIndex
+	}
+	return 0
+}
+
+func (m *RootSignature) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
 // SignedMapRoot represents a commitment by a Map to a particular tree.
 type SignedMapRoot struct {
 	// map_root holds the TLS-serialization of the following structure (described
@@ -523,7 +870,7 @@ type SignedMapRoot struct {
 func (m *SignedMapRoot) Reset()                    { *m = SignedMapRoot{} }
 func (m *SignedMapRoot) String() string            { return proto.CompactTextString(m) }
 func (*SignedMapRoot) ProtoMessage()               {}
-func (*SignedMapRoot) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{3} }
+func (*SignedMapRoot) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{9} }
 
 func (m *SignedMapRoot) GetMapRoot() []byte {
 	if m != nil {
@@ -541,87 +888,127 @@ func (m *SignedMapRoot) GetSignature() []byte {
 
 func init() {
 	proto.RegisterType((*Tree)(nil), "trillian.Tree")
+	proto.RegisterType((*MultiSigConfig)(nil), "trillian.MultiSigConfig")
+	proto.RegisterType((*TreeSigner)(nil), "trillian.TreeSigner")
+	proto.RegisterType((*TreeKey)(nil), "trillian.TreeKey")
+	proto.RegisterType((*ScheduledTransition)(nil), "trillian.ScheduledTransition")
+	proto.RegisterType((*TreeTransitionStatus)(nil), "trillian.TreeTransitionStatus")
 	proto.RegisterType((*SignedEntryTimestamp)(nil), "trillian.SignedEntryTimestamp")
 	proto.RegisterType((*SignedLogRoot)(nil), "trillian.SignedLogRoot")
+	proto.RegisterType((*RootSignature)(nil), "trillian.RootSignature")
 	proto.RegisterType((*SignedMapRoot)(nil), "trillian.SignedMapRoot")
 	proto.RegisterEnum("trillian.LogRootFormat", LogRootFormat_name, LogRootFormat_value)
 	proto.RegisterEnum("trillian.MapRootFormat", MapRootFormat_name, MapRootFormat_value)
 	proto.RegisterEnum("trillian.HashStrategy", HashStrategy_name, HashStrategy_value)
 	proto.RegisterEnum("trillian.TreeState", TreeState_name, TreeState_value)
 	proto.RegisterEnum("trillian.TreeType", TreeType_name, TreeType_value)
+	proto.RegisterEnum("trillian.TreeTransitionStatus_Phase", TreeTransitionStatus_Phase_name, TreeTransitionStatus_Phase_value)
 }
 
 func init() { proto.RegisterFile("trillian.proto", fileDescriptor3) }
 
 var fileDescriptor3 = []byte{
-	// 1097 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x8c, 0x56, 0xdb, 0x6f, 0xdb, 0x36,
-	0x17, 0xaf, 0x6c, 0xc5, 0x96, 0xe9, 0x4b, 0x18, 0xa6, 0x17, 0xd9, 0xfd, 0xf0, 0xd5, 0xcb, 0x06,
-	0x2c, 0x2b, 0x06, 0x67, 0xf5, 0xd6, 0x02, 0x43, 0x1f, 0x06, 0x35, 0x56, 0x62, 0x3b, 0x89, 0x6d,
-	0x50, 0x5a, 0x87, 0xe6, 0x85, 0x90, 0x6d, 0x4e, 0x16, 0xa2, 0x1b, 0x24, 0xba, 0xa8, 0xfa, 0x3c,
-	0x60, 0x18, 0xb6, 0x3f, 0x7a, 0x20, 0x45, 0xd9, 0x89, 0xb3, 0xae, 0x7b, 0x49, 0x78, 0xce, 0xef,
-	0xc2, 0x23, 0xf2, 0x1c, 0xc9, 0xa0, 0xc5, 0x12, 0xcf, 0xf7, 0x3d, 0x27, 0xec, 0xc5, 0x49, 0xc4,
-	0x22, 0xa4, 0x15, 0x71, 0xa7, 0xb3, 0x48, 0xb2, 0x98, 0x45, 0x27, 0x37, 0x34, 0x4b, 0xe3, 0xb9,
-	0xfc, 0x97, 0xb3, 0x3a, 0xba, 0xc4, 0x52, 0xcf, 0x8d, 0xe7, 0xf9, 0x5f, 0x89, 0xb4, 0xdd, 0x28,
-	0x72, 0x7d, 0x7a, 0x22, 0xa2, 0xf9, 0xfa, 0xd7, 0x13, 0x27, 0xcc, 0x24, 0xf4, 0xff, 0x5d, 0x68,
-	0xb9, 0x4e, 0x1c, 0xe6, 0x45, 0x72, 0xeb, 0xce, 0xb3, 0x5d, 0x9c, 0x79, 0x01, 0x4d, 0x99, 0x13,
-	0xc4, 0x39, 0xe1, 0xe8, 0x8f, 0x2a, 0x50, 0xed, 0x84, 0x52, 0xf4, 0x04, 0x54, 0x59, 0x42, 0x29,
-	0xf1, 0x96, 0xba, 0xd2, 0x55, 0x8e, 0xcb, 0xb8, 0xc2, 0xc3, 0xd1, 0x12, 0xf5, 0x01, 0x10, 0x40,
-	0xca, 0x1c, 0x46, 0xf5, 0x52, 0x57, 0x39, 0x6e, 0xf5, 0x0f, 0x7b, 0x9b, 0x47, 0xe4, 0x62, 0x8b,
-	0x43, 0xb8, 0xc6, 0x8a, 0x25, 0x3a, 0x01, 0x22, 0x20, 0x2c, 0x8b, 0xa9, 0x5e, 0x16, 0x12, 0x74,
-	0x57, 0x62, 0x67, 0x31, 0xc5, 0x1a, 0x93, 0x2b, 0xf4, 0x1a, 0x34, 0x57, 0x4e, 0xba, 0x22, 0x29,
-	0x4b, 0x1c, 0x46, 0xdd, 0x4c, 0x57, 0x85, 0xe8, 0xf1, 0x56, 0x34, 0x74, 0xd2, 0x95, 0x25, 0x51,
-	0xdc, 0x58, 0xdd, 0x8a, 0xd0, 0x05, 0x68, 0x09, 0xb1, 0xe3, 0xbb, 0x51, 0xe2, 0xb1, 0x55, 0xa0,
-	0xef, 0x09, 0xf5, 0x57, 0xbd, 0xfc, 0x14, 0x07, 0x9e, 0xeb, 0x31, 0xc7, 0xf7, 0x33, 0xcb, 0x73,
-	0x43, 0xba, 0x14, 0x56, 0x46, 0xc1, 0xc5, 0x62, 0xe3, 0x4d, 0x88, 0xae, 0xc1, 0x61, 0xea, 0xb9,
-	0xa1, 0xc3, 0xd6, 0x09, 0xbd, 0xe5, 0x58, 0x11, 0x8e, 0xdf, 0x7c, 0xc2, 0xd1, 0x2a, 0x14, 0x5b,
-	0x5b, 0x94, 0xde, 0xcb, 0xa1, 0x2f, 0x40, 0x63, 0xe9, 0xa5, 0xb1, 0xef, 0x64, 0x24, 0x74, 0x02,
-	0xaa, 0x6b, 0x5d, 0xe5, 0xb8, 0x86, 0xeb, 0x32, 0x37, 0x71, 0x02, 0x8a, 0xba, 0xa0, 0xbe, 0xa4,
-	0xe9, 0x22, 0xf1, 0x62, 0x7e, 0x8b, 0x7a, 0x4d, 0x32, 0xb6, 0x29, 0xf4, 0x12, 0xd4, 0xe3, 0xc4,
-	0x7b, 0xef, 0x30, 0x4a, 0x6e, 0x68, 0xa6, 0x37, 0xba, 0xca, 0x71, 0xbd, 0xff, 0xb0, 0x97, 0x5f,
-	0x74, 0xaf, 0xb8, 0xe8, 0x9e, 0x11, 0x66, 0x18, 0x48, 0xe2, 0x05, 0xcd, 0xd0, 0x4f, 0x00, 0xa6,
-	0x2c, 0x4a, 0x1c, 0x97, 0x92, 0x94, 0x32, 0xe6, 0x85, 0x6e, 0xaa, 0x37, 0xff, 0x45, 0xbb, 0x2f,
-	0xd9, 0x96, 0x24, 0xa3, 0xef, 0x00, 0x88, 0xd7, 0x73, 0xdf, 0x5b, 0x88, 0x6d, 0x5b, 0x42, 0x7a,
-	0xd0, 0x93, 0x2d, 0x3c, 0x13, 0xc8, 0x05, 0xcd, 0x70, 0x2d, 0x2e, 0x96, 0xc8, 0x04, 0x07, 0x81,
-	0xf3, 0x81, 0x24, 0x51, 0xc4, 0x48, 0xd1, 0x97, 0xfa, 0xbe, 0x10, 0xb6, 0xef, 0xed, 0x39, 0x90,
-	0x04, 0xbc, 0x1f, 0x38, 0x1f, 0x70, 0x14, 0xb1, 0x22, 0x81, 0x5e, 0x83, 0xfa, 0x22, 0xa1, 0xfc,
-	0x79, 0x79, 0xf3, 0xea, 0x50, 0x18, 0x74, 0xee, 0x19, 0xd8, 0x45, 0x67, 0x63, 0x90, 0xd3, 0x79,
-	0x82, 0x8b, 0xd7, 0xf1, 0x72, 0x23, 0x3e, 0xf8, 0xbc, 0x38, 0xa7, 0x0b, 0xb1, 0x0e, 0xaa, 0x4b,
-	0xea, 0x53, 0x46, 0x97, 0xfa, 0x61, 0x57, 0x39, 0xd6, 0x70, 0x11, 0x72, 0xdb, 0x7c, 0x99, 0xdb,
-	0x3e, 0xfc, 0xbc, 0x6d, 0x4e, 0xe7, 0x89, 0xb1, 0xaa, 0x21, 0x78, 0x38, 0x56, 0xb5, 0x2a, 0xd4,
-	0xc6, 0xaa, 0x06, 0x60, 0x7d, 0xac, 0x6a, 0x75, 0xd8, 0x38, 0xfa, 0x4b, 0x01, 0x0f, 0xf3, 0x86,
-	0x32, 0x43, 0x96, 0x64, 0x1b, 0x31, 0xfa, 0x1a, 0xec, 0x6f, 0xe6, 0x96, 0x84, 0x4e, 0x18, 0xa5,
-	0x72, 0x46, 0x5b, 0x9b, 0xf4, 0x84, 0x67, 0xd1, 0x23, 0x50, 0xf1, 0x23, 0x97, 0xcf, 0x70, 0x49,
-	0xe0, 0x7b, 0x7e, 0xe4, 0x8e, 0x96, 0xe8, 0x07, 0x50, 0xdb, 0x74, 0xa3, 0x18, 0xc7, 0x7a, 0xff,
-	0xf1, 0x3f, 0x77, 0x32, 0xde, 0x12, 0x8f, 0x7e, 0x2f, 0x81, 0x66, 0x9e, 0xbd, 0x8c, 0x5c, 0x7e,
-	0x23, 0xff, 0xbd, 0x8e, 0xa7, 0xa0, 0x26, 0x6e, 0x9d, 0x8f, 0x96, 0x28, 0xa5, 0x81, 0x35, 0x9e,
-	0xe0, 0x93, 0xc7, 0xc1, 0xfc, 0x85, 0xe2, 0x7d, 0xcc, 0xab, 0x29, 0xe7, 0x2f, 0x02, 0xcb, 0xfb,
-	0x48, 0xd1, 0x97, 0xa0, 0x29, 0xc0, 0x84, 0xbe, 0xf7, 0x52, 0xde, 0x2f, 0x15, 0x41, 0x68, 0xf0,
-	0x24, 0x96, 0x39, 0xd4, 0x06, 0xda, 0x0d, 0xcd, 0xc8, 0xca, 0x0b, 0x99, 0x5e, 0x15, 0xee, 0xd5,
-	0x1b, 0x9a, 0x0d, 0xbd, 0x90, 0x71, 0x88, 0x9f, 0x00, 0xdf, 0x4c, 0x8c, 0x57, 0x03, 0x57, 0x7d,
-	0x59, 0xfd, 0xb7, 0x00, 0x15, 0x10, 0xd9, 0x1e, 0x47, 0x4d, 0x90, 0xa0, 0x24, 0x6d, 0x06, 0x79,
-	0xac, 0x6a, 0x2a, 0xdc, 0x1b, 0xab, 0xda, 0x1e, 0xac, 0x1c, 0x25, 0xc5, 0x41, 0x5c, 0x39, 0xb1,
-	0xb0, 0x6a, 0x03, 0x2d, 0x70, 0xe2, 0x7c, 0x97, 0xdc, 0xa0, 0x1a, 0x48, 0xe8, 0x7f, 0xb7, 0xcf,
-	0x5a, 0x15, 0xd8, 0x36, 0x31, 0x56, 0x35, 0x05, 0x96, 0xc6, 0xaa, 0x56, 0x82, 0xe5, 0xb1, 0xaa,
-	0x95, 0xa1, 0x9a, 0xef, 0x30, 0x56, 0xb5, 0x0a, 0xac, 0x6e, 0x5a, 0x42, 0x83, 0xb5, 0xe7, 0x03,
-	0xd0, 0x94, 0xc7, 0x7e, 0x16, 0x25, 0x81, 0xc3, 0xd0, 0x53, 0xf0, 0xe4, 0x72, 0x7a, 0x4e, 0xf0,
-	0x74, 0x6a, 0x93, 0xb3, 0x29, 0xbe, 0x32, 0x6c, 0xf2, 0xf3, 0xe4, 0x62, 0x32, 0xfd, 0x65, 0x02,
-	0x1f, 0xa0, 0xc7, 0x00, 0xed, 0x82, 0x6f, 0x5f, 0x40, 0x85, 0xbb, 0xc8, 0x9a, 0xb7, 0x2e, 0x57,
-	0xc6, 0xec, 0xd3, 0x2e, 0xbb, 0xa0, 0x70, 0xf9, 0x4d, 0x01, 0x8d, 0xdb, 0xef, 0x5f, 0xd4, 0x06,
-	0x8f, 0xa4, 0x8a, 0x0c, 0x0d, 0x6b, 0x48, 0x2c, 0x1b, 0x1b, 0xb6, 0x79, 0xfe, 0x0e, 0x3e, 0x40,
-	0x08, 0xb4, 0xf0, 0xd9, 0xe9, 0xab, 0x1f, 0x5f, 0xf5, 0x89, 0x35, 0x34, 0xfa, 0x2f, 0x5f, 0x41,
-	0x05, 0x1d, 0x82, 0x7d, 0xdb, 0xb4, 0x6c, 0xc2, 0xcd, 0x39, 0xdf, 0xc4, 0xb0, 0xc4, 0x3d, 0xa6,
-	0x6f, 0xc6, 0xe6, 0xa9, 0x4d, 0x76, 0xf8, 0x65, 0xf4, 0x08, 0x1c, 0x9c, 0x4e, 0x27, 0xa3, 0x0b,
-	0x8b, 0xa7, 0x5e, 0xbe, 0xe8, 0x13, 0x9e, 0x56, 0x9f, 0xff, 0xa9, 0x80, 0xda, 0xe6, 0x73, 0xc3,
-	0x8b, 0x2d, 0x6a, 0xb0, 0xb1, 0x69, 0x12, 0xcb, 0x36, 0x6c, 0x13, 0x3e, 0x40, 0x00, 0x54, 0x8c,
-	0x53, 0x7b, 0xf4, 0xd6, 0x84, 0x0a, 0x5f, 0x9f, 0xe1, 0xe9, 0xb5, 0x39, 0x81, 0x25, 0xf4, 0x0c,
-	0x3c, 0x19, 0x98, 0x33, 0x6c, 0x9e, 0x1a, 0xb6, 0x39, 0x20, 0xd6, 0xf4, 0xcc, 0x26, 0x03, 0xf3,
-	0xd2, 0xb4, 0xcd, 0x01, 0x2c, 0x77, 0x4a, 0x9a, 0xb2, 0x43, 0x18, 0x1a, 0x78, 0xb0, 0x21, 0xa8,
-	0x82, 0xd0, 0x00, 0xda, 0x00, 0x1b, 0xa3, 0xc9, 0x68, 0x72, 0x0e, 0xf7, 0x9e, 0x9f, 0x03, 0xad,
-	0xf8, 0x90, 0xf1, 0x82, 0xef, 0xd4, 0x62, 0xbf, 0x9b, 0xf1, 0x52, 0xaa, 0xa0, 0x7c, 0x39, 0x3d,
-	0x87, 0x0a, 0x5f, 0x5c, 0x19, 0x33, 0x58, 0xe2, 0xa7, 0x33, 0xc3, 0xe6, 0x14, 0x0f, 0x4c, 0x6c,
-	0x0e, 0x08, 0x07, 0xcb, 0x6f, 0x86, 0xa0, 0xbd, 0x88, 0x82, 0xe2, 0xdd, 0x71, 0xf7, 0xb7, 0xc3,
-	0x9b, 0xa6, 0x2d, 0xe3, 0x19, 0x0f, 0x67, 0xca, 0x75, 0xc7, 0xf5, 0xd8, 0x6a, 0x3d, 0xef, 0x2d,
-	0xa2, 0xe0, 0x44, 0x7e, 0xdc, 0x0b, 0xc9, 0xbc, 0x22, 0x34, 0xdf, 0xff, 0x1d, 0x00, 0x00, 0xff,
-	0xff, 0x19, 0x4e, 0xf0, 0x51, 0x81, 0x08, 0x00, 0x00,
+	// 1621 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x57, 0xdd, 0x72, 0xe2, 0xc8,
+	0x15, 0x1e, 0x09, 0x01, 0xe2, 0x00, 0xb6, 0xdc, 0x30, 0xb6, 0xcc, 0x26, 0x3b, 0x5e, 0xb2, 0x55,
+	0x71, 0xa6, 0x12, 0x9c, 0x65, 0x63, 0x27, 0xb3, 0x7b, 0x91, 0x92, 0x41, 0x36, 0x06, 0x0c, 0x54,
+	0x4b, 0x99, 0xd4, 0xce, 0x45, 0x54, 0xb2, 0xe9, 0x15, 0x2a, 0x0b, 0xa4, 0x92, 0x1a, 0xd7, 0x68,
+	0x9f, 0x20, 0x55, 0xc9, 0x23, 0xe4, 0x3e, 0xaf, 0x91, 0x87, 0x49, 0xde, 0x23, 0xd5, 0x2d, 0x89,
+	0x3f, 0xcf, 0xac, 0x67, 0x6e, 0xec, 0x3e, 0xdf, 0xf9, 0xce, 0xd7, 0x3f, 0x3a, 0xe7, 0x74, 0x03,
+	0x7b, 0x34, 0x74, 0x3d, 0xcf, 0xb5, 0x17, 0xad, 0x20, 0xf4, 0xa9, 0x8f, 0xe4, 0xcc, 0x6e, 0x34,
+	0xee, 0xc3, 0x38, 0xa0, 0xfe, 0xd9, 0x03, 0x89, 0xa3, 0xe0, 0x2e, 0xfd, 0x97, 0xb0, 0x1a, 0x6a,
+	0xea, 0x8b, 0x5c, 0x27, 0xb8, 0x4b, 0xfe, 0xa6, 0x9e, 0x63, 0xc7, 0xf7, 0x1d, 0x8f, 0x9c, 0x71,
+	0xeb, 0x6e, 0xf9, 0xe3, 0x99, 0xbd, 0x88, 0x53, 0xd7, 0x97, 0xbb, 0xae, 0xe9, 0x32, 0xb4, 0xa9,
+	0xeb, 0xa7, 0x53, 0x37, 0x5e, 0xed, 0xfa, 0xa9, 0x3b, 0x27, 0x11, 0xb5, 0xe7, 0x41, 0x42, 0x68,
+	0xfe, 0x1b, 0x40, 0x32, 0x43, 0x42, 0xd0, 0x11, 0x14, 0x69, 0x48, 0x88, 0xe5, 0x4e, 0x55, 0xe1,
+	0x44, 0x38, 0xcd, 0xe1, 0x02, 0x33, 0x6f, 0xa6, 0xa8, 0x0d, 0xc0, 0x1d, 0x11, 0xb5, 0x29, 0x51,
+	0xc5, 0x13, 0xe1, 0x74, 0xaf, 0x5d, 0x6b, 0xad, 0xb6, 0xc8, 0x82, 0x0d, 0xe6, 0xc2, 0x25, 0x9a,
+	0x0d, 0xd1, 0x19, 0x70, 0xc3, 0xa2, 0x71, 0x40, 0xd4, 0x1c, 0x0f, 0x41, 0xdb, 0x21, 0x66, 0x1c,
+	0x10, 0x2c, 0xd3, 0x74, 0x84, 0xbe, 0x87, 0xea, 0xcc, 0x8e, 0x66, 0x56, 0x44, 0x43, 0x9b, 0x12,
+	0x27, 0x56, 0x25, 0x1e, 0x74, 0xb8, 0x0e, 0xea, 0xd9, 0xd1, 0xcc, 0x48, 0xbd, 0xb8, 0x32, 0xdb,
+	0xb0, 0xd0, 0x00, 0xf6, 0x78, 0xb0, 0xed, 0x39, 0x7e, 0xe8, 0xd2, 0xd9, 0x5c, 0xcd, 0xf3, 0xe8,
+	0xaf, 0x5b, 0xc9, 0x29, 0x76, 0x5d, 0xc7, 0xa5, 0xb6, 0xe7, 0xc5, 0x86, 0xeb, 0x2c, 0xc8, 0x94,
+	0x4b, 0x69, 0x19, 0x17, 0x57, 0x67, 0x9b, 0x26, 0x7a, 0x07, 0xb5, 0xc8, 0x75, 0x16, 0x36, 0x5d,
+	0x86, 0x64, 0x43, 0xb1, 0xc0, 0x15, 0x7f, 0xf3, 0x11, 0x45, 0x23, 0x8b, 0x58, 0xcb, 0xa2, 0xe8,
+	0x09, 0x86, 0xbe, 0x82, 0xca, 0xd4, 0x8d, 0x02, 0xcf, 0x8e, 0xad, 0x85, 0x3d, 0x27, 0xaa, 0x7c,
+	0x22, 0x9c, 0x96, 0x70, 0x39, 0xc5, 0x46, 0xf6, 0x9c, 0xa0, 0x13, 0x28, 0x4f, 0x49, 0x74, 0x1f,
+	0xba, 0x01, 0xfb, 0x8a, 0x6a, 0x29, 0x65, 0xac, 0x21, 0x74, 0x0e, 0xe5, 0x20, 0x74, 0x1f, 0x6d,
+	0x4a, 0xac, 0x07, 0x12, 0xab, 0x95, 0x13, 0xe1, 0xb4, 0xdc, 0xae, 0xb7, 0x92, 0x0f, 0xdd, 0xca,
+	0x3e, 0x74, 0x4b, 0x5b, 0xc4, 0x18, 0x52, 0xe2, 0x80, 0xc4, 0xe8, 0xcf, 0xa0, 0x44, 0xd4, 0x0f,
+	0x6d, 0x87, 0x58, 0x11, 0xa1, 0xd4, 0x5d, 0x38, 0x91, 0x5a, 0xfd, 0x99, 0xd8, 0xfd, 0x94, 0x6d,
+	0xa4, 0x64, 0xf4, 0x7b, 0x80, 0x60, 0x79, 0xe7, 0xb9, 0xf7, 0x7c, 0xda, 0x3d, 0x1e, 0x7a, 0xd0,
+	0x4a, 0x53, 0x78, 0xc2, 0x3d, 0x03, 0x12, 0xe3, 0x52, 0x90, 0x0d, 0x91, 0x0e, 0x07, 0x73, 0xfb,
+	0xbd, 0x15, 0xfa, 0x3e, 0xb5, 0xb2, 0xbc, 0x54, 0xf7, 0x79, 0xe0, 0xf1, 0x93, 0x39, 0xbb, 0x29,
+	0x01, 0xef, 0xcf, 0xed, 0xf7, 0xd8, 0xf7, 0x69, 0x06, 0xa0, 0xef, 0xa1, 0x7c, 0x1f, 0x12, 0xb6,
+	0x5f, 0x96, 0xbc, 0xaa, 0xc2, 0x05, 0x1a, 0x4f, 0x04, 0xcc, 0x2c, 0xb3, 0x31, 0x24, 0x74, 0x06,
+	0xb0, 0xe0, 0x65, 0x30, 0x5d, 0x05, 0x1f, 0x3c, 0x1f, 0x9c, 0xd0, 0x79, 0xb0, 0x0a, 0xc5, 0x29,
+	0xf1, 0x08, 0x25, 0x53, 0xb5, 0x76, 0x22, 0x9c, 0xca, 0x38, 0x33, 0x99, 0x6c, 0x32, 0x4c, 0x64,
+	0xeb, 0xcf, 0xcb, 0x26, 0x74, 0x2e, 0xfb, 0x0a, 0xca, 0x0f, 0x24, 0xb6, 0x1e, 0x49, 0x18, 0xb1,
+	0x13, 0x79, 0xc9, 0xcb, 0x0d, 0x1e, 0x48, 0xfc, 0x36, 0x41, 0xd0, 0x05, 0x54, 0x83, 0x90, 0x3c,
+	0xba, 0xfe, 0x32, 0x62, 0x87, 0x1d, 0xa9, 0x87, 0x27, 0x39, 0x7e, 0xda, 0x5b, 0x25, 0xc4, 0x4e,
+	0xbb, 0x92, 0xf1, 0x06, 0x24, 0x8e, 0xd0, 0x39, 0x94, 0xe6, 0x4b, 0x8f, 0xba, 0x56, 0xe4, 0x3a,
+	0xea, 0x11, 0x5f, 0x93, 0xba, 0x8e, 0xb9, 0x65, 0x2e, 0xc3, 0x75, 0x3a, 0xfe, 0xe2, 0x47, 0xd7,
+	0xc1, 0xf2, 0x3c, 0xb5, 0x51, 0x1b, 0x0a, 0x9e, 0x7d, 0x47, 0xbc, 0x48, 0x55, 0xf9, 0x3c, 0x8d,
+	0xed, 0x79, 0x5a, 0x43, 0xee, 0xd4, 0x17, 0x34, 0x8c, 0x71, 0xca, 0x44, 0x13, 0xa8, 0x47, 0xf7,
+	0x33, 0x32, 0x5d, 0x7a, 0x64, 0x6a, 0xd1, 0xd0, 0x5e, 0x44, 0x2e, 0xff, 0xbc, 0xc7, 0x7c, 0xd6,
+	0x5f, 0xae, 0x15, 0x8c, 0x8c, 0x65, 0xae, 0x48, 0xb8, 0x16, 0x3d, 0x05, 0xd1, 0x00, 0x0e, 0xd6,
+	0x3a, 0xbc, 0xdb, 0x2c, 0x23, 0xb5, 0xc1, 0xe5, 0xbe, 0xdc, 0xe9, 0x1d, 0x2b, 0x9a, 0xc1, 0x59,
+	0x58, 0xa1, 0x3b, 0x48, 0xe3, 0x0d, 0x94, 0x37, 0x56, 0x8d, 0x14, 0xc8, 0xb1, 0xa4, 0x15, 0x78,
+	0x35, 0xb1, 0x21, 0xaa, 0x43, 0xfe, 0xd1, 0xf6, 0x96, 0x49, 0x43, 0x2b, 0xe1, 0xc4, 0xf8, 0x4e,
+	0xfc, 0x93, 0xd0, 0x97, 0x64, 0xa4, 0xd4, 0xfa, 0x92, 0x5c, 0x54, 0xe4, 0xbe, 0x24, 0x83, 0x52,
+	0xee, 0x4b, 0x72, 0x59, 0xa9, 0x34, 0xff, 0x06, 0x7b, 0xdb, 0x27, 0x88, 0x7e, 0x01, 0x25, 0x3a,
+	0x0b, 0x49, 0x34, 0xf3, 0xbd, 0xa4, 0x69, 0xe6, 0xf1, 0x1a, 0x40, 0x2d, 0x28, 0xb2, 0x16, 0x40,
+	0xc2, 0x48, 0x15, 0xf9, 0xb1, 0xd6, 0x77, 0x9a, 0x26, 0x77, 0xe2, 0x8c, 0xd4, 0x5c, 0x02, 0xac,
+	0xe1, 0xdd, 0x2a, 0x17, 0x3e, 0xb1, 0xca, 0xb7, 0x8b, 0x54, 0x7c, 0xbe, 0x48, 0x9b, 0xff, 0x15,
+	0xa0, 0x98, 0x66, 0xd3, 0x6e, 0x62, 0x0a, 0x4f, 0x12, 0xf3, 0xb3, 0xe5, 0x59, 0xa1, 0x84, 0x84,
+	0xba, 0x61, 0x5a, 0x28, 0xb9, 0xe7, 0x0b, 0x25, 0xa1, 0x33, 0x00, 0x35, 0x40, 0x0e, 0x7d, 0x9a,
+	0xf4, 0x0d, 0x76, 0x21, 0x54, 0xf0, 0xca, 0x46, 0xbf, 0x03, 0x94, 0x8d, 0xad, 0x55, 0xab, 0xe5,
+	0x8d, 0xbf, 0x82, 0x0f, 0x32, 0xcf, 0xaa, 0x2f, 0x37, 0xff, 0x2e, 0x40, 0xed, 0x03, 0xa9, 0x88,
+	0x2e, 0xa0, 0x42, 0xed, 0xd0, 0x21, 0x34, 0xbd, 0xdf, 0x84, 0x8f, 0xdf, 0x6f, 0xe5, 0x84, 0xc8,
+	0x0d, 0xf4, 0x06, 0x20, 0xa2, 0x76, 0x48, 0x93, 0x6d, 0x89, 0xcf, 0x6e, 0xab, 0xc4, 0xd9, 0xcc,
+	0x6e, 0xfe, 0x4b, 0x84, 0xfa, 0x87, 0xd2, 0x18, 0x7d, 0x07, 0xf9, 0x60, 0x66, 0x47, 0xd9, 0x22,
+	0xbe, 0xfe, 0xf9, 0xac, 0x6f, 0x4d, 0x18, 0x17, 0x27, 0x21, 0x4f, 0xf6, 0x21, 0x7e, 0xe2, 0x3e,
+	0x76, 0xfa, 0x63, 0xee, 0x73, 0xfa, 0x63, 0xd3, 0x80, 0x3c, 0x5f, 0x04, 0x3a, 0x80, 0xea, 0x5f,
+	0x46, 0x83, 0xd1, 0xf8, 0xaf, 0x23, 0x6b, 0xd2, 0xd3, 0x0c, 0x5d, 0x79, 0x81, 0xca, 0x50, 0x9c,
+	0xe8, 0xa3, 0xee, 0xcd, 0xe8, 0x5a, 0x11, 0x50, 0x05, 0xe4, 0x2e, 0xd6, 0x6e, 0x46, 0xcc, 0x12,
+	0x51, 0x15, 0x4a, 0x9d, 0xf1, 0xed, 0x64, 0xa8, 0x9b, 0x7a, 0x57, 0xc9, 0x71, 0x53, 0x1b, 0x75,
+	0xf4, 0xe1, 0x50, 0xef, 0x2a, 0x52, 0xf3, 0x9f, 0x02, 0xd4, 0x93, 0x6b, 0x95, 0xd7, 0xee, 0x6a,
+	0x66, 0xf4, 0x6b, 0xd8, 0x5f, 0xbd, 0x5e, 0xac, 0x85, 0xbd, 0xf0, 0xa3, 0x34, 0x43, 0xf7, 0x56,
+	0xf0, 0x88, 0xa1, 0xe8, 0x25, 0x14, 0x3c, 0xdf, 0x61, 0x2f, 0x19, 0x91, 0xfb, 0xf3, 0x9e, 0xef,
+	0xdc, 0x4c, 0xd1, 0x1f, 0xa0, 0xb4, 0x4e, 0x94, 0x64, 0xa3, 0x87, 0x1f, 0xbe, 0xcf, 0xf1, 0x9a,
+	0xd8, 0xfc, 0x8f, 0x08, 0xd5, 0x04, 0x1d, 0xfa, 0x0e, 0xbb, 0x97, 0x3e, 0x7d, 0x1d, 0x5f, 0x40,
+	0x89, 0xdf, 0x7d, 0xec, 0x81, 0xa1, 0x8a, 0x59, 0xfe, 0xfa, 0x94, 0xbd, 0x3f, 0x98, 0x33, 0x79,
+	0x56, 0xb9, 0x3f, 0x25, 0xab, 0xc9, 0x25, 0xcf, 0x21, 0xc3, 0xfd, 0x89, 0xa0, 0x5f, 0x41, 0x95,
+	0x3b, 0x59, 0x73, 0xe7, 0xa5, 0x58, 0xe0, 0x84, 0x0a, 0x03, 0x71, 0x8a, 0xa1, 0x63, 0x90, 0x59,
+	0xb5, 0xce, 0xdc, 0x05, 0x55, 0x8b, 0x5c, 0xbd, 0xf8, 0x40, 0xe2, 0x9e, 0xbb, 0xa0, 0xcc, 0xc5,
+	0x4e, 0x80, 0x4d, 0xc6, 0x1f, 0x19, 0x15, 0x5c, 0xf4, 0xd2, 0xd5, 0xff, 0x16, 0x50, 0xe6, 0xda,
+	0xa8, 0x9b, 0x12, 0x27, 0x29, 0x29, 0x69, 0x55, 0x36, 0xe8, 0x8f, 0x00, 0x2b, 0x52, 0xa4, 0x02,
+	0xef, 0x63, 0x47, 0xeb, 0xa4, 0xda, 0x22, 0xe3, 0x0d, 0x6a, 0x5f, 0x92, 0x25, 0x25, 0xdf, 0x97,
+	0xe4, 0xbc, 0x52, 0x68, 0x4e, 0xa0, 0xba, 0xad, 0xfa, 0x15, 0x54, 0x92, 0xae, 0x67, 0xb9, 0x8b,
+	0x29, 0x79, 0x9f, 0xf6, 0xce, 0x72, 0x82, 0xdd, 0x30, 0x88, 0xf5, 0xd6, 0xf5, 0xea, 0x92, 0xb3,
+	0xdb, 0xf8, 0x28, 0x61, 0xf6, 0x4d, 0x6e, 0xed, 0x80, 0xef, 0xea, 0x18, 0xe4, 0xb9, 0x1d, 0x24,
+	0x1b, 0x4e, 0xf6, 0x52, 0x9c, 0xa7, 0xae, 0x2d, 0x25, 0x69, 0x47, 0xa9, 0x2f, 0xc9, 0x82, 0x22,
+	0xf6, 0x25, 0x59, 0x54, 0x72, 0x7d, 0x49, 0xce, 0x29, 0x52, 0xb2, 0xe6, 0xbe, 0x24, 0x17, 0x94,
+	0xe2, 0xea, 0x16, 0x90, 0x95, 0xd2, 0xeb, 0x2e, 0x54, 0xd3, 0x0c, 0xb8, 0xf2, 0xc3, 0xb9, 0x4d,
+	0xd1, 0x17, 0x70, 0x34, 0x1c, 0x5f, 0x5b, 0x78, 0x3c, 0x36, 0xad, 0xab, 0x31, 0xbe, 0xd5, 0x4c,
+	0x2b, 0x2d, 0x02, 0xe5, 0x05, 0x3a, 0x04, 0xb4, 0xeb, 0x7c, 0xfb, 0x8d, 0x22, 0x30, 0x95, 0x74,
+	0xcd, 0x6b, 0x95, 0x5b, 0x6d, 0xf2, 0x71, 0x95, 0x5d, 0x27, 0x57, 0xf9, 0x9f, 0x00, 0x95, 0xcd,
+	0x07, 0x31, 0x3a, 0x86, 0x97, 0x59, 0x01, 0xf6, 0x34, 0xa3, 0x67, 0x19, 0x26, 0xd6, 0x4c, 0xfd,
+	0xfa, 0x07, 0xe5, 0x05, 0x42, 0xb0, 0x87, 0xaf, 0x3a, 0x17, 0x6f, 0x2e, 0xda, 0x96, 0xd1, 0xd3,
+	0xda, 0xe7, 0x17, 0x8a, 0x80, 0x6a, 0xb0, 0x6f, 0xea, 0x86, 0x69, 0x31, 0x71, 0xc6, 0xd7, 0xb1,
+	0x22, 0x32, 0x8d, 0xf1, 0x65, 0x5f, 0xef, 0x98, 0xd6, 0x0e, 0x3f, 0x87, 0x5e, 0xc2, 0x41, 0x67,
+	0x3c, 0xba, 0x19, 0x18, 0x0c, 0x3a, 0xff, 0xa6, 0x6d, 0x31, 0x58, 0x62, 0xcb, 0xdb, 0xa0, 0x66,
+	0x78, 0x1e, 0xd5, 0x41, 0xd9, 0xc0, 0xbf, 0xe5, 0x68, 0x01, 0x1d, 0x41, 0x2d, 0x43, 0x2f, 0x87,
+	0xda, 0x40, 0x6f, 0x5f, 0x72, 0x47, 0x11, 0xa9, 0x50, 0x9f, 0xe0, 0xb1, 0x39, 0xde, 0x9d, 0x57,
+	0x7e, 0xfd, 0x0f, 0x01, 0x4a, 0xab, 0xc6, 0xc5, 0xa6, 0xcb, 0x36, 0x69, 0x62, 0x5d, 0xb7, 0x0c,
+	0x53, 0x33, 0x59, 0xab, 0x01, 0x28, 0x68, 0x1d, 0xf3, 0xe6, 0xad, 0xae, 0x08, 0x6c, 0x7c, 0x85,
+	0xc7, 0xef, 0xf4, 0x91, 0x22, 0xa2, 0x57, 0x70, 0xd4, 0xd5, 0x27, 0x58, 0xef, 0x68, 0xa6, 0xde,
+	0xb5, 0x8c, 0xf1, 0x95, 0x69, 0x75, 0xf5, 0xb4, 0xeb, 0x34, 0x44, 0x59, 0xd8, 0x21, 0xf4, 0x34,
+	0xdc, 0x5d, 0x11, 0x24, 0x4e, 0xd8, 0xec, 0x5b, 0xf9, 0xd7, 0xd7, 0x20, 0x67, 0x3f, 0x5d, 0xd8,
+	0x89, 0x6c, 0xad, 0xc5, 0xfc, 0x61, 0xc2, 0x96, 0x52, 0x84, 0xdc, 0x70, 0xcc, 0x3a, 0x5e, 0x11,
+	0x72, 0xb7, 0xda, 0x44, 0x11, 0xd9, 0xf1, 0x4f, 0xb0, 0x3e, 0xc6, 0x5d, 0x1d, 0xeb, 0x5d, 0x8b,
+	0x39, 0x73, 0x97, 0x3d, 0x38, 0xbe, 0xf7, 0xe7, 0x59, 0x93, 0xdd, 0xfe, 0xb5, 0x78, 0x59, 0x35,
+	0x53, 0x7b, 0xc2, 0xcc, 0x89, 0xf0, 0xae, 0xe1, 0xb8, 0x74, 0xb6, 0xbc, 0x6b, 0xdd, 0xfb, 0xf3,
+	0xb3, 0xf4, 0xe7, 0x5c, 0x16, 0x72, 0x57, 0xe0, 0x31, 0xdf, 0xfe, 0x7f, 0x00, 0xc3, 0x3e, 0xad,
+	0x91, 0x73, 0x0e, 0x00, 0x00,
 }
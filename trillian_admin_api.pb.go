@@ -9,7 +9,10 @@ import fmt "fmt"
 import math "math"
 import keyspb "github.com/google/trillian/crypto/keyspb"
 import _ "google.golang.org/genproto/googleapis/api/annotations"
+import google_protobuf2 "github.com/golang/protobuf/ptypes/any"
+import google_protobuf3 "github.com/golang/protobuf/ptypes/duration"
 import google_protobuf4 "google.golang.org/genproto/protobuf/field_mask"
+import google_protobuf1 "github.com/golang/protobuf/ptypes/timestamp"
 
 import (
 	context "golang.org/x/net/context"
@@ -22,10 +25,24 @@ var _ = fmt.Errorf
 var _ = math.Inf
 
 // ListTrees request.
-// No filters or pagination options are provided.
+// Trees are listed in order of tree ID. All the filters set must match for a
+// tree to be returned.
 type ListTreesRequest struct {
 	// If true, deleted trees are included in the response.
 	ShowDeleted bool `protobuf:"varint,1,opt,name=show_deleted,json=showDeleted" json:"show_deleted,omitempty"`
+	// Maximum number of trees to return. If zero, all matching trees are
+	// returned in a single response.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize" json:"page_size,omitempty"`
+	// Token returned as next_page_token by a previous ListTrees call with the
+	// same filters, to continue the listing from where it stopped.
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken" json:"page_token,omitempty"`
+	// If set, only trees of one of these types are returned.
+	TreeTypes []TreeType `protobuf:"varint,4,rep,packed,name=tree_types,json=treeTypes,enum=trillian.TreeType" json:"tree_types,omitempty"`
+	// If set, only trees in one of these states are returned.
+	TreeStates []TreeState `protobuf:"varint,5,rep,packed,name=tree_states,json=treeStates,enum=trillian.TreeState" json:"tree_states,omitempty"`
+	// If set, only trees that have all of these labels, with the same values,
+	// are returned.
+	LabelSelector map[string]string `protobuf:"bytes,6,rep,name=label_selector,json=labelSelector" json:"label_selector,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 }
 
 func (m *ListTreesRequest) Reset()                    { *m = ListTreesRequest{} }
@@ -40,12 +57,48 @@ func (m *ListTreesRequest) GetShowDeleted() bool {
 	return false
 }
 
+func (m *ListTreesRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListTreesRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *ListTreesRequest) GetTreeTypes() []TreeType {
+	if m != nil {
+		return m.TreeTypes
+	}
+	return nil
+}
+
+func (m *ListTreesRequest) GetTreeStates() []TreeState {
+	if m != nil {
+		return m.TreeStates
+	}
+	return nil
+}
+
+func (m *ListTreesRequest) GetLabelSelector() map[string]string {
+	if m != nil {
+		return m.LabelSelector
+	}
+	return nil
+}
+
 // ListTrees response.
-// No pagination is provided, all trees the requester has access to are
-// returned.
 type ListTreesResponse struct {
 	// Trees matching the list request filters.
 	Tree []*Tree `protobuf:"bytes,1,rep,name=tree" json:"tree,omitempty"`
+	// Token to pass as page_token to get the next page of trees. Empty if there
+	// are no more trees.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken" json:"next_page_token,omitempty"`
 }
 
 func (m *ListTreesResponse) Reset()                    { *m = ListTreesResponse{} }
@@ -60,6 +113,13 @@ func (m *ListTreesResponse) GetTree() []*Tree {
 	return nil
 }
 
+func (m *ListTreesResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
 // GetTree request.
 type GetTreeRequest struct {
 	// ID of the tree to retrieve.
@@ -170,6 +230,294 @@ func (m *UndeleteTreeRequest) GetTreeId() int64 {
 	return 0
 }
 
+// RotateTreeKey request.
+type RotateTreeKeyRequest struct {
+	// ID of the tree whose key is to be rotated.
+	TreeId int64 `protobuf:"varint,1,opt,name=tree_id,json=treeId" json:"tree_id,omitempty"`
+	// The new private key of the tree.
+	PrivateKey *google_protobuf2.Any `protobuf:"bytes,2,opt,name=private_key,json=privateKey" json:"private_key,omitempty"`
+	// Describes how the new private key should be generated.
+	// Only needs to be set if private_key is not set.
+	KeySpec *keyspb.Specification `protobuf:"bytes,3,opt,name=key_spec,json=keySpec" json:"key_spec,omitempty"`
+	// How long roots signed by the replaced key remain valid for, e.g. to allow
+	// for servers that haven't yet picked up the new key.
+	TransitionPeriod *google_protobuf3.Duration `protobuf:"bytes,4,opt,name=transition_period,json=transitionPeriod" json:"transition_period,omitempty"`
+}
+
+func (m *RotateTreeKeyRequest) Reset()                    { *m = RotateTreeKeyRequest{} }
+func (m *RotateTreeKeyRequest) String() string            { return proto.CompactTextString(m) }
+func (*RotateTreeKeyRequest) ProtoMessage()               {}
+func (*RotateTreeKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{7} }
+
+func (m *RotateTreeKeyRequest) GetTreeId() int64 {
+	if m != nil {
+		return m.TreeId
+	}
+	return 0
+}
+
+func (m *RotateTreeKeyRequest) GetPrivateKey() *google_protobuf2.Any {
+	if m != nil {
+		return m.PrivateKey
+	}
+	return nil
+}
+
+func (m *RotateTreeKeyRequest) GetKeySpec() *keyspb.Specification {
+	if m != nil {
+		return m.KeySpec
+	}
+	return nil
+}
+
+func (m *RotateTreeKeyRequest) GetTransitionPeriod() *google_protobuf3.Duration {
+	if m != nil {
+		return m.TransitionPeriod
+	}
+	return nil
+}
+
+// RedactLeaf request.
+type RedactLeafRequest struct {
+	// ID of the log the leaf belongs to.
+	LogId int64 `protobuf:"varint,1,opt,name=log_id,json=logId" json:"log_id,omitempty"`
+	// Index of the leaf to redact.
+	LeafIndex int64 `protobuf:"varint,2,opt,name=leaf_index,json=leafIndex" json:"leaf_index,omitempty"`
+	// Numbers of the fields of the leaf value to redact.
+	RedactedFields []int32 `protobuf:"varint,3,rep,packed,name=redacted_fields,json=redactedFields" json:"redacted_fields,omitempty"`
+	// Whether to also remove the leaf's extra data, which isn't covered by the
+	// leaf hash.
+	ClearExtraData bool `protobuf:"varint,4,opt,name=clear_extra_data,json=clearExtraData" json:"clear_extra_data,omitempty"`
+}
+
+func (m *RedactLeafRequest) Reset()                    { *m = RedactLeafRequest{} }
+func (m *RedactLeafRequest) String() string            { return proto.CompactTextString(m) }
+func (*RedactLeafRequest) ProtoMessage()               {}
+func (*RedactLeafRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{8} }
+
+func (m *RedactLeafRequest) GetLogId() int64 {
+	if m != nil {
+		return m.LogId
+	}
+	return 0
+}
+
+func (m *RedactLeafRequest) GetLeafIndex() int64 {
+	if m != nil {
+		return m.LeafIndex
+	}
+	return 0
+}
+
+func (m *RedactLeafRequest) GetRedactedFields() []int32 {
+	if m != nil {
+		return m.RedactedFields
+	}
+	return nil
+}
+
+func (m *RedactLeafRequest) GetClearExtraData() bool {
+	if m != nil {
+		return m.ClearExtraData
+	}
+	return false
+}
+
+// Record of a change made to a tree through the TrillianAdmin API.
+type TreeAuditEntry struct {
+	// ID of the changed tree.
+	TreeId int64 `protobuf:"varint,1,opt,name=tree_id,json=treeId" json:"tree_id,omitempty"`
+	// Identity of the caller that made the change, as seen by the server: the
+	// common name of its TLS client certificate if it presented one, otherwise its
+	// network address.
+	Principal string `protobuf:"bytes,2,opt,name=principal" json:"principal,omitempty"`
+	// Name of the RPC that made the change, e.g. "UpdateTree".
+	Method string `protobuf:"bytes,3,opt,name=method" json:"method,omitempty"`
+	// The tree before the change, without private keys.
+	// Unset for trees created by the change.
+	Before *Tree `protobuf:"bytes,4,opt,name=before" json:"before,omitempty"`
+	// The tree after the change, without private keys.
+	After *Tree `protobuf:"bytes,5,opt,name=after" json:"after,omitempty"`
+	// Time at which the change was made.
+	Timestamp *google_protobuf1.Timestamp `protobuf:"bytes,6,opt,name=timestamp" json:"timestamp,omitempty"`
+	// The redaction of one of the tree's leaves, for changes made by RedactLeaf.
+	// The tree itself is unchanged by redactions, so before and after are equal.
+	Redaction *RedactLeafRequest `protobuf:"bytes,7,opt,name=redaction" json:"redaction,omitempty"`
+}
+
+func (m *TreeAuditEntry) Reset()                    { *m = TreeAuditEntry{} }
+func (m *TreeAuditEntry) String() string            { return proto.CompactTextString(m) }
+func (*TreeAuditEntry) ProtoMessage()               {}
+func (*TreeAuditEntry) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{9} }
+
+func (m *TreeAuditEntry) GetTreeId() int64 {
+	if m != nil {
+		return m.TreeId
+	}
+	return 0
+}
+
+func (m *TreeAuditEntry) GetPrincipal() string {
+	if m != nil {
+		return m.Principal
+	}
+	return ""
+}
+
+func (m *TreeAuditEntry) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *TreeAuditEntry) GetBefore() *Tree {
+	if m != nil {
+		return m.Before
+	}
+	return nil
+}
+
+func (m *TreeAuditEntry) GetAfter() *Tree {
+	if m != nil {
+		return m.After
+	}
+	return nil
+}
+
+func (m *TreeAuditEntry) GetTimestamp() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+func (m *TreeAuditEntry) GetRedaction() *RedactLeafRequest {
+	if m != nil {
+		return m.Redaction
+	}
+	return nil
+}
+
+// ListTreeAuditEntries request.
+type ListTreeAuditEntriesRequest struct {
+	// ID of the tree whose changes are to be listed.
+	TreeId int64 `protobuf:"varint,1,opt,name=tree_id,json=treeId" json:"tree_id,omitempty"`
+	// Maximum number of entries to return. If zero, or more than 1000, up to
+	// 1000 entries are returned.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize" json:"page_size,omitempty"`
+	// Token returned as next_page_token by a previous ListTreeAuditEntries call
+	// for the same tree, to continue the listing from where it stopped.
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken" json:"page_token,omitempty"`
+}
+
+func (m *ListTreeAuditEntriesRequest) Reset()                    { *m = ListTreeAuditEntriesRequest{} }
+func (m *ListTreeAuditEntriesRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListTreeAuditEntriesRequest) ProtoMessage()               {}
+func (*ListTreeAuditEntriesRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{10} }
+
+func (m *ListTreeAuditEntriesRequest) GetTreeId() int64 {
+	if m != nil {
+		return m.TreeId
+	}
+	return 0
+}
+
+func (m *ListTreeAuditEntriesRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListTreeAuditEntriesRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+// ListTreeAuditEntries response.
+type ListTreeAuditEntriesResponse struct {
+	// Changes made to the tree, oldest first.
+	Entries []*TreeAuditEntry `protobuf:"bytes,1,rep,name=entries" json:"entries,omitempty"`
+	// Token to pass as page_token to get the next page of entries. Empty if
+	// there are no more entries. Pages may hold fewer than page_size entries,
+	// or none, even if more entries follow.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken" json:"next_page_token,omitempty"`
+}
+
+func (m *ListTreeAuditEntriesResponse) Reset()                    { *m = ListTreeAuditEntriesResponse{} }
+func (m *ListTreeAuditEntriesResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListTreeAuditEntriesResponse) ProtoMessage()               {}
+func (*ListTreeAuditEntriesResponse) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{11} }
+
+func (m *ListTreeAuditEntriesResponse) GetEntries() []*TreeAuditEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+func (m *ListTreeAuditEntriesResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+// CloneTree request.
+type CloneTreeRequest struct {
+	// ID of the log to clone.
+	TreeId int64 `protobuf:"varint,1,opt,name=tree_id,json=treeId" json:"tree_id,omitempty"`
+	// Number of leaves to copy into the clone. May not exceed the size of the
+	// log's latest signed root. If zero, the size of that root is used.
+	// A smaller clone is checked against that root with a consistency proof.
+	TreeSize int64 `protobuf:"varint,2,opt,name=tree_size,json=treeSize" json:"tree_size,omitempty"`
+	// Describes how a new private key should be generated for the clone.
+	// If unset, the clone is signed with the same key as the log, and freeze
+	// must be set: an ACTIVE clone signing with the log's key could sign roots
+	// that conflict with the log's.
+	KeySpec *keyspb.Specification `protobuf:"bytes,3,opt,name=key_spec,json=keySpec" json:"key_spec,omitempty"`
+	// Whether to freeze the clone once its leaves are copied. Otherwise the
+	// clone is left ACTIVE, so that further leaves may be added to it, which
+	// requires key_spec.
+	Freeze bool `protobuf:"varint,4,opt,name=freeze" json:"freeze,omitempty"`
+}
+
+func (m *CloneTreeRequest) Reset()                    { *m = CloneTreeRequest{} }
+func (m *CloneTreeRequest) String() string            { return proto.CompactTextString(m) }
+func (*CloneTreeRequest) ProtoMessage()               {}
+func (*CloneTreeRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{12} }
+
+func (m *CloneTreeRequest) GetTreeId() int64 {
+	if m != nil {
+		return m.TreeId
+	}
+	return 0
+}
+
+func (m *CloneTreeRequest) GetTreeSize() int64 {
+	if m != nil {
+		return m.TreeSize
+	}
+	return 0
+}
+
+func (m *CloneTreeRequest) GetKeySpec() *keyspb.Specification {
+	if m != nil {
+		return m.KeySpec
+	}
+	return nil
+}
+
+func (m *CloneTreeRequest) GetFreeze() bool {
+	if m != nil {
+		return m.Freeze
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*ListTreesRequest)(nil), "trillian.ListTreesRequest")
 	proto.RegisterType((*ListTreesResponse)(nil), "trillian.ListTreesResponse")
@@ -178,6 +526,12 @@ func init() {
 	proto.RegisterType((*UpdateTreeRequest)(nil), "trillian.UpdateTreeRequest")
 	proto.RegisterType((*DeleteTreeRequest)(nil), "trillian.DeleteTreeRequest")
 	proto.RegisterType((*UndeleteTreeRequest)(nil), "trillian.UndeleteTreeRequest")
+	proto.RegisterType((*RotateTreeKeyRequest)(nil), "trillian.RotateTreeKeyRequest")
+	proto.RegisterType((*RedactLeafRequest)(nil), "trillian.RedactLeafRequest")
+	proto.RegisterType((*TreeAuditEntry)(nil), "trillian.TreeAuditEntry")
+	proto.RegisterType((*ListTreeAuditEntriesRequest)(nil), "trillian.ListTreeAuditEntriesRequest")
+	proto.RegisterType((*ListTreeAuditEntriesResponse)(nil), "trillian.ListTreeAuditEntriesResponse")
+	proto.RegisterType((*CloneTreeRequest)(nil), "trillian.CloneTreeRequest")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -211,6 +565,26 @@ type TrillianAdminClient interface {
 	// A soft-deleted tree may be undeleted for a certain period, after which
 	// it'll be permanently deleted.
 	UndeleteTree(ctx context.Context, in *UndeleteTreeRequest, opts ...grpc.CallOption) (*Tree, error)
+	// Replaces the signing key of a tree with a new key version.
+	// The replaced key signs a statement naming the new key, so that clients
+	// trusting the replaced key may come to trust the new one.
+	// Not supported by Cloud Spanner storage.
+	RotateTreeKey(ctx context.Context, in *RotateTreeKeyRequest, opts ...grpc.CallOption) (*Tree, error)
+	// Replaces the value of a log leaf with a redacted form that has the same
+	// leaf hash, so that the leaf remains provably included in the log.
+	// Requires a hash strategy that supports redaction.
+	RedactLeaf(ctx context.Context, in *RedactLeafRequest, opts ...grpc.CallOption) (*LogLeaf, error)
+	// Lists the changes made to a tree through this API, as recorded in the
+	// server's audit log.
+	ListTreeAuditEntries(ctx context.Context, in *ListTreeAuditEntriesRequest, opts ...grpc.CallOption) (*ListTreeAuditEntriesResponse, error)
+	// Creates a copy of a log, up to a given size, as a new PREORDERED_LOG tree
+	// in the same storage. The clone has the log's configuration and leaves,
+	// and a root with the same hash as the log's root at that size.
+	// Requires storage that supports PREORDERED_LOG trees.
+	// The leaves are copied within the call, so its deadline must allow for the
+	// size of the clone. Until the copy is complete the clone is FROZEN, and it's
+	// deleted if the copy fails or its root doesn't match the log's.
+	CloneTree(ctx context.Context, in *CloneTreeRequest, opts ...grpc.CallOption) (*Tree, error)
 }
 
 type trillianAdminClient struct {
@@ -275,6 +649,42 @@ func (c *trillianAdminClient) UndeleteTree(ctx context.Context, in *UndeleteTree
 	return out, nil
 }
 
+func (c *trillianAdminClient) RotateTreeKey(ctx context.Context, in *RotateTreeKeyRequest, opts ...grpc.CallOption) (*Tree, error) {
+	out := new(Tree)
+	err := grpc.Invoke(ctx, "/trillian.TrillianAdmin/RotateTreeKey", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trillianAdminClient) RedactLeaf(ctx context.Context, in *RedactLeafRequest, opts ...grpc.CallOption) (*LogLeaf, error) {
+	out := new(LogLeaf)
+	err := grpc.Invoke(ctx, "/trillian.TrillianAdmin/RedactLeaf", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trillianAdminClient) ListTreeAuditEntries(ctx context.Context, in *ListTreeAuditEntriesRequest, opts ...grpc.CallOption) (*ListTreeAuditEntriesResponse, error) {
+	out := new(ListTreeAuditEntriesResponse)
+	err := grpc.Invoke(ctx, "/trillian.TrillianAdmin/ListTreeAuditEntries", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trillianAdminClient) CloneTree(ctx context.Context, in *CloneTreeRequest, opts ...grpc.CallOption) (*Tree, error) {
+	out := new(Tree)
+	err := grpc.Invoke(ctx, "/trillian.TrillianAdmin/CloneTree", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for TrillianAdmin service
 
 type TrillianAdminServer interface {
@@ -298,6 +708,26 @@ type TrillianAdminServer interface {
 	// A soft-deleted tree may be undeleted for a certain period, after which
 	// it'll be permanently deleted.
 	UndeleteTree(context.Context, *UndeleteTreeRequest) (*Tree, error)
+	// Replaces the signing key of a tree with a new key version.
+	// The replaced key signs a statement naming the new key, so that clients
+	// trusting the replaced key may come to trust the new one.
+	// Not supported by Cloud Spanner storage.
+	RotateTreeKey(context.Context, *RotateTreeKeyRequest) (*Tree, error)
+	// Replaces the value of a log leaf with a redacted form that has the same
+	// leaf hash, so that the leaf remains provably included in the log.
+	// Requires a hash strategy that supports redaction.
+	RedactLeaf(context.Context, *RedactLeafRequest) (*LogLeaf, error)
+	// Lists the changes made to a tree through this API, as recorded in the
+	// server's audit log.
+	ListTreeAuditEntries(context.Context, *ListTreeAuditEntriesRequest) (*ListTreeAuditEntriesResponse, error)
+	// Creates a copy of a log, up to a given size, as a new PREORDERED_LOG tree
+	// in the same storage. The clone has the log's configuration and leaves,
+	// and a root with the same hash as the log's root at that size.
+	// Requires storage that supports PREORDERED_LOG trees.
+	// The leaves are copied within the call, so its deadline must allow for the
+	// size of the clone. Until the copy is complete the clone is FROZEN, and it's
+	// deleted if the copy fails or its root doesn't match the log's.
+	CloneTree(context.Context, *CloneTreeRequest) (*Tree, error)
 }
 
 func RegisterTrillianAdminServer(s *grpc.Server, srv TrillianAdminServer) {
@@ -412,6 +842,78 @@ func _TrillianAdmin_UndeleteTree_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TrillianAdmin_RotateTreeKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateTreeKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrillianAdminServer).RotateTreeKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trillian.TrillianAdmin/RotateTreeKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrillianAdminServer).RotateTreeKey(ctx, req.(*RotateTreeKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrillianAdmin_RedactLeaf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedactLeafRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrillianAdminServer).RedactLeaf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trillian.TrillianAdmin/RedactLeaf",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrillianAdminServer).RedactLeaf(ctx, req.(*RedactLeafRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrillianAdmin_ListTreeAuditEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTreeAuditEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrillianAdminServer).ListTreeAuditEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trillian.TrillianAdmin/ListTreeAuditEntries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrillianAdminServer).ListTreeAuditEntries(ctx, req.(*ListTreeAuditEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrillianAdmin_CloneTree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloneTreeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrillianAdminServer).CloneTree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trillian.TrillianAdmin/CloneTree",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrillianAdminServer).CloneTree(ctx, req.(*CloneTreeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _TrillianAdmin_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "trillian.TrillianAdmin",
 	HandlerType: (*TrillianAdminServer)(nil),
@@ -440,6 +942,22 @@ var _TrillianAdmin_serviceDesc = grpc.ServiceDesc{
 			MethodName: "UndeleteTree",
 			Handler:    _TrillianAdmin_UndeleteTree_Handler,
 		},
+		{
+			MethodName: "RotateTreeKey",
+			Handler:    _TrillianAdmin_RotateTreeKey_Handler,
+		},
+		{
+			MethodName: "RedactLeaf",
+			Handler:    _TrillianAdmin_RedactLeaf_Handler,
+		},
+		{
+			MethodName: "ListTreeAuditEntries",
+			Handler:    _TrillianAdmin_ListTreeAuditEntries_Handler,
+		},
+		{
+			MethodName: "CloneTree",
+			Handler:    _TrillianAdmin_CloneTree_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "trillian_admin_api.proto",
@@ -448,40 +966,83 @@ var _TrillianAdmin_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("trillian_admin_api.proto", fileDescriptor2) }
 
 var fileDescriptor2 = []byte{
-	// 548 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x9c, 0x54, 0xe1, 0x6a, 0x13, 0x4d,
-	0x14, 0xfd, 0xb6, 0x2d, 0x6d, 0xbe, 0x9b, 0x1a, 0xcc, 0x94, 0x62, 0xba, 0x56, 0x8c, 0xa3, 0x42,
-	0x8d, 0xb2, 0x6b, 0x23, 0x22, 0x54, 0xfc, 0xd1, 0x2a, 0x15, 0x41, 0x21, 0xac, 0x29, 0x82, 0x20,
-	0xcb, 0x66, 0xf7, 0x26, 0x1d, 0x93, 0xec, 0xac, 0x3b, 0x13, 0x25, 0x88, 0x7f, 0x7c, 0x05, 0x5f,
-	0xc6, 0xf7, 0xf0, 0x15, 0x7c, 0x10, 0x99, 0xd9, 0xd9, 0xee, 0xa6, 0xdb, 0x48, 0xf1, 0xd7, 0xee,
-	0xdc, 0x73, 0xef, 0x3d, 0x77, 0xce, 0xb9, 0x0c, 0xb4, 0x64, 0xca, 0x26, 0x13, 0x16, 0xc4, 0x7e,
-	0x10, 0x4d, 0x59, 0xec, 0x07, 0x09, 0x73, 0x92, 0x94, 0x4b, 0x4e, 0x6a, 0x39, 0x62, 0x37, 0xf2,
-	0xbf, 0x0c, 0xb1, 0xed, 0x30, 0x9d, 0x27, 0x92, 0xbb, 0x63, 0x9c, 0x8b, 0x64, 0x60, 0x3e, 0x06,
-	0xdb, 0x1d, 0x71, 0x3e, 0x9a, 0xa0, 0x1b, 0x24, 0xcc, 0x0d, 0xe2, 0x98, 0xcb, 0x40, 0x32, 0x1e,
-	0x0b, 0x83, 0xb6, 0x0d, 0xaa, 0x4f, 0x83, 0xd9, 0xd0, 0x1d, 0x32, 0x9c, 0x44, 0xfe, 0x34, 0x10,
-	0xe3, 0x2c, 0x83, 0x3e, 0x86, 0xab, 0xaf, 0x99, 0x90, 0xfd, 0x14, 0x51, 0x78, 0xf8, 0x69, 0x86,
-	0x42, 0x92, 0x5b, 0xb0, 0x29, 0x4e, 0xf9, 0x17, 0x3f, 0xc2, 0x09, 0x4a, 0x8c, 0x5a, 0x56, 0xdb,
-	0xda, 0xab, 0x79, 0x75, 0x15, 0x7b, 0x91, 0x85, 0xe8, 0x13, 0x68, 0x96, 0xca, 0x44, 0xc2, 0x63,
-	0x81, 0x84, 0xc2, 0x9a, 0x4c, 0x11, 0x5b, 0x56, 0x7b, 0x75, 0xaf, 0xde, 0x6d, 0x38, 0x67, 0xd7,
-	0x50, 0x69, 0x9e, 0xc6, 0xe8, 0x3d, 0x68, 0xbc, 0x44, 0x5d, 0x97, 0xb3, 0x5d, 0x83, 0x0d, 0x85,
-	0xf8, 0x2c, 0x23, 0x5a, 0xf5, 0xd6, 0xd5, 0xf1, 0x55, 0x44, 0x19, 0x34, 0x9f, 0xa7, 0x18, 0x48,
-	0x2c, 0x67, 0x17, 0x1c, 0xd6, 0x32, 0x0e, 0xf2, 0x10, 0x6a, 0x63, 0x9c, 0xfb, 0x22, 0xc1, 0xb0,
-	0xb5, 0xa2, 0xf3, 0xb6, 0x1d, 0x23, 0xda, 0xdb, 0x04, 0x43, 0x36, 0x64, 0xa1, 0x56, 0xc9, 0xdb,
-	0x18, 0xe3, 0x5c, 0x45, 0xa8, 0x84, 0xe6, 0x49, 0x12, 0xfd, 0x03, 0xd5, 0x53, 0xa8, 0xcf, 0x74,
-	0xa1, 0xd6, 0xd4, 0xb0, 0xd9, 0x4e, 0x26, 0xbb, 0x93, 0xcb, 0xee, 0x1c, 0x2b, 0xd9, 0xdf, 0x04,
-	0x62, 0xec, 0x41, 0x96, 0xae, 0xfe, 0xe9, 0x03, 0x68, 0x66, 0x7a, 0x5e, 0x4a, 0x0e, 0x07, 0xb6,
-	0x4e, 0xe2, 0xe8, 0xd2, 0xf9, 0xdd, 0x9f, 0x6b, 0x70, 0xa5, 0x6f, 0x46, 0x3e, 0x54, 0xbb, 0x46,
-	0x8e, 0xe1, 0xff, 0x33, 0xd3, 0x88, 0x5d, 0xdc, 0xe7, 0xfc, 0x02, 0xd8, 0xd7, 0x2f, 0xc4, 0x32,
-	0x97, 0xe9, 0x7f, 0xe4, 0x1d, 0x6c, 0x18, 0x0f, 0x49, 0xab, 0xc8, 0x5c, 0xb4, 0xd5, 0x3e, 0xa7,
-	0x17, 0xa5, 0xdf, 0x7f, 0xfd, 0xfe, 0xb1, 0xb2, 0x4b, 0x6c, 0xf7, 0xf3, 0xfe, 0x00, 0x65, 0xb0,
-	0xef, 0xaa, 0x39, 0x85, 0xfb, 0xd5, 0x4c, 0xff, 0xac, 0xf3, 0x8d, 0xf4, 0x01, 0x0a, 0xc7, 0x49,
-	0x69, 0x8a, 0xca, 0x1e, 0x54, 0xda, 0xef, 0xe8, 0xf6, 0x5b, 0xb4, 0xb1, 0xd8, 0xfe, 0xc0, 0xea,
-	0x10, 0x04, 0x28, 0xcc, 0x2d, 0x77, 0xad, 0x58, 0x5e, 0xe9, 0xda, 0xd1, 0x5d, 0xef, 0x74, 0x6f,
-	0x5e, 0x34, 0xb4, 0x53, 0x4c, 0xae, 0x68, 0x3e, 0x00, 0x14, 0x6e, 0x96, 0x69, 0x2a, 0x1e, 0x2f,
-	0xd3, 0xa6, 0xf3, 0x37, 0x6d, 0x3e, 0xc2, 0x66, 0xd9, 0x7e, 0x72, 0xa3, 0x74, 0x8f, 0xea, 0x5a,
-	0x54, 0x28, 0xee, 0x6b, 0x8a, 0xbb, 0x9d, 0xdb, 0xcb, 0x29, 0x0e, 0x66, 0xa6, 0xcf, 0x51, 0x0f,
-	0x76, 0x42, 0x3e, 0xcd, 0xb7, 0x78, 0xf1, 0x35, 0x3a, 0xda, 0x5e, 0x58, 0xaa, 0xc3, 0x84, 0xf5,
-	0x54, 0xb8, 0x67, 0xbd, 0xb7, 0x47, 0x4c, 0x9e, 0xce, 0x06, 0x4e, 0xc8, 0xa7, 0xae, 0x79, 0x77,
-	0xf2, 0xd2, 0xc1, 0xba, 0xae, 0x7d, 0xf4, 0x27, 0x00, 0x00, 0xff, 0xff, 0x58, 0xc1, 0xa5, 0x85,
-	0xff, 0x04, 0x00, 0x00,
+	// 1239 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x57, 0x5f, 0x6f, 0xdb, 0x54,
+	0x14, 0xc7, 0x4d, 0x9b, 0x26, 0xa7, 0x6b, 0xd6, 0xdc, 0x75, 0xc3, 0x75, 0xbb, 0x2d, 0x98, 0xfd,
+	0xc9, 0x02, 0xc4, 0xac, 0x30, 0x69, 0x14, 0x4d, 0x5a, 0xf7, 0x0f, 0x4d, 0x1b, 0x52, 0xe5, 0x66,
+	0x42, 0x42, 0x42, 0xd6, 0x4d, 0x7c, 0x92, 0x5d, 0xe2, 0xd8, 0xc6, 0xbe, 0x29, 0x73, 0xa7, 0xf1,
+	0xc0, 0x47, 0x00, 0x89, 0x27, 0x9e, 0x78, 0xe3, 0xf3, 0xf0, 0x05, 0x78, 0xe0, 0x13, 0xf0, 0x09,
+	0xd0, 0xbd, 0xbe, 0x8e, 0x9d, 0x26, 0xe9, 0x0a, 0x3c, 0xd5, 0xf7, 0xfc, 0x3f, 0xbf, 0x7b, 0xee,
+	0xef, 0x34, 0xa0, 0xf3, 0x88, 0x79, 0x1e, 0xa3, 0xbe, 0x43, 0xdd, 0x11, 0xf3, 0x1d, 0x1a, 0xb2,
+	0x76, 0x18, 0x05, 0x3c, 0x20, 0x95, 0x4c, 0x63, 0xd4, 0xb2, 0xaf, 0x54, 0x63, 0x5c, 0x9a, 0xf8,
+	0x78, 0xc1, 0x20, 0xf7, 0x30, 0x8c, 0x5e, 0x94, 0x84, 0x3c, 0xb0, 0x86, 0x98, 0xc4, 0x61, 0x57,
+	0xfd, 0x51, 0xba, 0x9d, 0x41, 0x10, 0x0c, 0x3c, 0xb4, 0x68, 0xc8, 0x2c, 0xea, 0xfb, 0x01, 0xa7,
+	0x9c, 0x05, 0x7e, 0xac, 0xb4, 0x5b, 0x4a, 0x2b, 0x4f, 0xdd, 0x71, 0xdf, 0xa2, 0x7e, 0xa2, 0x54,
+	0x57, 0x4e, 0xaa, 0xdc, 0x71, 0x24, 0x7d, 0x95, 0xbe, 0x71, 0x52, 0xdf, 0x67, 0xe8, 0xb9, 0xce,
+	0x88, 0xc6, 0x43, 0x65, 0x71, 0xf5, 0xa4, 0x05, 0x67, 0x23, 0x8c, 0x39, 0x1d, 0x85, 0xa9, 0x81,
+	0xf9, 0xf7, 0x12, 0x6c, 0x3c, 0x67, 0x31, 0xef, 0x44, 0x88, 0xb1, 0x8d, 0xdf, 0x8d, 0x31, 0xe6,
+	0xe4, 0x3d, 0x38, 0x17, 0xbf, 0x0c, 0xbe, 0x77, 0x5c, 0xf4, 0x90, 0xa3, 0xab, 0x6b, 0x0d, 0xad,
+	0x59, 0xb1, 0xd7, 0x84, 0xec, 0x51, 0x2a, 0x22, 0xdb, 0x50, 0x0d, 0xe9, 0x00, 0x9d, 0x98, 0x1d,
+	0xa3, 0xbe, 0xd4, 0xd0, 0x9a, 0x2b, 0x76, 0x45, 0x08, 0x0e, 0xd9, 0x31, 0x92, 0xcb, 0x00, 0x52,
+	0xc9, 0x83, 0x21, 0xfa, 0x7a, 0xa9, 0xa1, 0x35, 0xab, 0xb6, 0x34, 0xef, 0x08, 0x01, 0xb9, 0x0d,
+	0xc0, 0x23, 0x44, 0x87, 0x27, 0x21, 0xc6, 0xfa, 0x72, 0xa3, 0xd4, 0xac, 0xed, 0x92, 0xf6, 0x04,
+	0x68, 0x51, 0x4a, 0x27, 0x09, 0xd1, 0xae, 0x72, 0xf5, 0x15, 0x93, 0x4f, 0x61, 0x4d, 0xba, 0xc4,
+	0x9c, 0x72, 0x8c, 0xf5, 0x15, 0xe9, 0x73, 0x61, 0xda, 0xe7, 0x50, 0xe8, 0x6c, 0xe0, 0xd9, 0x67,
+	0x4c, 0x3a, 0x50, 0xf3, 0x68, 0x17, 0x3d, 0x27, 0x46, 0x0f, 0x7b, 0x3c, 0x88, 0xf4, 0x72, 0xa3,
+	0xd4, 0x5c, 0xdb, 0xfd, 0x28, 0x77, 0x3c, 0xd9, 0x7b, 0xfb, 0xb9, 0x70, 0x38, 0x54, 0xf6, 0x8f,
+	0x7d, 0x1e, 0x25, 0xf6, 0xba, 0x57, 0x94, 0x19, 0xf7, 0x81, 0xcc, 0x1a, 0x91, 0x0d, 0x28, 0x0d,
+	0x31, 0x91, 0x50, 0x55, 0x6d, 0xf1, 0x49, 0x36, 0x61, 0xe5, 0x88, 0x7a, 0xe3, 0x14, 0x9e, 0xaa,
+	0x9d, 0x1e, 0xf6, 0x96, 0xee, 0x6a, 0xa6, 0x03, 0xf5, 0x42, 0xde, 0x38, 0x0c, 0xfc, 0x18, 0x89,
+	0x09, 0xcb, 0xa2, 0x74, 0x5d, 0x93, 0x25, 0xd6, 0xa6, 0x7b, 0xb3, 0xa5, 0x8e, 0xdc, 0x80, 0xf3,
+	0x3e, 0xbe, 0xe2, 0x4e, 0x01, 0xdd, 0x34, 0xf8, 0xba, 0x10, 0x1f, 0x64, 0x08, 0x9b, 0xb7, 0xa0,
+	0xf6, 0x05, 0xca, 0xf8, 0xd9, 0x95, 0xbe, 0x0b, 0xab, 0x12, 0x40, 0x96, 0xde, 0x66, 0xc9, 0x2e,
+	0x8b, 0xe3, 0x53, 0xd7, 0x64, 0x50, 0x7f, 0x18, 0x21, 0xe5, 0x58, 0xb4, 0xce, 0x6b, 0xd1, 0x16,
+	0xd6, 0xf2, 0x31, 0x54, 0x86, 0x98, 0x38, 0x71, 0x88, 0x3d, 0x59, 0xc4, 0xda, 0xee, 0xc5, 0xb6,
+	0x1a, 0xfb, 0xc3, 0x10, 0x7b, 0xac, 0xcf, 0x7a, 0x72, 0x56, 0xed, 0xd5, 0x21, 0x26, 0x42, 0x62,
+	0x72, 0xa8, 0xbf, 0x08, 0xdd, 0xff, 0x90, 0xea, 0x73, 0x58, 0x1b, 0x4b, 0x47, 0x39, 0xda, 0x2a,
+	0x9b, 0xd1, 0x4e, 0x67, 0xbb, 0x9d, 0xcd, 0x76, 0xfb, 0x89, 0x98, 0xfe, 0x2f, 0x69, 0x3c, 0xb4,
+	0x21, 0x35, 0x17, 0xdf, 0xe6, 0x87, 0x50, 0x4f, 0x87, 0xf6, 0x4c, 0x70, 0xb4, 0xe1, 0xc2, 0x0b,
+	0xdf, 0x3d, 0xbb, 0xfd, 0x9f, 0x1a, 0x6c, 0xda, 0xe2, 0x45, 0x4b, 0xf3, 0x67, 0x98, 0xbc, 0xcd,
+	0x83, 0xdc, 0x81, 0xb5, 0x30, 0x62, 0x47, 0xa2, 0x1b, 0x31, 0x30, 0x69, 0x33, 0x9b, 0x33, 0xcd,
+	0xec, 0xfb, 0x89, 0x0d, 0xca, 0xf0, 0x19, 0x26, 0x53, 0x70, 0x97, 0xce, 0x02, 0x37, 0x79, 0x02,
+	0x75, 0x1e, 0x51, 0x3f, 0x66, 0x42, 0xec, 0x84, 0x18, 0xb1, 0xc0, 0xd5, 0x97, 0xa5, 0xeb, 0xd6,
+	0x4c, 0xba, 0x47, 0x8a, 0x59, 0xec, 0x8d, 0xdc, 0xe7, 0x40, 0xba, 0x98, 0xbf, 0x6a, 0x50, 0xb7,
+	0xd1, 0xa5, 0x3d, 0xfe, 0x1c, 0x69, 0x3f, 0xeb, 0xef, 0x22, 0x94, 0x05, 0x03, 0x4e, 0xda, 0x5b,
+	0xf1, 0x82, 0xc1, 0x53, 0x57, 0x3c, 0x7d, 0x0f, 0x69, 0xdf, 0x61, 0xbe, 0x8b, 0xaf, 0x64, 0x73,
+	0x25, 0xbb, 0x2a, 0x24, 0x4f, 0x85, 0x80, 0xdc, 0x84, 0xf3, 0x91, 0x0c, 0x85, 0xae, 0x23, 0xc9,
+	0x2a, 0xd6, 0x4b, 0x8d, 0x52, 0x73, 0xc5, 0xae, 0x65, 0x62, 0x79, 0x89, 0x31, 0x69, 0xc2, 0x46,
+	0xcf, 0x43, 0x1a, 0x39, 0xf8, 0x8a, 0x47, 0xd4, 0x71, 0x29, 0xa7, 0xb2, 0xf6, 0x8a, 0x5d, 0x93,
+	0xf2, 0xc7, 0x42, 0xfc, 0x88, 0x72, 0x6a, 0xfe, 0xb6, 0x04, 0x35, 0x81, 0xfd, 0xfe, 0xd8, 0x65,
+	0x3c, 0x7d, 0x8b, 0x0b, 0xb1, 0xdf, 0x81, 0x6a, 0x18, 0x31, 0xbf, 0xc7, 0x42, 0xea, 0xa9, 0x97,
+	0x93, 0x0b, 0xc8, 0x25, 0x28, 0x8f, 0x90, 0xbf, 0x0c, 0x5c, 0x45, 0x59, 0xea, 0x44, 0x6e, 0x40,
+	0xb9, 0x8b, 0xfd, 0x20, 0x42, 0x7d, 0x79, 0xee, 0x90, 0x2a, 0x2d, 0xb9, 0x06, 0x2b, 0xb4, 0xcf,
+	0x31, 0xd2, 0x57, 0xe6, 0x9a, 0xa5, 0x4a, 0x72, 0x17, 0xaa, 0x13, 0x12, 0xd6, 0xcb, 0x0b, 0x46,
+	0xb9, 0x93, 0x59, 0xd8, 0xb9, 0x31, 0xf9, 0x0c, 0xaa, 0x29, 0x4a, 0x2c, 0xf0, 0xf5, 0x55, 0xe9,
+	0xb9, 0x9d, 0xe7, 0x98, 0xb9, 0x22, 0x3b, 0xb7, 0x36, 0x23, 0xd8, 0xce, 0x18, 0x67, 0x82, 0x13,
+	0xcb, 0x09, 0x7f, 0x21, 0x60, 0xff, 0x83, 0xe6, 0xcd, 0x63, 0xd8, 0x99, 0x9f, 0x53, 0x11, 0xde,
+	0x2e, 0xac, 0x62, 0x2a, 0x52, 0x9c, 0xa7, 0x4f, 0x03, 0x96, 0x5f, 0xa8, 0x9d, 0x19, 0x9e, 0x99,
+	0x00, 0x7f, 0xd2, 0x60, 0xe3, 0xa1, 0x17, 0xf8, 0x67, 0x7a, 0xc4, 0xa2, 0x4b, 0xa9, 0x98, 0x74,
+	0x59, 0xb2, 0x2b, 0x72, 0x8d, 0x88, 0x2e, 0xff, 0xfd, 0xc3, 0xbb, 0x04, 0xe5, 0x7e, 0x84, 0x78,
+	0x8c, 0x6a, 0x62, 0xd5, 0x69, 0xf7, 0xf7, 0x0a, 0xac, 0x77, 0x54, 0x87, 0xfb, 0xe2, 0x3f, 0x0e,
+	0xf2, 0x04, 0xaa, 0x93, 0x45, 0x40, 0x8c, 0xc5, 0x5b, 0xc9, 0xd8, 0x9e, 0xab, 0x4b, 0x81, 0x34,
+	0xdf, 0x21, 0x5f, 0xc1, 0xaa, 0xe2, 0x7b, 0x52, 0x00, 0x71, 0x7a, 0x05, 0x18, 0x27, 0xe6, 0xd1,
+	0x34, 0x7f, 0xfc, 0xe3, 0xaf, 0x9f, 0x97, 0x76, 0x88, 0x61, 0x1d, 0xdd, 0xee, 0x22, 0xa7, 0xb7,
+	0x2d, 0xd1, 0x73, 0x6c, 0xbd, 0x56, 0x20, 0xdd, 0x6b, 0xbd, 0x21, 0x1d, 0x80, 0x7c, 0x3b, 0x90,
+	0x42, 0x15, 0x33, 0x3b, 0x63, 0x26, 0xfc, 0x96, 0x0c, 0x7f, 0xc1, 0xac, 0x4d, 0x87, 0xdf, 0xd3,
+	0x5a, 0x04, 0x01, 0xf2, 0x45, 0x50, 0x8c, 0x3a, 0xb3, 0x1e, 0x66, 0xa2, 0xb6, 0x64, 0xd4, 0x6b,
+	0xbb, 0x57, 0xe7, 0x15, 0xdd, 0xce, 0x2b, 0x17, 0x69, 0xbe, 0x01, 0xc8, 0x99, 0xbf, 0x98, 0x66,
+	0x66, 0x1f, 0x2c, 0xc2, 0xa6, 0x75, 0x1a, 0x36, 0xdf, 0xc2, 0xb9, 0xe2, 0xaa, 0x20, 0x97, 0x0b,
+	0x7d, 0xf8, 0xee, 0x5b, 0x53, 0x7c, 0x20, 0x53, 0x5c, 0x6f, 0xbd, 0xbf, 0x38, 0xc5, 0xde, 0x58,
+	0xc5, 0x21, 0x21, 0xac, 0x4f, 0x6d, 0x19, 0x72, 0xa5, 0xf0, 0xf0, 0xe7, 0xac, 0x9f, 0x99, 0x6c,
+	0x96, 0xcc, 0x76, 0xcb, 0xbc, 0x76, 0x4a, 0xb6, 0x48, 0x06, 0x7a, 0x86, 0x89, 0x00, 0xef, 0x07,
+	0x80, 0x9c, 0x51, 0xc8, 0x69, 0x3c, 0x63, 0xd4, 0x0b, 0xc3, 0x19, 0x0c, 0x84, 0xc6, 0xbc, 0x2f,
+	0xd3, 0xed, 0x99, 0x77, 0x26, 0xe9, 0xbc, 0x60, 0x10, 0x5b, 0xaf, 0xd3, 0x95, 0x71, 0xaf, 0xf5,
+	0xc6, 0xf2, 0x90, 0x1e, 0x89, 0xfc, 0xf9, 0xb6, 0x90, 0x25, 0xc8, 0xe0, 0x22, 0xff, 0x2f, 0x1a,
+	0x6c, 0xce, 0xa3, 0x0f, 0x72, 0x7d, 0xf6, 0x29, 0xcc, 0xa1, 0x34, 0xe3, 0xc6, 0xdb, 0xcc, 0xd4,
+	0xe3, 0x51, 0xc0, 0x90, 0x9b, 0x8b, 0x81, 0xb1, 0x68, 0x31, 0xbf, 0x0b, 0xd5, 0x09, 0xb3, 0x14,
+	0xdf, 0xec, 0x49, 0xba, 0x59, 0x74, 0xe1, 0x66, 0xe3, 0x94, 0x2b, 0xe8, 0x89, 0x20, 0x7b, 0x5a,
+	0xeb, 0xc1, 0x01, 0x6c, 0xf5, 0x82, 0x51, 0xb6, 0x17, 0xa6, 0x7f, 0x84, 0x3c, 0xb8, 0x38, 0xc5,
+	0x22, 0xfb, 0x21, 0x3b, 0x10, 0xe2, 0x03, 0xed, 0x6b, 0x63, 0xc0, 0xf8, 0xcb, 0x71, 0xb7, 0xdd,
+	0x0b, 0x46, 0x56, 0xea, 0x6a, 0x65, 0xae, 0xdd, 0xb2, 0xf4, 0xfd, 0xe4, 0x9f, 0x01, 0x00, 0x20,
+	0xb1, 0xab, 0xb0, 0xf6, 0x0c, 0x00, 0x00,
 }
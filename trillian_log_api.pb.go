@@ -55,6 +55,7 @@ It has these top-level messages:
 	GetSignedMapRootResponse
 	InitMapRequest
 	InitMapResponse
+	MapMultiInclusionProof
 	ListTreesRequest
 	ListTreesResponse
 	GetTreeRequest
@@ -62,9 +63,21 @@ It has these top-level messages:
 	UpdateTreeRequest
 	DeleteTreeRequest
 	UndeleteTreeRequest
+	RotateTreeKeyRequest
+	RedactLeafRequest
+	TreeAuditEntry
+	ListTreeAuditEntriesRequest
+	ListTreeAuditEntriesResponse
+	CloneTreeRequest
 	Tree
+	MultiSigConfig
+	TreeSigner
+	TreeKey
+	ScheduledTransition
+	TreeTransitionStatus
 	SignedEntryTimestamp
 	SignedLogRoot
+	RootSignature
 	SignedMapRoot
 */
 package trillian
@@ -19,6 +19,39 @@ var _ = proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
 
+// MapProofFormat specifies how inclusion proofs are encoded in
+// MapLeafInclusion messages.
+type MapProofFormat int32
+
+const (
+	// Every proof holds one entry per tree level, with empty entries for
+	// default (empty) subtrees.
+	MapProofFormat_MAP_PROOF_FORMAT_FULL MapProofFormat = 0
+	// Proofs hold only the non-empty entries, and a bitmap recording which
+	// tree levels they belong to.
+	MapProofFormat_MAP_PROOF_FORMAT_COMPRESSED MapProofFormat = 1
+	// A single proof covering all requested indexes is returned in
+	// GetMapLeavesResponse.multi_inclusion, and MapLeafInclusion messages hold
+	// no proof entries.
+	MapProofFormat_MAP_PROOF_FORMAT_MULTI MapProofFormat = 2
+)
+
+var MapProofFormat_name = map[int32]string{
+	0: "MAP_PROOF_FORMAT_FULL",
+	1: "MAP_PROOF_FORMAT_COMPRESSED",
+	2: "MAP_PROOF_FORMAT_MULTI",
+}
+var MapProofFormat_value = map[string]int32{
+	"MAP_PROOF_FORMAT_FULL":       0,
+	"MAP_PROOF_FORMAT_COMPRESSED": 1,
+	"MAP_PROOF_FORMAT_MULTI":      2,
+}
+
+func (x MapProofFormat) String() string {
+	return proto.EnumName(MapProofFormat_name, int32(x))
+}
+func (MapProofFormat) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{0} }
+
 // MapLeaf represents the data behind Map leaves.
 type MapLeaf struct {
 	// index is the location of this leaf.
@@ -67,8 +100,14 @@ func (m *MapLeaf) GetExtraData() []byte {
 }
 
 type MapLeafInclusion struct {
-	Leaf      *MapLeaf `protobuf:"bytes,1,opt,name=leaf" json:"leaf,omitempty"`
+	Leaf *MapLeaf `protobuf:"bytes,1,opt,name=leaf" json:"leaf,omitempty"`
+	// inclusion holds the proof entries, ordered from the leaf level upwards.
+	// For MAP_PROOF_FORMAT_COMPRESSED proofs, only non-empty entries are present.
 	Inclusion [][]byte `protobuf:"bytes,2,rep,name=inclusion,proto3" json:"inclusion,omitempty"`
+	// inclusion_bitmap is set for MAP_PROOF_FORMAT_COMPRESSED proofs only.
+	// Bit i (byte i/8, mask 1<<(i%8)) is set iff the proof entry at level i is
+	// non-empty.
+	InclusionBitmap []byte `protobuf:"bytes,3,opt,name=inclusion_bitmap,json=inclusionBitmap,proto3" json:"inclusion_bitmap,omitempty"`
 }
 
 func (m *MapLeafInclusion) Reset()                    { *m = MapLeafInclusion{} }
@@ -90,9 +129,18 @@ func (m *MapLeafInclusion) GetInclusion() [][]byte {
 	return nil
 }
 
+func (m *MapLeafInclusion) GetInclusionBitmap() []byte {
+	if m != nil {
+		return m.InclusionBitmap
+	}
+	return nil
+}
+
 type GetMapLeavesRequest struct {
 	MapId int64    `protobuf:"varint,1,opt,name=map_id,json=mapId" json:"map_id,omitempty"`
 	Index [][]byte `protobuf:"bytes,2,rep,name=index,proto3" json:"index,omitempty"`
+	// proof_format selects the encoding of the returned inclusion proofs.
+	ProofFormat MapProofFormat `protobuf:"varint,4,opt,name=proof_format,json=proofFormat,enum=trillian.MapProofFormat" json:"proof_format,omitempty"`
 }
 
 func (m *GetMapLeavesRequest) Reset()                    { *m = GetMapLeavesRequest{} }
@@ -114,6 +162,13 @@ func (m *GetMapLeavesRequest) GetIndex() [][]byte {
 	return nil
 }
 
+func (m *GetMapLeavesRequest) GetProofFormat() MapProofFormat {
+	if m != nil {
+		return m.ProofFormat
+	}
+	return MapProofFormat_MAP_PROOF_FORMAT_FULL
+}
+
 // This message replaces the current implementation of GetMapLeavesRequest
 // with the difference that revision must be >=0.
 type GetMapLeavesByRevisionRequest struct {
@@ -121,6 +176,8 @@ type GetMapLeavesByRevisionRequest struct {
 	Index [][]byte `protobuf:"bytes,2,rep,name=index,proto3" json:"index,omitempty"`
 	// revision >= 0.
 	Revision int64 `protobuf:"varint,3,opt,name=revision" json:"revision,omitempty"`
+	// proof_format selects the encoding of the returned inclusion proofs.
+	ProofFormat MapProofFormat `protobuf:"varint,4,opt,name=proof_format,json=proofFormat,enum=trillian.MapProofFormat" json:"proof_format,omitempty"`
 }
 
 func (m *GetMapLeavesByRevisionRequest) Reset()                    { *m = GetMapLeavesByRevisionRequest{} }
@@ -149,9 +206,18 @@ func (m *GetMapLeavesByRevisionRequest) GetRevision() int64 {
 	return 0
 }
 
+func (m *GetMapLeavesByRevisionRequest) GetProofFormat() MapProofFormat {
+	if m != nil {
+		return m.ProofFormat
+	}
+	return MapProofFormat_MAP_PROOF_FORMAT_FULL
+}
+
 type GetMapLeavesResponse struct {
 	MapLeafInclusion []*MapLeafInclusion `protobuf:"bytes,2,rep,name=map_leaf_inclusion,json=mapLeafInclusion" json:"map_leaf_inclusion,omitempty"`
 	MapRoot          *SignedMapRoot      `protobuf:"bytes,3,opt,name=map_root,json=mapRoot" json:"map_root,omitempty"`
+	// multi_inclusion is set for MAP_PROOF_FORMAT_MULTI requests only.
+	MultiInclusion *MapMultiInclusionProof `protobuf:"bytes,4,opt,name=multi_inclusion,json=multiInclusion" json:"multi_inclusion,omitempty"`
 }
 
 func (m *GetMapLeavesResponse) Reset()                    { *m = GetMapLeavesResponse{} }
@@ -173,6 +239,13 @@ func (m *GetMapLeavesResponse) GetMapRoot() *SignedMapRoot {
 	return nil
 }
 
+func (m *GetMapLeavesResponse) GetMultiInclusion() *MapMultiInclusionProof {
+	if m != nil {
+		return m.MultiInclusion
+	}
+	return nil
+}
+
 type SetMapLeavesRequest struct {
 	MapId    int64      `protobuf:"varint,1,opt,name=map_id,json=mapId" json:"map_id,omitempty"`
 	Leaves   []*MapLeaf `protobuf:"bytes,2,rep,name=leaves" json:"leaves,omitempty"`
@@ -311,6 +384,36 @@ func (m *InitMapResponse) GetCreated() *SignedMapRoot {
 	return nil
 }
 
+// MapMultiInclusionProof proves the inclusion of several map leaves at once,
+// holding each sibling node shared by their paths only once.
+// See merkle.VerifyMapMultiInclusionProof for the order of the entries.
+type MapMultiInclusionProof struct {
+	// bitmap records which proof entries are non-empty, using the same encoding
+	// as MapLeafInclusion.inclusion_bitmap.
+	Bitmap []byte `protobuf:"bytes,1,opt,name=bitmap,proto3" json:"bitmap,omitempty"`
+	// hashes holds the non-empty proof entries.
+	Hashes [][]byte `protobuf:"bytes,2,rep,name=hashes,proto3" json:"hashes,omitempty"`
+}
+
+func (m *MapMultiInclusionProof) Reset()                    { *m = MapMultiInclusionProof{} }
+func (m *MapMultiInclusionProof) String() string            { return proto.CompactTextString(m) }
+func (*MapMultiInclusionProof) ProtoMessage()               {}
+func (*MapMultiInclusionProof) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{12} }
+
+func (m *MapMultiInclusionProof) GetBitmap() []byte {
+	if m != nil {
+		return m.Bitmap
+	}
+	return nil
+}
+
+func (m *MapMultiInclusionProof) GetHashes() [][]byte {
+	if m != nil {
+		return m.Hashes
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*MapLeaf)(nil), "trillian.MapLeaf")
 	proto.RegisterType((*MapLeafInclusion)(nil), "trillian.MapLeafInclusion")
@@ -324,6 +427,8 @@ func init() {
 	proto.RegisterType((*GetSignedMapRootResponse)(nil), "trillian.GetSignedMapRootResponse")
 	proto.RegisterType((*InitMapRequest)(nil), "trillian.InitMapRequest")
 	proto.RegisterType((*InitMapResponse)(nil), "trillian.InitMapResponse")
+	proto.RegisterType((*MapMultiInclusionProof)(nil), "trillian.MapMultiInclusionProof")
+	proto.RegisterEnum("trillian.MapProofFormat", MapProofFormat_name, MapProofFormat_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -570,49 +675,59 @@ var _TrillianMap_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("trillian_map_api.proto", fileDescriptor1) }
 
 var fileDescriptor1 = []byte{
-	// 693 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xa4, 0x55, 0xdd, 0x4e, 0xdb, 0x4c,
-	0x10, 0xfd, 0xf2, 0x47, 0x92, 0xc9, 0x27, 0x9a, 0x2e, 0xb4, 0x18, 0x43, 0x2a, 0x30, 0x42, 0x14,
-	0x21, 0xc5, 0x25, 0xbd, 0xe3, 0xae, 0x08, 0x89, 0x1f, 0x01, 0x42, 0x4e, 0x45, 0xa5, 0xde, 0xa4,
-	0x43, 0xb2, 0x24, 0x2b, 0xd9, 0x5e, 0x37, 0xde, 0x44, 0xb4, 0x08, 0x55, 0xea, 0x45, 0x5f, 0xa0,
-	0xbd, 0xea, 0x45, 0x5f, 0xaa, 0xaf, 0xd0, 0x07, 0xa9, 0x76, 0xd7, 0xf9, 0x77, 0xd2, 0xa8, 0xbd,
-	0xf3, 0xee, 0x99, 0x99, 0x73, 0xe6, 0xcc, 0xac, 0x0c, 0x4f, 0x45, 0x9b, 0xb9, 0x2e, 0x43, 0xbf,
-	0xe6, 0x61, 0x50, 0xc3, 0x80, 0x95, 0x83, 0x36, 0x17, 0x9c, 0xe4, 0x7a, 0xf7, 0xe6, 0x62, 0xef,
-	0x4b, 0x23, 0xe6, 0x7a, 0x93, 0xf3, 0xa6, 0x4b, 0x6d, 0x0c, 0x98, 0x8d, 0xbe, 0xcf, 0x05, 0x0a,
-	0xc6, 0xfd, 0x50, 0xa3, 0xd6, 0x47, 0xc8, 0x5e, 0x60, 0x70, 0x4e, 0xf1, 0x96, 0x2c, 0x43, 0x86,
-	0xf9, 0x0d, 0x7a, 0x67, 0x24, 0x36, 0x12, 0xcf, 0xff, 0x77, 0xf4, 0x81, 0xac, 0x41, 0xde, 0xa5,
-	0x78, 0x5b, 0x6b, 0x61, 0xd8, 0x32, 0x92, 0x0a, 0xc9, 0xc9, 0x8b, 0x13, 0x0c, 0x5b, 0xa4, 0x04,
-	0xa0, 0xc0, 0x2e, 0xba, 0x1d, 0x6a, 0xa4, 0x14, 0xaa, 0xc2, 0xaf, 0xe5, 0x85, 0x84, 0xe9, 0x9d,
-	0x68, 0x63, 0xad, 0x81, 0x02, 0x8d, 0xb4, 0x86, 0xd5, 0xcd, 0x11, 0x0a, 0xb4, 0xde, 0x40, 0x31,
-	0xe2, 0x3e, 0xf5, 0xeb, 0x6e, 0x27, 0x64, 0xdc, 0x27, 0xdb, 0x90, 0x96, 0xf9, 0x4a, 0x43, 0xa1,
-	0xf2, 0xb8, 0xdc, 0x6f, 0x26, 0x8a, 0x74, 0x14, 0x4c, 0xd6, 0x21, 0xcf, 0x7a, 0x39, 0x46, 0x72,
-	0x23, 0x25, 0x0b, 0xf7, 0x2f, 0xac, 0x13, 0x58, 0x3a, 0xa6, 0x42, 0x67, 0x74, 0x69, 0xe8, 0xd0,
-	0xf7, 0x1d, 0x1a, 0x0a, 0xf2, 0x04, 0x16, 0xa4, 0x69, 0xac, 0xa1, 0xaa, 0xa7, 0x9c, 0x8c, 0x87,
-	0xc1, 0x69, 0x63, 0xd0, 0xb7, 0xae, 0xa3, 0x0f, 0x67, 0xe9, 0x5c, 0xaa, 0x98, 0xb6, 0x5a, 0x50,
-	0x1a, 0xae, 0x74, 0xf8, 0xc1, 0xa1, 0x5d, 0x26, 0x39, 0xfe, 0xa6, 0x26, 0x31, 0x21, 0xd7, 0x8e,
-	0xf2, 0x95, 0x59, 0x29, 0xa7, 0x7f, 0xb6, 0xbe, 0x25, 0x60, 0x79, 0x54, 0x74, 0x18, 0x70, 0x3f,
-	0xa4, 0xe4, 0x04, 0x88, 0x64, 0x50, 0x3e, 0x8f, 0xf6, 0x5c, 0xa8, 0x98, 0x13, 0xfe, 0xf4, 0x9d,
-	0x74, 0x8a, 0xde, 0xb8, 0xb7, 0x15, 0xc8, 0xc9, 0x4a, 0x6d, 0xce, 0x85, 0xa2, 0x2f, 0x54, 0x56,
-	0x06, 0xf9, 0x55, 0xd6, 0xf4, 0x69, 0xe3, 0x02, 0x03, 0x87, 0x73, 0xe1, 0x64, 0x3d, 0xfd, 0x61,
-	0x7d, 0x82, 0xa5, 0xea, 0xfc, 0x56, 0xee, 0xc2, 0x82, 0xab, 0xe2, 0x22, 0x7d, 0x31, 0xf3, 0x8b,
-	0x02, 0xa4, 0x17, 0x1e, 0x15, 0xa8, 0x36, 0x23, 0xa3, 0xd7, 0xaa, 0x77, 0xd6, 0xde, 0x9f, 0xa5,
-	0x73, 0xe9, 0x62, 0xc6, 0x3a, 0x83, 0xe5, 0x6a, 0x9c, 0x2d, 0xc3, 0xcd, 0x24, 0xe7, 0x6c, 0xe6,
-	0x05, 0xac, 0x1c, 0x53, 0x31, 0x0a, 0xce, 0x6c, 0xc8, 0xba, 0x86, 0xcd, 0xf1, 0x8c, 0xb9, 0x77,
-	0x60, 0x78, 0xda, 0xc9, 0xb1, 0x69, 0x5f, 0x82, 0x31, 0xa9, 0xe4, 0x1f, 0x3a, 0xdb, 0x81, 0xc5,
-	0x53, 0x9f, 0x49, 0x9b, 0xfe, 0xd0, 0xd0, 0x11, 0x3c, 0xea, 0x07, 0x46, 0x7c, 0xfb, 0x90, 0xad,
-	0xb7, 0x29, 0x0a, 0xda, 0x88, 0x5e, 0xdd, 0x74, 0xba, 0x28, 0xae, 0xf2, 0x3d, 0x03, 0x85, 0xd7,
-	0x51, 0xcc, 0x05, 0x06, 0xe4, 0x1c, 0xf2, 0xc7, 0x54, 0xe8, 0x09, 0x91, 0xd2, 0x20, 0x3d, 0xe6,
-	0x15, 0x9a, 0xcf, 0xa6, 0xc1, 0x5a, 0x8e, 0xf5, 0x1f, 0x79, 0xa7, 0x9e, 0xef, 0xf8, 0x8b, 0x23,
-	0x3b, 0xf1, 0x89, 0x13, 0xf3, 0x98, 0x83, 0xe1, 0x1c, 0xf2, 0xd5, 0x38, 0xbd, 0xd5, 0xd9, 0x7a,
-	0xab, 0xf1, 0xd5, 0xbe, 0x24, 0xa0, 0x38, 0x3e, 0x4d, 0xb2, 0x39, 0x22, 0x22, 0x6e, 0xe7, 0x4c,
-	0x6b, 0x56, 0x48, 0x54, 0x7d, 0xef, 0xf3, 0xcf, 0x5f, 0x5f, 0x93, 0xdb, 0x64, 0xcb, 0xee, 0xee,
-	0xdf, 0x50, 0x81, 0xfb, 0xb6, 0x87, 0x41, 0x68, 0xdf, 0xeb, 0xd9, 0x3e, 0xd8, 0x72, 0x4b, 0xc2,
-	0x03, 0x17, 0x85, 0x9c, 0xf9, 0x8f, 0x04, 0x98, 0xd3, 0xd7, 0x95, 0xec, 0x4d, 0xe7, 0x9b, 0x34,
-	0x71, 0x1e, 0x71, 0xb6, 0x12, 0xb7, 0x4b, 0x76, 0x66, 0x89, 0xb3, 0xef, 0x7b, 0x5b, 0xff, 0x40,
-	0xea, 0x90, 0x8d, 0xb6, 0x8f, 0x18, 0x83, 0xfa, 0xa3, 0x9b, 0x6b, 0xae, 0xc6, 0x20, 0x11, 0xe1,
-	0x96, 0x22, 0x2c, 0x59, 0x6b, 0xf1, 0x84, 0x07, 0xcc, 0x67, 0xe2, 0xf0, 0x12, 0x56, 0xeb, 0xdc,
-	0x2b, 0xeb, 0xdf, 0x5e, 0x79, 0xf4, 0x6f, 0x78, 0xb8, 0x34, 0xb4, 0xb6, 0xaf, 0x02, 0x76, 0x25,
-	0x2f, 0xaf, 0x12, 0x6f, 0xcd, 0x26, 0x13, 0xad, 0xce, 0x4d, 0xb9, 0xce, 0x3d, 0x3b, 0xfa, 0x5f,
-	0xf6, 0x12, 0x6f, 0x16, 0x54, 0xe6, 0xcb, 0xdf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x2d, 0xb0, 0x61,
-	0x82, 0x7b, 0x07, 0x00, 0x00,
+	// 863 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0xcd, 0x6e, 0xdb, 0x46,
+	0x10, 0x0e, 0xf5, 0x63, 0xcb, 0x23, 0x43, 0x66, 0xd7, 0x8e, 0x43, 0xd3, 0x71, 0xeb, 0x30, 0x08,
+	0x5c, 0x37, 0x80, 0x58, 0xab, 0xb7, 0xf4, 0x64, 0xd7, 0xb1, 0x2d, 0x43, 0x8c, 0x05, 0xd2, 0xc9,
+	0xa1, 0x17, 0x76, 0x2d, 0xad, 0xad, 0x05, 0x48, 0x2e, 0x4b, 0xae, 0x8c, 0xb4, 0x41, 0x50, 0x20,
+	0x40, 0xfb, 0x02, 0xbd, 0xf5, 0xd0, 0x5b, 0x9f, 0xa8, 0x0f, 0xd0, 0x4b, 0x1f, 0xa4, 0xd8, 0xe5,
+	0x8a, 0x16, 0x2d, 0xda, 0x11, 0x9a, 0x9b, 0x66, 0xbe, 0x99, 0x9d, 0x6f, 0x66, 0xbf, 0x59, 0x11,
+	0xd6, 0x79, 0x42, 0x83, 0x80, 0xe2, 0xc8, 0x0f, 0x71, 0xec, 0xe3, 0x98, 0xb6, 0xe3, 0x84, 0x71,
+	0x86, 0x1a, 0x13, 0xbf, 0xd9, 0x9a, 0xfc, 0xca, 0x10, 0xf3, 0xf1, 0x15, 0x63, 0x57, 0x01, 0xb1,
+	0x71, 0x4c, 0x6d, 0x1c, 0x45, 0x8c, 0x63, 0x4e, 0x59, 0x94, 0x66, 0xa8, 0xf5, 0x33, 0x2c, 0x3a,
+	0x38, 0xee, 0x11, 0x7c, 0x89, 0xd6, 0xa0, 0x4e, 0xa3, 0x21, 0x79, 0x6b, 0x68, 0xdb, 0xda, 0x97,
+	0xcb, 0x6e, 0x66, 0xa0, 0x4d, 0x58, 0x0a, 0x08, 0xbe, 0xf4, 0x47, 0x38, 0x1d, 0x19, 0x15, 0x89,
+	0x34, 0x84, 0xe3, 0x04, 0xa7, 0x23, 0xb4, 0x05, 0x20, 0xc1, 0x6b, 0x1c, 0x8c, 0x89, 0x51, 0x95,
+	0xa8, 0x0c, 0x7f, 0x23, 0x1c, 0x02, 0x26, 0x6f, 0x79, 0x82, 0xfd, 0x21, 0xe6, 0xd8, 0xa8, 0x65,
+	0xb0, 0xf4, 0x1c, 0x62, 0x8e, 0xad, 0x0f, 0x1a, 0xe8, 0xaa, 0x78, 0x37, 0x1a, 0x04, 0xe3, 0x94,
+	0xb2, 0x08, 0x3d, 0x83, 0x9a, 0x38, 0x40, 0x92, 0x68, 0x76, 0x3e, 0x6b, 0xe7, 0xdd, 0xa8, 0x48,
+	0x57, 0xc2, 0xe8, 0x31, 0x2c, 0xd1, 0x49, 0x8e, 0x51, 0xd9, 0xae, 0x8a, 0x93, 0x73, 0x07, 0xda,
+	0x05, 0x3d, 0x37, 0xfc, 0x0b, 0xca, 0x43, 0x1c, 0x2b, 0x76, 0x2b, 0xb9, 0xff, 0x40, 0xba, 0xad,
+	0x5f, 0x35, 0x58, 0x3d, 0x26, 0x3c, 0x3b, 0xfd, 0x9a, 0xa4, 0x2e, 0xf9, 0x71, 0x4c, 0x52, 0x8e,
+	0x1e, 0xc2, 0x82, 0x98, 0x30, 0x1d, 0x4a, 0x26, 0x55, 0xb7, 0x1e, 0xe2, 0xb8, 0x3b, 0xbc, 0x19,
+	0x52, 0x56, 0x53, 0x0d, 0xe9, 0x5b, 0x58, 0x8e, 0x13, 0xc6, 0x2e, 0xfd, 0x4b, 0x96, 0x84, 0x98,
+	0xcb, 0x56, 0x5b, 0x1d, 0xa3, 0x40, 0xbe, 0x2f, 0x02, 0x8e, 0x24, 0xee, 0x36, 0xe3, 0x1b, 0xe3,
+	0xb4, 0xd6, 0xa8, 0xea, 0x35, 0xeb, 0x2f, 0x0d, 0xb6, 0xa6, 0x79, 0x1c, 0xfc, 0xe4, 0x92, 0x6b,
+	0x2a, 0x88, 0xfe, 0x2f, 0x46, 0x26, 0x34, 0x12, 0x95, 0x2f, 0x3b, 0xaf, 0xba, 0xb9, 0xfd, 0x49,
+	0x6c, 0xad, 0x7f, 0x34, 0x58, 0x2b, 0xce, 0x2b, 0x8d, 0x59, 0x94, 0x12, 0x74, 0x02, 0x48, 0xd0,
+	0x93, 0x7a, 0x28, 0x5e, 0x4d, 0xb3, 0x63, 0xce, 0x5c, 0x63, 0x7e, 0xe1, 0xae, 0x1e, 0xde, 0x96,
+	0x40, 0x07, 0x1a, 0xe2, 0xa4, 0x84, 0x31, 0x2e, 0xb9, 0x37, 0x3b, 0x8f, 0x6e, 0xf2, 0x3d, 0x7a,
+	0x15, 0x91, 0xa1, 0x83, 0x63, 0x97, 0x31, 0xee, 0x2e, 0x86, 0xd9, 0x0f, 0xd4, 0x85, 0x95, 0x70,
+	0x1c, 0x70, 0x3a, 0x55, 0xba, 0x26, 0x53, 0xb7, 0x0b, 0xa5, 0x1d, 0x11, 0x93, 0x57, 0x92, 0x4d,
+	0xba, 0xad, 0xb0, 0xe0, 0xb4, 0x7e, 0x81, 0x55, 0x6f, 0x7e, 0x41, 0xec, 0xc2, 0x42, 0x20, 0xe3,
+	0x54, 0xab, 0x25, 0x8a, 0x55, 0x01, 0xe2, 0x4e, 0x42, 0xc2, 0xb1, 0x5c, 0x86, 0x7a, 0xb6, 0x49,
+	0x13, 0x3b, 0x13, 0xc1, 0x69, 0xad, 0x51, 0xd3, 0xeb, 0xd6, 0x29, 0xac, 0x79, 0x65, 0x13, 0x9e,
+	0x9e, 0x4b, 0x65, 0xbe, 0xb9, 0x58, 0x5f, 0xc3, 0xa3, 0x63, 0xc2, 0x8b, 0xe0, 0xbd, 0x0d, 0x59,
+	0x6f, 0xe0, 0xc9, 0xed, 0x8c, 0xb9, 0xb5, 0x38, 0xad, 0xba, 0x4a, 0x51, 0x75, 0xd6, 0x2b, 0x30,
+	0x66, 0x99, 0x7c, 0x42, 0x67, 0x3b, 0xd0, 0xea, 0x46, 0x54, 0x8c, 0xe9, 0x23, 0x0d, 0x1d, 0xc2,
+	0x4a, 0x1e, 0xa8, 0xea, 0xed, 0xc1, 0xe2, 0x20, 0x21, 0x98, 0x93, 0xa1, 0xa1, 0x7d, 0xa4, 0x9c,
+	0x8a, 0xb3, 0x4e, 0x60, 0xbd, 0x5c, 0x3f, 0x68, 0x1d, 0x16, 0xd4, 0x13, 0x93, 0x3d, 0x9c, 0xca,
+	0x12, 0x7e, 0xf1, 0x68, 0x2a, 0x65, 0x2c, 0xbb, 0xca, 0xfa, 0x6a, 0x04, 0xad, 0xe2, 0x82, 0xa1,
+	0x0d, 0x78, 0xe8, 0xec, 0xf7, 0xfd, 0xbe, 0x7b, 0x76, 0x76, 0xe4, 0x1f, 0x9d, 0xb9, 0xce, 0xfe,
+	0xb9, 0x7f, 0xf4, 0xba, 0xd7, 0xd3, 0x1f, 0xa0, 0x2f, 0x60, 0x73, 0x06, 0xfa, 0xee, 0xcc, 0xe9,
+	0xbb, 0x2f, 0x3d, 0xef, 0xe5, 0xa1, 0xae, 0x21, 0x13, 0xd6, 0x67, 0x02, 0x9c, 0xd7, 0xbd, 0xf3,
+	0xae, 0x5e, 0xe9, 0xfc, 0x51, 0x87, 0xe6, 0xb9, 0xea, 0xcb, 0xc1, 0x31, 0xea, 0xc1, 0xd2, 0x31,
+	0xe1, 0x99, 0xaa, 0xd0, 0xd6, 0x4d, 0xcb, 0x25, 0xef, 0x9f, 0xf9, 0xf9, 0x5d, 0x70, 0x36, 0x42,
+	0xeb, 0x01, 0xfa, 0x41, 0x3e, 0x9c, 0xb7, 0x5f, 0x2b, 0xb4, 0x53, 0x9e, 0x38, 0xa3, 0xa1, 0x39,
+	0x2a, 0xf4, 0x60, 0xc9, 0x2b, 0xe3, 0xeb, 0xdd, 0xcf, 0xd7, 0x2b, 0x3f, 0xed, 0x37, 0x0d, 0xf4,
+	0xdb, 0x0a, 0x44, 0x4f, 0x0a, 0x24, 0xca, 0xf6, 0xc4, 0xb4, 0xee, 0x0b, 0x51, 0xa7, 0x3f, 0xff,
+	0xf0, 0xf7, 0xbf, 0xbf, 0x57, 0x9e, 0xa1, 0xa7, 0xf6, 0xf5, 0xde, 0x05, 0xe1, 0x78, 0xcf, 0x0e,
+	0x71, 0x9c, 0xda, 0xef, 0x32, 0x3d, 0xbe, 0xb7, 0x85, 0xb2, 0xd3, 0x17, 0x01, 0xe6, 0x42, 0xa7,
+	0x7f, 0x6a, 0x60, 0xde, 0xbd, 0x62, 0xe8, 0xf9, 0xdd, 0xf5, 0x66, 0x87, 0x38, 0x0f, 0x39, 0x5b,
+	0x92, 0xdb, 0x45, 0x3b, 0xf7, 0x91, 0xb3, 0xdf, 0x4d, 0x36, 0xf5, 0x3d, 0x1a, 0xc0, 0xa2, 0xda,
+	0x18, 0x34, 0xf5, 0xaf, 0x50, 0xdc, 0x36, 0x73, 0xa3, 0x04, 0x51, 0x05, 0x9f, 0xca, 0x82, 0x5b,
+	0xd6, 0x66, 0x79, 0xc1, 0x17, 0x34, 0xa2, 0xfc, 0xe0, 0x15, 0x6c, 0x0c, 0x58, 0xd8, 0xce, 0xbe,
+	0x4e, 0xda, 0xc5, 0x8f, 0x96, 0x83, 0xd5, 0x29, 0xd9, 0xee, 0xc7, 0xb4, 0x2f, 0x9c, 0x7d, 0xed,
+	0x7b, 0xf3, 0x8a, 0xf2, 0xd1, 0xf8, 0xa2, 0x3d, 0x60, 0xa1, 0xad, 0x3e, 0x6b, 0x26, 0x89, 0x17,
+	0x0b, 0x32, 0xf3, 0x9b, 0xff, 0x06, 0x00, 0xaa, 0x15, 0x1c, 0x6d, 0x22, 0x09, 0x00, 0x00,
 }
@@ -0,0 +1,169 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// noteSignaturePrefix starts each signature line of a note.
+const noteSignaturePrefix = "— "
+
+// Checkpoint is a human-readable commitment to the state of a log. Its text
+// form is the body of a signed note:
+//   <origin>
+//   <tree size, in decimal>
+//   <root hash, in standard base64>
+type Checkpoint struct {
+	// Origin is a unique name for the log, such as its URL without a scheme.
+	Origin   string
+	TreeSize uint64
+	RootHash []byte
+}
+
+// NewCheckpoint returns the Checkpoint of root for the log named origin.
+func NewCheckpoint(origin string, root *LogRootV1) *Checkpoint {
+	return &Checkpoint{Origin: origin, TreeSize: root.TreeSize, RootHash: root.RootHash}
+}
+
+// MarshalText returns the text form of c, which is the body of its note.
+func (c *Checkpoint) MarshalText() ([]byte, error) {
+	if !isNoteName(c.Origin) {
+		return nil, fmt.Errorf("invalid checkpoint origin %q", c.Origin)
+	}
+	return []byte(fmt.Sprintf("%s\n%d\n%s\n", c.Origin, c.TreeSize, base64.StdEncoding.EncodeToString(c.RootHash))), nil
+}
+
+// UnmarshalText parses the text form of a Checkpoint into c.
+func (c *Checkpoint) UnmarshalText(text []byte) error {
+	if c == nil {
+		return fmt.Errorf("nil checkpoint")
+	}
+	lines := strings.SplitAfter(string(text), "\n")
+	if len(lines) != 4 || lines[3] != "" {
+		return fmt.Errorf("checkpoint has %v lines, want 3", len(lines)-1)
+	}
+	origin := strings.TrimSuffix(lines[0], "\n")
+	if !isNoteName(origin) {
+		return fmt.Errorf("invalid checkpoint origin %q", origin)
+	}
+	size, err := strconv.ParseUint(strings.TrimSuffix(lines[1], "\n"), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid checkpoint tree size: %v", err)
+	}
+	rootHash, err := base64.StdEncoding.DecodeString(strings.TrimSuffix(lines[2], "\n"))
+	if err != nil {
+		return fmt.Errorf("invalid checkpoint root hash: %v", err)
+	}
+	*c = Checkpoint{Origin: origin, TreeSize: size, RootHash: rootHash}
+	return nil
+}
+
+// NoteSignature is a signature line of a signed note.
+type NoteSignature struct {
+	// Name identifies the signer's key, and is typically the origin of the log.
+	Name string
+	// KeyHash distinguishes between keys with the same name. See NoteKeyHash.
+	KeyHash   uint32
+	Signature []byte
+}
+
+// NoteKeyHash returns the key hash of the public key identified by keyID,
+// named name. It is the first 4 bytes of SHA-256(name || "\n" || keyID), big
+// endian. In the signed note format, keyID is an algorithm byte followed by
+// the encoded key; see crypto.NoteKeyID for the IDs used by Trillian.
+func NoteKeyHash(name string, keyID []byte) uint32 {
+	h := sha256.New()
+	h.Write([]byte(name))
+	h.Write([]byte("\n"))
+	h.Write(keyID)
+	return binary.BigEndian.Uint32(h.Sum(nil))
+}
+
+// MarshalNote returns the signed note made up of text and sigs. Each signature
+// line has the form "— <name> <base64(key hash || signature)>".
+func MarshalNote(text []byte, sigs []NoteSignature) ([]byte, error) {
+	if len(text) == 0 || text[len(text)-1] != '\n' {
+		return nil, fmt.Errorf("note text must end in a newline")
+	}
+	if len(sigs) == 0 {
+		return nil, fmt.Errorf("note must have at least one signature")
+	}
+	var buf bytes.Buffer
+	buf.Write(text)
+	buf.WriteString("\n")
+	for _, sig := range sigs {
+		if !isNoteName(sig.Name) {
+			return nil, fmt.Errorf("invalid signature name %q", sig.Name)
+		}
+		hashAndSig := make([]byte, 4+len(sig.Signature))
+		binary.BigEndian.PutUint32(hashAndSig, sig.KeyHash)
+		copy(hashAndSig[4:], sig.Signature)
+		fmt.Fprintf(&buf, "%s%s %s\n", noteSignaturePrefix, sig.Name, base64.StdEncoding.EncodeToString(hashAndSig))
+	}
+	return buf.Bytes(), nil
+}
+
+// ParseNote splits a signed note into its text and signatures. The signatures
+// are not verified.
+func ParseNote(note []byte) ([]byte, []NoteSignature, error) {
+	if !utf8.Valid(note) {
+		return nil, nil, fmt.Errorf("note is not valid UTF-8")
+	}
+	split := bytes.LastIndex(note, []byte("\n\n"))
+	if split < 0 {
+		return nil, nil, fmt.Errorf("note has no signatures")
+	}
+	text, sigLines := note[:split+1], note[split+2:]
+	if len(sigLines) == 0 || sigLines[len(sigLines)-1] != '\n' {
+		return nil, nil, fmt.Errorf("note signatures must end in a newline")
+	}
+
+	var sigs []NoteSignature
+	for _, line := range strings.SplitAfter(string(sigLines[:len(sigLines)-1]), "\n") {
+		line = strings.TrimSuffix(line, "\n")
+		if !strings.HasPrefix(line, noteSignaturePrefix) {
+			return nil, nil, fmt.Errorf("malformed signature line %q", line)
+		}
+		fields := strings.Split(strings.TrimPrefix(line, noteSignaturePrefix), " ")
+		if len(fields) != 2 || !isNoteName(fields[0]) {
+			return nil, nil, fmt.Errorf("malformed signature line %q", line)
+		}
+		hashAndSig, err := base64.StdEncoding.DecodeString(fields[1])
+		if err != nil || len(hashAndSig) < 5 {
+			return nil, nil, fmt.Errorf("malformed signature line %q", line)
+		}
+		sigs = append(sigs, NoteSignature{
+			Name:      fields[0],
+			KeyHash:   binary.BigEndian.Uint32(hashAndSig),
+			Signature: hashAndSig[4:],
+		})
+	}
+	return text, sigs, nil
+}
+
+// isNoteName returns true if name can be used as a checkpoint origin or a
+// signature name: it must be non-empty and free of spaces and plus signs.
+func isNoteName(name string) bool {
+	return name != "" && utf8.ValidString(name) && strings.IndexFunc(name, unicode.IsSpace) < 0 && !strings.Contains(name, "+")
+}
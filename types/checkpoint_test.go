@@ -0,0 +1,101 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckpoint(t *testing.T) {
+	c := NewCheckpoint("example.com/log/1", &LogRootV1{TreeSize: 42, RootHash: []byte("foo")})
+	text, err := c.MarshalText()
+	if err != nil {
+		t.Fatalf("MarshalText(): %v", err)
+	}
+	if got, want := string(text), "example.com/log/1\n42\nZm9v\n"; got != want {
+		t.Errorf("MarshalText(): %q, want %q", got, want)
+	}
+	var got Checkpoint
+	if err := got.UnmarshalText(text); err != nil {
+		t.Fatalf("UnmarshalText(): %v", err)
+	}
+	if !reflect.DeepEqual(&got, c) {
+		t.Errorf("serialize/parse round trip failed. got %#v, want %#v", got, c)
+	}
+}
+
+func TestUnmarshalCheckpoint(t *testing.T) {
+	for _, tc := range []struct {
+		desc    string
+		text    string
+		wantErr bool
+	}{
+		{desc: "valid", text: "origin\n1\nZm9v\n"},
+		{desc: "noTrailingNewline", text: "origin\n1\nZm9v", wantErr: true},
+		{desc: "extraLine", text: "origin\n1\nZm9v\nextra\n", wantErr: true},
+		{desc: "spaceInOrigin", text: "my origin\n1\nZm9v\n", wantErr: true},
+		{desc: "negativeSize", text: "origin\n-1\nZm9v\n", wantErr: true},
+		{desc: "badHash", text: "origin\n1\n!!!\n", wantErr: true},
+		{desc: "empty", text: "", wantErr: true},
+	} {
+		var c Checkpoint
+		err := c.UnmarshalText([]byte(tc.text))
+		if got, want := err != nil, tc.wantErr; got != want {
+			t.Errorf("%v: UnmarshalText(): %v, wantErr %v", tc.desc, err, want)
+		}
+	}
+}
+
+func TestNote(t *testing.T) {
+	text := []byte("origin\n1\nZm9v\n")
+	sigs := []NoteSignature{
+		{Name: "origin", KeyHash: NoteKeyHash("origin", []byte("key1")), Signature: []byte("sig1")},
+		{Name: "witness", KeyHash: NoteKeyHash("witness", []byte("key2")), Signature: []byte("sig2")},
+	}
+	note, err := MarshalNote(text, sigs)
+	if err != nil {
+		t.Fatalf("MarshalNote(): %v", err)
+	}
+	gotText, gotSigs, err := ParseNote(note)
+	if err != nil {
+		t.Fatalf("ParseNote(): %v", err)
+	}
+	if !reflect.DeepEqual(gotText, text) {
+		t.Errorf("ParseNote(): text %q, want %q", gotText, text)
+	}
+	if !reflect.DeepEqual(gotSigs, sigs) {
+		t.Errorf("ParseNote(): signatures %#v, want %#v", gotSigs, sigs)
+	}
+}
+
+func TestParseNoteErrors(t *testing.T) {
+	for _, tc := range []struct {
+		desc string
+		note string
+	}{
+		{desc: "noSignatures", note: "origin\n1\nZm9v\n"},
+		{desc: "emptySignatures", note: "origin\n1\nZm9v\n\n"},
+		{desc: "noPrefix", note: "origin\n1\nZm9v\n\norigin AAAAAAE=\n"},
+		{desc: "missingName", note: "origin\n1\nZm9v\n\n— AAAAAAE=\n"},
+		{desc: "shortSignature", note: "origin\n1\nZm9v\n\n— origin AAAA\n"},
+		{desc: "badBase64", note: "origin\n1\nZm9v\n\n— origin !!!\n"},
+		{desc: "noTrailingNewline", note: "origin\n1\nZm9v\n\n— origin AAAAAAE="},
+	} {
+		if _, _, err := ParseNote([]byte(tc.note)); err == nil {
+			t.Errorf("%v: ParseNote(): nil err, want error", tc.desc)
+		}
+	}
+}
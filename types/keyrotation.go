@@ -0,0 +1,126 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/google/certificate-transparency-go/tls"
+)
+
+// keyRotationFormatV1 is the version tag of KeyRotationV1.
+const keyRotationFormatV1 = 1
+
+// KeyRotationV1 holds the TLS-deserialization of the following structure
+// (described in RFC5246 section 4 notation):
+// struct {
+//   uint64 tree_id;
+//   uint64 key_version;
+//   opaque public_key<1..65535>;
+//   uint64 timestamp_nanos;
+// } KeyRotationV1;
+//
+// A KeyRotationV1 is signed by the key it replaces, and states that roots of
+// tree_id are signed by public_key (in DER form) from timestamp_nanos onwards.
+type KeyRotationV1 struct {
+	TreeID         uint64
+	KeyVersion     uint64
+	PublicKey      []byte `tls:"minlen:1,maxlen:65535"`
+	TimestampNanos uint64
+}
+
+// KeyRotation holds the TLS-deserialization of the following structure
+// (described in RFC5246 section 4 notation):
+// enum { v1(1), (65535)} Version;
+// struct {
+//   Version version;
+//   select(version) {
+//     case v1: KeyRotationV1;
+//   }
+// } KeyRotation;
+type KeyRotation struct {
+	Version tls.Enum       `tls:"size:2"`
+	V1      *KeyRotationV1 `tls:"selector:Version,val:1"`
+}
+
+// UnmarshalBinary verifies that keyRotationBytes is a TLS serialized
+// KeyRotation, has the v1 tag, and populates the caller with the deserialized
+// *KeyRotationV1.
+func (k *KeyRotationV1) UnmarshalBinary(keyRotationBytes []byte) error {
+	if len(keyRotationBytes) < 3 {
+		return fmt.Errorf("keyRotationBytes too short")
+	}
+	if k == nil {
+		return fmt.Errorf("nil key rotation")
+	}
+	version := binary.BigEndian.Uint16(keyRotationBytes)
+	if version != keyRotationFormatV1 {
+		return fmt.Errorf("invalid KeyRotation.Version: %v, want %v", version, keyRotationFormatV1)
+	}
+
+	var keyRotation KeyRotation
+	if _, err := tls.Unmarshal(keyRotationBytes, &keyRotation); err != nil {
+		return err
+	}
+
+	*k = *keyRotation.V1
+	return nil
+}
+
+// MarshalBinary returns a canonical TLS serialization of KeyRotation.
+func (k *KeyRotationV1) MarshalBinary() ([]byte, error) {
+	return tls.Marshal(KeyRotation{
+		Version: tls.Enum(keyRotationFormatV1),
+		V1:      k,
+	})
+}
+
+// SerializeKeyVersionHint returns a key hint identifying version of the key of
+// tree treeID. The hint of version zero is the same as SerializeKeyHint(treeID),
+// so that trees which have never had their key rotated keep their hints.
+// Later versions are serialized as treeID and version, both big endian uint64.
+func SerializeKeyVersionHint(treeID, version int64) []byte {
+	if version == 0 {
+		return SerializeKeyHint(treeID)
+	}
+	hint := make([]byte, 16)
+	binary.BigEndian.PutUint64(hint, uint64(treeID))
+	binary.BigEndian.PutUint64(hint[8:], uint64(version))
+	return hint
+}
+
+// ParseKeyVersionHint converts a key hint into a tree ID and key version.
+// hasVersion is false for hints that don't identify a key version, such as
+// those produced by SerializeKeyHint.
+func ParseKeyVersionHint(hint []byte) (treeID, version int64, hasVersion bool, err error) {
+	switch len(hint) {
+	case 8:
+		treeID, err := ParseKeyHint(hint)
+		return treeID, 0, false, err
+	case 16:
+		treeID, err := ParseKeyHint(hint[:8])
+		if err != nil {
+			return 0, 0, false, err
+		}
+		version := int64(binary.BigEndian.Uint64(hint[8:]))
+		if version <= 0 {
+			return 0, 0, false, fmt.Errorf("hint %x has invalid key version %v", hint, version)
+		}
+		return treeID, version, true, nil
+	default:
+		return 0, 0, false, fmt.Errorf("hint is %v bytes, want 8 or 16", len(hint))
+	}
+}
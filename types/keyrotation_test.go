@@ -0,0 +1,85 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestKeyRotation(t *testing.T) {
+	want := &KeyRotationV1{
+		TreeID:         12345,
+		KeyVersion:     2,
+		PublicKey:      []byte("der"),
+		TimestampNanos: 987654321,
+	}
+	b, err := want.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary(): %v", err)
+	}
+	var got KeyRotationV1
+	if err := got.UnmarshalBinary(b); err != nil {
+		t.Fatalf("UnmarshalBinary(): %v", err)
+	}
+	if !reflect.DeepEqual(&got, want) {
+		t.Errorf("serialize/parse round trip failed. got %#v, want %#v", got, want)
+	}
+
+	// A statement must name a public key.
+	if empty, err := (&KeyRotationV1{}).MarshalBinary(); err == nil {
+		t.Errorf("MarshalBinary(empty PublicKey): %x, want err", empty)
+	}
+	b[1] = 2 // Corrupt the version tag.
+	if err := got.UnmarshalBinary(b); err == nil {
+		t.Error("UnmarshalBinary(bad version): nil, want err")
+	}
+}
+
+func TestKeyVersionHint(t *testing.T) {
+	for _, tc := range []struct {
+		hint           []byte
+		wantTreeID     int64
+		wantVersion    int64
+		wantHasVersion bool
+		wantErr        bool
+	}{
+		{hint: SerializeKeyVersionHint(4, 0), wantTreeID: 4},
+		{hint: SerializeKeyHint(4), wantTreeID: 4},
+		{hint: SerializeKeyVersionHint(4, 1), wantTreeID: 4, wantVersion: 1, wantHasVersion: true},
+		{hint: SerializeKeyVersionHint(3561657513447883733, 7), wantTreeID: 3561657513447883733, wantVersion: 7, wantHasVersion: true},
+		{hint: []byte{0, 0, 0, 0, 0, 0, 0, 4, 0, 0, 0, 0, 0, 0, 0, 0}, wantErr: true},    // Explicit version 0
+		{hint: []byte{0, 0, 0, 0, 0, 0, 0, 4, 0xff, 0, 0, 0, 0, 0, 0, 1}, wantErr: true}, // Negative version
+		{hint: []byte{0xff, 0, 0, 0, 0, 0, 0, 4, 0, 0, 0, 0, 0, 0, 0, 1}, wantErr: true}, // Negative tree ID
+		{hint: []byte{0, 0, 0, 0, 0, 0, 0, 4, 0, 0, 0, 0, 0, 0, 0, 0, 1}, wantErr: true}, // Wrong byte len
+		{hint: nil, wantErr: true},
+	} {
+		treeID, version, hasVersion, err := ParseKeyVersionHint(tc.hint)
+		if got, want := err != nil, tc.wantErr; got != want {
+			t.Errorf("ParseKeyVersionHint(%x): %v, wantErr: %v", tc.hint, err, want)
+		}
+		if err != nil {
+			continue
+		}
+		if treeID != tc.wantTreeID || version != tc.wantVersion || hasVersion != tc.wantHasVersion {
+			t.Errorf("ParseKeyVersionHint(%x): (%v, %v, %v), want (%v, %v, %v)", tc.hint, treeID, version, hasVersion, tc.wantTreeID, tc.wantVersion, tc.wantHasVersion)
+		}
+	}
+
+	if got, want := SerializeKeyVersionHint(4, 0), SerializeKeyHint(4); !bytes.Equal(got, want) {
+		t.Errorf("SerializeKeyVersionHint(4, 0): %x, want %x", got, want)
+	}
+}
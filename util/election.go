@@ -31,6 +31,18 @@ type MasterElection interface {
 	Close(context.Context) error
 }
 
+// FencedElection is a MasterElection that provides fencing tokens, which
+// identify a particular mastership, and increase every time mastership of
+// the election changes hands. Passing the token to storage (see
+// storage.WithFencingToken) prevents writes by instances that have lost
+// mastership without noticing yet.
+type FencedElection interface {
+	MasterElection
+	// Epoch returns the fencing token of the current instance's most recent
+	// mastership. It is only meaningful while the instance is master.
+	Epoch() int64
+}
+
 // ElectionFactory encapsulates the creation of a MasterElection instance for a treeID.
 type ElectionFactory interface {
 	NewElection(ctx context.Context, treeID int64) (MasterElection, error)
@@ -56,6 +56,12 @@ func (eme *MasterElection) IsMaster(ctx context.Context) (bool, error) {
 	return string(leader.Kvs[0].Value) == eme.instanceID, nil
 }
 
+// Epoch returns the revision at which the current instance most recently
+// became master, which serves as a fencing token.
+func (eme *MasterElection) Epoch() int64 {
+	return eme.election.Rev()
+}
+
 // ResignAndRestart releases mastership, and re-joins the election.
 func (eme *MasterElection) ResignAndRestart(ctx context.Context) error {
 	return eme.election.Resign(ctx)
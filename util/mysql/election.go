@@ -0,0 +1,280 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mysql holds a MySQL-specific implementation of the
+// util.MasterElection interface.
+//
+// Mastership of a tree is a lease, stored as a row of the MasterLease table.
+// The master renews its lease periodically; other instances take it over once
+// it expires. Each change of master increments the lease's epoch, which can
+// be used as a fencing token.
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian/util"
+)
+
+const (
+	// DefaultLeaseDuration is the default duration of mastership leases.
+	DefaultLeaseDuration = 10 * time.Second
+
+	// nowMillisSQL is the current time of the database, in milliseconds since
+	// the epoch. Lease expiry is always computed and checked against the
+	// database's clock, so that instances needn't have synchronised clocks.
+	nowMillisSQL = "CAST(UNIX_TIMESTAMP(NOW(3)) * 1000 AS SIGNED)"
+
+	insertLeaseSQL  = "INSERT IGNORE INTO MasterLease(TreeId, InstanceId, Epoch, ExpiryMillis) VALUES(?, '', 0, 0)"
+	acquireLeaseSQL = `UPDATE MasterLease SET InstanceId = ?, Epoch = Epoch + 1, ExpiryMillis = ` + nowMillisSQL + ` + ?
+		WHERE TreeId = ? AND ExpiryMillis <= ` + nowMillisSQL
+	renewLeaseSQL = "UPDATE MasterLease SET ExpiryMillis = " + nowMillisSQL + " + ?" +
+		" WHERE TreeId = ? AND InstanceId = ? AND Epoch = ? AND ExpiryMillis > " + nowMillisSQL
+	releaseLeaseSQL = "UPDATE MasterLease SET ExpiryMillis = 0 WHERE TreeId = ? AND InstanceId = ? AND Epoch = ?"
+	selectLeaseSQL  = "SELECT InstanceId, Epoch, ExpiryMillis > " + nowMillisSQL + " FROM MasterLease WHERE TreeId = ?"
+)
+
+// errClosed is returned by operations on a closed MasterElection.
+var errClosed = errors.New("election closed")
+
+// MasterElection is an implementation of util.MasterElection based on MySQL.
+type MasterElection struct {
+	instanceID    string
+	treeID        int64
+	db            *sql.DB
+	leaseDuration time.Duration
+
+	mu sync.Mutex
+	// epoch is the epoch of the lease acquired by this instance, or 0 if it
+	// hasn't acquired one. The lease may have since expired.
+	epoch int64
+	// stopRenewal stops the renewal of the acquired lease, if any.
+	stopRenewal func()
+	// leaseCreated is set once the tree's row of MasterLease is known to exist.
+	leaseCreated bool
+	closed       bool
+}
+
+// Start commences election operation.
+func (me *MasterElection) Start(ctx context.Context) error {
+	return nil
+}
+
+// WaitForMastership blocks until the current instance is master.
+func (me *MasterElection) WaitForMastership(ctx context.Context) error {
+	pollInterval := me.leaseDuration / 4
+	for {
+		acquired, err := me.tryAcquire(ctx)
+		if err != nil {
+			return err
+		}
+		if acquired {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// tryAcquire attempts to acquire the lease, and returns whether the current
+// instance holds it.
+func (me *MasterElection) tryAcquire(ctx context.Context) (bool, error) {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	if me.closed {
+		return false, errClosed
+	}
+	if me.epoch != 0 {
+		if master, err := me.isMaster(ctx); err != nil || master {
+			return master, err
+		}
+		if err := me.release(ctx); err != nil {
+			return false, err
+		}
+	}
+	if !me.leaseCreated {
+		if _, err := me.db.ExecContext(ctx, insertLeaseSQL, me.treeID); err != nil {
+			return false, fmt.Errorf("failed to create lease: %v", err)
+		}
+		me.leaseCreated = true
+	}
+
+	res, err := me.db.ExecContext(ctx, acquireLeaseSQL, me.instanceID, toMillis(me.leaseDuration), me.treeID)
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire lease: %v", err)
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return false, err
+	}
+
+	var instanceID string
+	var epoch int64
+	var live bool
+	if err := me.db.QueryRowContext(ctx, selectLeaseSQL, me.treeID).Scan(&instanceID, &epoch, &live); err != nil {
+		return false, fmt.Errorf("failed to read acquired lease: %v", err)
+	}
+	if instanceID != me.instanceID {
+		// The lease expired before it could be read, and was taken over.
+		return false, nil
+	}
+	me.epoch = epoch
+	glog.Infof("%d: %s acquired mastership lease, epoch %d", me.treeID, me.instanceID, me.epoch)
+
+	renewCtx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go me.renew(renewCtx, me.epoch, done)
+	me.stopRenewal = func() {
+		cancel()
+		<-done
+	}
+	return true, nil
+}
+
+// renew periodically extends the lease of the given epoch, until ctx is
+// cancelled or the lease is lost.
+func (me *MasterElection) renew(ctx context.Context, epoch int64, done chan<- struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(me.leaseDuration / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		// Whether the renewal succeeded is checked by IsMaster, as MySQL
+		// doesn't count rows updated to their current values as affected.
+		if _, err := me.db.ExecContext(ctx, renewLeaseSQL, toMillis(me.leaseDuration), me.treeID, me.instanceID, epoch); err != nil && ctx.Err() == nil {
+			glog.Warningf("%d: failed to renew mastership lease: %v", me.treeID, err)
+		}
+	}
+}
+
+// IsMaster returns whether the current instance is the master.
+func (me *MasterElection) IsMaster(ctx context.Context) (bool, error) {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	if me.closed {
+		return false, errClosed
+	}
+	return me.isMaster(ctx)
+}
+
+// isMaster checks that the lease acquired by the current instance is still
+// held. Must be called with mu held.
+func (me *MasterElection) isMaster(ctx context.Context) (bool, error) {
+	if me.epoch == 0 {
+		return false, nil
+	}
+	var instanceID string
+	var epoch int64
+	var live bool
+	if err := me.db.QueryRowContext(ctx, selectLeaseSQL, me.treeID).Scan(&instanceID, &epoch, &live); err != nil {
+		return false, fmt.Errorf("failed to read lease: %v", err)
+	}
+	return instanceID == me.instanceID && epoch == me.epoch && live, nil
+}
+
+// Epoch returns the epoch of the lease most recently acquired by the current
+// instance, or 0 if it hasn't acquired one. The epoch is only a valid fencing
+// token while IsMaster returns true.
+func (me *MasterElection) Epoch() int64 {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	return me.epoch
+}
+
+// ResignAndRestart releases mastership, and re-joins the election.
+func (me *MasterElection) ResignAndRestart(ctx context.Context) error {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	if me.closed {
+		return errClosed
+	}
+	return me.release(ctx)
+}
+
+// release stops renewing the lease acquired by the current instance, and
+// expires it, if still held. Must be called with mu held.
+func (me *MasterElection) release(ctx context.Context) error {
+	if me.epoch == 0 {
+		return nil
+	}
+	me.stopRenewal()
+	epoch := me.epoch
+	me.epoch = 0
+	me.stopRenewal = nil
+	if _, err := me.db.ExecContext(ctx, releaseLeaseSQL, me.treeID, me.instanceID, epoch); err != nil {
+		return fmt.Errorf("failed to release lease: %v", err)
+	}
+	return nil
+}
+
+// Close terminates election operation.
+func (me *MasterElection) Close(ctx context.Context) error {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	if me.closed {
+		return nil
+	}
+	me.closed = true
+	return me.release(ctx)
+}
+
+func toMillis(d time.Duration) int64 {
+	return d.Nanoseconds() / int64(time.Millisecond)
+}
+
+// ElectionFactory creates mysql.MasterElection instances.
+type ElectionFactory struct {
+	db            *sql.DB
+	instanceID    string
+	leaseDuration time.Duration
+}
+
+// NewElectionFactory builds an election factory that uses the given
+// parameters. The passed in database must have the MasterLease table, and
+// should remain valid for the lifetime of the ElectionFactory. Leases are
+// timed by the database's clock, so the instances' clocks needn't agree.
+func NewElectionFactory(instanceID string, db *sql.DB, leaseDuration time.Duration) *ElectionFactory {
+	if leaseDuration <= 0 {
+		leaseDuration = DefaultLeaseDuration
+	}
+	return &ElectionFactory{
+		db:            db,
+		instanceID:    instanceID,
+		leaseDuration: leaseDuration,
+	}
+}
+
+// NewElection creates a specific mysql.MasterElection instance.
+func (ef ElectionFactory) NewElection(ctx context.Context, treeID int64) (util.MasterElection, error) {
+	me := &MasterElection{
+		instanceID:    ef.instanceID,
+		treeID:        treeID,
+		db:            ef.db,
+		leaseDuration: ef.leaseDuration,
+	}
+	glog.Infof("MasterElection created: %d, %s", treeID, ef.instanceID)
+	return me, nil
+}
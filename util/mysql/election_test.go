@@ -0,0 +1,119 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/trillian/storage/testdb"
+)
+
+const (
+	treeID        = 10
+	leaseDuration = 200 * time.Millisecond
+)
+
+func newElection(ctx context.Context, t *testing.T, db *sql.DB, instanceID string) *MasterElection {
+	t.Helper()
+	fact := NewElectionFactory(instanceID, db, leaseDuration)
+	el, err := fact.NewElection(ctx, treeID)
+	if err != nil {
+		t.Fatalf("NewElection(%v): %v", treeID, err)
+	}
+	if err := el.Start(ctx); err != nil {
+		t.Fatalf("Start(%v): %v", instanceID, err)
+	}
+	return el.(*MasterElection)
+}
+
+func checkMaster(ctx context.Context, t *testing.T, el *MasterElection, want bool) {
+	t.Helper()
+	if got, err := el.IsMaster(ctx); err != nil || got != want {
+		t.Errorf("%v: IsMaster() = (%v, %v), want (%v, nil)", el.instanceID, got, err, want)
+	}
+}
+
+func TestMasterElection(t *testing.T) {
+	testdb.SkipIfNoMySQL(t)
+	ctx := context.Background()
+	db, err := testdb.NewTrillianDB(ctx)
+	if err != nil {
+		t.Fatalf("NewTrillianDB(): %v", err)
+	}
+	defer db.Close()
+
+	el1 := newElection(ctx, t, db, "serv1")
+	defer el1.Close(ctx)
+	el2 := newElection(ctx, t, db, "serv2")
+	defer el2.Close(ctx)
+
+	checkMaster(ctx, t, el1, false)
+	if err := el1.WaitForMastership(ctx); err != nil {
+		t.Fatalf("WaitForMastership(serv1): %v", err)
+	}
+	checkMaster(ctx, t, el1, true)
+	checkMaster(ctx, t, el2, false)
+	if got, want := el1.Epoch(), int64(1); got != want {
+		t.Errorf("Epoch() = %v, want %v", got, want)
+	}
+
+	// The lease is renewed, so remains held for longer than leaseDuration.
+	time.Sleep(2 * leaseDuration)
+	checkMaster(ctx, t, el1, true)
+
+	waitCtx, cancel := context.WithTimeout(ctx, leaseDuration)
+	defer cancel()
+	if err := el2.WaitForMastership(waitCtx); err == nil {
+		t.Error("WaitForMastership(serv2) = nil, want error")
+	}
+
+	// Resigning lets the other instance take over, with a new epoch.
+	if err := el1.ResignAndRestart(ctx); err != nil {
+		t.Fatalf("ResignAndRestart(serv1): %v", err)
+	}
+	checkMaster(ctx, t, el1, false)
+	if err := el2.WaitForMastership(ctx); err != nil {
+		t.Fatalf("WaitForMastership(serv2): %v", err)
+	}
+	checkMaster(ctx, t, el2, true)
+	if got, want := el2.Epoch(), int64(2); got != want {
+		t.Errorf("Epoch() = %v, want %v", got, want)
+	}
+
+	// A master that stops renewing its lease, e.g. because it's paused, loses
+	// mastership once the lease expires.
+	el2.mu.Lock()
+	el2.stopRenewal()
+	el2.mu.Unlock()
+	time.Sleep(leaseDuration)
+	checkMaster(ctx, t, el2, false)
+	if err := el1.WaitForMastership(ctx); err != nil {
+		t.Fatalf("WaitForMastership(serv1): %v", err)
+	}
+	checkMaster(ctx, t, el1, true)
+	if got, want := el1.Epoch(), int64(3); got != want {
+		t.Errorf("Epoch() = %v, want %v", got, want)
+	}
+
+	if err := el1.Close(ctx); err != nil {
+		t.Errorf("Close(serv1): %v", err)
+	}
+	if _, err := el1.IsMaster(ctx); err != errClosed {
+		t.Errorf("IsMaster() after Close() = %v, want %v", err, errClosed)
+	}
+}
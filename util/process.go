@@ -26,6 +26,13 @@ import (
 
 // StartHTTPServer starts an HTTP server on the given address.
 func StartHTTPServer(addr, certFile, keyFile string) error {
+	return StartHTTPServerWithHandler(addr, certFile, keyFile, nil)
+}
+
+// StartHTTPServerWithHandler starts an HTTP server on the given address, which
+// serves requests with handler. If handler is nil, http.DefaultServeMux is
+// used.
+func StartHTTPServerWithHandler(addr, certFile, keyFile string, handler http.Handler) error {
 	sock, err := net.Listen("tcp", addr)
 	if err != nil {
 		return err
@@ -34,9 +41,9 @@ func StartHTTPServer(addr, certFile, keyFile string) error {
 		glog.Info("HTTP server starting")
 		// Let http.ServeTLS handle the error case when only one of the flags is set.
 		if certFile != "" || keyFile != "" {
-			err = http.ServeTLS(sock, nil, certFile, keyFile)
+			err = http.ServeTLS(sock, handler, certFile, keyFile)
 		} else {
-			err = http.Serve(sock, nil)
+			err = http.Serve(sock, handler)
 		}
 		if err != nil {
 			glog.Errorf("HTTP server stopped: %v", err)